@@ -34,7 +34,19 @@ func main() {
 		Produces(restful.MIME_JSON)
 
 	ws.Route(ws.GET("/products/{sku}").To(handler.GetProductDetails)).
-		Doc("Query product details")
+		Doc("Query product details").
+		Param(ws.QueryParameter("currency", "ISO-4217 code of the currency to show prices in")).
+		Param(ws.HeaderParameter("Accept-Language", "Languages to show product and category names in, most preferred first"))
+
+	ws.Route(ws.GET("/products/{sku}/related").To(handler.GetRelatedProducts)).
+		Doc("Recommend products related to a product").
+		Param(ws.QueryParameter("limit", "Maximum number of related products")).
+		Param(ws.QueryParameter("currency", "ISO-4217 code of the currency to show prices in"))
+
+	ws.Route(ws.GET("/suggestions").To(handler.SuggestProducts)).
+		Doc("Suggest products as the user types").
+		Param(ws.QueryParameter("q", "Prefix typed so far")).
+		Param(ws.QueryParameter("limit", "Maximum number of suggestions"))
 
 	wc.Add(ws)
 	webService.Handle("/", wc)
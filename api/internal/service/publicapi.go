@@ -1,11 +1,76 @@
 package service
 
 type productDetails struct {
+	SKU            string                 `json:"sku"`
+	ParentSKU      string                 `json:"parent_sku,omitempty"`
+	Name           string                 `json:"name"`
+	Description    string                 `json:"description"`
+	Manufacturer   string                 `json:"manufacturer"`
+	Model          string                 `json:"model"`
+	Price          money                  `json:"price"`
+	StockRemaining uint32                 `json:"stock_remaining"`
+	Attributes     map[string]string      `json:"attributes,omitempty"`
+	Specifications map[string]interface{} `json:"specifications,omitempty"` // each a string, number or boolean
+	Media          []mediaAsset           `json:"media"`
+	Rating         rating                 `json:"rating"`
+	Breadcrumbs    []breadcrumb           `json:"breadcrumbs"`
+	Variants       []variantDetails       `json:"variants,omitempty"`
+	Components     []bundleComponent      `json:"components,omitempty"`
+}
+
+type variantDetails struct {
+	SKU            string            `json:"sku"`
+	Model          string            `json:"model"`
+	Price          money             `json:"price"`
+	StockRemaining uint32            `json:"stock_remaining"`
+	Attributes     map[string]string `json:"attributes,omitempty"`
+}
+
+// bundleComponent is a product sold as part of a bundle, and how many of it each bundle holds
+type bundleComponent struct {
 	SKU            string `json:"sku"`
-	Name           string `json:"name"`
-	Description    string `json:"description"`
-	Manufacturer   string `json:"manufacturer"`
-	Model          string `json:"model"`
-	Price          int64  `json:"price"`
+	Quantity       uint32 `json:"quantity"`
 	StockRemaining uint32 `json:"stock_remaining"`
 }
+
+// money is an amount in the minor units (e.g. cents) of an ISO-4217 currency
+type money struct {
+	Amount       int64  `json:"amount"`
+	CurrencyCode string `json:"currency_code"`
+}
+
+// mediaAsset is an image, video or document shown with a product, listed in display order
+type mediaAsset struct {
+	URL     string `json:"url"`
+	AltText string `json:"alt_text"`
+	Type    string `json:"type"`
+	Width   uint32 `json:"width,omitempty"`
+	Height  uint32 `json:"height,omitempty"`
+}
+
+// rating summarizes a product's approved reviews. The histogram counts the reviews giving each
+// rating, keyed by the number of stars from "1" to "5".
+type rating struct {
+	Average   float64           `json:"average"`
+	Count     uint32            `json:"count"`
+	Histogram map[string]uint32 `json:"histogram"`
+}
+
+type breadcrumb struct {
+	CategoryID uint64 `json:"category_id"`
+	Name       string `json:"name"`
+}
+
+// relatedProduct is a product recommended alongside another, with the ways in which the two are related
+type relatedProduct struct {
+	SKU                 string   `json:"sku"`
+	Name                string   `json:"name"`
+	Price               money    `json:"price"`
+	Relations           []string `json:"relations"`
+	BoughtTogetherCount uint32   `json:"bought_together_count,omitempty"`
+}
+
+type productSuggestion struct {
+	SKU  string `json:"sku"`
+	Name string `json:"name"`
+}
@@ -11,6 +11,9 @@ import (
 	"github.com/micro/go-micro/errors"
 	"golang.org/x/net/context"
 	"net/http"
+	"sort"
+	"strconv"
+	"strings"
 )
 
 const (
@@ -46,9 +49,11 @@ func NewCommerceService(c client.Client) *CommerceService {
 func (cs *CommerceService) GetProductDetails(request *restful.Request, response *restful.Response) {
 
 	sku := request.PathParameter("sku")
+	currencyCode := request.QueryParameter("currency")
+	locales := preferredLocales(request.HeaderParameter("Accept-Language"))
 	log.Logf("Received request for product details: %s", sku)
 	ctx := context.Background()
-	catalogCh := cs.getCatalogDetails(ctx, sku)
+	catalogCh := cs.getCatalogDetails(ctx, sku, currencyCode, locales)
 	warehouseCh := cs.getWarehouseDetails(ctx, sku)
 
 	catalogReply := <-catalogCh
@@ -57,36 +62,204 @@ func (cs *CommerceService) GetProductDetails(request *restful.Request, response
 		return
 	}
 
-	warehouseReply := <-warehouseCh
-	if warehouseReply.err != nil {
-		writeError(response, warehouseReply.err)
+	product := catalogReply.catalogResponse.Product
+	switch product.Status {
+	case catalog.ProductStatus_PS_DISCONTINUED:
+		response.WriteError(http.StatusGone, stderrors.New("Product has been discontinued"))
+		return
+	case catalog.ProductStatus_PS_DRAFT:
+		response.WriteError(http.StatusNotFound, stderrors.New("No such product"))
 		return
 	}
-	product := catalogReply.catalogResponse.Product
 
 	details := productDetails{
 		SKU:            product.Sku,
-		StockRemaining: warehouseReply.warehouseResponse.Details.StockRemaining,
+		ParentSKU:      product.ParentSku,
 		Manufacturer:   product.Manufacturer,
-		Price:          product.Price,
+		Price:          toMoney(product.Price),
 		Model:          product.Model,
 		Name:           product.Name,
 		Description:    product.Description,
+		Attributes:     attributeMap(product.Attributes),
+		Specifications: specificationMap(product.Specifications),
+		Media:          toMediaAssets(product.Media),
+		Rating:         toRating(catalogReply.catalogResponse.Rating),
+		Breadcrumbs:    make([]breadcrumb, 0, len(catalogReply.catalogResponse.Breadcrumbs)),
+	}
+	for _, category := range catalogReply.catalogResponse.Breadcrumbs {
+		details.Breadcrumbs = append(details.Breadcrumbs, breadcrumb{CategoryID: category.CategoryId, Name: category.Name})
+	}
+
+	// a product with variants is stocked through its variants, each under its own SKU
+	if variants := catalogReply.catalogResponse.Variants; len(variants) > 0 {
+		variantDetails, err := cs.getVariantDetails(ctx, variants)
+		if err != nil {
+			writeError(response, err)
+			return
+		}
+		details.Variants = variantDetails
+		for _, variant := range variantDetails {
+			details.StockRemaining += variant.StockRemaining
+		}
+		response.WriteEntity(details)
+		return
 	}
+
+	warehouseReply := <-warehouseCh
+	if warehouseReply.err != nil {
+		writeError(response, warehouseReply.err)
+		return
+	}
+	details.StockRemaining = warehouseReply.warehouseResponse.Details.StockRemaining
+	details.Components = toBundleComponents(product.Components, warehouseReply.warehouseResponse.Details.Components)
 	response.WriteEntity(details)
 }
 
-func (cs *CommerceService) getCatalogDetails(ctx context.Context, sku string) chan catalogResults {
+// preferredLocales lists the languages in an Accept-Language header, most preferred first, leaving out
+// any that aren't well-formed language tags. An empty list asks for the catalog's own language.
+func preferredLocales(acceptLanguage string) (locales []string) {
+	type preference struct {
+		tag     string
+		quality float64
+	}
+	var preferences []preference
+	for _, entry := range strings.Split(acceptLanguage, ",") {
+		params := strings.Split(entry, ";")
+		tag := strings.TrimSpace(params[0])
+		quality := 1.0
+		for _, param := range params[1:] {
+			if q := strings.TrimSpace(param); strings.HasPrefix(q, "q=") {
+				if parsed, err := strconv.ParseFloat(q[2:], 64); err == nil {
+					quality = parsed
+				}
+			}
+		}
+		if quality > 0 && validLanguageTag(tag) {
+			preferences = append(preferences, preference{tag: tag, quality: quality})
+		}
+	}
+	sort.SliceStable(preferences, func(i, j int) bool {
+		return preferences[i].quality > preferences[j].quality
+	})
+	for _, p := range preferences {
+		locales = append(locales, p.tag)
+	}
+	return locales
+}
+
+// validLanguageTag checks a language tag the way the catalog does: a language of two or three
+// letters, followed by subtags of up to eight letters and digits, separated by hyphens
+func validLanguageTag(tag string) bool {
+	subtags := strings.Split(tag, "-")
+	for i, subtag := range subtags {
+		if len(subtag) == 0 || len(subtag) > 8 || (i == 0 && (len(subtag) < 2 || len(subtag) > 3)) {
+			return false
+		}
+		for _, r := range subtag {
+			isLetter := (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+			if !isLetter && (i == 0 || r < '0' || r > '9') {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (cs *CommerceService) SuggestProducts(request *restful.Request, response *restful.Response) {
+
+	prefix := request.QueryParameter("q")
+	// an absent or malformed limit falls back to the catalog's default
+	limit, _ := strconv.ParseUint(request.QueryParameter("limit"), 10, 32)
+	res, err := cs.catalogClient.SuggestProducts(context.Background(), &catalog.SuggestRequest{
+		Prefix: prefix,
+		Limit:  uint32(limit),
+	})
+	if err != nil {
+		writeError(response, err)
+		return
+	}
+
+	suggestions := make([]productSuggestion, 0, len(res.Suggestions))
+	for _, suggestion := range res.Suggestions {
+		suggestions = append(suggestions, productSuggestion{SKU: suggestion.Sku, Name: suggestion.Name})
+	}
+	response.WriteEntity(suggestions)
+}
+
+func (cs *CommerceService) GetRelatedProducts(request *restful.Request, response *restful.Response) {
+
+	sku := request.PathParameter("sku")
+	// an absent or malformed limit falls back to the catalog's default
+	limit, _ := strconv.ParseUint(request.QueryParameter("limit"), 10, 32)
+	res, err := cs.catalogClient.GetRelatedProducts(context.Background(), &catalog.RelatedRequest{
+		Sku:          sku,
+		Limit:        uint32(limit),
+		CurrencyCode: request.QueryParameter("currency"),
+	})
+	if err != nil {
+		writeError(response, err)
+		return
+	}
+
+	related := make([]relatedProduct, 0, len(res.Related))
+	for _, r := range res.Related {
+		relations := make([]string, 0, len(r.Relations))
+		for _, relation := range r.Relations {
+			relations = append(relations, relationNames[relation])
+		}
+		related = append(related, relatedProduct{
+			SKU:                 r.Product.Sku,
+			Name:                r.Product.Name,
+			Price:               toMoney(r.Product.Price),
+			Relations:           relations,
+			BoughtTogetherCount: r.BoughtTogetherCount,
+		})
+	}
+	response.WriteEntity(related)
+}
+
+func (cs *CommerceService) getCatalogDetails(ctx context.Context, sku, currencyCode string, locales []string) chan catalogResults {
 	ch := make(chan catalogResults, 1)
 
+	request := &catalog.DetailRequest{Sku: sku, CurrencyCode: currencyCode}
+	if len(locales) > 0 {
+		request.Locale, request.FallbackLocales = locales[0], locales[1:]
+	}
 	go func() {
-		res, err := cs.catalogClient.GetProductDetails(ctx, &catalog.DetailRequest{Sku: sku})
+		res, err := cs.catalogClient.GetProductDetails(ctx, request)
 		ch <- catalogResults{catalogResponse: res, err: err}
 	}()
 
 	return ch
 }
 
+// getVariantDetails looks up the warehouse stock of each variant. A variant the warehouse doesn't
+// know about is reported as out of stock.
+func (cs *CommerceService) getVariantDetails(ctx context.Context, variants []*catalog.Product) (details []variantDetails, err error) {
+	channels := make([]chan warehouseResults, 0, len(variants))
+	for _, variant := range variants {
+		channels = append(channels, cs.getWarehouseDetails(ctx, variant.Sku))
+	}
+	for i, variant := range variants {
+		reply := <-channels[i]
+		detail := variantDetails{
+			SKU:        variant.Sku,
+			Model:      variant.Model,
+			Price:      toMoney(variant.Price),
+			Attributes: attributeMap(variant.Attributes),
+		}
+		if reply.err != nil {
+			if realError := errors.Parse(reply.err.Error()); realError == nil || realError.Code != http.StatusNotFound {
+				return nil, reply.err
+			}
+		} else {
+			detail.StockRemaining = reply.warehouseResponse.Details.StockRemaining
+		}
+		details = append(details, detail)
+	}
+	return details, nil
+}
+
 func (cs *CommerceService) getWarehouseDetails(ctx context.Context, sku string) chan warehouseResults {
 	ch := make(chan warehouseResults, 1)
 
@@ -107,3 +280,103 @@ func writeError(response *restful.Response, err error) {
 	response.WriteError(http.StatusInternalServerError, err)
 
 }
+
+func attributeMap(attributes []*catalog.ProductAttribute) map[string]string {
+	if len(attributes) == 0 {
+		return nil
+	}
+	m := make(map[string]string, len(attributes))
+	for _, attribute := range attributes {
+		m[attribute.Name] = attribute.Value
+	}
+	return m
+}
+
+// mediaTypes names each type of media asset in the API's responses
+var mediaTypes = map[catalog.MediaType]string{
+	catalog.MediaType_MT_IMAGE:    "image",
+	catalog.MediaType_MT_VIDEO:    "video",
+	catalog.MediaType_MT_DOCUMENT: "document",
+}
+
+// relationNames names each way in which products can be related in the API's responses
+var relationNames = map[catalog.Relation]string{
+	catalog.Relation_REL_UNKNOWN:           "unknown",
+	catalog.Relation_REL_LINKED:            "linked",
+	catalog.Relation_REL_BOUGHT_TOGETHER:   "bought_together",
+	catalog.Relation_REL_SAME_CATEGORY:     "same_category",
+	catalog.Relation_REL_SAME_MANUFACTURER: "same_manufacturer",
+}
+
+func toMediaAssets(media []*catalog.MediaAsset) []mediaAsset {
+	assets := make([]mediaAsset, 0, len(media))
+	for _, asset := range media {
+		mediaType, ok := mediaTypes[asset.MediaType]
+		if !ok {
+			mediaType = "unknown"
+		}
+		assets = append(assets, mediaAsset{
+			URL:     asset.Url,
+			AltText: asset.AltText,
+			Type:    mediaType,
+			Width:   asset.Width,
+			Height:  asset.Height,
+		})
+	}
+	return assets
+}
+
+func toRating(summary *catalog.RatingSummary) rating {
+	r := rating{
+		Average:   summary.GetAverageRating(),
+		Count:     summary.GetReviewCount(),
+		Histogram: make(map[string]uint32, 5),
+	}
+	for stars := 1; stars <= 5; stars++ {
+		r.Histogram[strconv.Itoa(stars)] = 0
+	}
+	for i, count := range summary.GetHistogram() {
+		r.Histogram[strconv.Itoa(i+1)] = count
+	}
+	return r
+}
+
+// specificationMap gives each specification its value as the JSON type matching its own type
+func specificationMap(specifications []*catalog.Specification) map[string]interface{} {
+	if len(specifications) == 0 {
+		return nil
+	}
+	m := make(map[string]interface{}, len(specifications))
+	for _, specification := range specifications {
+		switch specification.Type {
+		case catalog.SpecificationType_ST_NUMBER:
+			m[specification.Name] = specification.NumberValue
+		case catalog.SpecificationType_ST_BOOLEAN:
+			m[specification.Name] = specification.BooleanValue
+		default:
+			m[specification.Name] = specification.TextValue
+		}
+	}
+	return m
+}
+
+// toBundleComponents lists a bundle's components along with the warehouse's stock of each
+func toBundleComponents(components []*catalog.BundleComponent, stock []*warehouse.ComponentStock) []bundleComponent {
+	remaining := make(map[string]uint32, len(stock))
+	for _, component := range stock {
+		remaining[component.Sku] = component.StockRemaining
+	}
+	var bundle []bundleComponent
+	for _, component := range components {
+		bundle = append(bundle, bundleComponent{
+			SKU:            component.Sku,
+			Quantity:       component.Quantity,
+			StockRemaining: remaining[component.Sku],
+		})
+	}
+	return bundle
+}
+
+func toMoney(price *catalog.Money) money {
+	return money{Amount: price.GetAmount(), CurrencyCode: price.GetCurrencyCode()}
+}
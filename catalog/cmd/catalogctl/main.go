@@ -0,0 +1,228 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/autodidaddict/go-shopping/catalog/internal/platform/broker"
+	"github.com/autodidaddict/go-shopping/catalog/internal/platform/bulk"
+	"github.com/autodidaddict/go-shopping/catalog/internal/platform/redis"
+	"github.com/autodidaddict/go-shopping/catalog/internal/service"
+	"github.com/autodidaddict/go-shopping/catalog/proto"
+	gmbroker "github.com/micro/go-micro/broker"
+	"golang.org/x/net/context"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const usage = `catalogctl imports and exports catalog products and categories in bulk.
+
+Usage:
+  catalogctl [-redis address] import [-format csv|jsonl] [-dry-run] [-publish] products|categories FILE
+  catalogctl [-redis address] export [-format csv|jsonl] [-o FILE] products|categories
+  catalogctl [-redis address] announce bundles
+
+Imports upsert products by SKU and categories by ID, so a file can safely be imported more than
+once. Import categories before the products that belong to them. The format defaults to the file's
+extension, and exports are written as CSV to standard output unless told otherwise.
+
+Announcing bundles publishes a product changed event for every bundle in the catalog, from which the
+warehouse learns the components of any bundle it missed.
+`
+
+type productChangedEventPublisher interface {
+	PublishProductChangedEvent(event *catalog.ProductChangedEvent) (err error)
+}
+
+// discardPublisher stands in for the event publisher when imports aren't to be announced
+type discardPublisher struct{}
+
+func (discardPublisher) PublishProductChangedEvent(event *catalog.ProductChangedEvent) (err error) {
+	return nil
+}
+
+func main() {
+	flag.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	redisAddress := flag.String("redis", ":6379", "address of the catalog's Redis server")
+	flag.Parse()
+
+	repo := redis.NewRedisRepository(*redisAddress)
+	var err error
+	switch flag.Arg(0) {
+	case "import":
+		err = runImport(repo, flag.Args()[1:])
+	case "export":
+		err = runExport(repo, flag.Args()[1:])
+	case "announce":
+		err = runAnnounce(repo, flag.Args()[1:])
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "catalogctl: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func runImport(repo *redis.CatalogRepository, args []string) error {
+	flags := flag.NewFlagSet("import", flag.ExitOnError)
+	formatName := flags.String("format", "", "csv or jsonl, defaulting to the file's extension")
+	dryRun := flags.Bool("dry-run", false, "validate the file without saving anything")
+	publish := flags.Bool("publish", false, "publish a product changed event for each saved product")
+	flags.Parse(args)
+	if flags.NArg() != 2 {
+		flags.Usage()
+		os.Exit(2)
+	}
+	kind, path := flags.Arg(0), flags.Arg(1)
+	if *formatName == "" {
+		*formatName = strings.TrimPrefix(filepath.Ext(path), ".")
+	}
+	format, err := bulk.ParseFormat(*formatName)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	var publisher productChangedEventPublisher = discardPublisher{}
+	if *publish && !*dryRun {
+		if err = gmbroker.Init(); err != nil {
+			return err
+		}
+		if err = gmbroker.Connect(); err != nil {
+			return err
+		}
+		publisher = broker.NewEventPublisher()
+	}
+	importer := service.NewCatalogImporter(repo, publisher)
+
+	var report service.ImportReport
+	switch kind {
+	case "products":
+		records, err := bulk.ReadProducts(file, format)
+		if err != nil {
+			return err
+		}
+		report = importer.ImportProducts(context.Background(), records, *dryRun)
+	case "categories":
+		records, err := bulk.ReadCategories(file, format)
+		if err != nil {
+			return err
+		}
+		report = importer.ImportCategories(context.Background(), records, *dryRun)
+	default:
+		return fmt.Errorf("unknown kind %q, expected products or categories", kind)
+	}
+
+	for _, rowError := range report.Errors {
+		fmt.Fprintln(os.Stderr, rowError)
+	}
+	verb := "Imported"
+	if *dryRun {
+		verb = "Validated"
+	}
+	fmt.Printf("%s %s: %d created, %d updated, %d failed\n", verb, kind, report.Created, report.Updated, len(report.Errors))
+	if len(report.Errors) > 0 {
+		return fmt.Errorf("%d rows could not be imported", len(report.Errors))
+	}
+	return nil
+}
+
+func runExport(repo *redis.CatalogRepository, args []string) error {
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	formatName := flags.String("format", "csv", "csv or jsonl")
+	output := flags.String("o", "", "file to write to instead of standard output")
+	flags.Parse(args)
+	if flags.NArg() != 1 {
+		flags.Usage()
+		os.Exit(2)
+	}
+	format, err := bulk.ParseFormat(*formatName)
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		w = file
+	}
+
+	switch kind := flags.Arg(0); kind {
+	case "products":
+		products, err := repo.GetAllProducts()
+		if err != nil {
+			return err
+		}
+		skus := make([]string, 0, len(products))
+		for _, product := range products {
+			skus = append(skus, product.Sku)
+		}
+		membership, err := repo.GetCategoryMembership(skus)
+		if err != nil {
+			return err
+		}
+		for _, categoryIDs := range membership {
+			sort.Slice(categoryIDs, func(i, j int) bool { return categoryIDs[i] < categoryIDs[j] })
+		}
+		return bulk.WriteProducts(w, format, products, membership)
+	case "categories":
+		categories, err := repo.GetCategories()
+		if err != nil {
+			return err
+		}
+		return bulk.WriteCategories(w, format, categories)
+	default:
+		return fmt.Errorf("unknown kind %q, expected products or categories", kind)
+	}
+}
+
+func runAnnounce(repo *redis.CatalogRepository, args []string) error {
+	if len(args) != 1 || args[0] != "bundles" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	products, err := repo.GetAllProducts()
+	if err != nil {
+		return err
+	}
+	if err = gmbroker.Init(); err != nil {
+		return err
+	}
+	if err = gmbroker.Connect(); err != nil {
+		return err
+	}
+	publisher := broker.NewEventPublisher()
+
+	announced := 0
+	for _, product := range products {
+		if product.Kind != catalog.ProductKind_PK_BUNDLE {
+			continue
+		}
+		err = publisher.PublishProductChangedEvent(&catalog.ProductChangedEvent{
+			Sku:        product.Sku,
+			ChangeType: catalog.ProductChangeType_PC_UPDATED,
+			Product:    product,
+			Timestamp:  time.Now().UTC().Unix(),
+		})
+		if err != nil {
+			return err
+		}
+		announced++
+	}
+	fmt.Printf("Announced %d bundles\n", announced)
+	return nil
+}
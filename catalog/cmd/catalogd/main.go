@@ -1,12 +1,15 @@
 package main
 
 import (
+	"github.com/autodidaddict/go-shopping/catalog/internal/platform/broker"
 	"github.com/autodidaddict/go-shopping/catalog/internal/platform/config"
 	"github.com/autodidaddict/go-shopping/catalog/internal/platform/redis"
 	"github.com/autodidaddict/go-shopping/catalog/internal/service"
 	"github.com/autodidaddict/go-shopping/catalog/proto"
+	"github.com/autodidaddict/go-shopping/shipping/proto"
 	"github.com/micro/go-grpc"
 	"github.com/micro/go-micro"
+	gmbroker "github.com/micro/go-micro/broker"
 	log "github.com/sirupsen/logrus"
 	"os"
 	"time"
@@ -16,6 +19,17 @@ func main() {
 	log.SetOutput(os.Stdout)
 	log.SetLevel(log.DebugLevel)
 
+	if err := gmbroker.Init(); err != nil {
+		log.Fatalf("Broker Init error: %v", err)
+	}
+	if err := gmbroker.Connect(); err != nil {
+		log.Fatalf("Broker Connect error: %v", err)
+	}
+	itemShippedChannel := make(chan *shipping.ItemShippedEvent)
+	if err := broker.CreateEventConsumer(itemShippedChannel); err != nil {
+		log.Fatalf("Broker Subscribe error: %v", err)
+	}
+
 	svc := grpc.NewService(
 		micro.Name(config.ServiceName),
 		micro.RegisterTTL(time.Second*30),
@@ -25,7 +39,28 @@ func main() {
 	svc.Init()
 
 	redisCatalogRepository := redis.NewRedisRepository(":6379")
-	catalog.RegisterCatalogHandler(svc.Server(), service.NewCatalogService(redisCatalogRepository))
+	publisher := broker.NewEventPublisher()
+	indexed, err := redisCatalogRepository.RebuildSearchIndex()
+	if err != nil {
+		log.Errorf("Failed to build product search index: %s", err)
+	} else {
+		log.Infof("Indexed %d products for search", indexed)
+	}
+	assigned, err := redisCatalogRepository.IndexCategoryMembership()
+	if err != nil {
+		log.Errorf("Failed to index product categories: %s", err)
+	} else {
+		log.Infof("Indexed %d category assignments", assigned)
+	}
+	priced, err := redisCatalogRepository.IndexScheduledPrices()
+	if err != nil {
+		log.Errorf("Failed to index scheduled prices: %s", err)
+	} else {
+		log.Infof("Indexed the scheduled prices of %d products", priced)
+	}
+	service.StartPriceScheduler(redisCatalogRepository, publisher, time.Minute)
+	catalog.RegisterCatalogHandler(svc.Server(), service.NewCatalogService(redisCatalogRepository, itemShippedChannel))
+	catalog.RegisterCatalogAdminHandler(svc.Server(), service.NewCatalogAdminService(redisCatalogRepository, publisher))
 
 	if err := svc.Run(); err != nil {
 		panic(err)
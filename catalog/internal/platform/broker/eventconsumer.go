@@ -0,0 +1,30 @@
+package broker
+
+import (
+	"github.com/autodidaddict/go-shopping/shipping/proto"
+	"github.com/micro/go-micro/broker"
+	"github.com/micro/protobuf/proto"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	itemShippedTopic = "go.shopping.item.shipped"
+)
+
+// CreateEventConsumer creates a broker subscription that converts broker messages into
+// item shipped events, placing those events on that channel so that the catalog can learn
+// which products are bought together.
+func CreateEventConsumer(itemShippedChannel chan *shipping.ItemShippedEvent) (err error) {
+	_, err = broker.Subscribe(itemShippedTopic, func(p broker.Publication) error {
+		log.Debugf("[sub] received message %+v", p.Message().Header)
+
+		var shippedEvent shipping.ItemShippedEvent
+		if err := proto.Unmarshal(p.Message().Body, &shippedEvent); err != nil {
+			log.Errorf("Failed to unmarshal broker message: %s", err)
+			return err
+		}
+		itemShippedChannel <- &shippedEvent
+		return nil
+	})
+	return err
+}
@@ -0,0 +1,41 @@
+package broker
+
+import (
+	"github.com/autodidaddict/go-shopping/catalog/proto"
+	"github.com/micro/go-micro/broker"
+	"github.com/micro/protobuf/proto"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	topic = "go.shopping.product.changed"
+)
+
+// EventPublisher is an event publisher for the go-micro broker
+type EventPublisher struct{}
+
+// NewEventPublisher creates a new broker event publisher
+func NewEventPublisher() *EventPublisher {
+	return &EventPublisher{}
+}
+
+// PublishProductChangedEvent publishes a product changed event on the broker
+func (p *EventPublisher) PublishProductChangedEvent(event *catalog.ProductChangedEvent) (err error) {
+	bytes, err := proto.Marshal(event)
+	if err != nil {
+		return err
+	}
+	msg := &broker.Message{
+		Header: map[string]string{
+			"sku":         event.Sku,
+			"change-type": event.ChangeType.String(),
+		},
+		Body: bytes,
+	}
+	if err := broker.Publish(topic, msg); err != nil {
+		log.Errorf("[pub] failed: %v", err)
+		return err
+	}
+	log.Debugf("[pub] pubbed product changed event, %s/%s", event.Sku, event.ChangeType)
+	return nil
+}
@@ -0,0 +1,652 @@
+package bulk
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"github.com/autodidaddict/go-shopping/catalog/proto"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Products and categories are exchanged either as CSV with a header row or as JSON Lines with one
+// object per line. The columns of a CSV file may appear in any order. Because a CSV cell holds a
+// single value, list-valued fields are joined with "|" and attributes are written as name=value
+// pairs, e.g. "color=red|size=L", as are bundle components, e.g. "CAM-1=1|LENS-2=2". Media assets,
+// translations, specifications and specification schemas are only carried by JSON Lines files, where
+// translations are keyed by locale and components map each component's SKU to its quantity. A product
+// with components is a bundle.
+// Specifications map each name to a JSON string, number or boolean, which gives the specification its
+// type.
+
+// Format names a bulk file format
+type Format string
+
+const (
+	// CSV is comma-separated values with a header row
+	CSV = Format("csv")
+
+	// JSONL is JSON Lines, one JSON object per line
+	JSONL = Format("jsonl")
+)
+
+// ParseFormat validates a format name
+func ParseFormat(name string) (Format, error) {
+	switch format := Format(strings.ToLower(name)); format {
+	case CSV, JSONL:
+		return format, nil
+	default:
+		return "", fmt.Errorf("unsupported format %q, expected csv or jsonl", name)
+	}
+}
+
+// ProductRecord is a product read from a bulk file along with the categories it belongs to. A row
+// that couldn't be read has Err set and no product.
+type ProductRecord struct {
+	Line        int
+	Product     *catalog.Product
+	CategoryIDs []uint64
+	Columns     map[string]bool // the columns of a CSV row; nil for JSON Lines, which carry every field
+	Err         error
+}
+
+// CategoryRecord is a category read from a bulk file. A row that couldn't be read has Err set and
+// no category.
+type CategoryRecord struct {
+	Line     int
+	Category *catalog.ProductCategory
+	Err      error
+}
+
+var productColumns = []string{"sku", "name", "description", "manufacturer", "model", "price", "currency_code",
+	"parent_sku", "attributes", "category_ids", "status", "components"}
+
+var categoryColumns = []string{"category_id", "name", "description", "parent_id"}
+
+type jsonProduct struct {
+	SKU          string                     `json:"sku"`
+	Name         string                     `json:"name"`
+	Description  string                     `json:"description,omitempty"`
+	Manufacturer string                     `json:"manufacturer,omitempty"`
+	Model        string                     `json:"model,omitempty"`
+	Price        int64                      `json:"price"`
+	CurrencyCode string                     `json:"currency_code,omitempty"`
+	ParentSKU    string                     `json:"parent_sku,omitempty"`
+	Attributes   map[string]string          `json:"attributes,omitempty"`
+	Media        []jsonMediaAsset           `json:"media,omitempty"`
+	CategoryIDs  []uint64                   `json:"category_ids,omitempty"`
+	Status       string                     `json:"status,omitempty"`
+	Translations map[string]jsonTranslation `json:"translations,omitempty"`
+	Components   map[string]uint32          `json:"components,omitempty"`
+	Specs        map[string]interface{}     `json:"specifications,omitempty"`
+}
+
+type jsonMediaAsset struct {
+	URL     string `json:"url"`
+	AltText string `json:"alt_text,omitempty"`
+	Type    string `json:"type"`
+	Width   uint32 `json:"width,omitempty"`
+	Height  uint32 `json:"height,omitempty"`
+}
+
+type jsonCategory struct {
+	CategoryID   uint64                     `json:"category_id"`
+	Name         string                     `json:"name"`
+	Description  string                     `json:"description,omitempty"`
+	ParentID     uint64                     `json:"parent_id,omitempty"`
+	Translations map[string]jsonTranslation `json:"translations,omitempty"`
+	Schema       []jsonDefinition           `json:"specification_schema,omitempty"`
+}
+
+type jsonDefinition struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Unit     string `json:"unit,omitempty"`
+	Required bool   `json:"required,omitempty"`
+}
+
+type jsonTranslation struct {
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// mediaTypes names each type of media asset in JSON Lines files
+var mediaTypes = map[catalog.MediaType]string{
+	catalog.MediaType_MT_IMAGE:    "image",
+	catalog.MediaType_MT_VIDEO:    "video",
+	catalog.MediaType_MT_DOCUMENT: "document",
+}
+
+// specificationTypes names each type of specification in JSON Lines specification schemas
+var specificationTypes = map[catalog.SpecificationType]string{
+	catalog.SpecificationType_ST_TEXT:    "text",
+	catalog.SpecificationType_ST_NUMBER:  "number",
+	catalog.SpecificationType_ST_BOOLEAN: "boolean",
+}
+
+// statuses names each product status. An empty status is read as active.
+var statuses = map[catalog.ProductStatus]string{
+	catalog.ProductStatus_PS_ACTIVE:       "active",
+	catalog.ProductStatus_PS_DRAFT:        "draft",
+	catalog.ProductStatus_PS_DISCONTINUED: "discontinued",
+	catalog.ProductStatus_PS_HIDDEN:       "hidden",
+}
+
+// ReadProducts reads every product in a bulk file. Rows that can't be read are returned with their
+// error so that the rest of the file can still be processed; an error is only returned when the
+// file as a whole is unreadable.
+func ReadProducts(r io.Reader, format Format) (records []ProductRecord, err error) {
+	if format == JSONL {
+		err = readLines(r, func(line int, data []byte) {
+			var p jsonProduct
+			if err := json.Unmarshal(data, &p); err != nil {
+				records = append(records, ProductRecord{Line: line, Err: err})
+				return
+			}
+			records = append(records, fromJSONProduct(line, p))
+		})
+		return records, err
+	}
+	err = readCSV(r, productColumns, func(line int, row map[string]string) {
+		records = append(records, fromCSVProduct(line, row))
+	})
+	return records, err
+}
+
+// ReadCategories reads every category in a bulk file, in the same manner as ReadProducts
+func ReadCategories(r io.Reader, format Format) (records []CategoryRecord, err error) {
+	if format == JSONL {
+		err = readLines(r, func(line int, data []byte) {
+			var c jsonCategory
+			if err := json.Unmarshal(data, &c); err != nil {
+				records = append(records, CategoryRecord{Line: line, Err: err})
+				return
+			}
+			records = append(records, CategoryRecord{Line: line, Category: &catalog.ProductCategory{
+				CategoryId:          c.CategoryID,
+				Name:                c.Name,
+				Description:         c.Description,
+				ParentId:            c.ParentID,
+				Translations:        fromJSONTranslations(c.Translations),
+				SpecificationSchema: fromJSONSchema(c.Schema),
+			}})
+		})
+		return records, err
+	}
+	err = readCSV(r, categoryColumns, func(line int, row map[string]string) {
+		records = append(records, fromCSVCategory(line, row))
+	})
+	return records, err
+}
+
+// WriteProducts writes products, along with the categories each belongs to, in a bulk format
+func WriteProducts(w io.Writer, format Format, products []*catalog.Product, membership map[string][]uint64) error {
+	if format == JSONL {
+		encoder := json.NewEncoder(w)
+		for _, product := range products {
+			if err := encoder.Encode(toJSONProduct(product, membership[product.Sku])); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	writer := csv.NewWriter(w)
+	writer.Write(productColumns)
+	for _, product := range products {
+		writer.Write([]string{
+			product.Sku,
+			product.Name,
+			product.Description,
+			product.Manufacturer,
+			product.Model,
+			strconv.FormatInt(product.Price.GetAmount(), 10),
+			product.Price.GetCurrencyCode(),
+			product.ParentSku,
+			joinAttributes(product.Attributes),
+			joinIDs(membership[product.Sku]),
+			statuses[product.Status],
+			joinComponents(product.Components),
+		})
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// WriteCategories writes categories in a bulk format
+func WriteCategories(w io.Writer, format Format, categories []*catalog.ProductCategory) error {
+	if format == JSONL {
+		encoder := json.NewEncoder(w)
+		for _, category := range categories {
+			err := encoder.Encode(jsonCategory{
+				CategoryID:   category.CategoryId,
+				Name:         category.Name,
+				Description:  category.Description,
+				ParentID:     category.ParentId,
+				Translations: toJSONTranslations(category.Translations),
+				Schema:       toJSONSchema(category.SpecificationSchema),
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	writer := csv.NewWriter(w)
+	writer.Write(categoryColumns)
+	for _, category := range categories {
+		writer.Write([]string{
+			strconv.FormatUint(category.CategoryId, 10),
+			category.Name,
+			category.Description,
+			strconv.FormatUint(category.ParentId, 10),
+		})
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// readLines hands each non-blank line to the callback along with its line number
+func readLines(r io.Reader, callback func(line int, data []byte)) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(strings.TrimSpace(scanner.Text())) > 0 {
+			callback(line, scanner.Bytes())
+		}
+	}
+	return scanner.Err()
+}
+
+// readCSV hands each row to the callback keyed by its column names. The header row must name only
+// known columns; cells missing from the end of a row read as empty.
+func readCSV(r io.Reader, columns []string, callback func(line int, row map[string]string)) error {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err != nil {
+		return fmt.Errorf("unable to read header: %s", err)
+	}
+	known := make(map[string]bool, len(columns))
+	for _, column := range columns {
+		known[column] = true
+	}
+	for i, name := range header {
+		header[i] = strings.TrimSpace(strings.ToLower(name))
+		if !known[header[i]] {
+			return fmt.Errorf("unknown column %q", name)
+		}
+	}
+
+	for {
+		fields, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		line, _ := reader.FieldPos(0)
+		row := make(map[string]string, len(header))
+		for i, name := range header {
+			if i < len(fields) {
+				row[name] = fields[i]
+			} else {
+				row[name] = ""
+			}
+		}
+		callback(line, row)
+	}
+}
+
+func fromCSVProduct(line int, row map[string]string) ProductRecord {
+	record := ProductRecord{Line: line}
+	price, err := parseInt(row["price"])
+	if err != nil {
+		record.Err = fmt.Errorf("invalid price: %s", row["price"])
+		return record
+	}
+	attributes, err := splitAttributes(row["attributes"])
+	if err != nil {
+		record.Err = err
+		return record
+	}
+	record.CategoryIDs, err = splitIDs(row["category_ids"])
+	if err != nil {
+		record.Err = err
+		return record
+	}
+	status, err := parseStatus(row["status"])
+	if err != nil {
+		record.Err = err
+		return record
+	}
+	components, err := splitComponents(row["components"])
+	if err != nil {
+		record.Err = err
+		return record
+	}
+	record.Columns = make(map[string]bool, len(row))
+	for column := range row {
+		record.Columns[column] = true
+	}
+	record.Product = &catalog.Product{
+		Sku:          strings.TrimSpace(row["sku"]),
+		Name:         row["name"],
+		Description:  row["description"],
+		Manufacturer: row["manufacturer"],
+		Model:        row["model"],
+		Price:        &catalog.Money{Amount: price, CurrencyCode: strings.TrimSpace(row["currency_code"])},
+		ParentSku:    strings.TrimSpace(row["parent_sku"]),
+		Attributes:   attributes,
+		Status:       status,
+		Components:   components,
+	}
+	if len(components) > 0 {
+		record.Product.Kind = catalog.ProductKind_PK_BUNDLE
+	}
+	return record
+}
+
+func fromCSVCategory(line int, row map[string]string) CategoryRecord {
+	record := CategoryRecord{Line: line}
+	categoryID, err := parseUint(row["category_id"])
+	if err != nil {
+		record.Err = fmt.Errorf("invalid category_id: %s", row["category_id"])
+		return record
+	}
+	parentID, err := parseUint(row["parent_id"])
+	if err != nil {
+		record.Err = fmt.Errorf("invalid parent_id: %s", row["parent_id"])
+		return record
+	}
+	record.Category = &catalog.ProductCategory{
+		CategoryId:  categoryID,
+		Name:        row["name"],
+		Description: row["description"],
+		ParentId:    parentID,
+	}
+	return record
+}
+
+func fromJSONProduct(line int, p jsonProduct) ProductRecord {
+	status, err := parseStatus(p.Status)
+	if err != nil {
+		return ProductRecord{Line: line, Err: err}
+	}
+	product := &catalog.Product{
+		Sku:          p.SKU,
+		Name:         p.Name,
+		Description:  p.Description,
+		Manufacturer: p.Manufacturer,
+		Model:        p.Model,
+		Price:        &catalog.Money{Amount: p.Price, CurrencyCode: p.CurrencyCode},
+		ParentSku:    p.ParentSKU,
+		Attributes:   sortedAttributes(p.Attributes),
+		Status:       status,
+		Translations: fromJSONTranslations(p.Translations),
+	}
+	if product.Specifications, err = fromJSONSpecifications(p.Specs); err != nil {
+		return ProductRecord{Line: line, Err: err}
+	}
+	for sku, quantity := range p.Components {
+		product.Kind = catalog.ProductKind_PK_BUNDLE
+		product.Components = append(product.Components, &catalog.BundleComponent{Sku: sku, Quantity: quantity})
+	}
+	sort.Slice(product.Components, func(i, j int) bool {
+		return product.Components[i].Sku < product.Components[j].Sku
+	})
+	for _, asset := range p.Media {
+		product.Media = append(product.Media, &catalog.MediaAsset{
+			Url:       asset.URL,
+			AltText:   asset.AltText,
+			MediaType: parseMediaType(asset.Type),
+			Width:     asset.Width,
+			Height:    asset.Height,
+		})
+	}
+	return ProductRecord{Line: line, Product: product, CategoryIDs: p.CategoryIDs}
+}
+
+func toJSONProduct(product *catalog.Product, categoryIDs []uint64) jsonProduct {
+	p := jsonProduct{
+		SKU:          product.Sku,
+		Name:         product.Name,
+		Description:  product.Description,
+		Manufacturer: product.Manufacturer,
+		Model:        product.Model,
+		Price:        product.Price.GetAmount(),
+		CurrencyCode: product.Price.GetCurrencyCode(),
+		ParentSKU:    product.ParentSku,
+		CategoryIDs:  categoryIDs,
+		Status:       statuses[product.Status],
+		Translations: toJSONTranslations(product.Translations),
+	}
+	if len(product.Specifications) > 0 {
+		p.Specs = make(map[string]interface{}, len(product.Specifications))
+		for _, specification := range product.Specifications {
+			switch specification.Type {
+			case catalog.SpecificationType_ST_NUMBER:
+				p.Specs[specification.Name] = specification.NumberValue
+			case catalog.SpecificationType_ST_BOOLEAN:
+				p.Specs[specification.Name] = specification.BooleanValue
+			default:
+				p.Specs[specification.Name] = specification.TextValue
+			}
+		}
+	}
+	if len(product.Components) > 0 {
+		p.Components = make(map[string]uint32, len(product.Components))
+		for _, component := range product.Components {
+			p.Components[component.Sku] = component.Quantity
+		}
+	}
+	if len(product.Attributes) > 0 {
+		p.Attributes = make(map[string]string, len(product.Attributes))
+		for _, attribute := range product.Attributes {
+			p.Attributes[attribute.Name] = attribute.Value
+		}
+	}
+	for _, asset := range product.Media {
+		p.Media = append(p.Media, jsonMediaAsset{
+			URL:     asset.Url,
+			AltText: asset.AltText,
+			Type:    mediaTypes[asset.MediaType],
+			Width:   asset.Width,
+			Height:  asset.Height,
+		})
+	}
+	return p
+}
+
+// fromJSONTranslations lists translations keyed by locale in order of locale
+func fromJSONTranslations(translations map[string]jsonTranslation) (list []*catalog.Translation) {
+	for locale, t := range translations {
+		list = append(list, &catalog.Translation{Locale: locale, Name: t.Name, Description: t.Description})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Locale < list[j].Locale })
+	return list
+}
+
+func toJSONTranslations(translations []*catalog.Translation) map[string]jsonTranslation {
+	if len(translations) == 0 {
+		return nil
+	}
+	m := make(map[string]jsonTranslation, len(translations))
+	for _, t := range translations {
+		m[t.Locale] = jsonTranslation{Name: t.Name, Description: t.Description}
+	}
+	return m
+}
+
+// fromJSONSpecifications lists specifications in order of name, typed by their JSON values
+func fromJSONSpecifications(specs map[string]interface{}) (specifications []*catalog.Specification, err error) {
+	for name, value := range specs {
+		specification := &catalog.Specification{Name: name}
+		switch v := value.(type) {
+		case string:
+			specification.Type, specification.TextValue = catalog.SpecificationType_ST_TEXT, v
+		case float64:
+			specification.Type, specification.NumberValue = catalog.SpecificationType_ST_NUMBER, v
+		case bool:
+			specification.Type, specification.BooleanValue = catalog.SpecificationType_ST_BOOLEAN, v
+		default:
+			return nil, fmt.Errorf("invalid specification %q, expected a string, number or boolean", name)
+		}
+		specifications = append(specifications, specification)
+	}
+	sort.Slice(specifications, func(i, j int) bool { return specifications[i].Name < specifications[j].Name })
+	return specifications, nil
+}
+
+// fromJSONSchema reads specification definitions, leaving any with an unknown type name untyped
+func fromJSONSchema(definitions []jsonDefinition) (schema []*catalog.SpecificationDefinition) {
+	for _, d := range definitions {
+		definition := &catalog.SpecificationDefinition{Name: d.Name, Unit: d.Unit, Required: d.Required}
+		for specificationType, name := range specificationTypes {
+			if name == strings.ToLower(d.Type) {
+				definition.Type = specificationType
+			}
+		}
+		schema = append(schema, definition)
+	}
+	return schema
+}
+
+func toJSONSchema(schema []*catalog.SpecificationDefinition) (definitions []jsonDefinition) {
+	for _, definition := range schema {
+		definitions = append(definitions, jsonDefinition{
+			Name:     definition.Name,
+			Type:     specificationTypes[definition.Type],
+			Unit:     definition.Unit,
+			Required: definition.Required,
+		})
+	}
+	return definitions
+}
+
+func parseStatus(name string) (catalog.ProductStatus, error) {
+	if name = strings.TrimSpace(strings.ToLower(name)); name == "" {
+		return catalog.ProductStatus_PS_ACTIVE, nil
+	}
+	for status, n := range statuses {
+		if n == name {
+			return status, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid status %q, expected active, draft, discontinued or hidden", name)
+}
+
+func parseMediaType(name string) catalog.MediaType {
+	for mediaType, n := range mediaTypes {
+		if n == strings.ToLower(name) {
+			return mediaType
+		}
+	}
+	return catalog.MediaType_MT_UNKNOWN
+}
+
+func parseInt(s string) (int64, error) {
+	if s = strings.TrimSpace(s); s == "" {
+		return 0, nil
+	}
+	return strconv.ParseInt(s, 10, 64)
+}
+
+func parseUint(s string) (uint64, error) {
+	if s = strings.TrimSpace(s); s == "" {
+		return 0, nil
+	}
+	return strconv.ParseUint(s, 10, 64)
+}
+
+func splitIDs(s string) (ids []uint64, err error) {
+	for _, field := range splitList(s) {
+		id, err := strconv.ParseUint(field, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid category ID: %s", field)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func joinIDs(ids []uint64) string {
+	fields := make([]string, 0, len(ids))
+	for _, id := range ids {
+		fields = append(fields, strconv.FormatUint(id, 10))
+	}
+	return strings.Join(fields, "|")
+}
+
+func splitAttributes(s string) (attributes []*catalog.ProductAttribute, err error) {
+	for _, field := range splitList(s) {
+		pair := strings.SplitN(field, "=", 2)
+		if len(pair) != 2 {
+			return nil, fmt.Errorf("invalid attribute %q, expected name=value", field)
+		}
+		attributes = append(attributes, &catalog.ProductAttribute{
+			Name:  strings.TrimSpace(pair[0]),
+			Value: strings.TrimSpace(pair[1]),
+		})
+	}
+	return attributes, nil
+}
+
+func joinAttributes(attributes []*catalog.ProductAttribute) string {
+	fields := make([]string, 0, len(attributes))
+	for _, attribute := range attributes {
+		fields = append(fields, attribute.Name+"="+attribute.Value)
+	}
+	return strings.Join(fields, "|")
+}
+
+// splitComponents reads bundle components written as SKU=quantity pairs, ordering them by SKU
+func splitComponents(s string) (components []*catalog.BundleComponent, err error) {
+	for _, field := range splitList(s) {
+		pair := strings.SplitN(field, "=", 2)
+		if len(pair) != 2 {
+			return nil, fmt.Errorf("invalid component %q, expected sku=quantity", field)
+		}
+		quantity, err := strconv.ParseUint(strings.TrimSpace(pair[1]), 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid component quantity %q", field)
+		}
+		components = append(components, &catalog.BundleComponent{Sku: strings.TrimSpace(pair[0]), Quantity: uint32(quantity)})
+	}
+	sort.Slice(components, func(i, j int) bool {
+		return components[i].Sku < components[j].Sku
+	})
+	return components, nil
+}
+
+func joinComponents(components []*catalog.BundleComponent) string {
+	fields := make([]string, 0, len(components))
+	for _, component := range components {
+		fields = append(fields, component.Sku+"="+strconv.FormatUint(uint64(component.Quantity), 10))
+	}
+	return strings.Join(fields, "|")
+}
+
+func sortedAttributes(m map[string]string) (attributes []*catalog.ProductAttribute) {
+	for name, value := range m {
+		attributes = append(attributes, &catalog.ProductAttribute{Name: name, Value: value})
+	}
+	sort.Slice(attributes, func(i, j int) bool {
+		return attributes[i].Name < attributes[j].Name
+	})
+	return attributes
+}
+
+func splitList(s string) (fields []string) {
+	for _, field := range strings.Split(s, "|") {
+		if field = strings.TrimSpace(field); field != "" {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
@@ -6,3 +6,7 @@ var Version = "(undefined)"
 
 // ServiceName indicates the discovery identity for this service
 const ServiceName = "go.shopping.srv.catalog"
+
+// BaseCurrency is the ISO-4217 code of the currency in which catalog prices are stored. Prices are
+// converted into other currencies using the exchange rate table.
+var BaseCurrency = "USD"
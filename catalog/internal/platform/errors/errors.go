@@ -17,4 +17,113 @@ const (
 
 	// NoSuchProduct indicates a request for a non-existent product
 	NoSuchProduct = Error("No such product")
+
+	// InvalidSKU indicates a SKU that is missing or contains characters that can't be used in a key
+	InvalidSKU = Error("Invalid SKU")
+
+	// MissingProductName indicates an attempt to save a product without a name
+	MissingProductName = Error("Product name is required")
+
+	// InvalidPrice indicates an attempt to save a product with a negative price
+	InvalidPrice = Error("Price cannot be negative")
+
+	// UnsupportedCurrency indicates an attempt to price a product in a currency other than the catalog's
+	// base currency
+	UnsupportedCurrency = Error("Prices must be given in the catalog's base currency")
+
+	// InvalidPriceSchedule indicates a scheduled price that ends before it starts, or starts in the past
+	InvalidPriceSchedule = Error("Scheduled prices must start in the future and end after they start")
+
+	// NoSuchPriceChange indicates an attempt to cancel a scheduled price that doesn't exist or was
+	// already cancelled
+	NoSuchPriceChange = Error("No such scheduled price")
+
+	// InvalidProductStatus indicates an attempt to save a product with an unknown lifecycle status
+	InvalidProductStatus = Error("Invalid product status")
+
+	// InvalidMediaAsset indicates a media asset without an absolute http(s) URL or a known media type
+	InvalidMediaAsset = Error("Invalid media asset")
+
+	// InvalidBundle indicates a bundle without components, with a component that doesn't exist or is a
+	// bundle itself, or with a component quantity of zero, or a product with components that isn't a
+	// bundle
+	InvalidBundle = Error("Bundles need one or more distinct products that aren't bundles, each with a quantity")
+
+	// InvalidTranslation indicates a translation with a malformed or repeated locale, or without any text
+	InvalidTranslation = Error("Translations need a valid locale, used only once, and a name or description")
+
+	// InvalidSpecification indicates a specification without a name, with the same name as another, or
+	// with an unknown type or a value of the wrong type
+	InvalidSpecification = Error("Specifications need a unique name and a value of a known type")
+
+	// InvalidSpecificationSchema indicates a specification definition without a name, with the same name
+	// as another or with an unknown type, or with a unit when it isn't a number
+	InvalidSpecificationSchema = Error("Specification definitions need a unique name and a known type, and only numbers have units")
+
+	// UndefinedSpecification indicates a product specification that none of the product's categories, or
+	// their ancestors, define, or that they define with another type
+	UndefinedSpecification = Error("Specifications must be defined, with the same type, by the product's categories")
+
+	// MissingSpecification indicates a product without a specification that one of its categories, or one
+	// of their ancestors, requires
+	MissingSpecification = Error("Product is missing a specification required by its categories")
+
+	// InvalidRelatedProduct indicates an attempt to link a product to itself
+	InvalidRelatedProduct = Error("A product cannot be related to itself")
+
+	// NoSuchReview indicates a request for a review that doesn't exist, or that customers can't see
+	// because it hasn't been approved
+	NoSuchReview = Error("No such review")
+
+	// InvalidRating indicates a review whose rating isn't between 1 and 5 stars
+	InvalidRating = Error("Ratings must be between 1 and 5 stars")
+
+	// InvalidReview indicates a review without a customer or a title, or with a title or body that is
+	// too long
+	InvalidReview = Error("Reviews need a customer and a title, and must not be too long")
+
+	// InvalidReviewStatus indicates an attempt to moderate a review into any state but approved or rejected
+	InvalidReviewStatus = Error("Reviews can only be approved or rejected")
+
+	// InvalidReviewVote indicates a customer voting on their own review
+	InvalidReviewVote = Error("Customers cannot vote on their own reviews")
+
+	// DuplicateReview indicates a customer reviewing a product they have already reviewed
+	DuplicateReview = Error("Customer has already reviewed this product")
+
+	// MissingCategoryName indicates an attempt to save a category without a name
+	MissingCategoryName = Error("Category name is required")
+
+	// InvalidParentCategory indicates an attempt to place a category beneath a category that doesn't
+	// exist, or beneath itself or one of its own subcategories
+	InvalidParentCategory = Error("Invalid parent category")
+
+	// InvalidParentProduct indicates an attempt to make a product a variant of a product that doesn't
+	// exist or is itself a variant, or to make a product with variants of its own into a variant
+	InvalidParentProduct = Error("Invalid parent product")
+
+	// InvalidAttribute indicates a product attribute without a name, or with the same name as another
+	InvalidAttribute = Error("Attribute names must be present and unique")
+
+	// ProductHasVariants indicates an attempt to delete a product whose variants still exist
+	ProductHasVariants = Error("Product has variants")
+
+	// ProductInBundle indicates an attempt to delete a product that is a component of a bundle, or to
+	// make such a product into a bundle
+	ProductInBundle = Error("Product is part of a bundle")
+
+	// DuplicateProduct indicates an attempt to create a product with a SKU that is already in use
+	DuplicateProduct = Error("Product already exists")
+
+	// DuplicateCategory indicates an attempt to create a category with an ID that is already in use
+	DuplicateCategory = Error("Category already exists")
+
+	// SearchTermsNotPruned indicates that a change was saved, but that search terms no product uses any
+	// longer couldn't be removed from the vocabulary afterwards. They match nothing, so they do no harm
+	// beyond being offered as spelling corrections.
+	SearchTermsNotPruned = Error("Change was saved, but unused search terms could not be pruned")
+
+	// ConcurrentModification indicates that a change was abandoned because the data it depended
+	// on was modified at the same time. The change can safely be retried.
+	ConcurrentModification = Error("Catalog was modified concurrently")
 )
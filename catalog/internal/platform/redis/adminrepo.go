@@ -0,0 +1,600 @@
+package redis
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/autodidaddict/go-shopping/catalog/internal/platform/errors"
+	"github.com/autodidaddict/go-shopping/catalog/proto"
+	"github.com/garyburd/redigo/redis"
+)
+
+// A variant is stored like any other product, with its parent_sku field naming the parent product. The
+// parent lists its variants in product:{sku}:variants, and each product's attributes are kept in a
+// product:{sku}:attributes hash. A product's media assets are kept, in display order, in the
+// product:{sku}:media list. Each product lists the categories it belongs to in the
+// product:{sku}:categories set, the reverse of the category:{id}:products sets, so that its categories
+// can be found without visiting every category.
+//
+// Changes that touch more than one key are made within MULTI/EXEC blocks so that the categories
+// set, the category:{id} hashes, the category:{id}:products sets and the search indexes never
+// disagree with one another. Any existence checks a change depends on are guarded with WATCH; if
+// a watched key is modified before the block executes the change is abandoned and
+// errors.ConcurrentModification is returned so that the caller can retry.
+
+func productKey(sku string) string {
+	return fmt.Sprintf("product:%s", sku)
+}
+
+func productAttributesKey(sku string) string {
+	return fmt.Sprintf("product:%s:attributes", sku)
+}
+
+func productMediaKey(sku string) string {
+	return fmt.Sprintf("product:%s:media", sku)
+}
+
+func productVariantsKey(sku string) string {
+	return fmt.Sprintf("product:%s:variants", sku)
+}
+
+func categoryKey(categoryID uint64) string {
+	return fmt.Sprintf("category:%d", categoryID)
+}
+
+func categoryProductsKey(categoryID uint64) string {
+	return fmt.Sprintf("category:%d:products", categoryID)
+}
+
+func productCategoriesKey(sku string) string {
+	return fmt.Sprintf("product:%s:categories", sku)
+}
+
+// CreateProduct adds a new product to the catalog and assigns it to the given categories
+func (r *CatalogRepository) CreateProduct(product *catalog.Product, categoryIDs []uint64) (err error) {
+	c, err := redis.Dial("tcp", r.redisDialString)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	if err = watch(c, productKey(product.Sku)); err != nil {
+		return err
+	}
+	if err = requireAbsent(c, productKey(product.Sku), errors.DuplicateProduct); err != nil {
+		return err
+	}
+	for _, categoryID := range categoryIDs {
+		if err = watch(c, categoryKey(categoryID)); err != nil {
+			return err
+		}
+		if err = requireExists(c, categoryKey(categoryID), errors.NoSuchCategory); err != nil {
+			return err
+		}
+	}
+	if err = requireValidParentProduct(c, product.Sku, product.ParentSku); err != nil {
+		return err
+	}
+	if err = requireValidComponents(c, product); err != nil {
+		return err
+	}
+	if err = requireConformingSpecifications(c, product.Sku, product.ParentSku, product.Specifications, categoryIDs); err != nil {
+		return err
+	}
+	priceChangeID, err := allocatePriceChangeID(c)
+	if err != nil {
+		return err
+	}
+
+	p := fromProduct(product)
+	c.Send("MULTI")
+	c.Send("HMSET", redis.Args{}.Add(productKey(p.SKU)).AddFlat(&p)...)
+	queuePriceChange(c, priceChangeID, listPriceChange(product))
+	queueListPrice(c, p)
+	queueAttributes(c, product)
+	queueMedia(c, product)
+	queueTranslations(c, productTranslationsKey(p.SKU), product.Translations)
+	queueSpecifications(c, product)
+	queueComponents(c, product)
+	if p.ParentSKU != "" {
+		c.Send("SADD", productVariantsKey(p.ParentSKU), p.SKU)
+	}
+	for _, categoryID := range categoryIDs {
+		c.Send("SADD", categoryProductsKey(categoryID), p.SKU)
+		c.Send("SADD", productCategoriesKey(p.SKU), categoryID)
+	}
+	queueIndex(c, p, product.Translations)
+	return execTransaction(c)
+}
+
+// UpdateProduct replaces the details of an existing product, returning the product as it was
+// before the update. A product without media keeps the media it has. errors.SearchTermsNotPruned
+// means that the product was nonetheless updated.
+func (r *CatalogRepository) UpdateProduct(product *catalog.Product) (previous *catalog.Product, err error) {
+	c, err := redis.Dial("tcp", r.redisDialString)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	if err = watch(c, productKey(product.Sku)); err != nil {
+		return nil, err
+	}
+	if err = requireExists(c, productKey(product.Sku), errors.NoSuchProduct); err != nil {
+		return nil, err
+	}
+	existing, err := loadProduct(c, product.Sku)
+	if err != nil {
+		return nil, err
+	}
+	if product.Media == nil {
+		product.Media = existing.Media
+	}
+	if product.ParentSku != existing.ParentSku {
+		if err = requireValidParentProduct(c, product.Sku, product.ParentSku); err != nil {
+			return nil, err
+		}
+	}
+	if err = requireValidComponents(c, product); err != nil {
+		return nil, err
+	}
+	if err = requireConformingSpecifications(c, product.Sku, product.ParentSku, product.Specifications, nil); err != nil {
+		return nil, err
+	}
+	old, err := loadIndexEntries(c, product.Sku)
+	if err != nil {
+		return nil, err
+	}
+	repriced := existing.GetPrice().GetAmount() != product.GetPrice().GetAmount() ||
+		existing.GetPrice().GetCurrencyCode() != product.GetPrice().GetCurrencyCode()
+	var priceChangeID uint64
+	if repriced {
+		if priceChangeID, err = allocatePriceChangeID(c); err != nil {
+			return nil, err
+		}
+	}
+
+	p := fromProduct(product)
+	c.Send("MULTI")
+	c.Send("HMSET", redis.Args{}.Add(productKey(p.SKU)).AddFlat(&p)...)
+	if repriced {
+		queuePriceChange(c, priceChangeID, listPriceChange(product))
+		if existing.ScheduledPrice == nil {
+			queueListPrice(c, p)
+		}
+	}
+	c.Send("DEL", productAttributesKey(p.SKU), productMediaKey(p.SKU), productTranslationsKey(p.SKU),
+		productSpecificationsKey(p.SKU))
+	queueAttributes(c, product)
+	queueMedia(c, product)
+	queueTranslations(c, productTranslationsKey(p.SKU), product.Translations)
+	queueSpecifications(c, product)
+	queueRemoveComponents(c, p.SKU, existing.Components)
+	queueComponents(c, product)
+	if existing.ParentSku != p.ParentSKU {
+		if existing.ParentSku != "" {
+			c.Send("SREM", productVariantsKey(existing.ParentSku), p.SKU)
+		}
+		if p.ParentSKU != "" {
+			c.Send("SADD", productVariantsKey(p.ParentSKU), p.SKU)
+		}
+	}
+	queueUnindex(c, p.SKU, old)
+	queueIndex(c, p, product.Translations)
+	if err = execTransaction(c); err != nil {
+		return nil, err
+	}
+	if err = pruneTerms(c, old.terms); err != nil {
+		return existing, errors.SearchTermsNotPruned
+	}
+	return existing, nil
+}
+
+// DeleteProduct removes a product from the catalog, from every category and from the search indexes.
+// Products that still have variants, or that are part of a bundle, can't be deleted.
+// errors.SearchTermsNotPruned means that the product was nonetheless deleted.
+func (r *CatalogRepository) DeleteProduct(sku string) (err error) {
+	c, err := redis.Dial("tcp", r.redisDialString)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	if err = watch(c, productKey(sku), productPricesKey(sku), productReviewsKey(sku), productCategoriesKey(sku)); err != nil {
+		return err
+	}
+	if err = requireExists(c, productKey(sku), errors.NoSuchProduct); err != nil {
+		return err
+	}
+	if err = watch(c, productVariantsKey(sku)); err != nil {
+		return err
+	}
+	if err = requireAbsent(c, productVariantsKey(sku), errors.ProductHasVariants); err != nil {
+		return err
+	}
+	if err = watch(c, productBundlesKey(sku)); err != nil {
+		return err
+	}
+	if err = requireAbsent(c, productBundlesKey(sku), errors.ProductInBundle); err != nil {
+		return err
+	}
+	components, err := loadComponents(c, sku)
+	if err != nil {
+		return err
+	}
+	parentSKU, err := redis.String(c.Do("HGET", productKey(sku), "parent_sku"))
+	if err != nil && err != redis.ErrNil {
+		return err
+	}
+	categoryIDs, err := redis.Int64s(c.Do("SMEMBERS", productCategoriesKey(sku)))
+	if err != nil {
+		return err
+	}
+	priceChangeIDs, err := redis.Int64s(c.Do("ZRANGE", productPricesKey(sku), 0, -1))
+	if err != nil {
+		return err
+	}
+	reviewIDs, err := redis.Int64s(c.Do("ZRANGE", productReviewsKey(sku), 0, -1))
+	if err != nil {
+		return err
+	}
+	old, err := loadIndexEntries(c, sku)
+	if err != nil {
+		return err
+	}
+
+	c.Send("MULTI")
+	for _, categoryID := range categoryIDs {
+		c.Send("SREM", categoryProductsKey(uint64(categoryID)), sku)
+	}
+	if parentSKU != "" {
+		c.Send("SREM", productVariantsKey(parentSKU), sku)
+	}
+	c.Send("DEL", productKey(sku), productAttributesKey(sku), productMediaKey(sku), productTranslationsKey(sku),
+		productSpecificationsKey(sku), productRelatedKey(sku), productBoughtWithKey(sku), productCategoriesKey(sku))
+	queueRemoveComponents(c, sku, components)
+	queueDeletePriceHistory(c, sku, priceChangeIDs)
+	queueDeleteReviews(c, sku, reviewIDs)
+	queueUnindex(c, sku, old)
+	if err = execTransaction(c); err != nil {
+		return err
+	}
+	if err = pruneTerms(c, old.terms); err != nil {
+		return errors.SearchTermsNotPruned
+	}
+	return nil
+}
+
+// CreateCategory adds a new category. When the category has no ID, the next free ID is allocated.
+func (r *CatalogRepository) CreateCategory(category *catalog.ProductCategory) (categoryID uint64, err error) {
+	c, err := redis.Dial("tcp", r.redisDialString)
+	if err != nil {
+		return 0, err
+	}
+	defer c.Close()
+
+	if err = watch(c, "categories"); err != nil {
+		return 0, err
+	}
+	categoryIDs, err := redis.Int64s(c.Do("SMEMBERS", "categories"))
+	if err != nil {
+		return 0, err
+	}
+	categoryID = category.CategoryId
+	if categoryID == 0 {
+		for _, existing := range categoryIDs {
+			if uint64(existing) > categoryID {
+				categoryID = uint64(existing)
+			}
+		}
+		categoryID++
+	}
+	if err = watch(c, categoryKey(categoryID)); err != nil {
+		return 0, err
+	}
+	if err = requireAbsent(c, categoryKey(categoryID), errors.DuplicateCategory); err != nil {
+		return 0, err
+	}
+	if err = requireValidParent(c, categoryID, category.ParentId); err != nil {
+		return 0, err
+	}
+
+	cat := redisCategory{Name: category.Name, Description: category.Description, ParentID: category.ParentId}
+	c.Send("MULTI")
+	c.Send("SADD", "categories", categoryID)
+	c.Send("HMSET", redis.Args{}.Add(categoryKey(categoryID)).AddFlat(&cat)...)
+	queueTranslations(c, categoryTranslationsKey(categoryID), category.Translations)
+	queueSpecificationSchema(c, categoryID, category.SpecificationSchema)
+	if err = execTransaction(c); err != nil {
+		return 0, err
+	}
+	return categoryID, nil
+}
+
+// UpdateCategory replaces the name, description, translations, specification schema and parent of an
+// existing category
+func (r *CatalogRepository) UpdateCategory(category *catalog.ProductCategory) (err error) {
+	c, err := redis.Dial("tcp", r.redisDialString)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	if err = watch(c, categoryKey(category.CategoryId)); err != nil {
+		return err
+	}
+	if err = requireExists(c, categoryKey(category.CategoryId), errors.NoSuchCategory); err != nil {
+		return err
+	}
+	if err = requireValidParent(c, category.CategoryId, category.ParentId); err != nil {
+		return err
+	}
+
+	cat := redisCategory{Name: category.Name, Description: category.Description, ParentID: category.ParentId}
+	c.Send("MULTI")
+	c.Send("SADD", "categories", category.CategoryId)
+	c.Send("HMSET", redis.Args{}.Add(categoryKey(category.CategoryId)).AddFlat(&cat)...)
+	c.Send("DEL", categoryTranslationsKey(category.CategoryId), categorySchemaKey(category.CategoryId))
+	queueTranslations(c, categoryTranslationsKey(category.CategoryId), category.Translations)
+	queueSpecificationSchema(c, category.CategoryId, category.SpecificationSchema)
+	return execTransaction(c)
+}
+
+// DeleteCategory removes a category. Products in the category are unassigned from it but not deleted,
+// and its subcategories are moved up to the category's parent.
+func (r *CatalogRepository) DeleteCategory(categoryID uint64) (err error) {
+	c, err := redis.Dial("tcp", r.redisDialString)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	if err = watch(c, categoryKey(categoryID), categoryProductsKey(categoryID)); err != nil {
+		return err
+	}
+	if err = requireExists(c, categoryKey(categoryID), errors.NoSuchCategory); err != nil {
+		return err
+	}
+	parents, err := watchCategoryParents(c)
+	if err != nil {
+		return err
+	}
+	skus, err := redis.Strings(c.Do("SMEMBERS", categoryProductsKey(categoryID)))
+	if err != nil {
+		c.Do("UNWATCH")
+		return err
+	}
+
+	c.Send("MULTI")
+	for _, sku := range skus {
+		c.Send("SREM", productCategoriesKey(sku), categoryID)
+	}
+	for childID, parentID := range parents {
+		if parentID == categoryID {
+			c.Send("HSET", categoryKey(childID), "parent_id", parents[categoryID])
+		}
+	}
+	c.Send("SREM", "categories", categoryID)
+	c.Send("DEL", categoryKey(categoryID), categoryProductsKey(categoryID), categoryTranslationsKey(categoryID),
+		categorySchemaKey(categoryID))
+	return execTransaction(c)
+}
+
+// AssignProductToCategory adds an existing product to an existing category, provided the product's
+// specifications conform to the category's schema
+func (r *CatalogRepository) AssignProductToCategory(sku string, categoryID uint64) (err error) {
+	c, err := redis.Dial("tcp", r.redisDialString)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	if err = watch(c, productKey(sku), categoryKey(categoryID)); err != nil {
+		return err
+	}
+	if err = requireExists(c, productKey(sku), errors.NoSuchProduct); err != nil {
+		return err
+	}
+	if err = requireExists(c, categoryKey(categoryID), errors.NoSuchCategory); err != nil {
+		return err
+	}
+	parentSKU, err := redis.String(c.Do("HGET", productKey(sku), "parent_sku"))
+	if err != nil && err != redis.ErrNil {
+		c.Do("UNWATCH")
+		return err
+	}
+	specifications, err := redis.StringMap(c.Do("HGETALL", productSpecificationsKey(sku)))
+	if err != nil {
+		c.Do("UNWATCH")
+		return err
+	}
+	err = requireConformingSpecifications(c, sku, parentSKU, decodeSpecifications(specifications), []uint64{categoryID})
+	if err != nil {
+		return err
+	}
+
+	c.Send("MULTI")
+	c.Send("SADD", "categories", categoryID)
+	c.Send("SADD", categoryProductsKey(categoryID), sku)
+	c.Send("SADD", productCategoriesKey(sku), categoryID)
+	return execTransaction(c)
+}
+
+// watchCategoryParents maps every category to its parent, watching all of the categories involved
+func watchCategoryParents(c redis.Conn) (parents map[uint64]uint64, err error) {
+	if err = watch(c, "categories"); err != nil {
+		return nil, err
+	}
+	categoryIDs, err := redis.Int64s(c.Do("SMEMBERS", "categories"))
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, len(categoryIDs))
+	for _, categoryID := range categoryIDs {
+		keys = append(keys, categoryKey(uint64(categoryID)))
+	}
+	if len(keys) > 0 {
+		if err = watch(c, keys...); err != nil {
+			return nil, err
+		}
+	}
+	parents = make(map[uint64]uint64, len(categoryIDs))
+	for _, categoryID := range categoryIDs {
+		parentID, err := redis.Uint64(c.Do("HGET", categoryKey(uint64(categoryID)), "parent_id"))
+		if err != nil && err != redis.ErrNil {
+			return nil, err
+		}
+		parents[uint64(categoryID)] = parentID
+	}
+	return parents, nil
+}
+
+// requireValidParent fails with errors.InvalidParentCategory, abandoning any watches, when a category's
+// parent doesn't exist or is the category itself or one of its descendants
+func requireValidParent(c redis.Conn, categoryID, parentID uint64) (err error) {
+	if parentID == 0 {
+		return nil
+	}
+	parents, err := watchCategoryParents(c)
+	if err != nil {
+		c.Do("UNWATCH")
+		return err
+	}
+	// walk up from the parent; a walk longer than the number of categories means the tree already has a cycle
+	id := parentID
+	for steps := 0; id != 0; steps++ {
+		_, exists := parents[id]
+		if !exists || id == categoryID || steps > len(parents) {
+			c.Do("UNWATCH")
+			return errors.InvalidParentCategory
+		}
+		id = parents[id]
+	}
+	return nil
+}
+
+// requireValidParentProduct fails with errors.InvalidParentProduct, abandoning any watches, when a
+// product's parent doesn't exist, is the product itself, is a variant or is a bundle. A product that
+// has variants of its own can't become a variant either, so variants are never nested.
+func requireValidParentProduct(c redis.Conn, sku, parentSKU string) (err error) {
+	if parentSKU == "" {
+		return nil
+	}
+	if err = watch(c, productKey(parentSKU), productVariantsKey(sku)); err != nil {
+		return err
+	}
+	if parentSKU == sku {
+		c.Do("UNWATCH")
+		return errors.InvalidParentProduct
+	}
+	if err = requireExists(c, productKey(parentSKU), errors.InvalidParentProduct); err != nil {
+		return err
+	}
+	if err = requireAbsent(c, productVariantsKey(sku), errors.InvalidParentProduct); err != nil {
+		return err
+	}
+	parent, err := redis.Values(c.Do("HMGET", productKey(parentSKU), "parent_sku", "kind"))
+	if err != nil {
+		c.Do("UNWATCH")
+		return err
+	}
+	var grandparent string
+	var kind int32
+	if _, err = redis.Scan(parent, &grandparent, &kind); err != nil {
+		c.Do("UNWATCH")
+		return err
+	}
+	if grandparent != "" || catalog.ProductKind(kind) == catalog.ProductKind_PK_BUNDLE {
+		c.Do("UNWATCH")
+		return errors.InvalidParentProduct
+	}
+	return nil
+}
+
+// queueAttributes queues the commands that save a product's attributes. It is meant to be used within
+// a MULTI block.
+func queueAttributes(c redis.Conn, product *catalog.Product) {
+	if len(product.Attributes) == 0 {
+		return
+	}
+	args := redis.Args{}.Add(productAttributesKey(product.Sku))
+	for _, attribute := range product.Attributes {
+		args = args.Add(attribute.Name, attribute.Value)
+	}
+	c.Send("HMSET", args...)
+}
+
+// queueMedia queues the commands that save a product's media assets. It is meant to be used within a
+// MULTI block.
+func queueMedia(c redis.Conn, product *catalog.Product) {
+	if len(product.Media) == 0 {
+		return
+	}
+	args := redis.Args{}.Add(productMediaKey(product.Sku))
+	for _, asset := range product.Media {
+		encoded, _ := json.Marshal(redisMediaAsset{
+			URL:       asset.Url,
+			AltText:   asset.AltText,
+			MediaType: int32(asset.MediaType),
+			Width:     asset.Width,
+			Height:    asset.Height,
+		})
+		args = args.Add(encoded)
+	}
+	c.Send("RPUSH", args...)
+}
+
+func watch(c redis.Conn, keys ...string) (err error) {
+	_, err = c.Do("WATCH", redis.Args{}.AddFlat(keys)...)
+	return err
+}
+
+// requireExists fails with notFound, abandoning any watches, when the key doesn't exist
+func requireExists(c redis.Conn, key string, notFound error) (err error) {
+	exists, err := redis.Bool(c.Do("EXISTS", key))
+	if err == nil && !exists {
+		err = notFound
+	}
+	if err != nil {
+		c.Do("UNWATCH")
+	}
+	return err
+}
+
+// requireAbsent fails with duplicate, abandoning any watches, when the key already exists
+func requireAbsent(c redis.Conn, key string, duplicate error) (err error) {
+	exists, err := redis.Bool(c.Do("EXISTS", key))
+	if err == nil && exists {
+		err = duplicate
+	}
+	if err != nil {
+		c.Do("UNWATCH")
+	}
+	return err
+}
+
+// execTransaction executes a MULTI block, reporting a conflict when a watched key was modified
+func execTransaction(c redis.Conn) (err error) {
+	reply, err := c.Do("EXEC")
+	if err != nil {
+		return err
+	}
+	if reply == nil {
+		return errors.ConcurrentModification
+	}
+	return nil
+}
+
+func fromProduct(product *catalog.Product) redisProduct {
+	return redisProduct{
+		SKU:          product.Sku,
+		Name:         product.Name,
+		Description:  product.Description,
+		Manufacturer: product.Manufacturer,
+		Model:        product.Model,
+		Price:        product.GetPrice().GetAmount(),
+		Currency:     product.GetPrice().GetCurrencyCode(),
+		ParentSKU:    product.ParentSku,
+		Status:       int32(product.Status),
+		Kind:         int32(product.Kind),
+	}
+}
@@ -0,0 +1,92 @@
+package redis
+
+import (
+	"fmt"
+	"github.com/autodidaddict/go-shopping/catalog/internal/platform/search"
+	"github.com/autodidaddict/go-shopping/catalog/proto"
+	"github.com/garyburd/redigo/redis"
+	"sort"
+	"strings"
+)
+
+// Autocomplete is backed by a single sorted set in which every member has the same score, so that
+// Redis orders the members lexicographically and ZRANGEBYLEX can find every member that starts
+// with a given prefix. Each member is a normalized product name (or a suffix of one beginning
+// at a word boundary, so that typing any word of the name will find it) followed by a NUL and the
+// product's SKU. The entries created for a product are kept in product:{sku}:completions.
+
+const (
+	autocompleteKey       = "search:autocomplete"
+	completionSeparator   = "\x00"
+	completionOverfetch   = 4
+	completionRangeFinish = "\xff"
+)
+
+func productCompletionsKey(sku string) string {
+	return fmt.Sprintf("product:%s:completions", sku)
+}
+
+// completionEntries returns the autocomplete index entries for a product
+func completionEntries(p redisProduct) (entries []string) {
+	words := search.Words(p.Name)
+	for i := range words {
+		entries = append(entries, strings.Join(words[i:], " ")+completionSeparator+p.SKU)
+	}
+	return entries
+}
+
+// SuggestProducts returns up to `limit` products whose names contain a word starting with the given
+// prefix. Products whose names start with the prefix are listed first, followed by shorter names.
+func (r *CatalogRepository) SuggestProducts(prefix string, limit int) (suggestions []*catalog.ProductSuggestion, err error) {
+	prefix = search.Normalize(prefix)
+	if len(prefix) == 0 || limit <= 0 {
+		return
+	}
+	c, err := redis.Dial("tcp", r.redisDialString)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	entries, err := redis.Strings(c.Do("ZRANGEBYLEX", autocompleteKey,
+		"["+prefix, "["+prefix+completionRangeFinish, "LIMIT", 0, limit*completionOverfetch))
+	if err != nil {
+		return nil, err
+	}
+
+	var skus []string
+	seen := make(map[string]bool)
+	for _, entry := range entries {
+		parts := strings.SplitN(entry, completionSeparator, 2)
+		if len(parts) != 2 || seen[parts[1]] {
+			continue
+		}
+		seen[parts[1]] = true
+		skus = append(skus, parts[1])
+		c.Send("HGET", fmt.Sprintf("product:%s", parts[1]), "name")
+	}
+	if err = c.Flush(); err != nil {
+		return nil, err
+	}
+	atStart := make(map[string]bool, len(skus))
+	for _, sku := range skus {
+		name, err := redis.String(c.Receive())
+		if err != nil && err != redis.ErrNil {
+			return nil, err
+		}
+		atStart[sku] = strings.HasPrefix(search.Normalize(name), prefix)
+		suggestions = append(suggestions, &catalog.ProductSuggestion{Sku: sku, Name: name})
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		a, b := suggestions[i], suggestions[j]
+		if atStart[a.Sku] != atStart[b.Sku] {
+			return atStart[a.Sku]
+		}
+		return len(a.Name) < len(b.Name)
+	})
+	if len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+	return suggestions, nil
+}
@@ -0,0 +1,101 @@
+package redis
+
+import (
+	"fmt"
+	"github.com/autodidaddict/go-shopping/catalog/internal/platform/errors"
+	"github.com/autodidaddict/go-shopping/catalog/proto"
+	"github.com/garyburd/redigo/redis"
+	"sort"
+	"strconv"
+)
+
+// A bundle lists its components in the product:{sku}:components hash of component SKU to quantity.
+// product:{sku}:bundles is the set of bundles that a product is a component of, which keeps a
+// component from being deleted, or from becoming a bundle itself, while bundles still hold it.
+
+func productComponentsKey(sku string) string {
+	return fmt.Sprintf("product:%s:components", sku)
+}
+
+func productBundlesKey(sku string) string {
+	return fmt.Sprintf("product:%s:bundles", sku)
+}
+
+// requireValidComponents watches a bundle's components, failing and abandoning any watches unless
+// each exists and isn't a bundle itself, and unless the bundle is neither a component of another
+// bundle nor a product with variants
+func requireValidComponents(c redis.Conn, product *catalog.Product) (err error) {
+	if product.Kind != catalog.ProductKind_PK_BUNDLE {
+		return nil
+	}
+	if err = watch(c, productBundlesKey(product.Sku), productVariantsKey(product.Sku)); err != nil {
+		return err
+	}
+	if err = requireAbsent(c, productBundlesKey(product.Sku), errors.ProductInBundle); err != nil {
+		return err
+	}
+	if err = requireAbsent(c, productVariantsKey(product.Sku), errors.InvalidBundle); err != nil {
+		return err
+	}
+	for _, component := range product.Components {
+		if err = watch(c, productKey(component.Sku)); err != nil {
+			return err
+		}
+		if err = requireExists(c, productKey(component.Sku), errors.InvalidBundle); err != nil {
+			return err
+		}
+		kind, err := redis.Int(c.Do("HGET", productKey(component.Sku), "kind"))
+		if err != nil && err != redis.ErrNil {
+			c.Do("UNWATCH")
+			return err
+		}
+		if catalog.ProductKind(kind) == catalog.ProductKind_PK_BUNDLE {
+			c.Do("UNWATCH")
+			return errors.InvalidBundle
+		}
+	}
+	return nil
+}
+
+// loadComponents loads a bundle's components ordered by SKU
+func loadComponents(c redis.Conn, sku string) (components []*catalog.BundleComponent, err error) {
+	quantities, err := redis.IntMap(c.Do("HGETALL", productComponentsKey(sku)))
+	if err != nil {
+		return nil, err
+	}
+	return decodeComponents(quantities), nil
+}
+
+// decodeComponents decodes a hash of component quantities keyed by SKU, ordering them by SKU
+func decodeComponents(quantities map[string]int) (components []*catalog.BundleComponent) {
+	for componentSKU, quantity := range quantities {
+		components = append(components, &catalog.BundleComponent{Sku: componentSKU, Quantity: uint32(quantity)})
+	}
+	sort.Slice(components, func(i, j int) bool {
+		return components[i].Sku < components[j].Sku
+	})
+	return components
+}
+
+// queueComponents queues the commands that save a bundle's components. It is meant to be used within
+// a MULTI block.
+func queueComponents(c redis.Conn, product *catalog.Product) {
+	if len(product.Components) == 0 {
+		return
+	}
+	args := redis.Args{}.Add(productComponentsKey(product.Sku))
+	for _, component := range product.Components {
+		args = args.Add(component.Sku, strconv.FormatUint(uint64(component.Quantity), 10))
+		c.Send("SADD", productBundlesKey(component.Sku), product.Sku)
+	}
+	c.Send("HMSET", args...)
+}
+
+// queueRemoveComponents queues the commands that remove a bundle's components. It is meant to be used
+// within a MULTI block.
+func queueRemoveComponents(c redis.Conn, sku string, components []*catalog.BundleComponent) {
+	for _, component := range components {
+		c.Send("SREM", productBundlesKey(component.Sku), sku)
+	}
+	c.Send("DEL", productComponentsKey(sku))
+}
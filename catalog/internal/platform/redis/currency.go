@@ -0,0 +1,20 @@
+package redis
+
+import (
+	"github.com/garyburd/redigo/redis"
+)
+
+// Exchange rates are kept in the currency:rates hash, keyed by ISO-4217 currency code. Each rate is a
+// decimal string giving the number of units of that currency that one unit of the base currency buys,
+// e.g. HSET currency:rates EUR 0.92. Rates can be changed at any time without restarting the service.
+const exchangeRatesKey = "currency:rates"
+
+// GetExchangeRates retrieves the exchange rate table
+func (r *CatalogRepository) GetExchangeRates() (rates map[string]string, err error) {
+	c, err := redis.Dial("tcp", r.redisDialString)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+	return redis.StringMap(c.Do("HGETALL", exchangeRatesKey))
+}
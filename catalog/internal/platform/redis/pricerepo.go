@@ -0,0 +1,386 @@
+package redis
+
+import (
+	"fmt"
+	"github.com/autodidaddict/go-shopping/catalog/internal/platform/config"
+	"github.com/autodidaddict/go-shopping/catalog/internal/platform/errors"
+	"github.com/autodidaddict/go-shopping/catalog/proto"
+	"github.com/garyburd/redigo/redis"
+	"time"
+)
+
+// Every price a product has had or is scheduled to have is kept in a price:{id} hash, with IDs
+// allocated from price:next_id. product:{sku}:prices is a sorted set of the product's price IDs
+// scored by the time each price starts, so that its history can be read in order. Price records
+// are never changed apart from being marked as cancelled, which keeps the history auditable.
+//
+// A scheduled price that hasn't ended or been cancelled is also kept in product:{sku}:scheduled,
+// scored by the time it starts, and in prices:due, scored by the next time it starts or ends. The
+// price in effect is kept in the product's own hash as effective_price, along with the ID of the
+// scheduled price it comes from as scheduled_price_id (0 while the list price is in effect), so that
+// it can be read and sorted on without visiting the history.
+
+const (
+	priceIDKey    = "price:next_id"
+	duePricesKey  = "prices:due"
+	duePriceBatch = 100
+)
+
+func priceKey(priceChangeID uint64) string {
+	return fmt.Sprintf("price:%d", priceChangeID)
+}
+
+func productPricesKey(sku string) string {
+	return fmt.Sprintf("product:%s:prices", sku)
+}
+
+func productScheduledPricesKey(sku string) string {
+	return fmt.Sprintf("product:%s:scheduled", sku)
+}
+
+// SchedulePrice records a scheduled price for an existing product. It takes effect once
+// ApplyScheduledPrices is run after it starts.
+func (r *CatalogRepository) SchedulePrice(change *catalog.PriceChange) (priceChangeID uint64, err error) {
+	c, err := redis.Dial("tcp", r.redisDialString)
+	if err != nil {
+		return 0, err
+	}
+	defer c.Close()
+
+	if err = watch(c, productKey(change.Sku)); err != nil {
+		return 0, err
+	}
+	if err = requireExists(c, productKey(change.Sku), errors.NoSuchProduct); err != nil {
+		return 0, err
+	}
+	priceChangeID, err = allocatePriceChangeID(c)
+	if err != nil {
+		return 0, err
+	}
+
+	c.Send("MULTI")
+	queuePriceChange(c, priceChangeID, change)
+	c.Send("ZADD", productScheduledPricesKey(change.Sku), change.StartsAt, priceChangeID)
+	c.Send("ZADD", duePricesKey, change.StartsAt, priceChangeID)
+	return priceChangeID, execTransaction(c)
+}
+
+// CancelScheduledPrice marks a scheduled price as cancelled so that it no longer takes effect. A
+// cancelled price that is in effect stays so until ApplyScheduledPrices is next run.
+func (r *CatalogRepository) CancelScheduledPrice(sku string, priceChangeID uint64) (err error) {
+	c, err := redis.Dial("tcp", r.redisDialString)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	if err = watch(c, priceKey(priceChangeID)); err != nil {
+		return err
+	}
+	change, err := loadPriceChange(c, priceChangeID)
+	if err != nil {
+		c.Do("UNWATCH")
+		return err
+	}
+	if change.SKU != sku || change.PriceType != int32(catalog.PriceType_PT_SCHEDULED) || change.CancelledAt != 0 {
+		c.Do("UNWATCH")
+		return errors.NoSuchPriceChange
+	}
+
+	now := time.Now().UTC().Unix()
+	c.Send("MULTI")
+	c.Send("HSET", priceKey(priceChangeID), "cancelled_at", now)
+	c.Send("ZREM", productScheduledPricesKey(sku), priceChangeID)
+	c.Send("ZADD", duePricesKey, now, priceChangeID)
+	return execTransaction(c)
+}
+
+// ApplyScheduledPrices brings up to date the price in effect of each product with a scheduled price
+// that has started, ended or been cancelled by the given time, returning the price each repriced
+// product had before. A price whose product changes while it is being applied is left due, to be
+// applied the next time round; so are those beyond the first hundred due.
+func (r *CatalogRepository) ApplyScheduledPrices(now int64) (previousPrices map[string]*catalog.Money, err error) {
+	c, err := redis.Dial("tcp", r.redisDialString)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	priceChangeIDs, err := redis.Int64s(c.Do("ZRANGEBYSCORE", duePricesKey, "-inf", now, "LIMIT", 0, duePriceBatch))
+	if err != nil {
+		return nil, err
+	}
+	previousPrices = make(map[string]*catalog.Money)
+	for _, priceChangeID := range priceChangeIDs {
+		sku, previous, err := applyScheduledPrice(c, uint64(priceChangeID), now)
+		if err == errors.ConcurrentModification {
+			continue
+		}
+		if err != nil {
+			return previousPrices, err
+		}
+		if _, ok := previousPrices[sku]; previous != nil && !ok {
+			previousPrices[sku] = previous
+		}
+	}
+	return previousPrices, nil
+}
+
+// IndexScheduledPrices keeps the price in effect with each product saved before it was kept there,
+// and indexes the product's scheduled prices, returning how many products it indexed
+func (r *CatalogRepository) IndexScheduledPrices() (indexed int, err error) {
+	c, err := redis.Dial("tcp", r.redisDialString)
+	if err != nil {
+		return 0, err
+	}
+	defer c.Close()
+
+	skus, err := scanProductSkus(c)
+	if err != nil {
+		return 0, err
+	}
+	now := time.Now().UTC().Unix()
+	for _, sku := range skus {
+		if err = watch(c, productKey(sku), productPricesKey(sku)); err != nil {
+			return indexed, err
+		}
+		kept, err := redis.Bool(c.Do("HEXISTS", productKey(sku), "effective_price"))
+		if err != nil || kept {
+			c.Do("UNWATCH")
+			if err != nil {
+				return indexed, err
+			}
+			continue
+		}
+		p, err := loadRedisProduct(c, sku)
+		if err != nil {
+			c.Do("UNWATCH")
+			return indexed, err
+		}
+		priceChangeIDs, err := redis.Int64s(c.Do("ZRANGE", productPricesKey(sku), 0, -1))
+		if err != nil {
+			c.Do("UNWATCH")
+			return indexed, err
+		}
+		c.Send("MULTI")
+		queueListPrice(c, p)
+		for _, priceChangeID := range priceChangeIDs {
+			change, err := loadPriceChange(c, uint64(priceChangeID))
+			if err != nil {
+				c.Do("DISCARD")
+				return indexed, err
+			}
+			if change.PriceType != int32(catalog.PriceType_PT_SCHEDULED) || change.CancelledAt != 0 ||
+				(change.EndsAt != 0 && change.EndsAt <= now) {
+				continue
+			}
+			c.Send("ZADD", productScheduledPricesKey(sku), change.StartsAt, priceChangeID)
+			c.Send("ZADD", duePricesKey, change.StartsAt, priceChangeID)
+		}
+		if err = execTransaction(c); err != nil {
+			return indexed, err
+		}
+		indexed++
+	}
+	return indexed, nil
+}
+
+// GetPriceHistory retrieves the prices of a product that start within the given range, ordered by
+// start time. A bound of 0 leaves that end of the range open.
+func (r *CatalogRepository) GetPriceHistory(sku string, from, to int64) (changes []*catalog.PriceChange, err error) {
+	c, err := redis.Dial("tcp", r.redisDialString)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	min, max := interface{}("-inf"), interface{}("+inf")
+	if from != 0 {
+		min = from
+	}
+	if to != 0 {
+		max = to
+	}
+	priceChangeIDs, err := redis.Int64s(c.Do("ZRANGEBYSCORE", productPricesKey(sku), min, max))
+	if err != nil {
+		return nil, err
+	}
+	for _, priceChangeID := range priceChangeIDs {
+		change, err := loadPriceChange(c, uint64(priceChangeID))
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, toPriceChange(uint64(priceChangeID), change))
+	}
+	return changes, nil
+}
+
+// allocatePriceChangeID reserves an ID for a new price record, abandoning any watches if it can't
+func allocatePriceChangeID(c redis.Conn) (priceChangeID uint64, err error) {
+	priceChangeID, err = redis.Uint64(c.Do("INCR", priceIDKey))
+	if err != nil {
+		c.Do("UNWATCH")
+	}
+	return priceChangeID, err
+}
+
+// listPriceChange records a change to a product's own price, taking effect immediately
+func listPriceChange(product *catalog.Product) *catalog.PriceChange {
+	now := time.Now().UTC().Unix()
+	return &catalog.PriceChange{
+		Sku:       product.Sku,
+		PriceType: catalog.PriceType_PT_LIST,
+		Price:     product.Price,
+		StartsAt:  now,
+		CreatedAt: now,
+	}
+}
+
+// applyScheduledPrice reschedules a due price for when it next ends, or stops scheduling it, and puts
+// the scheduled price that started most recently and hasn't ended in effect for its product, or the
+// list price when there is none. It returns the price in effect before, if it changed.
+func applyScheduledPrice(c redis.Conn, priceChangeID uint64, now int64) (sku string, previous *catalog.Money, err error) {
+	if err = watch(c, priceKey(priceChangeID)); err != nil {
+		return "", nil, err
+	}
+	change, err := loadPriceChange(c, priceChangeID)
+	if err == errors.NoSuchPriceChange {
+		c.Do("UNWATCH")
+		_, err = c.Do("ZREM", duePricesKey, priceChangeID)
+		return "", nil, err
+	}
+	if err != nil {
+		c.Do("UNWATCH")
+		return "", nil, err
+	}
+	sku = change.SKU
+	if err = watch(c, productKey(sku), productScheduledPricesKey(sku)); err != nil {
+		return sku, nil, err
+	}
+	v, err := redis.Values(c.Do("HGETALL", productKey(sku)))
+	if err != nil {
+		c.Do("UNWATCH")
+		return sku, nil, err
+	}
+	if len(v) == 0 {
+		c.Do("UNWATCH")
+		_, err = c.Do("ZREM", duePricesKey, priceChangeID)
+		return sku, nil, err
+	}
+	p, current, err := scanProductHash(v)
+	if err != nil {
+		c.Do("UNWATCH")
+		return sku, nil, err
+	}
+	ended := change.CancelledAt != 0 || (change.EndsAt != 0 && change.EndsAt <= now)
+
+	// scheduled prices are removed as they end, so only those that started by now need checking
+	startedIDs, err := redis.Int64s(c.Do("ZREVRANGEBYSCORE", productScheduledPricesKey(sku), now, "-inf"))
+	if err != nil {
+		c.Do("UNWATCH")
+		return sku, nil, err
+	}
+	var effectiveID uint64
+	var effective redisPriceChange
+	for _, startedID := range startedIDs {
+		if uint64(startedID) == priceChangeID && ended {
+			continue
+		}
+		started, err := loadPriceChange(c, uint64(startedID))
+		if err != nil {
+			c.Do("UNWATCH")
+			return sku, nil, err
+		}
+		if effectiveID != 0 && started.StartsAt < effective.StartsAt {
+			break
+		}
+		if started.CancelledAt != 0 || (started.EndsAt != 0 && started.EndsAt <= now) {
+			continue
+		}
+		if effectiveID == 0 || uint64(startedID) > effectiveID {
+			effectiveID, effective = uint64(startedID), started
+		}
+	}
+	amount := p.Price
+	if effectiveID != 0 {
+		amount = effective.Amount
+	}
+
+	c.Send("MULTI")
+	switch {
+	case ended:
+		c.Send("ZREM", productScheduledPricesKey(sku), priceChangeID)
+		c.Send("ZREM", duePricesKey, priceChangeID)
+	case change.EndsAt != 0:
+		c.Send("ZADD", duePricesKey, change.EndsAt, priceChangeID)
+	default:
+		c.Send("ZREM", duePricesKey, priceChangeID)
+	}
+	c.Send("HMSET", productKey(sku), "effective_price", amount, "scheduled_price_id", effectiveID)
+	if err = execTransaction(c); err != nil {
+		return sku, nil, err
+	}
+	if current.Amount != amount {
+		previous = &catalog.Money{Amount: current.Amount, CurrencyCode: config.BaseCurrency}
+	}
+	return sku, previous, nil
+}
+
+// queueListPrice queues the commands that put a product's list price in effect. It is meant to be
+// used within a MULTI block.
+func queueListPrice(c redis.Conn, p redisProduct) {
+	c.Send("HMSET", productKey(p.SKU), "effective_price", p.Price, "scheduled_price_id", 0)
+}
+
+// queuePriceChange queues the commands that add a price to a product's history. It is meant to be
+// used within a MULTI block.
+func queuePriceChange(c redis.Conn, priceChangeID uint64, change *catalog.PriceChange) {
+	p := redisPriceChange{
+		SKU:       change.Sku,
+		PriceType: int32(change.PriceType),
+		Amount:    change.GetPrice().GetAmount(),
+		Currency:  change.GetPrice().GetCurrencyCode(),
+		StartsAt:  change.StartsAt,
+		EndsAt:    change.EndsAt,
+		CreatedAt: change.CreatedAt,
+	}
+	c.Send("HMSET", redis.Args{}.Add(priceKey(priceChangeID)).AddFlat(&p)...)
+	c.Send("ZADD", productPricesKey(change.Sku), change.StartsAt, priceChangeID)
+}
+
+// queueDeletePriceHistory queues the commands that remove a product's price history. It is meant to
+// be used within a MULTI block.
+func queueDeletePriceHistory(c redis.Conn, sku string, priceChangeIDs []int64) {
+	for _, priceChangeID := range priceChangeIDs {
+		c.Send("DEL", priceKey(uint64(priceChangeID)))
+	}
+	c.Send("DEL", productPricesKey(sku), productScheduledPricesKey(sku))
+	if len(priceChangeIDs) > 0 {
+		c.Send("ZREM", redis.Args{}.Add(duePricesKey).AddFlat(priceChangeIDs)...)
+	}
+}
+
+func loadPriceChange(c redis.Conn, priceChangeID uint64) (change redisPriceChange, err error) {
+	v, err := redis.Values(c.Do("HGETALL", priceKey(priceChangeID)))
+	if err != nil {
+		return change, err
+	}
+	if len(v) == 0 {
+		return change, errors.NoSuchPriceChange
+	}
+	err = redis.ScanStruct(v, &change)
+	return change, err
+}
+
+func toPriceChange(priceChangeID uint64, p redisPriceChange) *catalog.PriceChange {
+	return &catalog.PriceChange{
+		PriceChangeId: priceChangeID,
+		Sku:           p.SKU,
+		PriceType:     catalog.PriceType(p.PriceType),
+		Price:         &catalog.Money{Amount: p.Amount, CurrencyCode: p.Currency},
+		StartsAt:      p.StartsAt,
+		EndsAt:        p.EndsAt,
+		CreatedAt:     p.CreatedAt,
+		CancelledAt:   p.CancelledAt,
+	}
+}
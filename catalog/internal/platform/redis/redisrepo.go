@@ -1,11 +1,18 @@
 package redis
 
 import (
+	"encoding/json"
 	"fmt"
+	"github.com/autodidaddict/go-shopping/catalog/internal/platform/config"
+	"github.com/autodidaddict/go-shopping/catalog/internal/platform/search"
 	"github.com/autodidaddict/go-shopping/catalog/proto"
 	"github.com/garyburd/redigo/redis"
+	"sort"
 )
 
+// categoryUnionKey is scratch space used to sort the products of several categories at once
+const categoryUnionKey = "category:union:products"
+
 // CatalogRepository is a Redis-backed product catalog repository
 type CatalogRepository struct {
 	redisDialString string
@@ -24,26 +31,58 @@ func (r *CatalogRepository) GetProduct(sku string) (product *catalog.Product, er
 		return nil, err
 	}
 	defer c.Close()
-	productKey := fmt.Sprintf("product:%s", sku)
-	v, err := redis.Values(c.Do("HGETALL", productKey))
+	return loadProduct(c, sku)
+}
+
+// GetProductVariants retrieves the variants of a product, ordered by SKU
+func (r *CatalogRepository) GetProductVariants(sku string) (variants []*catalog.Product, err error) {
+	c, err := redis.Dial("tcp", r.redisDialString)
 	if err != nil {
 		return nil, err
 	}
-	var p redisProduct
-	err = redis.ScanStruct(v, &p)
+	defer c.Close()
+
+	skus, err := redis.Strings(c.Do("SORT", productVariantsKey(sku), "ALPHA"))
 	if err != nil {
 		return nil, err
 	}
+	return loadProducts(c, skus)
+}
 
-	product = &catalog.Product{
-		p.SKU,
-		p.Name,
-		p.Description,
-		p.Manufacturer,
-		p.Model,
-		p.Price}
+// GetProducts retrieves the given products, in the same order, skipping any that don't exist
+func (r *CatalogRepository) GetProducts(skus []string) (products []*catalog.Product, err error) {
+	c, err := redis.Dial("tcp", r.redisDialString)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
 
-	return product, nil
+	loaded, err := loadProducts(c, skus)
+	if err != nil {
+		return nil, err
+	}
+	for _, product := range loaded {
+		if product.Sku != "" {
+			products = append(products, product)
+		}
+	}
+	return products, nil
+}
+
+// GetAllProducts retrieves every product in the catalog, ordered by SKU
+func (r *CatalogRepository) GetAllProducts() (products []*catalog.Product, err error) {
+	c, err := redis.Dial("tcp", r.redisDialString)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	skus, err := scanProductSkus(c)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(skus)
+	return loadProducts(c, skus)
 }
 
 // GetCategories retrieves a list of product categories
@@ -70,48 +109,397 @@ func (r *CatalogRepository) GetCategories() (categories []*catalog.ProductCatego
 		if err != nil {
 			return nil, err
 		}
+		translations, err := loadTranslations(c, categoryTranslationsKey(uint64(categoryID)))
+		if err != nil {
+			return nil, err
+		}
+		schema, err := loadSpecificationSchema(c, uint64(categoryID))
+		if err != nil {
+			return nil, err
+		}
 		categories = append(categories, &catalog.ProductCategory{
-			CategoryId:  uint64(categoryID),
-			Name:        cat.Name,
-			Description: cat.Description,
+			CategoryId:          uint64(categoryID),
+			Name:                cat.Name,
+			Description:         cat.Description,
+			ParentId:            cat.ParentID,
+			Translations:        translations,
+			SpecificationSchema: schema,
 		})
 	}
 	return categories, nil
 }
 
-// GetProductsInCategory retrieves a list of products within a given category
-func (r *CatalogRepository) GetProductsInCategory(categoryID uint64) (products []*catalog.Product, err error) {
+// GetProductsInCategories retrieves a page of the products within any of the given categories, along
+// with the total number of distinct products in those categories. Sorting and paging are done by Redis
+// so that only the products on the requested page are loaded. Products that aren't active are left
+// out unless includeInactive is set.
+func (r *CatalogRepository) GetProductsInCategories(categoryIDs []uint64, order catalog.SortOrder, includeInactive bool,
+	offset, limit int) (products []*catalog.Product, total int, err error) {
+
+	if len(categoryIDs) == 0 {
+		return nil, 0, nil
+	}
+	c, err := redis.Dial("tcp", r.redisDialString)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer c.Close()
+
+	var productIDs []string
+	if len(categoryIDs) == 1 && includeInactive {
+		productIDs, total, err = sortCategory(c, categoryProductsKey(categoryIDs[0]), order, offset, limit)
+	} else {
+		productIDs, total, err = sortCategoryUnion(c, categoryIDs, order, includeInactive, offset, limit)
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	products, err = loadProducts(c, productIDs)
+	return products, total, err
+}
+
+// GetProductsByManufacturer retrieves up to limit of the active products from a manufacturer, ignoring
+// case, choosing those whose list prices are closest to the given price
+func (r *CatalogRepository) GetProductsByManufacturer(manufacturer string, price int64, limit int) (products []*catalog.Product,
+	err error) {
+
+	c, err := redis.Dial("tcp", r.redisDialString)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	key := manufacturerProductsKey(manufacturer)
+	c.Send("ZREVRANGEBYSCORE", key, price, "-inf", "WITHSCORES", "LIMIT", 0, limit)
+	c.Send("ZRANGEBYSCORE", key, fmt.Sprintf("(%d", price), "+inf", "WITHSCORES", "LIMIT", 0, limit)
+	if err = c.Flush(); err != nil {
+		return nil, err
+	}
+	cheaper, err := redis.Int64Map(c.Receive())
+	if err != nil {
+		return nil, err
+	}
+	dearer, err := redis.Int64Map(c.Receive())
+	if err != nil {
+		return nil, err
+	}
+	differences := make(map[string]int64, len(cheaper)+len(dearer))
+	skus := make([]string, 0, len(cheaper)+len(dearer))
+	for sku, listPrice := range cheaper {
+		differences[sku] = price - listPrice
+		skus = append(skus, sku)
+	}
+	for sku, listPrice := range dearer {
+		differences[sku] = listPrice - price
+		skus = append(skus, sku)
+	}
+	sort.Slice(skus, func(i, j int) bool {
+		if differences[skus[i]] != differences[skus[j]] {
+			return differences[skus[i]] < differences[skus[j]]
+		}
+		return skus[i] < skus[j]
+	})
+	if len(skus) > limit {
+		skus = skus[:limit]
+	}
+	return loadProducts(c, skus)
+}
+
+func sortCategory(c redis.Conn, key string, order catalog.SortOrder, offset, limit int) (skus []string, total int, err error) {
+	total, err = redis.Int(c.Do("SCARD", key))
+	if err != nil {
+		return nil, 0, err
+	}
+	args := redis.Args{}.Add(key).AddFlat(sortArgs(order)).Add("LIMIT", offset, limit)
+	skus, err = redis.Strings(c.Do("SORT", args...))
+	return skus, total, err
+}
+
+// sortCategoryUnion sorts the union of one or more categories, less any inactive products unless
+// includeInactive is set. The union is stored in a scratch key that is created, sorted and removed
+// within a single MULTI block, so concurrent requests can safely share it.
+func sortCategoryUnion(c redis.Conn, categoryIDs []uint64, order catalog.SortOrder, includeInactive bool,
+	offset, limit int) (skus []string, total int, err error) {
+
+	keys := redis.Args{}
+	for _, categoryID := range categoryIDs {
+		keys = keys.Add(categoryProductsKey(categoryID))
+	}
+	c.Send("MULTI")
+	c.Send("SUNIONSTORE", append(redis.Args{}.Add(categoryUnionKey), keys...)...)
+	if !includeInactive {
+		c.Send("SDIFFSTORE", categoryUnionKey, categoryUnionKey, inactiveProductsKey)
+	}
+	c.Send("SORT", redis.Args{}.Add(categoryUnionKey).AddFlat(sortArgs(order)).Add("LIMIT", offset, limit)...)
+	c.Send("DEL", categoryUnionKey)
+	replies, err := redis.Values(c.Do("EXEC"))
+	if err != nil {
+		return nil, 0, err
+	}
+	if !includeInactive {
+		replies = replies[1:]
+	}
+	if _, err = redis.Scan(replies, &total, &skus); err != nil {
+		return nil, 0, err
+	}
+	return skus, total, nil
+}
+
+// sortArgs translates a sort order into the arguments of a Redis SORT over a set of SKUs. Products
+// are sorted by the price in effect.
+func sortArgs(order catalog.SortOrder) []string {
+	switch order {
+	case catalog.SortOrder_SO_NAME_ASC:
+		return []string{"BY", "product:*->name", "ALPHA"}
+	case catalog.SortOrder_SO_NAME_DESC:
+		return []string{"BY", "product:*->name", "ALPHA", "DESC"}
+	case catalog.SortOrder_SO_PRICE_ASC:
+		return []string{"BY", "product:*->effective_price"}
+	case catalog.SortOrder_SO_PRICE_DESC:
+		return []string{"BY", "product:*->effective_price", "DESC"}
+	default:
+		return []string{"ALPHA"}
+	}
+}
+
+// Find searches for `searchTerm` within the given list of categories. A product matches when every
+// token of the search term (or a close misspelling of it) appears in its name, description,
+// manufacturer or model. An empty list of categories searches the entire catalog. A limit other than
+// 0 loads only that many of the matching products, those with the lowest SKUs.
+func (r *CatalogRepository) Find(searchTerm string, categories []uint64, limit int) (products []*catalog.Product, err error) {
+	terms := search.Tokenize(searchTerm)
+	if len(terms) == 0 {
+		return
+	}
 	c, err := redis.Dial("tcp", r.redisDialString)
 	if err != nil {
 		return nil, err
 	}
 	defer c.Close()
 
-	key := fmt.Sprintf("category:%d:products", categoryID)
-	productIDs, err := redis.Strings(c.Do("SMEMBERS", key))
-	for _, productID := range productIDs {
-		productKey := fmt.Sprintf("product:%s", productID)
-		v, err := redis.Values(c.Do("HGETALL", productKey))
+	skus, _, err := findSkus(c, terms)
+	if err != nil {
+		return nil, err
+	}
+	if len(categories) > 0 {
+		skus, err = filterByCategories(c, skus, categories)
 		if err != nil {
 			return nil, err
 		}
-		var p redisProduct
-		err = redis.ScanStruct(v, &p)
-		products = append(products, &catalog.Product{
-			Description:  p.Description,
-			Name:         p.Name,
-			Manufacturer: p.Manufacturer,
-			Price:        p.Price,
-			Model:        p.Model,
-			Sku:          p.SKU,
-		})
 	}
-	return products, nil
+	if limit > 0 && len(skus) > limit {
+		sort.Strings(skus)
+		skus = skus[:limit]
+	}
+	return loadProducts(c, skus)
 }
 
-// Find searches for `searchTerm` within the given list of categories.
-func (r *CatalogRepository) Find(searchTerm string, categories []uint64) (products []*catalog.Product, err error) {
-	return
+// FindMatches finds the products matching a search term as Find does, scoring each by the weight in it
+// of the terms matched, as recorded in the search index. Rather than whole products, it loads only
+// what a search is refined, ranked and sorted by: each product's name, manufacturer, price, status,
+// translations and specifications, so that a page of results can be picked before any are loaded.
+func (r *CatalogRepository) FindMatches(searchTerm string) (matches []*catalog.Product, scores map[string]float64,
+	err error) {
+
+	terms := search.Tokenize(searchTerm)
+	if len(terms) == 0 {
+		return nil, nil, nil
+	}
+	c, err := redis.Dial("tcp", r.redisDialString)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer c.Close()
+
+	skus, strengths, err := findSkus(c, terms)
+	if err != nil || len(skus) == 0 {
+		return nil, nil, err
+	}
+	matched := make([]string, 0, len(strengths))
+	for term := range strengths {
+		matched = append(matched, term)
+	}
+	for _, sku := range skus {
+		c.Send("HMGET", redis.Args{}.Add(productKey(sku)).AddFlat(summaryFields)...)
+		c.Send("HGETALL", productTranslationsKey(sku))
+		c.Send("HGETALL", productSpecificationsKey(sku))
+		c.Send("HMGET", redis.Args{}.Add(productWeightsKey(sku)).AddFlat(matched)...)
+	}
+	if err = c.Flush(); err != nil {
+		return nil, nil, err
+	}
+	scores = make(map[string]float64, len(skus))
+	for range skus {
+		match, weights, err := receiveMatch(c)
+		if err != nil {
+			return nil, nil, err
+		}
+		if match == nil {
+			continue
+		}
+		for i, term := range matched {
+			scores[match.Sku] += weights[i] * strengths[term]
+		}
+		matches = append(matches, match)
+	}
+	return matches, scores, nil
+}
+
+// summaryFields are the fields of a product hash that FindMatches loads
+var summaryFields = []string{"sku", "name", "mfr", "price", "currency", "status", "effective_price", "scheduled_price_id"}
+
+// receiveMatch receives the replies to the commands FindMatches sends for a single product, returning
+// a nil product when it no longer exists
+func receiveMatch(c redis.Conn) (match *catalog.Product, weights []float64, err error) {
+	values, err := redis.Values(c.Receive())
+	if err != nil {
+		return nil, nil, err
+	}
+	translations, err := redis.StringMap(c.Receive())
+	if err != nil {
+		return nil, nil, err
+	}
+	specifications, err := redis.StringMap(c.Receive())
+	if err != nil {
+		return nil, nil, err
+	}
+	weightValues, err := redis.Values(c.Receive())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// the product hash is scanned as though HGETALL had returned just the fields asked for
+	var hash []interface{}
+	for i, value := range values {
+		if value != nil {
+			hash = append(hash, []byte(summaryFields[i]), value)
+		}
+	}
+	p, effective, err := scanProductHash(hash)
+	if err != nil {
+		return nil, nil, err
+	}
+	if p.SKU == "" {
+		return nil, nil, nil
+	}
+	weights = make([]float64, len(weightValues))
+	for i, value := range weightValues {
+		if value == nil {
+			continue
+		}
+		if weights[i], err = redis.Float64(value, nil); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	match = toProduct(p)
+	if effective.ScheduledPriceID != 0 {
+		match.ScheduledPrice = &catalog.Money{Amount: effective.Amount, CurrencyCode: config.BaseCurrency}
+	}
+	match.Translations = decodeTranslations(translations)
+	match.Specifications = decodeSpecifications(specifications)
+	return match, weights, nil
+}
+
+// findSkus finds the SKUs of the products matching every search token, along with the strength of each
+// indexed term that matched: 1 for a search token itself, and less for a correction of one
+func findSkus(c redis.Conn, tokens []string) (skus []string, strengths map[string]float64, err error) {
+	strengths = make(map[string]float64)
+	for i, token := range tokens {
+		matches, err := matchingTerms(c, token)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(matches) == 0 {
+			return nil, nil, nil
+		}
+		termKeys := redis.Args{}
+		for _, match := range matches {
+			termKeys = termKeys.Add(termKey(match))
+			strength := search.FuzzyMatchStrength
+			if match == token {
+				strength = 1
+			}
+			if strength > strengths[match] {
+				strengths[match] = strength
+			}
+		}
+		termSkus, err := redis.Strings(c.Do("SUNION", termKeys...))
+		if err != nil {
+			return nil, nil, err
+		}
+		if i == 0 {
+			skus = termSkus
+		} else {
+			skus = intersect(skus, termSkus)
+		}
+	}
+	return skus, strengths, nil
+}
+
+// GetCategoryMembership determines which categories each of the given products belongs to, in order
+// of category ID
+func (r *CatalogRepository) GetCategoryMembership(skus []string) (membership map[string][]uint64, err error) {
+	membership = make(map[string][]uint64, len(skus))
+	if len(skus) == 0 {
+		return membership, nil
+	}
+	c, err := redis.Dial("tcp", r.redisDialString)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	for _, sku := range skus {
+		c.Send("SORT", productCategoriesKey(sku))
+	}
+	if err = c.Flush(); err != nil {
+		return nil, err
+	}
+	for _, sku := range skus {
+		categoryIDs, err := redis.Int64s(c.Receive())
+		if err != nil {
+			return nil, err
+		}
+		for _, categoryID := range categoryIDs {
+			membership[sku] = append(membership[sku], uint64(categoryID))
+		}
+	}
+	return membership, nil
+}
+
+// IndexCategoryMembership lists every product in the categories it belongs to, for products assigned
+// to categories before each product kept a list of its own
+func (r *CatalogRepository) IndexCategoryMembership() (indexed int, err error) {
+	c, err := redis.Dial("tcp", r.redisDialString)
+	if err != nil {
+		return 0, err
+	}
+	defer c.Close()
+
+	categoryIDs, err := redis.Int64s(c.Do("SMEMBERS", "categories"))
+	if err != nil {
+		return 0, err
+	}
+	for _, categoryID := range categoryIDs {
+		if err = watch(c, categoryProductsKey(uint64(categoryID))); err != nil {
+			return indexed, err
+		}
+		skus, err := redis.Strings(c.Do("SMEMBERS", categoryProductsKey(uint64(categoryID))))
+		if err != nil {
+			c.Do("UNWATCH")
+			return indexed, err
+		}
+		c.Send("MULTI")
+		for _, sku := range skus {
+			c.Send("SADD", productCategoriesKey(sku), categoryID)
+		}
+		if err = execTransaction(c); err != nil {
+			return indexed, err
+		}
+		indexed += len(skus)
+	}
+	return indexed, nil
 }
 
 // CategoryExists indicates whether a given category exists
@@ -138,3 +526,166 @@ func (r *CatalogRepository) ProductExists(sku string) (exists bool, err error) {
 	exists, err = redis.Bool(c.Do("EXISTS", productKey))
 	return exists, err
 }
+
+func loadRedisProduct(c redis.Conn, sku string) (p redisProduct, err error) {
+	productKey := fmt.Sprintf("product:%s", sku)
+	v, err := redis.Values(c.Do("HGETALL", productKey))
+	if err != nil {
+		return p, err
+	}
+	err = redis.ScanStruct(v, &p)
+	return p, err
+}
+
+// scanProductHash reads a product hash along with the price in effect kept in it
+func scanProductHash(v []interface{}) (p redisProduct, effective redisEffectivePrice, err error) {
+	if err = redis.ScanStruct(v, &p); err != nil {
+		return p, effective, err
+	}
+	err = redis.ScanStruct(v, &effective)
+	return p, effective, err
+}
+
+// loadProduct loads a product along with its attributes, media assets, translations, specifications
+// and components, and the scheduled price in effect, if any
+func loadProduct(c redis.Conn, sku string) (product *catalog.Product, err error) {
+	products, err := loadProducts(c, []string{sku})
+	if err != nil {
+		return nil, err
+	}
+	return products[0], nil
+}
+
+// loadProducts loads several products as loadProduct does, pipelining the commands for all of them
+func loadProducts(c redis.Conn, skus []string) (products []*catalog.Product, err error) {
+	for _, sku := range skus {
+		c.Send("HGETALL", productKey(sku))
+		c.Send("HGETALL", productAttributesKey(sku))
+		c.Send("LRANGE", productMediaKey(sku), 0, -1)
+		c.Send("HGETALL", productTranslationsKey(sku))
+		c.Send("HGETALL", productSpecificationsKey(sku))
+		c.Send("HGETALL", productComponentsKey(sku))
+	}
+	if err = c.Flush(); err != nil {
+		return nil, err
+	}
+	for range skus {
+		product, err := receiveProduct(c)
+		if err != nil {
+			return nil, err
+		}
+		products = append(products, product)
+	}
+	return products, nil
+}
+
+// receiveProduct receives the replies to the commands loadProducts sends for a single product
+func receiveProduct(c redis.Conn) (product *catalog.Product, err error) {
+	v, err := redis.Values(c.Receive())
+	if err != nil {
+		return nil, err
+	}
+	p, effective, err := scanProductHash(v)
+	if err != nil {
+		return nil, err
+	}
+	attributes, err := redis.StringMap(c.Receive())
+	if err != nil {
+		return nil, err
+	}
+	media, err := redis.ByteSlices(c.Receive())
+	if err != nil {
+		return nil, err
+	}
+	translations, err := redis.StringMap(c.Receive())
+	if err != nil {
+		return nil, err
+	}
+	specifications, err := redis.StringMap(c.Receive())
+	if err != nil {
+		return nil, err
+	}
+	components, err := redis.IntMap(c.Receive())
+	if err != nil {
+		return nil, err
+	}
+
+	product = toProduct(p)
+	if effective.ScheduledPriceID != 0 {
+		product.ScheduledPrice = &catalog.Money{Amount: effective.Amount, CurrencyCode: config.BaseCurrency}
+	}
+	for name, value := range attributes {
+		product.Attributes = append(product.Attributes, &catalog.ProductAttribute{Name: name, Value: value})
+	}
+	sort.Slice(product.Attributes, func(i, j int) bool {
+		return product.Attributes[i].Name < product.Attributes[j].Name
+	})
+	product.Media = decodeMedia(media)
+	product.Translations = decodeTranslations(translations)
+	product.Specifications = decodeSpecifications(specifications)
+	product.Components = decodeComponents(components)
+	return product, nil
+}
+
+// decodeMedia decodes a list of media assets in display order, skipping any that can't be decoded
+func decodeMedia(encoded [][]byte) (media []*catalog.MediaAsset) {
+	for _, e := range encoded {
+		var asset redisMediaAsset
+		if json.Unmarshal(e, &asset) != nil {
+			continue
+		}
+		media = append(media, &catalog.MediaAsset{
+			Url:       asset.URL,
+			AltText:   asset.AltText,
+			MediaType: catalog.MediaType(asset.MediaType),
+			Width:     asset.Width,
+			Height:    asset.Height,
+		})
+	}
+	return media
+}
+
+// filterByCategories keeps only those SKUs that belong to at least one of the given categories
+func filterByCategories(c redis.Conn, skus []string, categories []uint64) (filtered []string, err error) {
+	categoryKeys := redis.Args{}
+	for _, categoryID := range categories {
+		categoryKeys = categoryKeys.Add(fmt.Sprintf("category:%d:products", categoryID))
+	}
+	members, err := redis.Strings(c.Do("SUNION", categoryKeys...))
+	if err != nil {
+		return nil, err
+	}
+	return intersect(skus, members), nil
+}
+
+func intersect(a, b []string) (both []string) {
+	inB := make(map[string]bool, len(b))
+	for _, s := range b {
+		inB[s] = true
+	}
+	for _, s := range a {
+		if inB[s] {
+			both = append(both, s)
+		}
+	}
+	return both
+}
+
+// toProduct converts a product hash, treating prices without a currency as being in the base currency
+func toProduct(p redisProduct) *catalog.Product {
+	currency := p.Currency
+	if currency == "" {
+		currency = config.BaseCurrency
+	}
+	return &catalog.Product{
+		Sku:          p.SKU,
+		Name:         p.Name,
+		Description:  p.Description,
+		Manufacturer: p.Manufacturer,
+		Model:        p.Model,
+		Price:        &catalog.Money{Amount: p.Price, CurrencyCode: currency},
+		ParentSku:    p.ParentSKU,
+		Status:       catalog.ProductStatus(p.Status),
+		Kind:         catalog.ProductKind(p.Kind),
+	}
+}
@@ -0,0 +1,138 @@
+package redis
+
+import (
+	"fmt"
+	"github.com/autodidaddict/go-shopping/catalog/internal/platform/errors"
+	"github.com/garyburd/redigo/redis"
+	"sort"
+)
+
+// Merchandisers link a product to the products they want recommended with it in the
+// product:{sku}:related set. Links only run one way.
+//
+// Products bought together are learned from item shipped events. The SKUs shipped under each order
+// are collected in shipped:order:{id} for long enough to see the whole order shipped, and every time
+// a new SKU joins an order the product:{sku}:bought_with sorted set of it and of every other SKU in
+// the order is incremented, so that each set scores other products by the number of orders in which
+// both were shipped.
+
+// shippedOrderTTL is how long, in seconds, an order's shipped SKUs are remembered
+const shippedOrderTTL = 90 * 24 * 60 * 60
+
+func productRelatedKey(sku string) string {
+	return fmt.Sprintf("product:%s:related", sku)
+}
+
+func productBoughtWithKey(sku string) string {
+	return fmt.Sprintf("product:%s:bought_with", sku)
+}
+
+func shippedOrderKey(orderID uint64) string {
+	return fmt.Sprintf("shipped:order:%d", orderID)
+}
+
+// LinkRelatedProduct recommends one existing product alongside another
+func (r *CatalogRepository) LinkRelatedProduct(sku string, relatedSKU string) (err error) {
+	c, err := redis.Dial("tcp", r.redisDialString)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	if err = watch(c, productKey(sku), productKey(relatedSKU)); err != nil {
+		return err
+	}
+	if err = requireExists(c, productKey(sku), errors.NoSuchProduct); err != nil {
+		return err
+	}
+	if err = requireExists(c, productKey(relatedSKU), errors.NoSuchProduct); err != nil {
+		return err
+	}
+
+	c.Send("MULTI")
+	c.Send("SADD", productRelatedKey(sku), relatedSKU)
+	return execTransaction(c)
+}
+
+// UnlinkRelatedProduct stops recommending one product alongside another. Removing a link that
+// doesn't exist is not an error.
+func (r *CatalogRepository) UnlinkRelatedProduct(sku string, relatedSKU string) (err error) {
+	c, err := redis.Dial("tcp", r.redisDialString)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	exists, err := redis.Bool(c.Do("EXISTS", productKey(sku)))
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return errors.NoSuchProduct
+	}
+	_, err = c.Do("SREM", productRelatedKey(sku), relatedSKU)
+	return err
+}
+
+// GetRelatedProductLinks lists the SKUs a merchandiser has linked to a product, ordered by SKU
+func (r *CatalogRepository) GetRelatedProductLinks(sku string) (skus []string, err error) {
+	c, err := redis.Dial("tcp", r.redisDialString)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	skus, err = redis.Strings(c.Do("SMEMBERS", productRelatedKey(sku)))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(skus)
+	return skus, nil
+}
+
+// GetBoughtTogether returns up to `limit` of the products most often shipped in the same order as the
+// given product, along with the number of orders in which each was
+func (r *CatalogRepository) GetBoughtTogether(sku string, limit int) (counts map[string]int, err error) {
+	c, err := redis.Dial("tcp", r.redisDialString)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	return redis.IntMap(c.Do("ZREVRANGE", productBoughtWithKey(sku), 0, limit-1, "WITHSCORES"))
+}
+
+// RecordShipment notes that a product was shipped as part of an order. Recording the same shipment
+// again has no effect.
+func (r *CatalogRepository) RecordShipment(orderID uint64, sku string) (err error) {
+	c, err := redis.Dial("tcp", r.redisDialString)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	key := shippedOrderKey(orderID)
+	if err = watch(c, key); err != nil {
+		return err
+	}
+	shipped, err := redis.Strings(c.Do("SMEMBERS", key))
+	if err != nil {
+		c.Do("UNWATCH")
+		return err
+	}
+	for _, other := range shipped {
+		if other == sku {
+			c.Do("UNWATCH")
+			return nil
+		}
+	}
+
+	c.Send("MULTI")
+	c.Send("SADD", key, sku)
+	c.Send("EXPIRE", key, shippedOrderTTL)
+	for _, other := range shipped {
+		c.Send("ZINCRBY", productBoughtWithKey(sku), 1, other)
+		c.Send("ZINCRBY", productBoughtWithKey(other), 1, sku)
+	}
+	return execTransaction(c)
+}
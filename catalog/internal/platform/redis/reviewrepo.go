@@ -0,0 +1,300 @@
+package redis
+
+import (
+	"fmt"
+	"github.com/autodidaddict/go-shopping/catalog/internal/platform/errors"
+	"github.com/autodidaddict/go-shopping/catalog/proto"
+	"github.com/garyburd/redigo/redis"
+	"strconv"
+	"time"
+)
+
+// Every review is kept in a review:{id} hash, with IDs allocated from review:next_id, and the votes
+// cast on it in the review:{id}:votes hash of customer ID to true for helpful or false for unhelpful.
+// product:{sku}:reviews is a sorted set of all of a product's review IDs scored by the time each was
+// written, and product:{sku}:reviews:approved holds just the ones customers can see.
+// product:{sku}:reviewers maps each customer to the review they wrote, as customers may only review a
+// product once. Reviews awaiting moderation are queued in the reviews:pending sorted set.
+//
+// The number of approved reviews giving each rating is kept in the product:{sku}:ratings hash of
+// stars to count, so that a product's rating never requires reading its reviews.
+
+const (
+	reviewIDKey       = "review:next_id"
+	pendingReviewsKey = "reviews:pending"
+)
+
+func reviewKey(reviewID uint64) string {
+	return fmt.Sprintf("review:%d", reviewID)
+}
+
+func reviewVotesKey(reviewID uint64) string {
+	return fmt.Sprintf("review:%d:votes", reviewID)
+}
+
+func productReviewsKey(sku string) string {
+	return fmt.Sprintf("product:%s:reviews", sku)
+}
+
+func productApprovedReviewsKey(sku string) string {
+	return fmt.Sprintf("product:%s:reviews:approved", sku)
+}
+
+func productReviewersKey(sku string) string {
+	return fmt.Sprintf("product:%s:reviewers", sku)
+}
+
+func productRatingsKey(sku string) string {
+	return fmt.Sprintf("product:%s:ratings", sku)
+}
+
+// CreateReview saves a review of an existing product, queueing it for moderation
+func (r *CatalogRepository) CreateReview(review *catalog.Review) (reviewID uint64, err error) {
+	c, err := redis.Dial("tcp", r.redisDialString)
+	if err != nil {
+		return 0, err
+	}
+	defer c.Close()
+
+	if err = watch(c, productKey(review.Sku), productReviewersKey(review.Sku)); err != nil {
+		return 0, err
+	}
+	if err = requireExists(c, productKey(review.Sku), errors.NoSuchProduct); err != nil {
+		return 0, err
+	}
+	reviewed, err := redis.Bool(c.Do("HEXISTS", productReviewersKey(review.Sku), review.CustomerId))
+	if err == nil && reviewed {
+		err = errors.DuplicateReview
+	}
+	if err != nil {
+		c.Do("UNWATCH")
+		return 0, err
+	}
+	reviewID, err = redis.Uint64(c.Do("INCR", reviewIDKey))
+	if err != nil {
+		c.Do("UNWATCH")
+		return 0, err
+	}
+
+	p := redisReview{
+		SKU:        review.Sku,
+		CustomerID: review.CustomerId,
+		Rating:     review.Rating,
+		Title:      review.Title,
+		Body:       review.Body,
+		Status:     int32(catalog.ReviewStatus_RS_PENDING),
+		CreatedAt:  review.CreatedAt,
+	}
+	c.Send("MULTI")
+	c.Send("HMSET", redis.Args{}.Add(reviewKey(reviewID)).AddFlat(&p)...)
+	c.Send("ZADD", productReviewsKey(review.Sku), review.CreatedAt, reviewID)
+	c.Send("HSET", productReviewersKey(review.Sku), review.CustomerId, reviewID)
+	c.Send("ZADD", pendingReviewsKey, review.CreatedAt, reviewID)
+	return reviewID, execTransaction(c)
+}
+
+// GetReview retrieves a review whatever its moderation state
+func (r *CatalogRepository) GetReview(reviewID uint64) (review *catalog.Review, err error) {
+	c, err := redis.Dial("tcp", r.redisDialString)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	p, err := loadReview(c, reviewID)
+	if err != nil {
+		return nil, err
+	}
+	return toReview(reviewID, p), nil
+}
+
+// GetReviews retrieves a page of a product's approved reviews, newest first, along with the total
+// number of approved reviews
+func (r *CatalogRepository) GetReviews(sku string, offset, limit int) (reviews []*catalog.Review, total int, err error) {
+	c, err := redis.Dial("tcp", r.redisDialString)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer c.Close()
+
+	return loadReviewPage(c, productApprovedReviewsKey(sku), "ZREVRANGE", offset, limit)
+}
+
+// GetPendingReviews retrieves a page of the reviews awaiting moderation, oldest first, along with the
+// total number awaiting moderation
+func (r *CatalogRepository) GetPendingReviews(offset, limit int) (reviews []*catalog.Review, total int, err error) {
+	c, err := redis.Dial("tcp", r.redisDialString)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer c.Close()
+
+	return loadReviewPage(c, pendingReviewsKey, "ZRANGE", offset, limit)
+}
+
+// GetRatingHistogram counts the approved reviews of a product giving each rating, from 1 star to 5
+func (r *CatalogRepository) GetRatingHistogram(sku string) (histogram []uint32, err error) {
+	c, err := redis.Dial("tcp", r.redisDialString)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	counts, err := redis.Ints(c.Do("HMGET", productRatingsKey(sku), 1, 2, 3, 4, 5))
+	if err != nil {
+		return nil, err
+	}
+	histogram = make([]uint32, len(counts))
+	for i, count := range counts {
+		histogram[i] = uint32(count)
+	}
+	return histogram, nil
+}
+
+// ModerateReview approves or rejects a review. A review can be moderated again, for example to
+// withdraw an approved review that drew complaints; its product's rating follows it in and out.
+func (r *CatalogRepository) ModerateReview(reviewID uint64, status catalog.ReviewStatus) (err error) {
+	c, err := redis.Dial("tcp", r.redisDialString)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	if err = watch(c, reviewKey(reviewID)); err != nil {
+		return err
+	}
+	review, err := loadReview(c, reviewID)
+	if err != nil {
+		c.Do("UNWATCH")
+		return err
+	}
+	previous := catalog.ReviewStatus(review.Status)
+	if previous == status {
+		c.Do("UNWATCH")
+		return nil
+	}
+
+	c.Send("MULTI")
+	c.Send("HMSET", reviewKey(reviewID), "status", int32(status), "moderated_at", time.Now().UTC().Unix())
+	c.Send("ZREM", pendingReviewsKey, reviewID)
+	if previous == catalog.ReviewStatus_RS_APPROVED {
+		c.Send("ZREM", productApprovedReviewsKey(review.SKU), reviewID)
+		c.Send("HINCRBY", productRatingsKey(review.SKU), review.Rating, -1)
+	}
+	if status == catalog.ReviewStatus_RS_APPROVED {
+		c.Send("ZADD", productApprovedReviewsKey(review.SKU), review.CreatedAt, reviewID)
+		c.Send("HINCRBY", productRatingsKey(review.SKU), review.Rating, 1)
+	}
+	return execTransaction(c)
+}
+
+// VoteReview records whether a customer found a review helpful, replacing any vote they cast on it
+// before, and returns the review's new vote counts
+func (r *CatalogRepository) VoteReview(reviewID uint64, customerID string, helpful bool) (helpfulVotes, unhelpfulVotes uint32, err error) {
+	c, err := redis.Dial("tcp", r.redisDialString)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer c.Close()
+
+	if err = watch(c, reviewKey(reviewID), reviewVotesKey(reviewID)); err != nil {
+		return 0, 0, err
+	}
+	review, err := loadReview(c, reviewID)
+	if err != nil {
+		c.Do("UNWATCH")
+		return 0, 0, err
+	}
+	previous, err := redis.String(c.Do("HGET", reviewVotesKey(reviewID), customerID))
+	if err != nil && err != redis.ErrNil {
+		c.Do("UNWATCH")
+		return 0, 0, err
+	}
+	vote := strconv.FormatBool(helpful)
+	if previous == vote {
+		c.Do("UNWATCH")
+		return review.HelpfulVotes, review.UnhelpfulVotes, nil
+	}
+
+	c.Send("MULTI")
+	c.Send("HSET", reviewVotesKey(reviewID), customerID, vote)
+	switch previous {
+	case "true":
+		c.Send("HINCRBY", reviewKey(reviewID), "helpful", -1)
+		review.HelpfulVotes--
+	case "false":
+		c.Send("HINCRBY", reviewKey(reviewID), "unhelpful", -1)
+		review.UnhelpfulVotes--
+	}
+	if helpful {
+		c.Send("HINCRBY", reviewKey(reviewID), "helpful", 1)
+		review.HelpfulVotes++
+	} else {
+		c.Send("HINCRBY", reviewKey(reviewID), "unhelpful", 1)
+		review.UnhelpfulVotes++
+	}
+	if err = execTransaction(c); err != nil {
+		return 0, 0, err
+	}
+	return review.HelpfulVotes, review.UnhelpfulVotes, nil
+}
+
+func loadReview(c redis.Conn, reviewID uint64) (review redisReview, err error) {
+	v, err := redis.Values(c.Do("HGETALL", reviewKey(reviewID)))
+	if err != nil {
+		return review, err
+	}
+	if len(v) == 0 {
+		return review, errors.NoSuchReview
+	}
+	err = redis.ScanStruct(v, &review)
+	return review, err
+}
+
+// loadReviewPage loads the reviews on one page of a sorted set of review IDs, read with the given
+// range command
+func loadReviewPage(c redis.Conn, key string, rangeCommand string, offset, limit int) (reviews []*catalog.Review, total int, err error) {
+	total, err = redis.Int(c.Do("ZCARD", key))
+	if err != nil {
+		return nil, 0, err
+	}
+	reviewIDs, err := redis.Int64s(c.Do(rangeCommand, key, offset, offset+limit-1))
+	if err != nil {
+		return nil, 0, err
+	}
+	for _, reviewID := range reviewIDs {
+		review, err := loadReview(c, uint64(reviewID))
+		if err != nil {
+			return nil, 0, err
+		}
+		reviews = append(reviews, toReview(uint64(reviewID), review))
+	}
+	return reviews, total, nil
+}
+
+// queueDeleteReviews queues the commands that remove a product's reviews and rating. It is meant to
+// be used within a MULTI block.
+func queueDeleteReviews(c redis.Conn, sku string, reviewIDs []int64) {
+	for _, reviewID := range reviewIDs {
+		c.Send("DEL", reviewKey(uint64(reviewID)), reviewVotesKey(uint64(reviewID)))
+		c.Send("ZREM", pendingReviewsKey, reviewID)
+	}
+	c.Send("DEL", productReviewsKey(sku), productApprovedReviewsKey(sku), productReviewersKey(sku),
+		productRatingsKey(sku))
+}
+
+func toReview(reviewID uint64, p redisReview) *catalog.Review {
+	return &catalog.Review{
+		ReviewId:       reviewID,
+		Sku:            p.SKU,
+		CustomerId:     p.CustomerID,
+		Rating:         p.Rating,
+		Title:          p.Title,
+		Body:           p.Body,
+		Status:         catalog.ReviewStatus(p.Status),
+		CreatedAt:      p.CreatedAt,
+		ModeratedAt:    p.ModeratedAt,
+		HelpfulVotes:   p.HelpfulVotes,
+		UnhelpfulVotes: p.UnhelpfulVotes,
+	}
+}
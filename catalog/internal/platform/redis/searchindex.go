@@ -0,0 +1,351 @@
+package redis
+
+import (
+	"fmt"
+	"github.com/autodidaddict/go-shopping/catalog/internal/platform/search"
+	"github.com/autodidaddict/go-shopping/catalog/proto"
+	"github.com/garyburd/redigo/redis"
+	"strings"
+)
+
+// The search index is a simple inverted index stored alongside the product hashes. Every
+// token found in a product's searchable fields gets a set at search:term:{token} containing
+// the SKUs of all products in which it appears. The tokens indexed for a given product are
+// kept in product:{sku}:terms so that the product can be cleanly re-indexed later, and how much each
+// of them counts for when ranking the product is kept in the product:{sku}:weights hash, so that
+// search results can be ranked and paged before any of them are loaded.
+//
+// To tolerate typos, every indexed token is also recorded in the search:terms vocabulary and
+// in a search:gram:{trigram} set for each of its trigrams. A misspelled search token is
+// corrected by gathering the vocabulary terms that share the most trigrams with it and keeping those
+// within a small edit distance.
+//
+// Products that aren't active are listed in the products:inactive set so that category listings can
+// leave them out before paging. They are still indexed for search, which filters them out itself,
+// but are left out of autocomplete.
+//
+// Active products are also listed by manufacturer, ignoring case, in the
+// manufacturer:{manufacturer}:products sorted set scored by list price, so that products from the
+// same manufacturer at a similar price can be found without searching.
+
+const (
+	vocabularyKey       = "search:terms"
+	inactiveProductsKey = "products:inactive"
+
+	// candidatesKey holds the terms sharing trigrams with a misspelled token, scored by how many they
+	// share, for just as long as the transaction that ranks them
+	candidatesKey = "search:candidates"
+
+	// maxCorrectionCandidates caps the vocabulary terms a misspelled token is compared with
+	maxCorrectionCandidates = 200
+)
+
+func termKey(term string) string {
+	return fmt.Sprintf("search:term:%s", term)
+}
+
+func gramKey(gram string) string {
+	return fmt.Sprintf("search:gram:%s", gram)
+}
+
+func productTermsKey(sku string) string {
+	return fmt.Sprintf("product:%s:terms", sku)
+}
+
+func productWeightsKey(sku string) string {
+	return fmt.Sprintf("product:%s:weights", sku)
+}
+
+func manufacturerProductsKey(manufacturer string) string {
+	return fmt.Sprintf("manufacturer:%s:products", strings.ToLower(strings.TrimSpace(manufacturer)))
+}
+
+// productTokens returns the search tokens for all of the searchable fields of a product, including
+// every translation of its name and description
+func productTokens(p redisProduct, translations []*catalog.Translation) []string {
+	fields := []string{p.Name, p.Description, p.Manufacturer, p.Model}
+	for _, translation := range translations {
+		fields = append(fields, translation.Name, translation.Description)
+	}
+	return search.Tokenize(strings.Join(fields, " "))
+}
+
+// productWeights returns how much each of a product's search tokens counts for when ranking it
+func productWeights(p redisProduct, translations []*catalog.Translation) map[string]float64 {
+	names, descriptions := []string{p.Name}, []string{p.Description}
+	for _, translation := range translations {
+		names = append(names, translation.Name)
+		descriptions = append(descriptions, translation.Description)
+	}
+	return search.TermWeights(names, descriptions, p.Manufacturer, p.Model)
+}
+
+// IndexProduct (re)builds the search and autocomplete index entries for a single product
+func (r *CatalogRepository) IndexProduct(sku string) (err error) {
+	c, err := redis.Dial("tcp", r.redisDialString)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+	return indexProduct(c, sku)
+}
+
+// RebuildSearchIndex indexes every product hash currently in the repository
+func (r *CatalogRepository) RebuildSearchIndex() (indexed int, err error) {
+	c, err := redis.Dial("tcp", r.redisDialString)
+	if err != nil {
+		return 0, err
+	}
+	defer c.Close()
+
+	skus, err := scanProductSkus(c)
+	if err != nil {
+		return 0, err
+	}
+	for _, sku := range skus {
+		if err = indexProduct(c, sku); err != nil {
+			return indexed, err
+		}
+		indexed++
+	}
+	return indexed, nil
+}
+
+// indexEntries records what the search and autocomplete indexes currently hold for a product
+type indexEntries struct {
+	terms        []string
+	completions  []string
+	manufacturer string
+}
+
+func loadIndexEntries(c redis.Conn, sku string) (entries indexEntries, err error) {
+	entries.terms, err = redis.Strings(c.Do("SMEMBERS", productTermsKey(sku)))
+	if err != nil {
+		return entries, err
+	}
+	entries.completions, err = redis.Strings(c.Do("SMEMBERS", productCompletionsKey(sku)))
+	if err != nil {
+		return entries, err
+	}
+	entries.manufacturer, err = redis.String(c.Do("HGET", productKey(sku), "mfr"))
+	if err == redis.ErrNil {
+		err = nil
+	}
+	return entries, err
+}
+
+func indexProduct(c redis.Conn, sku string) (err error) {
+	p, err := loadRedisProduct(c, sku)
+	if err != nil {
+		return err
+	}
+	translations, err := loadTranslations(c, productTranslationsKey(sku))
+	if err != nil {
+		return err
+	}
+	old, err := loadIndexEntries(c, sku)
+	if err != nil {
+		return err
+	}
+
+	c.Send("MULTI")
+	queueUnindex(c, sku, old)
+	queueIndex(c, p, translations)
+	if _, err = c.Do("EXEC"); err != nil {
+		return err
+	}
+	return pruneTerms(c, old.terms)
+}
+
+// queueIndex queues the commands that add a product to the indexes. It is meant to be used
+// within a MULTI block.
+func queueIndex(c redis.Conn, p redisProduct, translations []*catalog.Translation) {
+	for _, term := range productTokens(p, translations) {
+		c.Send("SADD", termKey(term), p.SKU)
+		c.Send("SADD", productTermsKey(p.SKU), term)
+		c.Send("SADD", vocabularyKey, term)
+		for _, gram := range search.Grams(term) {
+			c.Send("SADD", gramKey(gram), term)
+		}
+	}
+	if weights := productWeights(p, translations); len(weights) > 0 {
+		c.Send("HMSET", redis.Args{}.Add(productWeightsKey(p.SKU)).AddFlat(weights)...)
+	}
+	if catalog.ProductStatus(p.Status) != catalog.ProductStatus_PS_ACTIVE {
+		c.Send("SADD", inactiveProductsKey, p.SKU)
+		return
+	}
+	for _, entry := range completionEntries(p) {
+		c.Send("ZADD", autocompleteKey, 0, entry)
+		c.Send("SADD", productCompletionsKey(p.SKU), entry)
+	}
+	if strings.TrimSpace(p.Manufacturer) != "" {
+		c.Send("ZADD", manufacturerProductsKey(p.Manufacturer), p.Price, p.SKU)
+	}
+}
+
+// queueUnindex queues the commands that remove a product's existing entries from the indexes. It is
+// meant to be used within a MULTI block, and followed by pruneTerms once the block has executed.
+func queueUnindex(c redis.Conn, sku string, old indexEntries) {
+	for _, term := range old.terms {
+		c.Send("SREM", termKey(term), sku)
+	}
+	for _, entry := range old.completions {
+		c.Send("ZREM", autocompleteKey, entry)
+	}
+	c.Send("SREM", inactiveProductsKey, sku)
+	if strings.TrimSpace(old.manufacturer) != "" {
+		c.Send("ZREM", manufacturerProductsKey(old.manufacturer), sku)
+	}
+	c.Send("DEL", productTermsKey(sku), productWeightsKey(sku), productCompletionsKey(sku))
+}
+
+// pruneTerms removes terms that no longer appear in any product from the vocabulary
+func pruneTerms(c redis.Conn, terms []string) (err error) {
+	for _, term := range terms {
+		remaining, err := redis.Int(c.Do("SCARD", termKey(term)))
+		if err != nil {
+			return err
+		}
+		if remaining > 0 {
+			continue
+		}
+		c.Send("MULTI")
+		c.Send("SREM", vocabularyKey, term)
+		for _, gram := range search.Grams(term) {
+			c.Send("SREM", gramKey(gram), term)
+		}
+		if _, err = c.Do("EXEC"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// matchingTerms returns the indexed terms that a search token should match: the token itself when
+// it is in the vocabulary, otherwise any vocabulary terms within its typo tolerance.
+func matchingTerms(c redis.Conn, token string) (terms []string, err error) {
+	known, err := redis.Bool(c.Do("SISMEMBER", vocabularyKey, token))
+	if err != nil {
+		return nil, err
+	}
+	if known {
+		return []string{token}, nil
+	}
+	return corrections(c, token, search.MaxEdits(token))
+}
+
+// corrections returns the vocabulary terms within maxEdits of a token. Only the terms sharing the
+// most trigrams with the token are considered, since common trigrams are shared by much of the
+// vocabulary.
+func corrections(c redis.Conn, token string, maxEdits int) (terms []string, err error) {
+	if maxEdits == 0 {
+		return nil, nil
+	}
+	grams := search.Grams(token)
+	args := redis.Args{}.Add(candidatesKey, len(grams))
+	for _, gram := range grams {
+		args = args.Add(gramKey(gram))
+	}
+	c.Send("MULTI")
+	c.Send("ZUNIONSTORE", args...)
+	c.Send("ZREVRANGE", candidatesKey, 0, maxCorrectionCandidates-1)
+	c.Send("DEL", candidatesKey)
+	replies, err := redis.Values(c.Do("EXEC"))
+	if err != nil {
+		return nil, err
+	}
+	candidates, err := redis.Strings(replies[1], nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, candidate := range candidates {
+		if search.Distance(token, candidate) <= maxEdits {
+			terms = append(terms, candidate)
+		}
+	}
+	return terms, nil
+}
+
+// SuggestQuery proposes a corrected version of a search term by replacing each unknown token with
+// the closest, most common vocabulary term. An empty suggestion means no correction was found.
+func (r *CatalogRepository) SuggestQuery(searchTerm string) (suggestion string, err error) {
+	tokens := search.Tokenize(searchTerm)
+	if len(tokens) == 0 {
+		return "", nil
+	}
+	c, err := redis.Dial("tcp", r.redisDialString)
+	if err != nil {
+		return "", err
+	}
+	defer c.Close()
+
+	corrected := false
+	for i, token := range tokens {
+		known, err := redis.Bool(c.Do("SISMEMBER", vocabularyKey, token))
+		if err != nil {
+			return "", err
+		}
+		if known {
+			continue
+		}
+		best, err := bestCorrection(c, token)
+		if err != nil {
+			return "", err
+		}
+		if best != "" {
+			tokens[i] = best
+			corrected = true
+		}
+	}
+	if !corrected {
+		return "", nil
+	}
+	return strings.Join(tokens, " "), nil
+}
+
+// bestCorrection picks the closest correction for a token, preferring terms used by more products.
+// Suggestions are a little more forgiving than search matching since they are only hints.
+func bestCorrection(c redis.Conn, token string) (best string, err error) {
+	candidates, err := corrections(c, token, search.MaxEdits(token)+1)
+	if err != nil {
+		return "", err
+	}
+	bestDistance, bestCount := 0, 0
+	for _, candidate := range candidates {
+		distance := search.Distance(token, candidate)
+		count, err := redis.Int(c.Do("SCARD", termKey(candidate)))
+		if err != nil {
+			return "", err
+		}
+		if best == "" || distance < bestDistance ||
+			(distance == bestDistance && (count > bestCount || (count == bestCount && candidate < best))) {
+			best, bestDistance, bestCount = candidate, distance, count
+		}
+	}
+	return best, nil
+}
+
+// scanProductSkus walks the keyspace for product:{sku} hashes, ignoring any product:{sku}:* sub-keys
+func scanProductSkus(c redis.Conn) (skus []string, err error) {
+	cursor := 0
+	for {
+		v, err := redis.Values(c.Do("SCAN", cursor, "MATCH", "product:*", "COUNT", 100))
+		if err != nil {
+			return nil, err
+		}
+		var keys []string
+		if _, err = redis.Scan(v, &cursor, &keys); err != nil {
+			return nil, err
+		}
+		for _, key := range keys {
+			sku := strings.TrimPrefix(key, "product:")
+			if !strings.Contains(sku, ":") {
+				skus = append(skus, sku)
+			}
+		}
+		if cursor == 0 {
+			return skus, nil
+		}
+	}
+}
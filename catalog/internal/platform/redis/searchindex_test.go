@@ -0,0 +1,109 @@
+package redis_test
+
+import (
+	"fmt"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/autodidaddict/go-shopping/catalog/internal/platform/redis"
+	"github.com/autodidaddict/go-shopping/catalog/proto"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestSpellingSuggestions(t *testing.T) {
+	Convey("Given a catalog repository with a large vocabulary", t, func() {
+		server := miniredis.RunT(t)
+		repo := redis.NewRedisRepository(server.Addr())
+		So(repo.CreateProduct(&catalog.Product{Sku: "TV0001", Name: "Television", Price: &catalog.Money{Amount: 49999,
+			CurrencyCode: "USD"}}, nil), ShouldBeNil)
+		for i := 0; i < 300; i++ {
+			// every one of these shares two trigrams with the misspelling, and more of them do than
+			// are ever compared with it
+			term := fmt.Sprintf("zz%dion", i)
+			server.SAdd("search:gram:ion", term)
+			server.SAdd("search:gram:on$", term)
+		}
+
+		Convey("a misspelled search term should still be corrected", func() {
+			suggestion, err := repo.SuggestQuery("televsion")
+			So(err, ShouldBeNil)
+			So(suggestion, ShouldEqual, "television")
+			So(server.Exists("search:candidates"), ShouldBeFalse)
+		})
+	})
+}
+
+func TestFindMatches(t *testing.T) {
+	Convey("Given a catalog repository with several products", t, func() {
+		server := miniredis.RunT(t)
+		repo := redis.NewRedisRepository(server.Addr())
+		usd := &catalog.Money{Amount: 49999, CurrencyCode: "USD"}
+		So(repo.CreateProduct(&catalog.Product{Sku: "NAME01", Name: "Smart Television", Manufacturer: "Acme",
+			Description: "A television with apps", Price: usd}, nil), ShouldBeNil)
+		So(repo.CreateProduct(&catalog.Product{Sku: "DESC01", Name: "Bluetooth Speaker", Manufacturer: "Acme",
+			Description: "Pairs with any television", Price: usd}, nil), ShouldBeNil)
+
+		Convey("matches should be scored by the weight of the terms matched in each", func() {
+			matches, scores, err := repo.FindMatches("television")
+			So(err, ShouldBeNil)
+			So(len(matches), ShouldEqual, 2)
+			So(scores["NAME01"], ShouldEqual, 11)
+			So(scores["DESC01"], ShouldEqual, 1)
+		})
+
+		Convey("corrected terms should count for less than exact ones", func() {
+			_, scores, err := repo.FindMatches("televsion acme")
+			So(err, ShouldBeNil)
+			So(scores["NAME01"], ShouldEqual, 9.5)
+			So(scores["DESC01"], ShouldEqual, 4.5)
+		})
+
+		Convey("matches should carry what search refines and sorts by, but not the whole product", func() {
+			matches, _, err := repo.FindMatches("speaker")
+			So(err, ShouldBeNil)
+			So(len(matches), ShouldEqual, 1)
+			So(matches[0].Name, ShouldEqual, "Bluetooth Speaker")
+			So(matches[0].Manufacturer, ShouldEqual, "Acme")
+			So(matches[0].Price.Amount, ShouldEqual, 49999)
+			So(matches[0].Description, ShouldBeEmpty)
+		})
+	})
+}
+
+func TestProductsByManufacturer(t *testing.T) {
+	Convey("Given a catalog repository with products from several manufacturers", t, func() {
+		server := miniredis.RunT(t)
+		repo := redis.NewRedisRepository(server.Addr())
+		product := func(sku, manufacturer string, price int64, status catalog.ProductStatus) *catalog.Product {
+			return &catalog.Product{Sku: sku, Name: "Widget", Manufacturer: manufacturer, Description: "Works with Acme",
+				Price: &catalog.Money{Amount: price, CurrencyCode: "USD"}, Status: status}
+		}
+		for _, p := range []*catalog.Product{
+			product("ACME01", "Acme", 1000, catalog.ProductStatus_PS_ACTIVE),
+			product("ACME02", "ACME", 5000, catalog.ProductStatus_PS_ACTIVE),
+			product("ACME03", "Acme", 9000, catalog.ProductStatus_PS_ACTIVE),
+			product("ACME04", "Acme", 4800, catalog.ProductStatus_PS_DISCONTINUED),
+			product("OTHER1", "Other", 5000, catalog.ProductStatus_PS_ACTIVE),
+		} {
+			So(repo.CreateProduct(p, nil), ShouldBeNil)
+		}
+
+		Convey("the active products from the manufacturer closest in price should be found", func() {
+			products, err := repo.GetProductsByManufacturer("acme", 6000, 2)
+			So(err, ShouldBeNil)
+			So(len(products), ShouldEqual, 2)
+			So(products[0].Sku, ShouldEqual, "ACME02")
+			So(products[1].Sku, ShouldEqual, "ACME03")
+		})
+
+		Convey("a product should be found under its new manufacturer once changed", func() {
+			_, err := repo.UpdateProduct(product("ACME01", "Other", 1000, catalog.ProductStatus_PS_ACTIVE))
+			So(err, ShouldBeNil)
+			products, err := repo.GetProductsByManufacturer("Acme", 0, 10)
+			So(err, ShouldBeNil)
+			So(len(products), ShouldEqual, 2)
+			products, err = repo.GetProductsByManufacturer("Other", 0, 10)
+			So(err, ShouldBeNil)
+			So(len(products), ShouldEqual, 2)
+		})
+	})
+}
@@ -0,0 +1,226 @@
+package redis
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/autodidaddict/go-shopping/catalog/internal/platform/errors"
+	"github.com/autodidaddict/go-shopping/catalog/proto"
+	"github.com/garyburd/redigo/redis"
+	"sort"
+)
+
+// A product's specifications are kept in the product:{sku}:specifications hash, and the schema of a
+// category in category:{id}:specification_schema, each mapping a specification's name to its
+// JSON-encoded value or definition.
+
+func productSpecificationsKey(sku string) string {
+	return fmt.Sprintf("product:%s:specifications", sku)
+}
+
+func categorySchemaKey(categoryID uint64) string {
+	return fmt.Sprintf("category:%d:specification_schema", categoryID)
+}
+
+// CheckSpecifications checks, without saving anything, that a product's specifications conform to the
+// schemas of the given categories and of the categories the product would otherwise belong to
+func (r *CatalogRepository) CheckSpecifications(product *catalog.Product, categoryIDs []uint64) (err error) {
+	c, err := redis.Dial("tcp", r.redisDialString)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	if err = requireConformingSpecifications(c, product.Sku, product.ParentSku, product.Specifications, categoryIDs); err != nil {
+		return err
+	}
+	_, err = c.Do("UNWATCH")
+	return err
+}
+
+// requireConformingSpecifications fails, abandoning any watches, unless a product's specifications
+// conform to the schemas of the given categories, of the categories the product and its parent already
+// belong to, and of every ancestor of those categories. The memberships, categories and schemas it
+// reads are watched, so that the change they were checked for is abandoned if any of them changes.
+func requireConformingSpecifications(c redis.Conn, sku, parentSKU string, specifications []*catalog.Specification,
+	categoryIDs []uint64) (err error) {
+
+	categoryIDs = append([]uint64(nil), categoryIDs...)
+	skus := []string{sku}
+	if parentSKU != "" {
+		skus = append(skus, parentSKU)
+	}
+	for _, member := range skus {
+		if err = watch(c, productCategoriesKey(member)); err != nil {
+			return err
+		}
+		memberOf, err := redis.Int64s(c.Do("SMEMBERS", productCategoriesKey(member)))
+		if err != nil {
+			c.Do("UNWATCH")
+			return err
+		}
+		for _, categoryID := range memberOf {
+			categoryIDs = append(categoryIDs, uint64(categoryID))
+		}
+	}
+	if len(categoryIDs) == 0 && len(specifications) == 0 {
+		return nil
+	}
+	categoryIDs, err = watchAncestors(c, categoryIDs)
+	if err != nil {
+		c.Do("UNWATCH")
+		return err
+	}
+	var schema []*catalog.SpecificationDefinition
+	for _, categoryID := range categoryIDs {
+		if err = watch(c, categorySchemaKey(categoryID)); err != nil {
+			return err
+		}
+		definitions, err := loadSpecificationSchema(c, categoryID)
+		if err != nil {
+			c.Do("UNWATCH")
+			return err
+		}
+		schema = append(schema, definitions...)
+	}
+	if err = conformsToSchema(specifications, schema); err != nil {
+		c.Do("UNWATCH")
+	}
+	return err
+}
+
+// watchAncestors adds every ancestor of the given categories to them, once each, watching every
+// category it visits
+func watchAncestors(c redis.Conn, categoryIDs []uint64) (withAncestors []uint64, err error) {
+	seen := make(map[uint64]bool, len(categoryIDs))
+	for len(categoryIDs) > 0 {
+		categoryID := categoryIDs[0]
+		categoryIDs = categoryIDs[1:]
+		if categoryID == 0 || seen[categoryID] {
+			continue
+		}
+		seen[categoryID] = true
+		withAncestors = append(withAncestors, categoryID)
+		if err = watch(c, categoryKey(categoryID)); err != nil {
+			return nil, err
+		}
+		parentID, err := redis.Uint64(c.Do("HGET", categoryKey(categoryID), "parent_id"))
+		if err != nil && err != redis.ErrNil {
+			return nil, err
+		}
+		categoryIDs = append(categoryIDs, parentID)
+	}
+	return withAncestors, nil
+}
+
+// conformsToSchema checks specifications against the definitions of a set of schemas. Every
+// specification must be defined with its type by one of them, and every required one must be present.
+// A name defined with different types by two of the schemas can't be satisfied.
+func conformsToSchema(specifications []*catalog.Specification, schema []*catalog.SpecificationDefinition) error {
+	types := make(map[string]catalog.SpecificationType, len(schema))
+	required := make(map[string]bool, len(schema))
+	for _, definition := range schema {
+		specificationType := definition.Type
+		if existing, ok := types[definition.Name]; ok && existing != specificationType {
+			specificationType = catalog.SpecificationType_ST_UNKNOWN
+		}
+		types[definition.Name] = specificationType
+		required[definition.Name] = required[definition.Name] || definition.Required
+	}
+	present := make(map[string]bool, len(specifications))
+	for _, specification := range specifications {
+		if specificationType, ok := types[specification.Name]; !ok || specificationType != specification.Type {
+			return errors.UndefinedSpecification
+		}
+		present[specification.Name] = true
+	}
+	for name, isRequired := range required {
+		if isRequired && !present[name] {
+			return errors.MissingSpecification
+		}
+	}
+	return nil
+}
+
+// queueSpecifications queues the commands that save a product's specifications. It is meant to be
+// used within a MULTI block.
+func queueSpecifications(c redis.Conn, product *catalog.Product) {
+	if len(product.Specifications) == 0 {
+		return
+	}
+	args := redis.Args{}.Add(productSpecificationsKey(product.Sku))
+	for _, specification := range product.Specifications {
+		encoded, _ := json.Marshal(redisSpecification{
+			Type:         int32(specification.Type),
+			TextValue:    specification.TextValue,
+			NumberValue:  specification.NumberValue,
+			BooleanValue: specification.BooleanValue,
+		})
+		args = args.Add(specification.Name, encoded)
+	}
+	c.Send("HMSET", args...)
+}
+
+// decodeSpecifications decodes a hash of specifications keyed by name, ordering them by name and
+// skipping any that can't be decoded
+func decodeSpecifications(encoded map[string]string) (specifications []*catalog.Specification) {
+	for name, e := range encoded {
+		var s redisSpecification
+		if json.Unmarshal([]byte(e), &s) != nil {
+			continue
+		}
+		specifications = append(specifications, &catalog.Specification{
+			Name:         name,
+			Type:         catalog.SpecificationType(s.Type),
+			TextValue:    s.TextValue,
+			NumberValue:  s.NumberValue,
+			BooleanValue: s.BooleanValue,
+		})
+	}
+	sort.Slice(specifications, func(i, j int) bool {
+		return specifications[i].Name < specifications[j].Name
+	})
+	return specifications
+}
+
+// queueSpecificationSchema queues the commands that save a category's specification schema. It is
+// meant to be used within a MULTI block.
+func queueSpecificationSchema(c redis.Conn, categoryID uint64, schema []*catalog.SpecificationDefinition) {
+	if len(schema) == 0 {
+		return
+	}
+	args := redis.Args{}.Add(categorySchemaKey(categoryID))
+	for _, definition := range schema {
+		encoded, _ := json.Marshal(redisSpecificationDefinition{
+			Type:     int32(definition.Type),
+			Unit:     definition.Unit,
+			Required: definition.Required,
+		})
+		args = args.Add(definition.Name, encoded)
+	}
+	c.Send("HMSET", args...)
+}
+
+// loadSpecificationSchema loads a category's specification schema ordered by name, skipping any
+// definitions that can't be decoded
+func loadSpecificationSchema(c redis.Conn, categoryID uint64) (schema []*catalog.SpecificationDefinition, err error) {
+	encoded, err := redis.StringMap(c.Do("HGETALL", categorySchemaKey(categoryID)))
+	if err != nil {
+		return nil, err
+	}
+	for name, e := range encoded {
+		var d redisSpecificationDefinition
+		if json.Unmarshal([]byte(e), &d) != nil {
+			continue
+		}
+		schema = append(schema, &catalog.SpecificationDefinition{
+			Name:     name,
+			Type:     catalog.SpecificationType(d.Type),
+			Unit:     d.Unit,
+			Required: d.Required,
+		})
+	}
+	sort.Slice(schema, func(i, j int) bool {
+		return schema[i].Name < schema[j].Name
+	})
+	return schema, nil
+}
@@ -0,0 +1,56 @@
+package redis_test
+
+import (
+	"github.com/alicebob/miniredis/v2"
+	"github.com/autodidaddict/go-shopping/catalog/internal/platform/errors"
+	"github.com/autodidaddict/go-shopping/catalog/internal/platform/redis"
+	"github.com/autodidaddict/go-shopping/catalog/proto"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestSpecificationSchemas(t *testing.T) {
+	Convey("Given a catalog repository with a subcategory of a category that has a specification schema", t, func() {
+		server := miniredis.RunT(t)
+		repo := redis.NewRedisRepository(server.Addr())
+		_, err := repo.CreateCategory(&catalog.ProductCategory{CategoryId: 50, Name: "Appliances",
+			SpecificationSchema: []*catalog.SpecificationDefinition{
+				{Name: "wattage", Type: catalog.SpecificationType_ST_NUMBER, Unit: "W", Required: true},
+			}})
+		So(err, ShouldBeNil)
+		_, err = repo.CreateCategory(&catalog.ProductCategory{CategoryId: 51, Name: "Kettles", ParentId: 50,
+			SpecificationSchema: []*catalog.SpecificationDefinition{
+				{Name: "cordless", Type: catalog.SpecificationType_ST_BOOLEAN},
+			}})
+		So(err, ShouldBeNil)
+		_, err = repo.CreateCategory(&catalog.ProductCategory{CategoryId: 60, Name: "Clearance"})
+		So(err, ShouldBeNil)
+
+		wattage := &catalog.Specification{Name: "wattage", Type: catalog.SpecificationType_ST_NUMBER, NumberValue: 1200}
+		cordless := &catalog.Specification{Name: "cordless", Type: catalog.SpecificationType_ST_BOOLEAN, BooleanValue: true}
+		kettle := func(specifications ...*catalog.Specification) *catalog.Product {
+			return &catalog.Product{Sku: "KETTLE", Name: "Kettle", Price: &catalog.Money{Amount: 2999, CurrencyCode: "USD"},
+				Specifications: specifications}
+		}
+
+		Convey("a product in the subcategory should conform to the schemas it inherits", func() {
+			So(repo.CreateProduct(kettle(cordless), []uint64{51}), ShouldEqual, errors.MissingSpecification)
+			So(repo.CreateProduct(kettle(wattage, cordless), []uint64{51}), ShouldBeNil)
+		})
+
+		Convey("a product should keep conforming to the schemas of the categories it belongs to", func() {
+			So(repo.CreateProduct(kettle(wattage, cordless), []uint64{51}), ShouldBeNil)
+
+			_, err := repo.UpdateProduct(kettle(cordless))
+			So(err, ShouldEqual, errors.MissingSpecification)
+			So(repo.CheckSpecifications(kettle(wattage), []uint64{60}), ShouldBeNil)
+		})
+
+		Convey("a product should only be assigned to a category whose schemas it conforms to", func() {
+			So(repo.CreateProduct(kettle(), []uint64{60}), ShouldBeNil)
+
+			So(repo.AssignProductToCategory("KETTLE", 51), ShouldEqual, errors.MissingSpecification)
+			So(repo.CheckSpecifications(kettle(cordless), nil), ShouldEqual, errors.UndefinedSpecification)
+		})
+	})
+}
@@ -0,0 +1,61 @@
+package redis
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/autodidaddict/go-shopping/catalog/proto"
+	"github.com/garyburd/redigo/redis"
+	"sort"
+)
+
+// Translations of a product's name and description are kept in the product:{sku}:translations hash,
+// and those of a category in category:{id}:translations, mapping each locale to its JSON-encoded text.
+// Translated text is indexed for search along with the catalog's own text, so that customers can
+// search in their own language.
+
+func productTranslationsKey(sku string) string {
+	return fmt.Sprintf("product:%s:translations", sku)
+}
+
+func categoryTranslationsKey(categoryID uint64) string {
+	return fmt.Sprintf("category:%d:translations", categoryID)
+}
+
+// queueTranslations queues the commands that save translations under the given key. It is meant to be
+// used within a MULTI block.
+func queueTranslations(c redis.Conn, key string, translations []*catalog.Translation) {
+	if len(translations) == 0 {
+		return
+	}
+	args := redis.Args{}.Add(key)
+	for _, translation := range translations {
+		encoded, _ := json.Marshal(redisTranslation{Name: translation.Name, Description: translation.Description})
+		args = args.Add(translation.Locale, encoded)
+	}
+	c.Send("HMSET", args...)
+}
+
+// loadTranslations loads the translations saved under the given key ordered by locale, skipping any
+// that can't be decoded
+func loadTranslations(c redis.Conn, key string) (translations []*catalog.Translation, err error) {
+	encoded, err := redis.StringMap(c.Do("HGETALL", key))
+	if err != nil {
+		return nil, err
+	}
+	return decodeTranslations(encoded), nil
+}
+
+// decodeTranslations decodes a hash of translations keyed by locale, ordering them by locale
+func decodeTranslations(encoded map[string]string) (translations []*catalog.Translation) {
+	for locale, e := range encoded {
+		var t redisTranslation
+		if json.Unmarshal([]byte(e), &t) != nil {
+			continue
+		}
+		translations = append(translations, &catalog.Translation{Locale: locale, Name: t.Name, Description: t.Description})
+	}
+	sort.Slice(translations, func(i, j int) bool {
+		return translations[i].Locale < translations[j].Locale
+	})
+	return translations
+}
@@ -7,9 +7,76 @@ type redisProduct struct {
 	Manufacturer string `redis:"mfr"`
 	Model        string `redis:"model"`
 	Price        int64  `redis:"price"`
+	Currency     string `redis:"currency"`
+	ParentSKU    string `redis:"parent_sku"`
+	Status       int32  `redis:"status"`
+	Kind         int32  `redis:"kind"`
+}
+
+// redisEffectivePrice is kept in the product hash apart from redisProduct, so that saving a product's
+// details never overwrites it
+type redisEffectivePrice struct {
+	Amount           int64  `redis:"effective_price"`
+	ScheduledPriceID uint64 `redis:"scheduled_price_id"`
+}
+
+// redisMediaAsset is stored JSON-encoded as an element of a product's media list
+type redisMediaAsset struct {
+	URL       string `json:"url"`
+	AltText   string `json:"alt,omitempty"`
+	MediaType int32  `json:"type"`
+	Width     uint32 `json:"w,omitempty"`
+	Height    uint32 `json:"h,omitempty"`
 }
 
 type redisCategory struct {
 	Name        string `redis:"name"`
 	Description string `redis:"description"`
+	ParentID    uint64 `redis:"parent_id"`
+}
+
+type redisPriceChange struct {
+	SKU         string `redis:"sku"`
+	PriceType   int32  `redis:"type"`
+	Amount      int64  `redis:"amount"`
+	Currency    string `redis:"currency"`
+	StartsAt    int64  `redis:"starts_at"`
+	EndsAt      int64  `redis:"ends_at"`
+	CreatedAt   int64  `redis:"created_at"`
+	CancelledAt int64  `redis:"cancelled_at"`
+}
+
+type redisReview struct {
+	SKU            string `redis:"sku"`
+	CustomerID     string `redis:"customer_id"`
+	Rating         uint32 `redis:"rating"`
+	Title          string `redis:"title"`
+	Body           string `redis:"body"`
+	Status         int32  `redis:"status"`
+	CreatedAt      int64  `redis:"created_at"`
+	ModeratedAt    int64  `redis:"moderated_at"`
+	HelpfulVotes   uint32 `redis:"helpful"`
+	UnhelpfulVotes uint32 `redis:"unhelpful"`
+}
+
+// redisTranslation is stored JSON-encoded as the value of a locale in a translations hash
+type redisTranslation struct {
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// redisSpecification is stored JSON-encoded as the value of a name in a product's specifications hash
+type redisSpecification struct {
+	Type         int32   `json:"type"`
+	TextValue    string  `json:"text,omitempty"`
+	NumberValue  float64 `json:"number,omitempty"`
+	BooleanValue bool    `json:"boolean,omitempty"`
+}
+
+// redisSpecificationDefinition is stored JSON-encoded as the value of a name in a category's
+// specification schema hash
+type redisSpecificationDefinition struct {
+	Type     int32  `json:"type"`
+	Unit     string `json:"unit,omitempty"`
+	Required bool   `json:"required,omitempty"`
 }
@@ -0,0 +1,81 @@
+package search
+
+import "unicode/utf8"
+
+const gramSize = 3
+
+// MaxEdits returns the number of typos tolerated for a search token of the given length. Very short
+// tokens must match exactly, otherwise nearly everything would match them.
+func MaxEdits(token string) int {
+	switch n := utf8.RuneCountInString(token); {
+	case n < 4:
+		return 0
+	case n < 8:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// Similar indicates whether a word is within the typo tolerance of a search token
+func Similar(token, word string) bool {
+	maxEdits := MaxEdits(token)
+	if maxEdits == 0 {
+		return token == word
+	}
+	return Distance(token, word) <= maxEdits
+}
+
+// Distance computes the optimal string alignment distance between two strings: the number of
+// single-character insertions, deletions, substitutions and adjacent transpositions required to
+// turn one into the other.
+func Distance(a, b string) int {
+	s, t := []rune(a), []rune(b)
+	rows := make([][]int, len(s)+1)
+	for i := range rows {
+		rows[i] = make([]int, len(t)+1)
+		rows[i][0] = i
+	}
+	for j := range rows[0] {
+		rows[0][j] = j
+	}
+	for i := 1; i <= len(s); i++ {
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+			d := minimum(rows[i-1][j]+1, rows[i][j-1]+1, rows[i-1][j-1]+cost)
+			if i > 1 && j > 1 && s[i-1] == t[j-2] && s[i-2] == t[j-1] {
+				d = minimum(d, rows[i-2][j-2]+1)
+			}
+			rows[i][j] = d
+		}
+	}
+	return rows[len(s)][len(t)]
+}
+
+// Grams splits a token into the overlapping character trigrams used to find candidate corrections
+// for a misspelled token. The token is padded so that its first and last letters carry extra weight.
+func Grams(token string) (grams []string) {
+	padded := []rune("^" + token + "$")
+	seen := make(map[string]bool)
+	for i := 0; i+gramSize <= len(padded); i++ {
+		gram := string(padded[i : i+gramSize])
+		if !seen[gram] {
+			seen[gram] = true
+			grams = append(grams, gram)
+		}
+	}
+	return grams
+}
+
+func minimum(values ...int) int {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
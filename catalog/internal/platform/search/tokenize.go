@@ -0,0 +1,39 @@
+// Package search contains the text handling shared by the catalog search index and the
+// search ranking done in the catalog service.
+package search
+
+import (
+	"strings"
+	"unicode"
+)
+
+// MinTokenLength is the length of the shortest token worth indexing or searching for
+const MinTokenLength = 2
+
+// Tokenize splits text into a de-duplicated list of lower-case search tokens, in the order
+// in which they first appear.
+func Tokenize(text string) (tokens []string) {
+	seen := make(map[string]bool)
+	for _, field := range Words(text) {
+		if len(field) < MinTokenLength || seen[field] {
+			continue
+		}
+		seen[field] = true
+		tokens = append(tokens, field)
+	}
+	return tokens
+}
+
+// Words splits text into lower-case words, keeping duplicates and short words.
+func Words(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), isSeparator)
+}
+
+func isSeparator(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
+
+// Normalize lower-cases text and collapses any punctuation and whitespace into single spaces
+func Normalize(text string) string {
+	return strings.Join(Words(text), " ")
+}
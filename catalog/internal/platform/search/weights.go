@@ -0,0 +1,45 @@
+package search
+
+// Each searchable field of a product has a weight saying how much a search term found in it counts
+// for when ranking products. A term counts once for every time it appears.
+const (
+	NameWeight         = 10.0
+	ModelWeight        = 6.0
+	ManufacturerWeight = 4.0
+	DescriptionWeight  = 1.0
+
+	// FuzzyMatchStrength discounts terms that only match a search token when allowing for typos
+	FuzzyMatchStrength = 0.5
+)
+
+// TermWeights totals the weight of every token in a product's fields. A product's name and
+// description may be given in several languages, in which case a token counts as often as it appears
+// in the one it appears in most.
+func TermWeights(names, descriptions []string, manufacturer, model string) map[string]float64 {
+	weights := make(map[string]float64)
+	addWeights(weights, names, NameWeight)
+	addWeights(weights, descriptions, DescriptionWeight)
+	addWeights(weights, []string{manufacturer}, ManufacturerWeight)
+	addWeights(weights, []string{model}, ModelWeight)
+	return weights
+}
+
+func addWeights(weights map[string]float64, texts []string, weight float64) {
+	most := make(map[string]int)
+	for _, text := range texts {
+		counts := make(map[string]int)
+		for _, word := range Words(text) {
+			if len(word) >= MinTokenLength {
+				counts[word]++
+			}
+		}
+		for word, count := range counts {
+			if count > most[word] {
+				most[word] = count
+			}
+		}
+	}
+	for word, count := range most {
+		weights[word] += weight * float64(count)
+	}
+}
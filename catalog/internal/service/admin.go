@@ -0,0 +1,501 @@
+package service
+
+import (
+	catalogerrors "github.com/autodidaddict/go-shopping/catalog/internal/platform/errors"
+	"github.com/autodidaddict/go-shopping/catalog/proto"
+	"github.com/micro/go-micro/errors"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type catalogAdminService struct {
+	repo           catalogAdminRepository
+	eventPublisher productChangedEventPublisher
+}
+
+type catalogAdminRepository interface {
+	CreateProduct(product *catalog.Product, categoryIDs []uint64) (err error)
+	UpdateProduct(product *catalog.Product) (previous *catalog.Product, err error)
+	DeleteProduct(sku string) (err error)
+	CreateCategory(category *catalog.ProductCategory) (categoryID uint64, err error)
+	UpdateCategory(category *catalog.ProductCategory) (err error)
+	DeleteCategory(categoryID uint64) (err error)
+	AssignProductToCategory(sku string, categoryID uint64) (err error)
+	SchedulePrice(change *catalog.PriceChange) (priceChangeID uint64, err error)
+	CancelScheduledPrice(sku string, priceChangeID uint64) (err error)
+	GetPriceHistory(sku string, from, to int64) (changes []*catalog.PriceChange, err error)
+	ApplyScheduledPrices(now int64) (previousPrices map[string]*catalog.Money, err error)
+	LinkRelatedProduct(sku string, relatedSKU string) (err error)
+	UnlinkRelatedProduct(sku string, relatedSKU string) (err error)
+	ModerateReview(reviewID uint64, status catalog.ReviewStatus) (err error)
+	GetPendingReviews(offset, limit int) (reviews []*catalog.Review, total int, err error)
+	ProductExists(sku string) (exists bool, err error)
+	GetProduct(sku string) (product *catalog.Product, err error)
+}
+
+type productChangedEventPublisher interface {
+	PublishProductChangedEvent(event *catalog.ProductChangedEvent) (err error)
+}
+
+// NewCatalogAdminService creates a new catalog administration service
+func NewCatalogAdminService(repo catalogAdminRepository, publisher productChangedEventPublisher) catalog.CatalogAdminHandler {
+	return &catalogAdminService{repo: repo, eventPublisher: publisher}
+}
+
+func (a *catalogAdminService) CreateProduct(ctx context.Context, request *catalog.CreateProductRequest,
+	response *catalog.CreateProductResponse) error {
+
+	if request == nil || request.Product == nil {
+		return errors.BadRequest("", "Missing create product request")
+	}
+	if err := validateProduct(request.Product); err != nil {
+		return errors.BadRequest(request.Product.Sku, "%s", err.Error())
+	}
+	if err := a.repo.CreateProduct(request.Product, request.CategoryIds); err != nil {
+		return adminError(request.Product.Sku, err)
+	}
+	response.Product = request.Product
+
+	a.publishChange(&catalog.ProductChangedEvent{
+		Sku:        request.Product.Sku,
+		ChangeType: catalog.ProductChangeType_PC_CREATED,
+		Product:    request.Product,
+	})
+	return nil
+}
+
+func (a *catalogAdminService) UpdateProduct(ctx context.Context, request *catalog.UpdateProductRequest,
+	response *catalog.UpdateProductResponse) error {
+
+	if request == nil || request.Product == nil {
+		return errors.BadRequest("", "Missing update product request")
+	}
+	if err := validateProduct(request.Product); err != nil {
+		return errors.BadRequest(request.Product.Sku, "%s", err.Error())
+	}
+	previous, err := a.repo.UpdateProduct(request.Product)
+	if err = savedChange(request.Product.Sku, err); err != nil {
+		return adminError(request.Product.Sku, err)
+	}
+	response.Product = request.Product
+
+	event := &catalog.ProductChangedEvent{
+		Sku:        request.Product.Sku,
+		ChangeType: catalog.ProductChangeType_PC_UPDATED,
+		Product:    request.Product,
+	}
+	if previous != nil && !samePrice(previous.Price, request.Product.Price) {
+		event.ChangeType = catalog.ProductChangeType_PC_REPRICED
+		event.PreviousPrice = previous.Price
+	}
+	if request.Product.Status == catalog.ProductStatus_PS_DISCONTINUED &&
+		(previous == nil || previous.Status != catalog.ProductStatus_PS_DISCONTINUED) {
+		event.ChangeType = catalog.ProductChangeType_PC_DISCONTINUED
+	}
+	a.publishChange(event)
+	return nil
+}
+
+func (a *catalogAdminService) DeleteProduct(ctx context.Context, request *catalog.DeleteProductRequest,
+	response *catalog.DeleteProductResponse) error {
+
+	if request == nil {
+		return errors.BadRequest("", "Missing delete product request")
+	}
+	if err := validateSKU(request.Sku); err != nil {
+		return errors.BadRequest(request.Sku, "%s", err.Error())
+	}
+	if err := savedChange(request.Sku, a.repo.DeleteProduct(request.Sku)); err != nil {
+		return adminError(request.Sku, err)
+	}
+	response.Success = true
+
+	a.publishChange(&catalog.ProductChangedEvent{
+		Sku:        request.Sku,
+		ChangeType: catalog.ProductChangeType_PC_DELETED,
+	})
+	return nil
+}
+
+func (a *catalogAdminService) CreateCategory(ctx context.Context, request *catalog.CreateCategoryRequest,
+	response *catalog.CreateCategoryResponse) error {
+
+	if request == nil || request.Category == nil {
+		return errors.BadRequest("", "Missing create category request")
+	}
+	id := strconv.FormatUint(request.Category.CategoryId, 10)
+	if err := validateCategory(request.Category); err != nil {
+		return errors.BadRequest(id, "%s", err.Error())
+	}
+	categoryID, err := a.repo.CreateCategory(request.Category)
+	if err != nil {
+		return adminError(id, err)
+	}
+	response.Category = &catalog.ProductCategory{
+		CategoryId:          categoryID,
+		Name:                request.Category.Name,
+		Description:         request.Category.Description,
+		ParentId:            request.Category.ParentId,
+		Translations:        request.Category.Translations,
+		SpecificationSchema: request.Category.SpecificationSchema,
+	}
+	return nil
+}
+
+func (a *catalogAdminService) UpdateCategory(ctx context.Context, request *catalog.UpdateCategoryRequest,
+	response *catalog.UpdateCategoryResponse) error {
+
+	if request == nil || request.Category == nil {
+		return errors.BadRequest("", "Missing update category request")
+	}
+	id := strconv.FormatUint(request.Category.CategoryId, 10)
+	if err := validateCategory(request.Category); err != nil {
+		return errors.BadRequest(id, "%s", err.Error())
+	}
+	if err := a.repo.UpdateCategory(request.Category); err != nil {
+		return adminError(id, err)
+	}
+	response.Category = request.Category
+	return nil
+}
+
+func (a *catalogAdminService) DeleteCategory(ctx context.Context, request *catalog.DeleteCategoryRequest,
+	response *catalog.DeleteCategoryResponse) error {
+
+	if request == nil {
+		return errors.BadRequest("", "Missing delete category request")
+	}
+	id := strconv.FormatUint(request.CategoryId, 10)
+	if err := a.repo.DeleteCategory(request.CategoryId); err != nil {
+		return adminError(id, err)
+	}
+	response.Success = true
+	return nil
+}
+
+func (a *catalogAdminService) AssignProductToCategory(ctx context.Context, request *catalog.AssignProductRequest,
+	response *catalog.AssignProductResponse) error {
+
+	if request == nil {
+		return errors.BadRequest("", "Missing assign product request")
+	}
+	if err := validateSKU(request.Sku); err != nil {
+		return errors.BadRequest(request.Sku, "%s", err.Error())
+	}
+	if err := a.repo.AssignProductToCategory(request.Sku, request.CategoryId); err != nil {
+		return adminError(request.Sku, err)
+	}
+	response.Success = true
+	return nil
+}
+
+func (a *catalogAdminService) SchedulePrice(ctx context.Context, request *catalog.SchedulePriceRequest,
+	response *catalog.SchedulePriceResponse) error {
+
+	if request == nil || request.Price == nil {
+		return errors.BadRequest("", "Missing schedule price request")
+	}
+	if err := validateSKU(request.Sku); err != nil {
+		return errors.BadRequest(request.Sku, "%s", err.Error())
+	}
+	if err := validatePrice(request.Price); err != nil {
+		return errors.BadRequest(request.Sku, "%s", err.Error())
+	}
+	now := time.Now().UTC().Unix()
+	startsAt, err := validateSchedule(request.StartsAt, request.EndsAt, now)
+	if err != nil {
+		return errors.BadRequest(request.Sku, "%s", err.Error())
+	}
+
+	change := &catalog.PriceChange{
+		Sku:       request.Sku,
+		PriceType: catalog.PriceType_PT_SCHEDULED,
+		Price:     request.Price,
+		StartsAt:  startsAt,
+		EndsAt:    request.EndsAt,
+		CreatedAt: now,
+	}
+	change.PriceChangeId, err = a.repo.SchedulePrice(change)
+	if err != nil {
+		return adminError(request.Sku, err)
+	}
+	response.PriceChange = change
+
+	// a price starting now shouldn't wait for the scheduler to take effect
+	if startsAt == now {
+		a.applyDuePrices()
+	}
+	return nil
+}
+
+func (a *catalogAdminService) CancelScheduledPrice(ctx context.Context, request *catalog.CancelScheduledPriceRequest,
+	response *catalog.CancelScheduledPriceResponse) error {
+
+	if request == nil {
+		return errors.BadRequest("", "Missing cancel scheduled price request")
+	}
+	if err := a.repo.CancelScheduledPrice(request.Sku, request.PriceChangeId); err != nil {
+		return adminError(request.Sku, err)
+	}
+	response.Success = true
+
+	a.applyDuePrices()
+	return nil
+}
+
+func (a *catalogAdminService) GetPriceHistory(ctx context.Context, request *catalog.PriceHistoryRequest,
+	response *catalog.PriceHistoryResponse) error {
+
+	if request == nil {
+		return errors.BadRequest("", "Missing price history request")
+	}
+	if request.From < 0 || request.To < 0 || (request.To != 0 && request.To < request.From) {
+		return errors.BadRequest(request.Sku, "Invalid price history range")
+	}
+	exists, err := a.repo.ProductExists(request.Sku)
+	if err != nil {
+		return errors.InternalServerError(request.Sku, "Failed to check product existence: %s", err.Error())
+	}
+	if !exists {
+		return errors.NotFound(request.Sku, "No such product")
+	}
+	changes, err := a.repo.GetPriceHistory(request.Sku, request.From, request.To)
+	if err != nil {
+		return errors.InternalServerError(request.Sku, "Failed to load price history: %s", err.Error())
+	}
+	response.PriceChanges = changes
+	return nil
+}
+
+func (a *catalogAdminService) LinkRelatedProduct(ctx context.Context, request *catalog.RelatedLinkRequest,
+	response *catalog.RelatedLinkResponse) error {
+
+	if request == nil {
+		return errors.BadRequest("", "Missing related product link request")
+	}
+	if err := validateRelatedLink(request); err != nil {
+		return errors.BadRequest(request.Sku, "%s", err.Error())
+	}
+	if err := a.repo.LinkRelatedProduct(request.Sku, request.RelatedSku); err != nil {
+		return adminError(request.Sku, err)
+	}
+	response.Success = true
+	return nil
+}
+
+func (a *catalogAdminService) UnlinkRelatedProduct(ctx context.Context, request *catalog.RelatedLinkRequest,
+	response *catalog.RelatedLinkResponse) error {
+
+	if request == nil {
+		return errors.BadRequest("", "Missing related product link request")
+	}
+	if err := validateRelatedLink(request); err != nil {
+		return errors.BadRequest(request.Sku, "%s", err.Error())
+	}
+	if err := a.repo.UnlinkRelatedProduct(request.Sku, request.RelatedSku); err != nil {
+		return adminError(request.Sku, err)
+	}
+	response.Success = true
+	return nil
+}
+
+// ModerateReview approves a review, showing it to customers and counting it in its product's rating,
+// or rejects it
+func (a *catalogAdminService) ModerateReview(ctx context.Context, request *catalog.ModerateReviewRequest,
+	response *catalog.ModerateReviewResponse) error {
+
+	if request == nil {
+		return errors.BadRequest("", "Missing moderate review request")
+	}
+	id := strconv.FormatUint(request.ReviewId, 10)
+	if request.Status != catalog.ReviewStatus_RS_APPROVED && request.Status != catalog.ReviewStatus_RS_REJECTED {
+		return errors.BadRequest(id, "%s", catalogerrors.InvalidReviewStatus.Error())
+	}
+	if err := a.repo.ModerateReview(request.ReviewId, request.Status); err != nil {
+		return adminError(id, err)
+	}
+	response.Success = true
+	return nil
+}
+
+// GetPendingReviews pages through the reviews awaiting moderation, oldest first
+func (a *catalogAdminService) GetPendingReviews(ctx context.Context, request *catalog.PendingReviewsRequest,
+	response *catalog.PendingReviewsResponse) error {
+
+	if request == nil {
+		return errors.BadRequest("", "Missing pending reviews request")
+	}
+	offset, ok := decodeCursor(request.Cursor)
+	if !ok {
+		return errors.BadRequest("", "Invalid page cursor")
+	}
+	reviews, total, err := a.repo.GetPendingReviews(offset, pageSize(request.PageSize))
+	if err != nil {
+		return errors.InternalServerError("", "Failed to load pending reviews: %s", err.Error())
+	}
+	response.Reviews = reviews
+	response.TotalCount = uint32(total)
+	response.NextPageCursor = nextCursor(offset, len(reviews), total)
+	return nil
+}
+
+// publishChange stamps and publishes a product changed event. The change has already been saved by
+// the time it is published, so a failure to publish is logged rather than failing the request.
+func (a *catalogAdminService) publishChange(event *catalog.ProductChangedEvent) {
+	event.Timestamp = time.Now().UTC().Unix()
+	if err := a.eventPublisher.PublishProductChangedEvent(event); err != nil {
+		log.Errorf("Failed to publish %s event for product %s: %v", event.ChangeType, event.Sku, err)
+	}
+}
+
+// savedChange treats a repository failure that happened after the change was saved as a success, so
+// that the change is still announced
+func savedChange(sku string, err error) error {
+	if err == catalogerrors.SearchTermsNotPruned {
+		log.Warnf("Product %s was saved, but unused search terms were not pruned", sku)
+		return nil
+	}
+	return err
+}
+
+// validateSKU makes sure a SKU can safely be embedded in the repository's keys and key patterns
+func validateSKU(sku string) error {
+	if len(sku) == 0 || strings.ContainsAny(sku, ":*?[]{} \t\r\n") {
+		return catalogerrors.InvalidSKU
+	}
+	return nil
+}
+
+// validateProduct checks a product before it is saved, filling in the base currency for prices that
+// don't name one
+func validateProduct(product *catalog.Product) error {
+	if err := validateSKU(product.Sku); err != nil {
+		return err
+	}
+	if len(strings.TrimSpace(product.Name)) == 0 {
+		return catalogerrors.MissingProductName
+	}
+	if _, ok := catalog.ProductStatus_name[int32(product.Status)]; !ok {
+		return catalogerrors.InvalidProductStatus
+	}
+	if product.Price == nil {
+		product.Price = &catalog.Money{}
+	}
+	if err := validatePrice(product.Price); err != nil {
+		return err
+	}
+	if product.ParentSku != "" {
+		if err := validateSKU(product.ParentSku); err != nil {
+			return catalogerrors.InvalidParentProduct
+		}
+	}
+	names := make(map[string]bool, len(product.Attributes))
+	for _, attribute := range product.Attributes {
+		if attribute == nil || len(strings.TrimSpace(attribute.Name)) == 0 || names[attribute.Name] {
+			return catalogerrors.InvalidAttribute
+		}
+		names[attribute.Name] = true
+	}
+	for _, asset := range product.Media {
+		if err := validateMediaAsset(asset); err != nil {
+			return err
+		}
+	}
+	if err := validateBundle(product); err != nil {
+		return err
+	}
+	if err := validateSpecifications(product.Specifications); err != nil {
+		return err
+	}
+	return validateTranslations(product.Translations)
+}
+
+// validateBundle checks that a bundle has distinct components, none of which is the bundle itself,
+// and that only bundles have components. Bundles are stocked through their components, so a bundle
+// can't be a variant.
+func validateBundle(product *catalog.Product) error {
+	if _, ok := catalog.ProductKind_name[int32(product.Kind)]; !ok {
+		return catalogerrors.InvalidBundle
+	}
+	if product.Kind != catalog.ProductKind_PK_BUNDLE {
+		if len(product.Components) > 0 {
+			return catalogerrors.InvalidBundle
+		}
+		return nil
+	}
+	if len(product.Components) == 0 || product.ParentSku != "" {
+		return catalogerrors.InvalidBundle
+	}
+	skus := make(map[string]bool, len(product.Components))
+	for _, component := range product.Components {
+		if component == nil || validateSKU(component.Sku) != nil || component.Quantity == 0 ||
+			component.Sku == product.Sku || skus[component.Sku] {
+			return catalogerrors.InvalidBundle
+		}
+		skus[component.Sku] = true
+	}
+	sort.Slice(product.Components, func(i, j int) bool {
+		return product.Components[i].Sku < product.Components[j].Sku
+	})
+	return nil
+}
+
+func validateMediaAsset(asset *catalog.MediaAsset) error {
+	if asset == nil || asset.MediaType == catalog.MediaType_MT_UNKNOWN {
+		return catalogerrors.InvalidMediaAsset
+	}
+	if _, ok := catalog.MediaType_name[int32(asset.MediaType)]; !ok {
+		return catalogerrors.InvalidMediaAsset
+	}
+	u, err := url.Parse(asset.Url)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return catalogerrors.InvalidMediaAsset
+	}
+	return nil
+}
+
+func validateRelatedLink(request *catalog.RelatedLinkRequest) error {
+	if err := validateSKU(request.Sku); err != nil {
+		return err
+	}
+	if err := validateSKU(request.RelatedSku); err != nil {
+		return err
+	}
+	if request.Sku == request.RelatedSku {
+		return catalogerrors.InvalidRelatedProduct
+	}
+	return nil
+}
+
+func validateCategory(category *catalog.ProductCategory) error {
+	if len(strings.TrimSpace(category.Name)) == 0 {
+		return catalogerrors.MissingCategoryName
+	}
+	if err := validateSpecificationSchema(category.SpecificationSchema); err != nil {
+		return err
+	}
+	return validateTranslations(category.Translations)
+}
+
+// adminError converts a repository failure into an appropriately coded RPC error
+func adminError(id string, err error) error {
+	switch err {
+	case catalogerrors.InvalidParentCategory, catalogerrors.InvalidParentProduct, catalogerrors.InvalidBundle,
+		catalogerrors.UndefinedSpecification, catalogerrors.MissingSpecification:
+		return errors.BadRequest(id, "%s", err.Error())
+	case catalogerrors.NoSuchProduct, catalogerrors.NoSuchCategory, catalogerrors.NoSuchPriceChange,
+		catalogerrors.NoSuchReview:
+		return errors.New(id, err.Error(), http.StatusNotFound)
+	case catalogerrors.DuplicateProduct, catalogerrors.DuplicateCategory, catalogerrors.DuplicateReview,
+		catalogerrors.ProductHasVariants, catalogerrors.ProductInBundle, catalogerrors.ConcurrentModification:
+		return errors.New(id, err.Error(), http.StatusConflict)
+	default:
+		return errors.InternalServerError(id, "Failed to update catalog: %s", err.Error())
+	}
+}
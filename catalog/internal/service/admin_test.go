@@ -0,0 +1,1100 @@
+package service_test
+
+import (
+	stderrors "errors"
+	catalogerrors "github.com/autodidaddict/go-shopping/catalog/internal/platform/errors"
+	"github.com/autodidaddict/go-shopping/catalog/internal/service"
+	"github.com/autodidaddict/go-shopping/catalog/proto"
+	"github.com/micro/go-micro/errors"
+	. "github.com/smartystreets/goconvey/convey"
+	"golang.org/x/net/context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestProductAdministration(t *testing.T) {
+	Convey("Given a catalog admin service", t, func() {
+		repo := newFakeAdminRepo()
+		pub := &fakeProductPublisher{}
+		svc := service.NewCatalogAdminService(repo, pub)
+		ctx := context.Background()
+
+		Convey("creating a valid product should invoke the repository", func() {
+			var resp catalog.CreateProductResponse
+			err := svc.CreateProduct(ctx, &catalog.CreateProductRequest{
+				Product:     &catalog.Product{Sku: "NEW001", Name: "Widget", Price: usd(999)},
+				CategoryIds: []uint64{42},
+			}, &resp)
+			So(err, ShouldBeNil)
+			So(resp.Product.Sku, ShouldEqual, "NEW001")
+			So(repo.products["NEW001"], ShouldNotBeNil)
+			So(repo.categoryProducts[42]["NEW001"], ShouldBeTrue)
+			So(len(pub.events), ShouldEqual, 1)
+			So(pub.events[0].ChangeType, ShouldEqual, catalog.ProductChangeType_PC_CREATED)
+			So(pub.events[0].Product.Name, ShouldEqual, "Widget")
+			So(pub.events[0].Timestamp, ShouldBeGreaterThan, 0)
+		})
+
+		Convey("creating a product with an unusable SKU should fail", func() {
+			var resp catalog.CreateProductResponse
+			err := svc.CreateProduct(ctx, &catalog.CreateProductRequest{
+				Product: &catalog.Product{Sku: "BAD:SKU", Name: "Widget"},
+			}, &resp)
+			So(err, ShouldNotBeNil)
+			realError := errors.Parse(err.Error())
+			So(realError.Code, ShouldEqual, http.StatusBadRequest)
+			So(realError.Detail, ShouldEqual, catalogerrors.InvalidSKU.Error())
+		})
+
+		Convey("creating a product without a name should fail", func() {
+			var resp catalog.CreateProductResponse
+			err := svc.CreateProduct(ctx, &catalog.CreateProductRequest{
+				Product: &catalog.Product{Sku: "NEW001"},
+			}, &resp)
+			So(err, ShouldNotBeNil)
+			realError := errors.Parse(err.Error())
+			So(realError.Code, ShouldEqual, http.StatusBadRequest)
+			So(realError.Detail, ShouldEqual, catalogerrors.MissingProductName.Error())
+		})
+
+		Convey("creating a product with a negative price should fail", func() {
+			var resp catalog.CreateProductResponse
+			err := svc.CreateProduct(ctx, &catalog.CreateProductRequest{
+				Product: &catalog.Product{Sku: "NEW001", Name: "Widget", Price: usd(-1)},
+			}, &resp)
+			So(err, ShouldNotBeNil)
+			realError := errors.Parse(err.Error())
+			So(realError.Code, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("creating a variant with duplicate attribute names should fail", func() {
+			var resp catalog.CreateProductResponse
+			err := svc.CreateProduct(ctx, &catalog.CreateProductRequest{
+				Product: &catalog.Product{Sku: "8675309-L", Name: "Jenny", ParentSku: "8675309", Attributes: []*catalog.ProductAttribute{
+					&catalog.ProductAttribute{Name: "size", Value: "L"},
+					&catalog.ProductAttribute{Name: "size", Value: "XL"},
+				}},
+			}, &resp)
+			So(err, ShouldNotBeNil)
+			realError := errors.Parse(err.Error())
+			So(realError.Code, ShouldEqual, http.StatusBadRequest)
+			So(realError.Detail, ShouldEqual, catalogerrors.InvalidAttribute.Error())
+		})
+
+		Convey("discontinuing a product should publish a discontinued event", func() {
+			var resp catalog.UpdateProductResponse
+			err := svc.UpdateProduct(ctx, &catalog.UpdateProductRequest{
+				Product: &catalog.Product{Sku: "8675309", Name: "Jenny", Price: usd(1500), Status: catalog.ProductStatus_PS_DISCONTINUED},
+			}, &resp)
+			So(err, ShouldBeNil)
+			So(repo.products["8675309"].Status, ShouldEqual, catalog.ProductStatus_PS_DISCONTINUED)
+			So(len(pub.events), ShouldEqual, 1)
+			So(pub.events[0].ChangeType, ShouldEqual, catalog.ProductChangeType_PC_DISCONTINUED)
+			So(pub.events[0].Product.Status, ShouldEqual, catalog.ProductStatus_PS_DISCONTINUED)
+		})
+
+		Convey("saving a product with an unknown status should fail", func() {
+			var resp catalog.CreateProductResponse
+			err := svc.CreateProduct(ctx, &catalog.CreateProductRequest{
+				Product: &catalog.Product{Sku: "NEW001", Name: "Widget", Status: catalog.ProductStatus(42)},
+			}, &resp)
+			So(err, ShouldNotBeNil)
+			realError := errors.Parse(err.Error())
+			So(realError.Code, ShouldEqual, http.StatusBadRequest)
+			So(realError.Detail, ShouldEqual, catalogerrors.InvalidProductStatus.Error())
+		})
+
+		Convey("creating a product with media should save the media in order", func() {
+			var resp catalog.CreateProductResponse
+			err := svc.CreateProduct(ctx, &catalog.CreateProductRequest{
+				Product: &catalog.Product{Sku: "NEW001", Name: "Widget", Media: []*catalog.MediaAsset{
+					&catalog.MediaAsset{Url: "https://cdn.example.com/widget.jpg", AltText: "A widget",
+						MediaType: catalog.MediaType_MT_IMAGE, Width: 1024, Height: 768},
+					&catalog.MediaAsset{Url: "http://cdn.example.com/widget.pdf", MediaType: catalog.MediaType_MT_DOCUMENT},
+				}},
+			}, &resp)
+			So(err, ShouldBeNil)
+			So(len(repo.products["NEW001"].Media), ShouldEqual, 2)
+			So(repo.products["NEW001"].Media[0].AltText, ShouldEqual, "A widget")
+		})
+
+		Convey("creating a product with media that has no usable URL should fail", func() {
+			for _, u := range []string{"", "/images/widget.jpg", "ftp://cdn.example.com/widget.jpg", "https://"} {
+				var resp catalog.CreateProductResponse
+				err := svc.CreateProduct(ctx, &catalog.CreateProductRequest{
+					Product: &catalog.Product{Sku: "NEW001", Name: "Widget", Media: []*catalog.MediaAsset{
+						&catalog.MediaAsset{Url: u, MediaType: catalog.MediaType_MT_IMAGE},
+					}},
+				}, &resp)
+				So(err, ShouldNotBeNil)
+				realError := errors.Parse(err.Error())
+				So(realError.Code, ShouldEqual, http.StatusBadRequest)
+				So(realError.Detail, ShouldEqual, catalogerrors.InvalidMediaAsset.Error())
+			}
+		})
+
+		Convey("creating a product with media of an unknown type should fail", func() {
+			var resp catalog.CreateProductResponse
+			err := svc.CreateProduct(ctx, &catalog.CreateProductRequest{
+				Product: &catalog.Product{Sku: "NEW001", Name: "Widget", Media: []*catalog.MediaAsset{
+					&catalog.MediaAsset{Url: "https://cdn.example.com/widget.jpg"},
+				}},
+			}, &resp)
+			So(err, ShouldNotBeNil)
+			realError := errors.Parse(err.Error())
+			So(realError.Code, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("creating a product should save its translations under canonical locales", func() {
+			var resp catalog.CreateProductResponse
+			err := svc.CreateProduct(ctx, &catalog.CreateProductRequest{
+				Product: &catalog.Product{Sku: "NEW001", Name: "Widget", Translations: []*catalog.Translation{
+					&catalog.Translation{Locale: "fr_ca", Name: "Bidule"},
+					&catalog.Translation{Locale: "zh-hant-tw", Description: "小工具"},
+				}},
+			}, &resp)
+			So(err, ShouldBeNil)
+			So(repo.products["NEW001"].Translations[0].Locale, ShouldEqual, "fr-CA")
+			So(repo.products["NEW001"].Translations[1].Locale, ShouldEqual, "zh-Hant-TW")
+		})
+
+		Convey("creating a product with a repeated, malformed or empty translation should fail", func() {
+			for _, translations := range [][]*catalog.Translation{
+				{{Locale: "fr", Name: "Bidule"}, {Locale: "FR", Name: "Machin"}},
+				{{Locale: "not a locale", Name: "Bidule"}},
+				{{Locale: "fr"}},
+			} {
+				err := svc.CreateProduct(ctx, &catalog.CreateProductRequest{
+					Product: &catalog.Product{Sku: "NEW001", Name: "Widget", Translations: translations},
+				}, &catalog.CreateProductResponse{})
+				So(err, ShouldNotBeNil)
+				realError := errors.Parse(err.Error())
+				So(realError.Code, ShouldEqual, http.StatusBadRequest)
+				So(realError.Detail, ShouldEqual, catalogerrors.InvalidTranslation.Error())
+			}
+		})
+
+		Convey("creating a variant of a non-existent product should fail", func() {
+			var resp catalog.CreateProductResponse
+			err := svc.CreateProduct(ctx, &catalog.CreateProductRequest{
+				Product: &catalog.Product{Sku: "NEW001-L", Name: "Widget", ParentSku: "NEW001"},
+			}, &resp)
+			So(err, ShouldNotBeNil)
+			realError := errors.Parse(err.Error())
+			So(realError.Code, ShouldEqual, http.StatusBadRequest)
+			So(realError.Detail, ShouldEqual, catalogerrors.InvalidParentProduct.Error())
+		})
+
+		Convey("deleting a product that still has variants should conflict", func() {
+			var create catalog.CreateProductResponse
+			err := svc.CreateProduct(ctx, &catalog.CreateProductRequest{
+				Product: &catalog.Product{Sku: "8675309-L", Name: "Jenny", ParentSku: "8675309"},
+			}, &create)
+			So(err, ShouldBeNil)
+
+			var resp catalog.DeleteProductResponse
+			err = svc.DeleteProduct(ctx, &catalog.DeleteProductRequest{Sku: "8675309"}, &resp)
+			So(err, ShouldNotBeNil)
+			realError := errors.Parse(err.Error())
+			So(realError.Code, ShouldEqual, http.StatusConflict)
+		})
+
+		Convey("creating a product without a currency should price it in the base currency", func() {
+			var resp catalog.CreateProductResponse
+			err := svc.CreateProduct(ctx, &catalog.CreateProductRequest{
+				Product: &catalog.Product{Sku: "NEW001", Name: "Widget", Price: &catalog.Money{Amount: 999}},
+			}, &resp)
+			So(err, ShouldBeNil)
+			So(repo.products["NEW001"].Price.CurrencyCode, ShouldEqual, "USD")
+		})
+
+		Convey("creating a product priced in another currency should fail", func() {
+			var resp catalog.CreateProductResponse
+			err := svc.CreateProduct(ctx, &catalog.CreateProductRequest{
+				Product: &catalog.Product{Sku: "NEW001", Name: "Widget", Price: &catalog.Money{Amount: 999, CurrencyCode: "EUR"}},
+			}, &resp)
+			So(err, ShouldNotBeNil)
+			realError := errors.Parse(err.Error())
+			So(realError.Code, ShouldEqual, http.StatusBadRequest)
+			So(realError.Detail, ShouldEqual, catalogerrors.UnsupportedCurrency.Error())
+		})
+
+		Convey("creating a product that already exists should conflict", func() {
+			var resp catalog.CreateProductResponse
+			err := svc.CreateProduct(ctx, &catalog.CreateProductRequest{
+				Product: &catalog.Product{Sku: "8675309", Name: "Widget"},
+			}, &resp)
+			So(err, ShouldNotBeNil)
+			realError := errors.Parse(err.Error())
+			So(realError.Code, ShouldEqual, http.StatusConflict)
+			So(len(pub.events), ShouldEqual, 0)
+		})
+
+		Convey("updating a product should publish an updated event", func() {
+			var resp catalog.UpdateProductResponse
+			err := svc.UpdateProduct(ctx, &catalog.UpdateProductRequest{
+				Product: &catalog.Product{Sku: "8675309", Name: "Jenny II", Price: usd(1500)},
+			}, &resp)
+			So(err, ShouldBeNil)
+			So(len(pub.events), ShouldEqual, 1)
+			So(pub.events[0].ChangeType, ShouldEqual, catalog.ProductChangeType_PC_UPDATED)
+			So(pub.events[0].Product.Name, ShouldEqual, "Jenny II")
+		})
+
+		Convey("changing a product's price should publish a repriced event", func() {
+			var resp catalog.UpdateProductResponse
+			err := svc.UpdateProduct(ctx, &catalog.UpdateProductRequest{
+				Product: &catalog.Product{Sku: "8675309", Name: "Jenny", Price: usd(1200)},
+			}, &resp)
+			So(err, ShouldBeNil)
+			So(len(pub.events), ShouldEqual, 1)
+			So(pub.events[0].ChangeType, ShouldEqual, catalog.ProductChangeType_PC_REPRICED)
+			So(pub.events[0].PreviousPrice.Amount, ShouldEqual, 1500)
+			So(pub.events[0].Product.Price.Amount, ShouldEqual, 1200)
+		})
+
+		Convey("a failure to publish should not fail a saved change", func() {
+			pub.shouldFail = true
+			var resp catalog.UpdateProductResponse
+			err := svc.UpdateProduct(ctx, &catalog.UpdateProductRequest{
+				Product: &catalog.Product{Sku: "8675309", Name: "Jenny II", Price: usd(1500)},
+			}, &resp)
+			So(err, ShouldBeNil)
+			So(repo.products["8675309"].Name, ShouldEqual, "Jenny II")
+		})
+
+		Convey("updating a non-existent product should produce a not found error", func() {
+			var resp catalog.UpdateProductResponse
+			err := svc.UpdateProduct(ctx, &catalog.UpdateProductRequest{
+				Product: &catalog.Product{Sku: "DONTEXIST", Name: "Widget"},
+			}, &resp)
+			So(err, ShouldNotBeNil)
+			realError := errors.Parse(err.Error())
+			So(realError.Code, ShouldEqual, http.StatusNotFound)
+		})
+
+		Convey("deleting a product should invoke the repository", func() {
+			var resp catalog.DeleteProductResponse
+			err := svc.DeleteProduct(ctx, &catalog.DeleteProductRequest{Sku: "8675309"}, &resp)
+			So(err, ShouldBeNil)
+			So(resp.Success, ShouldBeTrue)
+			So(repo.products["8675309"], ShouldBeNil)
+			So(len(pub.events), ShouldEqual, 1)
+			So(pub.events[0].ChangeType, ShouldEqual, catalog.ProductChangeType_PC_DELETED)
+			So(pub.events[0].Sku, ShouldEqual, "8675309")
+		})
+
+		Convey("a deleted product should be announced even when its search terms can't be pruned", func() {
+			repo.unprunedTerms = true
+			var resp catalog.DeleteProductResponse
+			err := svc.DeleteProduct(ctx, &catalog.DeleteProductRequest{Sku: "8675309"}, &resp)
+			So(err, ShouldBeNil)
+			So(resp.Success, ShouldBeTrue)
+			So(repo.products["8675309"], ShouldBeNil)
+			So(len(pub.events), ShouldEqual, 1)
+			So(pub.events[0].ChangeType, ShouldEqual, catalog.ProductChangeType_PC_DELETED)
+		})
+
+		Convey("product changes should fail when the repository fails", func() {
+			repo.shouldFail = true
+			var resp catalog.UpdateProductResponse
+			err := svc.UpdateProduct(ctx, &catalog.UpdateProductRequest{
+				Product: &catalog.Product{Sku: "8675309", Name: "Widget"},
+			}, &resp)
+			So(err, ShouldNotBeNil)
+			realError := errors.Parse(err.Error())
+			So(realError.Code, ShouldEqual, http.StatusInternalServerError)
+			So(len(pub.events), ShouldEqual, 0)
+		})
+	})
+}
+
+func TestBundleAdministration(t *testing.T) {
+	Convey("Given a catalog admin service", t, func() {
+		repo := newFakeAdminRepo()
+		repo.products["LENS01"] = &catalog.Product{Sku: "LENS01", Name: "Lens"}
+		svc := service.NewCatalogAdminService(repo, &fakeProductPublisher{})
+		ctx := context.Background()
+
+		bundle := func(components ...*catalog.BundleComponent) *catalog.Product {
+			return &catalog.Product{Sku: "KIT001", Name: "Camera Kit", Price: usd(99900), Kind: catalog.ProductKind_PK_BUNDLE,
+				Components: components}
+		}
+
+		Convey("creating a bundle should save its components ordered by SKU", func() {
+			err := svc.CreateProduct(ctx, &catalog.CreateProductRequest{Product: bundle(
+				&catalog.BundleComponent{Sku: "LENS01", Quantity: 2},
+				&catalog.BundleComponent{Sku: "8675309", Quantity: 1},
+			)}, &catalog.CreateProductResponse{})
+			So(err, ShouldBeNil)
+			So(repo.products["KIT001"].Kind, ShouldEqual, catalog.ProductKind_PK_BUNDLE)
+			So(repo.products["KIT001"].Components[0].Sku, ShouldEqual, "8675309")
+			So(repo.products["KIT001"].Components[1].Quantity, ShouldEqual, 2)
+		})
+
+		Convey("bundles with missing, repeated or empty components should be rejected", func() {
+			for _, product := range []*catalog.Product{
+				bundle(),
+				bundle(&catalog.BundleComponent{Sku: "LENS01"}),
+				bundle(&catalog.BundleComponent{Sku: "LENS01", Quantity: 1}, &catalog.BundleComponent{Sku: "LENS01", Quantity: 1}),
+				bundle(&catalog.BundleComponent{Sku: "KIT001", Quantity: 1}),
+				bundle(&catalog.BundleComponent{Sku: "DONTEXIST", Quantity: 1}),
+				&catalog.Product{Sku: "NEW001", Name: "Widget", Components: []*catalog.BundleComponent{{Sku: "LENS01", Quantity: 1}}},
+			} {
+				err := svc.CreateProduct(ctx, &catalog.CreateProductRequest{Product: product}, &catalog.CreateProductResponse{})
+				So(err, ShouldNotBeNil)
+				realError := errors.Parse(err.Error())
+				So(realError.Code, ShouldEqual, http.StatusBadRequest)
+				So(realError.Detail, ShouldEqual, catalogerrors.InvalidBundle.Error())
+			}
+		})
+
+		Convey("a bundle can't be a variant", func() {
+			product := bundle(&catalog.BundleComponent{Sku: "LENS01", Quantity: 1})
+			product.ParentSku = "8675309"
+			err := svc.CreateProduct(ctx, &catalog.CreateProductRequest{Product: product}, &catalog.CreateProductResponse{})
+			So(err, ShouldNotBeNil)
+			So(errors.Parse(err.Error()).Detail, ShouldEqual, catalogerrors.InvalidBundle.Error())
+		})
+
+		Convey("deleting a product that is part of a bundle should conflict", func() {
+			err := svc.CreateProduct(ctx, &catalog.CreateProductRequest{Product: bundle(
+				&catalog.BundleComponent{Sku: "LENS01", Quantity: 1},
+			)}, &catalog.CreateProductResponse{})
+			So(err, ShouldBeNil)
+			err = svc.DeleteProduct(ctx, &catalog.DeleteProductRequest{Sku: "LENS01"}, &catalog.DeleteProductResponse{})
+			So(err, ShouldNotBeNil)
+			So(errors.Parse(err.Error()).Code, ShouldEqual, http.StatusConflict)
+		})
+	})
+}
+
+func TestPriceScheduling(t *testing.T) {
+	Convey("Given a catalog admin service", t, func() {
+		repo := newFakeAdminRepo()
+		pub := &fakeProductPublisher{}
+		svc := service.NewCatalogAdminService(repo, pub)
+		ctx := context.Background()
+		now := time.Now().UTC().Unix()
+
+		Convey("scheduling a price should record it", func() {
+			var resp catalog.SchedulePriceResponse
+			err := svc.SchedulePrice(ctx, &catalog.SchedulePriceRequest{
+				Sku:      "8675309",
+				Price:    &catalog.Money{Amount: 999},
+				StartsAt: now + 3600,
+				EndsAt:   now + 7200,
+			}, &resp)
+			So(err, ShouldBeNil)
+			So(resp.PriceChange.PriceChangeId, ShouldEqual, 1)
+			So(resp.PriceChange.PriceType, ShouldEqual, catalog.PriceType_PT_SCHEDULED)
+			So(resp.PriceChange.Price.CurrencyCode, ShouldEqual, "USD")
+			So(len(repo.priceChanges), ShouldEqual, 1)
+		})
+
+		Convey("a price without a start time should start immediately", func() {
+			var resp catalog.SchedulePriceResponse
+			err := svc.SchedulePrice(ctx, &catalog.SchedulePriceRequest{Sku: "8675309", Price: usd(999)}, &resp)
+			So(err, ShouldBeNil)
+			So(resp.PriceChange.StartsAt, ShouldBeGreaterThanOrEqualTo, now)
+		})
+
+		Convey("a price starting immediately should take effect and be announced", func() {
+			var resp catalog.SchedulePriceResponse
+			err := svc.SchedulePrice(ctx, &catalog.SchedulePriceRequest{Sku: "8675309", Price: usd(999)}, &resp)
+			So(err, ShouldBeNil)
+			So(repo.products["8675309"].ScheduledPrice.Amount, ShouldEqual, 999)
+			So(len(pub.events), ShouldEqual, 1)
+			So(pub.events[0].ChangeType, ShouldEqual, catalog.ProductChangeType_PC_REPRICED)
+			So(pub.events[0].PreviousPrice.Amount, ShouldEqual, 1500)
+			So(pub.events[0].Product.Price.Amount, ShouldEqual, 999)
+		})
+
+		Convey("a price starting later should not take effect yet", func() {
+			var resp catalog.SchedulePriceResponse
+			err := svc.SchedulePrice(ctx, &catalog.SchedulePriceRequest{Sku: "8675309", Price: usd(999), StartsAt: now + 3600}, &resp)
+			So(err, ShouldBeNil)
+			So(repo.products["8675309"].ScheduledPrice, ShouldBeNil)
+			So(len(pub.events), ShouldEqual, 0)
+		})
+
+		Convey("cancelling a price in effect should restore and announce the list price", func() {
+			var scheduled catalog.SchedulePriceResponse
+			err := svc.SchedulePrice(ctx, &catalog.SchedulePriceRequest{Sku: "8675309", Price: usd(999)}, &scheduled)
+			So(err, ShouldBeNil)
+
+			var resp catalog.CancelScheduledPriceResponse
+			err = svc.CancelScheduledPrice(ctx, &catalog.CancelScheduledPriceRequest{
+				Sku:           "8675309",
+				PriceChangeId: scheduled.PriceChange.PriceChangeId,
+			}, &resp)
+			So(err, ShouldBeNil)
+			So(repo.products["8675309"].ScheduledPrice, ShouldBeNil)
+			So(len(pub.events), ShouldEqual, 2)
+			So(pub.events[1].PreviousPrice.Amount, ShouldEqual, 999)
+			So(pub.events[1].Product.Price.Amount, ShouldEqual, 1500)
+		})
+
+		Convey("a price that ends before it starts should be rejected", func() {
+			var resp catalog.SchedulePriceResponse
+			err := svc.SchedulePrice(ctx, &catalog.SchedulePriceRequest{
+				Sku:      "8675309",
+				Price:    usd(999),
+				StartsAt: now + 7200,
+				EndsAt:   now + 3600,
+			}, &resp)
+			So(err, ShouldNotBeNil)
+			realError := errors.Parse(err.Error())
+			So(realError.Code, ShouldEqual, http.StatusBadRequest)
+			So(realError.Detail, ShouldEqual, catalogerrors.InvalidPriceSchedule.Error())
+		})
+
+		Convey("a price that starts in the past should be rejected", func() {
+			var resp catalog.SchedulePriceResponse
+			err := svc.SchedulePrice(ctx, &catalog.SchedulePriceRequest{Sku: "8675309", Price: usd(999), StartsAt: now - 3600}, &resp)
+			So(err, ShouldNotBeNil)
+			realError := errors.Parse(err.Error())
+			So(realError.Code, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("scheduling a price for a non-existent product should produce a not found error", func() {
+			var resp catalog.SchedulePriceResponse
+			err := svc.SchedulePrice(ctx, &catalog.SchedulePriceRequest{Sku: "DONTEXIST", Price: usd(999)}, &resp)
+			So(err, ShouldNotBeNil)
+			realError := errors.Parse(err.Error())
+			So(realError.Code, ShouldEqual, http.StatusNotFound)
+		})
+
+		Convey("cancelling a scheduled price should mark it as cancelled", func() {
+			var scheduled catalog.SchedulePriceResponse
+			err := svc.SchedulePrice(ctx, &catalog.SchedulePriceRequest{Sku: "8675309", Price: usd(999), StartsAt: now + 3600}, &scheduled)
+			So(err, ShouldBeNil)
+
+			var resp catalog.CancelScheduledPriceResponse
+			err = svc.CancelScheduledPrice(ctx, &catalog.CancelScheduledPriceRequest{
+				Sku:           "8675309",
+				PriceChangeId: scheduled.PriceChange.PriceChangeId,
+			}, &resp)
+			So(err, ShouldBeNil)
+			So(resp.Success, ShouldBeTrue)
+			So(repo.priceChanges[0].CancelledAt, ShouldBeGreaterThan, 0)
+
+			err = svc.CancelScheduledPrice(ctx, &catalog.CancelScheduledPriceRequest{
+				Sku:           "8675309",
+				PriceChangeId: scheduled.PriceChange.PriceChangeId,
+			}, &resp)
+			So(err, ShouldNotBeNil)
+			realError := errors.Parse(err.Error())
+			So(realError.Code, ShouldEqual, http.StatusNotFound)
+		})
+
+		Convey("the price history should be reported for a range of start times", func() {
+			for _, startsAt := range []int64{now + 100, now + 200, now + 300} {
+				var resp catalog.SchedulePriceResponse
+				err := svc.SchedulePrice(ctx, &catalog.SchedulePriceRequest{Sku: "8675309", Price: usd(999), StartsAt: startsAt}, &resp)
+				So(err, ShouldBeNil)
+			}
+			var resp catalog.PriceHistoryResponse
+			err := svc.GetPriceHistory(ctx, &catalog.PriceHistoryRequest{Sku: "8675309", From: now + 150}, &resp)
+			So(err, ShouldBeNil)
+			So(len(resp.PriceChanges), ShouldEqual, 2)
+			So(resp.PriceChanges[0].StartsAt, ShouldEqual, now+200)
+		})
+
+		Convey("a backwards price history range should be rejected", func() {
+			var resp catalog.PriceHistoryResponse
+			err := svc.GetPriceHistory(ctx, &catalog.PriceHistoryRequest{Sku: "8675309", From: now, To: now - 1}, &resp)
+			So(err, ShouldNotBeNil)
+			realError := errors.Parse(err.Error())
+			So(realError.Code, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("the price history of a non-existent product should produce a not found error", func() {
+			var resp catalog.PriceHistoryResponse
+			err := svc.GetPriceHistory(ctx, &catalog.PriceHistoryRequest{Sku: "DONTEXIST"}, &resp)
+			So(err, ShouldNotBeNil)
+			realError := errors.Parse(err.Error())
+			So(realError.Code, ShouldEqual, http.StatusNotFound)
+		})
+	})
+}
+
+func TestRelatedProductLinks(t *testing.T) {
+	Convey("Given a catalog admin service", t, func() {
+		repo := newFakeAdminRepo()
+		repo.products["NEW001"] = &catalog.Product{Sku: "NEW001", Name: "Widget"}
+		svc := service.NewCatalogAdminService(repo, &fakeProductPublisher{})
+		ctx := context.Background()
+
+		Convey("linking and unlinking related products should invoke the repository", func() {
+			var resp catalog.RelatedLinkResponse
+			err := svc.LinkRelatedProduct(ctx, &catalog.RelatedLinkRequest{Sku: "8675309", RelatedSku: "NEW001"}, &resp)
+			So(err, ShouldBeNil)
+			So(resp.Success, ShouldBeTrue)
+			So(repo.relatedLinks["8675309"], ShouldResemble, []string{"NEW001"})
+
+			err = svc.UnlinkRelatedProduct(ctx, &catalog.RelatedLinkRequest{Sku: "8675309", RelatedSku: "NEW001"}, &resp)
+			So(err, ShouldBeNil)
+			So(repo.relatedLinks["8675309"], ShouldBeEmpty)
+		})
+
+		Convey("linking a product to itself should be rejected", func() {
+			var resp catalog.RelatedLinkResponse
+			err := svc.LinkRelatedProduct(ctx, &catalog.RelatedLinkRequest{Sku: "8675309", RelatedSku: "8675309"}, &resp)
+			So(err, ShouldNotBeNil)
+			realError := errors.Parse(err.Error())
+			So(realError.Code, ShouldEqual, http.StatusBadRequest)
+			So(realError.Detail, ShouldEqual, catalogerrors.InvalidRelatedProduct.Error())
+		})
+
+		Convey("linking a non-existent product should produce a not found error", func() {
+			var resp catalog.RelatedLinkResponse
+			err := svc.LinkRelatedProduct(ctx, &catalog.RelatedLinkRequest{Sku: "8675309", RelatedSku: "DONTEXIST"}, &resp)
+			So(err, ShouldNotBeNil)
+			realError := errors.Parse(err.Error())
+			So(realError.Code, ShouldEqual, http.StatusNotFound)
+		})
+	})
+}
+
+func TestReviewModeration(t *testing.T) {
+	Convey("Given a catalog admin service", t, func() {
+		repo := newFakeAdminRepo()
+		svc := service.NewCatalogAdminService(repo, &fakeProductPublisher{})
+		ctx := context.Background()
+
+		Convey("moderating a review should take it out of the pending queue", func() {
+			var resp catalog.ModerateReviewResponse
+			err := svc.ModerateReview(ctx, &catalog.ModerateReviewRequest{ReviewId: 1, Status: catalog.ReviewStatus_RS_APPROVED}, &resp)
+			So(err, ShouldBeNil)
+			So(resp.Success, ShouldBeTrue)
+			So(repo.reviews[1].Status, ShouldEqual, catalog.ReviewStatus_RS_APPROVED)
+
+			var pending catalog.PendingReviewsResponse
+			err = svc.GetPendingReviews(ctx, &catalog.PendingReviewsRequest{}, &pending)
+			So(err, ShouldBeNil)
+			So(pending.TotalCount, ShouldEqual, 1)
+			So(pending.Reviews[0].ReviewId, ShouldEqual, 2)
+		})
+
+		Convey("reviews can only be approved or rejected", func() {
+			err := svc.ModerateReview(ctx, &catalog.ModerateReviewRequest{ReviewId: 1, Status: catalog.ReviewStatus_RS_PENDING},
+				&catalog.ModerateReviewResponse{})
+			So(err, ShouldNotBeNil)
+			realError := errors.Parse(err.Error())
+			So(realError.Code, ShouldEqual, http.StatusBadRequest)
+			So(realError.Detail, ShouldEqual, catalogerrors.InvalidReviewStatus.Error())
+		})
+
+		Convey("moderating a non-existent review should produce a not found error", func() {
+			err := svc.ModerateReview(ctx, &catalog.ModerateReviewRequest{ReviewId: 99, Status: catalog.ReviewStatus_RS_REJECTED},
+				&catalog.ModerateReviewResponse{})
+			So(err, ShouldNotBeNil)
+			So(errors.Parse(err.Error()).Code, ShouldEqual, http.StatusNotFound)
+		})
+	})
+}
+
+func TestProductSpecifications(t *testing.T) {
+	Convey("Given a catalog admin service with a category that has a specification schema", t, func() {
+		repo := newFakeAdminRepo()
+		svc := service.NewCatalogAdminService(repo, &fakeProductPublisher{})
+		ctx := context.Background()
+
+		err := svc.CreateCategory(ctx, &catalog.CreateCategoryRequest{Category: &catalog.ProductCategory{
+			CategoryId: 50,
+			Name:       "Appliances",
+			SpecificationSchema: []*catalog.SpecificationDefinition{
+				{Name: "wattage", Type: catalog.SpecificationType_ST_NUMBER, Unit: "W", Required: true},
+				{Name: "energy_star", Type: catalog.SpecificationType_ST_BOOLEAN},
+			},
+		}}, &catalog.CreateCategoryResponse{})
+		So(err, ShouldBeNil)
+
+		kettle := func(specifications ...*catalog.Specification) *catalog.CreateProductRequest {
+			return &catalog.CreateProductRequest{
+				Product:     &catalog.Product{Sku: "KETTLE", Name: "Kettle", Price: usd(2999), Specifications: specifications},
+				CategoryIds: []uint64{50},
+			}
+		}
+		wattage := &catalog.Specification{Name: "wattage", Type: catalog.SpecificationType_ST_NUMBER, NumberValue: 1200}
+
+		Convey("the schema should be saved ordered by name", func() {
+			So(repo.categories[50].SpecificationSchema[0].Name, ShouldEqual, "energy_star")
+		})
+
+		Convey("a schema with repeated names, unknown types or units on anything but numbers should be rejected", func() {
+			for _, schema := range [][]*catalog.SpecificationDefinition{
+				{{Name: "size", Type: catalog.SpecificationType_ST_NUMBER}, {Name: "size", Type: catalog.SpecificationType_ST_TEXT}},
+				{{Name: "size"}},
+				{{Name: "color", Type: catalog.SpecificationType_ST_TEXT, Unit: "nm"}},
+			} {
+				err := svc.UpdateCategory(ctx, &catalog.UpdateCategoryRequest{Category: &catalog.ProductCategory{
+					CategoryId: 50, Name: "Appliances", SpecificationSchema: schema,
+				}}, &catalog.UpdateCategoryResponse{})
+				So(err, ShouldNotBeNil)
+				So(errors.Parse(err.Error()).Detail, ShouldEqual, catalogerrors.InvalidSpecificationSchema.Error())
+			}
+		})
+
+		Convey("a product with the specifications its category defines should be created", func() {
+			err := svc.CreateProduct(ctx, kettle(wattage,
+				&catalog.Specification{Name: "energy_star", Type: catalog.SpecificationType_ST_BOOLEAN, BooleanValue: true},
+			), &catalog.CreateProductResponse{})
+			So(err, ShouldBeNil)
+			So(repo.products["KETTLE"].Specifications[0].Name, ShouldEqual, "energy_star")
+		})
+
+		Convey("a product missing a required specification should be rejected", func() {
+			err := svc.CreateProduct(ctx, kettle(), &catalog.CreateProductResponse{})
+			So(err, ShouldNotBeNil)
+			realError := errors.Parse(err.Error())
+			So(realError.Code, ShouldEqual, http.StatusBadRequest)
+			So(realError.Detail, ShouldEqual, catalogerrors.MissingSpecification.Error())
+		})
+
+		Convey("a specification that is undefined or of the wrong type should be rejected", func() {
+			for _, specification := range []*catalog.Specification{
+				{Name: "color", Type: catalog.SpecificationType_ST_TEXT, TextValue: "white"},
+				{Name: "energy_star", Type: catalog.SpecificationType_ST_TEXT, TextValue: "yes"},
+			} {
+				err := svc.CreateProduct(ctx, kettle(wattage, specification), &catalog.CreateProductResponse{})
+				So(err, ShouldNotBeNil)
+				So(errors.Parse(err.Error()).Detail, ShouldEqual, catalogerrors.UndefinedSpecification.Error())
+			}
+		})
+
+		Convey("a specification whose value doesn't match its type should be rejected", func() {
+			err := svc.CreateProduct(ctx, kettle(&catalog.Specification{Name: "wattage", Type: catalog.SpecificationType_ST_NUMBER,
+				TextValue: "1200"}), &catalog.CreateProductResponse{})
+			So(err, ShouldNotBeNil)
+			So(errors.Parse(err.Error()).Detail, ShouldEqual, catalogerrors.InvalidSpecification.Error())
+		})
+
+		Convey("assigning a product to a category whose required specifications it lacks should be rejected", func() {
+			err := svc.AssignProductToCategory(ctx, &catalog.AssignProductRequest{Sku: "8675309", CategoryId: 50},
+				&catalog.AssignProductResponse{})
+			So(err, ShouldNotBeNil)
+			So(errors.Parse(err.Error()).Detail, ShouldEqual, catalogerrors.MissingSpecification.Error())
+			So(repo.categoryProducts[50]["8675309"], ShouldBeFalse)
+		})
+	})
+}
+
+func TestCategoryAdministration(t *testing.T) {
+	Convey("Given a catalog admin service", t, func() {
+		repo := newFakeAdminRepo()
+		pub := &fakeProductPublisher{}
+		svc := service.NewCatalogAdminService(repo, pub)
+		ctx := context.Background()
+
+		Convey("creating a category without an ID should allocate one", func() {
+			var resp catalog.CreateCategoryResponse
+			err := svc.CreateCategory(ctx, &catalog.CreateCategoryRequest{
+				Category: &catalog.ProductCategory{Name: "Garden"},
+			}, &resp)
+			So(err, ShouldBeNil)
+			So(resp.Category.CategoryId, ShouldEqual, 43)
+			So(resp.Category.Name, ShouldEqual, "Garden")
+		})
+
+		Convey("creating a category without a name should fail", func() {
+			var resp catalog.CreateCategoryResponse
+			err := svc.CreateCategory(ctx, &catalog.CreateCategoryRequest{
+				Category: &catalog.ProductCategory{Description: "Nameless"},
+			}, &resp)
+			So(err, ShouldNotBeNil)
+			realError := errors.Parse(err.Error())
+			So(realError.Code, ShouldEqual, http.StatusBadRequest)
+			So(realError.Detail, ShouldEqual, catalogerrors.MissingCategoryName.Error())
+		})
+
+		Convey("an invalid parent category should be rejected", func() {
+			var resp catalog.UpdateCategoryResponse
+			err := svc.UpdateCategory(ctx, &catalog.UpdateCategoryRequest{
+				Category: &catalog.ProductCategory{CategoryId: 42, Name: "Electronics", ParentId: 42},
+			}, &resp)
+			So(err, ShouldNotBeNil)
+			realError := errors.Parse(err.Error())
+			So(realError.Code, ShouldEqual, http.StatusBadRequest)
+			So(realError.Detail, ShouldEqual, catalogerrors.InvalidParentCategory.Error())
+		})
+
+		Convey("deleting a non-existent category should produce a not found error", func() {
+			var resp catalog.DeleteCategoryResponse
+			err := svc.DeleteCategory(ctx, &catalog.DeleteCategoryRequest{CategoryId: 1}, &resp)
+			So(err, ShouldNotBeNil)
+			realError := errors.Parse(err.Error())
+			So(realError.Code, ShouldEqual, http.StatusNotFound)
+		})
+
+		Convey("assigning a product to a category should invoke the repository", func() {
+			var resp catalog.AssignProductResponse
+			err := svc.AssignProductToCategory(ctx, &catalog.AssignProductRequest{Sku: "8675309", CategoryId: 42}, &resp)
+			So(err, ShouldBeNil)
+			So(resp.Success, ShouldBeTrue)
+			So(repo.categoryProducts[42]["8675309"], ShouldBeTrue)
+		})
+
+		Convey("assigning a product to a non-existent category should produce a not found error", func() {
+			var resp catalog.AssignProductResponse
+			err := svc.AssignProductToCategory(ctx, &catalog.AssignProductRequest{Sku: "8675309", CategoryId: 1}, &resp)
+			So(err, ShouldNotBeNil)
+			realError := errors.Parse(err.Error())
+			So(realError.Code, ShouldEqual, http.StatusNotFound)
+		})
+	})
+}
+
+type fakeAdminRepo struct {
+	shouldFail       bool
+	unprunedTerms    bool
+	priceChanges     []*catalog.PriceChange
+	products         map[string]*catalog.Product
+	categories       map[uint64]*catalog.ProductCategory
+	categoryProducts map[uint64]map[string]bool
+	relatedLinks     map[string][]string
+	reviews          map[uint64]*catalog.Review
+}
+
+func newFakeAdminRepo() *fakeAdminRepo {
+	return &fakeAdminRepo{
+		products: map[string]*catalog.Product{
+			"8675309": &catalog.Product{Sku: "8675309", Name: "Jenny", Price: usd(1500)},
+		},
+		categories: map[uint64]*catalog.ProductCategory{
+			42: &catalog.ProductCategory{CategoryId: 42, Name: "Electronics"},
+		},
+		categoryProducts: map[uint64]map[string]bool{
+			42: map[string]bool{},
+		},
+		relatedLinks: map[string][]string{},
+		reviews: map[uint64]*catalog.Review{
+			1: &catalog.Review{ReviewId: 1, Sku: "8675309", Rating: 5, Status: catalog.ReviewStatus_RS_PENDING},
+			2: &catalog.Review{ReviewId: 2, Sku: "8675309", Rating: 2, Status: catalog.ReviewStatus_RS_PENDING},
+		},
+	}
+}
+
+func (r *fakeAdminRepo) CreateProduct(product *catalog.Product, categoryIDs []uint64) (err error) {
+	if r.shouldFail {
+		return stderrors.New("Faily Fail")
+	}
+	if r.products[product.Sku] != nil {
+		return catalogerrors.DuplicateProduct
+	}
+	if product.ParentSku != "" && r.products[product.ParentSku] == nil {
+		return catalogerrors.InvalidParentProduct
+	}
+	for _, component := range product.Components {
+		if r.products[component.Sku] == nil || r.products[component.Sku].Kind == catalog.ProductKind_PK_BUNDLE {
+			return catalogerrors.InvalidBundle
+		}
+	}
+	for _, categoryID := range categoryIDs {
+		if r.categories[categoryID] == nil {
+			return catalogerrors.NoSuchCategory
+		}
+	}
+	if err = r.requireConformingSpecifications(product, categoryIDs); err != nil {
+		return err
+	}
+	r.products[product.Sku] = product
+	for _, categoryID := range categoryIDs {
+		r.categoryProducts[categoryID][product.Sku] = true
+	}
+	return nil
+}
+
+func (r *fakeAdminRepo) UpdateProduct(product *catalog.Product) (previous *catalog.Product, err error) {
+	if r.shouldFail {
+		return nil, stderrors.New("Faily Fail")
+	}
+	previous = r.products[product.Sku]
+	if previous == nil {
+		return nil, catalogerrors.NoSuchProduct
+	}
+	if err = r.requireConformingSpecifications(product, nil); err != nil {
+		return nil, err
+	}
+	r.products[product.Sku] = product
+	return previous, nil
+}
+
+func (r *fakeAdminRepo) DeleteProduct(sku string) (err error) {
+	if r.shouldFail {
+		return stderrors.New("Faily Fail")
+	}
+	if r.products[sku] == nil {
+		return catalogerrors.NoSuchProduct
+	}
+	for _, product := range r.products {
+		if product.ParentSku == sku {
+			return catalogerrors.ProductHasVariants
+		}
+		for _, component := range product.Components {
+			if component.Sku == sku {
+				return catalogerrors.ProductInBundle
+			}
+		}
+	}
+	delete(r.products, sku)
+	for _, skus := range r.categoryProducts {
+		delete(skus, sku)
+	}
+	if r.unprunedTerms {
+		return catalogerrors.SearchTermsNotPruned
+	}
+	return nil
+}
+
+func (r *fakeAdminRepo) CreateCategory(category *catalog.ProductCategory) (categoryID uint64, err error) {
+	if r.shouldFail {
+		return 0, stderrors.New("Faily Fail")
+	}
+	categoryID = category.CategoryId
+	if categoryID == 0 {
+		for id := range r.categories {
+			if id > categoryID {
+				categoryID = id
+			}
+		}
+		categoryID++
+	}
+	if r.categories[categoryID] != nil {
+		return 0, catalogerrors.DuplicateCategory
+	}
+	r.categories[categoryID] = category
+	r.categoryProducts[categoryID] = map[string]bool{}
+	return categoryID, nil
+}
+
+func (r *fakeAdminRepo) UpdateCategory(category *catalog.ProductCategory) (err error) {
+	if r.shouldFail {
+		return stderrors.New("Faily Fail")
+	}
+	if r.categories[category.CategoryId] == nil {
+		return catalogerrors.NoSuchCategory
+	}
+	if category.ParentId == category.CategoryId || (category.ParentId != 0 && r.categories[category.ParentId] == nil) {
+		return catalogerrors.InvalidParentCategory
+	}
+	r.categories[category.CategoryId] = category
+	return nil
+}
+
+func (r *fakeAdminRepo) DeleteCategory(categoryID uint64) (err error) {
+	if r.shouldFail {
+		return stderrors.New("Faily Fail")
+	}
+	if r.categories[categoryID] == nil {
+		return catalogerrors.NoSuchCategory
+	}
+	delete(r.categories, categoryID)
+	delete(r.categoryProducts, categoryID)
+	return nil
+}
+
+func (r *fakeAdminRepo) AssignProductToCategory(sku string, categoryID uint64) (err error) {
+	if r.shouldFail {
+		return stderrors.New("Faily Fail")
+	}
+	if r.products[sku] == nil {
+		return catalogerrors.NoSuchProduct
+	}
+	if r.categories[categoryID] == nil {
+		return catalogerrors.NoSuchCategory
+	}
+	if err = r.requireConformingSpecifications(r.products[sku], []uint64{categoryID}); err != nil {
+		return err
+	}
+	r.categoryProducts[categoryID][sku] = true
+	return nil
+}
+
+func (r *fakeAdminRepo) SchedulePrice(change *catalog.PriceChange) (priceChangeID uint64, err error) {
+	if r.shouldFail {
+		return 0, stderrors.New("Faily Fail")
+	}
+	if r.products[change.Sku] == nil {
+		return 0, catalogerrors.NoSuchProduct
+	}
+	r.priceChanges = append(r.priceChanges, change)
+	return uint64(len(r.priceChanges)), nil
+}
+
+func (r *fakeAdminRepo) CancelScheduledPrice(sku string, priceChangeID uint64) (err error) {
+	if r.shouldFail {
+		return stderrors.New("Faily Fail")
+	}
+	if priceChangeID == 0 || priceChangeID > uint64(len(r.priceChanges)) {
+		return catalogerrors.NoSuchPriceChange
+	}
+	change := r.priceChanges[priceChangeID-1]
+	if change.Sku != sku || change.CancelledAt != 0 {
+		return catalogerrors.NoSuchPriceChange
+	}
+	change.CancelledAt = time.Now().UTC().Unix()
+	return nil
+}
+
+func (r *fakeAdminRepo) GetPriceHistory(sku string, from, to int64) (changes []*catalog.PriceChange, err error) {
+	if r.shouldFail {
+		return nil, stderrors.New("Faily Fail")
+	}
+	for _, change := range r.priceChanges {
+		if change.Sku == sku && change.StartsAt >= from && (to == 0 || change.StartsAt <= to) {
+			changes = append(changes, change)
+		}
+	}
+	return changes, nil
+}
+
+func (r *fakeAdminRepo) ApplyScheduledPrices(now int64) (previousPrices map[string]*catalog.Money, err error) {
+	if r.shouldFail {
+		return nil, stderrors.New("Faily Fail")
+	}
+	previousPrices = make(map[string]*catalog.Money)
+	for sku, product := range r.products {
+		previous := product.Price
+		if product.ScheduledPrice != nil {
+			previous = product.ScheduledPrice
+		}
+		product.ScheduledPrice = nil
+		for _, change := range r.priceChanges {
+			if change.Sku == sku && change.CancelledAt == 0 && change.StartsAt <= now &&
+				(change.EndsAt == 0 || now < change.EndsAt) {
+				product.ScheduledPrice = change.Price
+			}
+		}
+		if product.ScheduledPrice != nil && product.ScheduledPrice.Amount != previous.Amount ||
+			product.ScheduledPrice == nil && product.Price.Amount != previous.Amount {
+			previousPrices[sku] = previous
+		}
+	}
+	return previousPrices, nil
+}
+
+func (r *fakeAdminRepo) ProductExists(sku string) (exists bool, err error) {
+	return r.products[sku] != nil, nil
+}
+
+func (r *fakeAdminRepo) LinkRelatedProduct(sku string, relatedSKU string) (err error) {
+	if r.shouldFail {
+		return stderrors.New("Faily Fail")
+	}
+	if r.products[sku] == nil || r.products[relatedSKU] == nil {
+		return catalogerrors.NoSuchProduct
+	}
+	r.relatedLinks[sku] = append(r.relatedLinks[sku], relatedSKU)
+	return nil
+}
+
+func (r *fakeAdminRepo) UnlinkRelatedProduct(sku string, relatedSKU string) (err error) {
+	if r.shouldFail {
+		return stderrors.New("Faily Fail")
+	}
+	if r.products[sku] == nil {
+		return catalogerrors.NoSuchProduct
+	}
+	var remaining []string
+	for _, linked := range r.relatedLinks[sku] {
+		if linked != relatedSKU {
+			remaining = append(remaining, linked)
+		}
+	}
+	r.relatedLinks[sku] = remaining
+	return nil
+}
+
+func (r *fakeAdminRepo) ModerateReview(reviewID uint64, status catalog.ReviewStatus) (err error) {
+	if r.shouldFail {
+		return stderrors.New("Faily Fail")
+	}
+	if r.reviews[reviewID] == nil {
+		return catalogerrors.NoSuchReview
+	}
+	r.reviews[reviewID].Status = status
+	return nil
+}
+
+func (r *fakeAdminRepo) GetPendingReviews(offset, limit int) (reviews []*catalog.Review, total int, err error) {
+	if r.shouldFail {
+		return nil, 0, stderrors.New("Faily Fail")
+	}
+	var pending []*catalog.Review
+	for reviewID := uint64(1); reviewID <= uint64(len(r.reviews)); reviewID++ {
+		if review := r.reviews[reviewID]; review != nil && review.Status == catalog.ReviewStatus_RS_PENDING {
+			pending = append(pending, review)
+		}
+	}
+	for i := offset; i < len(pending) && i < offset+limit; i++ {
+		reviews = append(reviews, pending[i])
+	}
+	return reviews, len(pending), nil
+}
+
+func (r *fakeAdminRepo) CategoryExists(categoryID uint64) (exists bool, err error) {
+	return r.categories[categoryID] != nil, nil
+}
+
+func (r *fakeAdminRepo) GetProduct(sku string) (product *catalog.Product, err error) {
+	if r.products[sku] == nil {
+		return nil, nil
+	}
+	saved := *r.products[sku]
+	return &saved, nil
+}
+
+func (r *fakeAdminRepo) CheckSpecifications(product *catalog.Product, categoryIDs []uint64) (err error) {
+	return r.requireConformingSpecifications(product, categoryIDs)
+}
+
+// requireConformingSpecifications checks a product's specifications against the schemas of the given
+// categories, of the categories the product and its parent belong to, and of their ancestors
+func (r *fakeAdminRepo) requireConformingSpecifications(product *catalog.Product, categoryIDs []uint64) error {
+	for categoryID, products := range r.categoryProducts {
+		if products[product.Sku] || (product.ParentSku != "" && products[product.ParentSku]) {
+			categoryIDs = append(categoryIDs, categoryID)
+		}
+	}
+	types := make(map[string]catalog.SpecificationType)
+	required := make(map[string]bool)
+	seen := make(map[uint64]bool)
+	for _, categoryID := range categoryIDs {
+		for category := r.categories[categoryID]; category != nil && !seen[category.CategoryId]; category = r.categories[category.ParentId] {
+			seen[category.CategoryId] = true
+			for _, definition := range category.SpecificationSchema {
+				types[definition.Name] = definition.Type
+				required[definition.Name] = required[definition.Name] || definition.Required
+			}
+		}
+	}
+	present := make(map[string]bool)
+	for _, specification := range product.Specifications {
+		if specificationType, ok := types[specification.Name]; !ok || specificationType != specification.Type {
+			return catalogerrors.UndefinedSpecification
+		}
+		present[specification.Name] = true
+	}
+	for name, isRequired := range required {
+		if isRequired && !present[name] {
+			return catalogerrors.MissingSpecification
+		}
+	}
+	return nil
+}
+
+type fakeProductPublisher struct {
+	shouldFail bool
+	events     []*catalog.ProductChangedEvent
+}
+
+func (p *fakeProductPublisher) PublishProductChangedEvent(event *catalog.ProductChangedEvent) (err error) {
+	if p.shouldFail {
+		return stderrors.New("Faily Fail")
+	}
+	p.events = append(p.events, event)
+	return nil
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"github.com/autodidaddict/go-shopping/catalog/proto"
+	"sort"
+)
+
+// categoryIndex arranges a flat list of categories into a tree. Categories whose parent doesn't exist
+// are treated as top-level categories so that nothing disappears from the tree.
+type categoryIndex struct {
+	byID     map[uint64]*catalog.ProductCategory
+	children map[uint64][]*catalog.ProductCategory
+}
+
+func newCategoryIndex(categories []*catalog.ProductCategory) categoryIndex {
+	idx := categoryIndex{
+		byID:     make(map[uint64]*catalog.ProductCategory, len(categories)),
+		children: make(map[uint64][]*catalog.ProductCategory),
+	}
+	for _, category := range categories {
+		idx.byID[category.CategoryId] = category
+	}
+	for _, category := range categories {
+		parentID := category.ParentId
+		if _, ok := idx.byID[parentID]; !ok {
+			parentID = 0
+		}
+		idx.children[parentID] = append(idx.children[parentID], category)
+	}
+	for _, siblings := range idx.children {
+		sort.Slice(siblings, func(i, j int) bool {
+			if siblings[i].Name != siblings[j].Name {
+				return siblings[i].Name < siblings[j].Name
+			}
+			return siblings[i].CategoryId < siblings[j].CategoryId
+		})
+	}
+	return idx
+}
+
+// tree returns the nodes beneath the given category, or the top-level nodes for a category ID of 0
+func (idx categoryIndex) tree(parentID uint64) (nodes []*catalog.CategoryNode) {
+	return idx.subtree(parentID, make(map[uint64]bool))
+}
+
+func (idx categoryIndex) subtree(parentID uint64, visited map[uint64]bool) (nodes []*catalog.CategoryNode) {
+	for _, child := range idx.children[parentID] {
+		if visited[child.CategoryId] {
+			continue
+		}
+		visited[child.CategoryId] = true
+		nodes = append(nodes, &catalog.CategoryNode{
+			Category: child,
+			Children: idx.subtree(child.CategoryId, visited),
+		})
+	}
+	return nodes
+}
+
+// descendants returns the given category along with every category beneath it
+func (idx categoryIndex) descendants(categoryID uint64) (categoryIDs []uint64) {
+	visited := map[uint64]bool{categoryID: true}
+	queue := []uint64{categoryID}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		categoryIDs = append(categoryIDs, id)
+		for _, child := range idx.children[id] {
+			if !visited[child.CategoryId] {
+				visited[child.CategoryId] = true
+				queue = append(queue, child.CategoryId)
+			}
+		}
+	}
+	return categoryIDs
+}
+
+// path returns the categories from the top of the tree down to the given category
+func (idx categoryIndex) path(categoryID uint64) (path []*catalog.ProductCategory) {
+	visited := make(map[uint64]bool)
+	for category, ok := idx.byID[categoryID]; ok && !visited[category.CategoryId]; category, ok = idx.byID[category.ParentId] {
+		visited[category.CategoryId] = true
+		path = append([]*catalog.ProductCategory{category}, path...)
+	}
+	return path
+}
+
+// breadcrumbs picks the deepest path among the categories a product belongs to, preferring the
+// lowest category ID when several are equally deep
+func (idx categoryIndex) breadcrumbs(categoryIDs []uint64) (best []*catalog.ProductCategory) {
+	var bestID uint64
+	for _, categoryID := range categoryIDs {
+		path := idx.path(categoryID)
+		if len(path) > len(best) || (len(path) == len(best) && len(path) > 0 && categoryID < bestID) {
+			best, bestID = path, categoryID
+		}
+	}
+	return best
+}
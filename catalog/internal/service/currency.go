@@ -0,0 +1,99 @@
+package service
+
+import (
+	"github.com/autodidaddict/go-shopping/catalog/internal/platform/config"
+	"github.com/autodidaddict/go-shopping/catalog/proto"
+	"math/big"
+)
+
+// minorUnitExceptions lists the currencies whose minor units aren't hundredths (ISO-4217 exponents)
+var minorUnitExceptions = map[string]int{
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0,
+	"PYG": 0, "RWF": 0, "UGX": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+}
+
+func minorUnits(currencyCode string) int {
+	if exponent, ok := minorUnitExceptions[currencyCode]; ok {
+		return exponent
+	}
+	return 2
+}
+
+func validateCurrencyCode(currencyCode string) bool {
+	if len(currencyCode) != 3 {
+		return false
+	}
+	for _, r := range currencyCode {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// exchangeRates holds the number of units of each currency that one unit of the base currency buys
+type exchangeRates map[string]*big.Rat
+
+// parseExchangeRates reads the repository's rate table. Rates that aren't positive decimal numbers
+// are ignored, leaving their currencies unsupported.
+func parseExchangeRates(table map[string]string) exchangeRates {
+	rates := exchangeRates{config.BaseCurrency: big.NewRat(1, 1)}
+	for currencyCode, value := range table {
+		rate, ok := new(big.Rat).SetString(value)
+		if ok && rate.Sign() > 0 && validateCurrencyCode(currencyCode) {
+			rates[currencyCode] = rate
+		}
+	}
+	return rates
+}
+
+// convert expresses a price in another currency, rounding to the nearest minor unit of that currency
+func (rates exchangeRates) convert(price *catalog.Money, currencyCode string) (converted *catalog.Money, ok bool) {
+	if price == nil || price.CurrencyCode == currencyCode {
+		return price, true
+	}
+	fromRate, fromOK := rates[price.CurrencyCode]
+	toRate, toOK := rates[currencyCode]
+	if !fromOK || !toOK {
+		return nil, false
+	}
+
+	amount := new(big.Rat).SetInt64(price.Amount)
+	amount.Mul(amount, toRate)
+	amount.Quo(amount, fromRate)
+	amount.Mul(amount, minorUnitScale(minorUnits(currencyCode)-minorUnits(price.CurrencyCode)))
+	return &catalog.Money{Amount: round(amount), CurrencyCode: currencyCode}, true
+}
+
+func minorUnitScale(exponent int) *big.Rat {
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(abs(exponent))), nil)
+	if exponent < 0 {
+		return new(big.Rat).SetFrac(big.NewInt(1), scale)
+	}
+	return new(big.Rat).SetInt(scale)
+}
+
+// round rounds to the nearest integer, with halves rounded away from zero
+func round(r *big.Rat) int64 {
+	quotient, remainder := new(big.Int).QuoRem(new(big.Int).Abs(r.Num()), r.Denom(), new(big.Int))
+	if remainder.Lsh(remainder, 1).Cmp(r.Denom()) >= 0 {
+		quotient.Add(quotient, big.NewInt(1))
+	}
+	if r.Sign() < 0 {
+		quotient.Neg(quotient)
+	}
+	return quotient.Int64()
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// samePrice reports whether two prices are identical, treating a missing price as zero
+func samePrice(a, b *catalog.Money) bool {
+	return a.GetAmount() == b.GetAmount() && a.GetCurrencyCode() == b.GetCurrencyCode()
+}
@@ -0,0 +1,198 @@
+package service
+
+import (
+	"github.com/autodidaddict/go-shopping/catalog/internal/platform/config"
+	"github.com/autodidaddict/go-shopping/catalog/proto"
+	"sort"
+	"strings"
+)
+
+// priceBuckets are the boundaries (in minor units of the search's price currency) of the price ranges
+// reported in the price facet. The last bucket has no upper bound.
+var priceBuckets = []int64{0, 2500, 5000, 10000, 25000, 50000, 100000}
+
+// refineSearch applies the status, manufacturer, category, price and specification refinements of a
+// search request, and counts the manufacturers, categories and price ranges of the products found.
+// membership maps each SKU to the categories that contain it, and prices maps each SKU to its price
+// in the search's price currency; products missing from prices match no price range.
+//
+// Each facet is counted over the products that pass every refinement but its own, so that refining
+// by one manufacturer still shows how many products the other manufacturers have.
+func refineSearch(products []*catalog.Product, request *catalog.SearchRequest,
+	membership map[string][]uint64, prices map[string]int64) (refined []*catalog.Product, facets *catalog.SearchFacets) {
+
+	manufacturers := make(map[string]bool, len(request.Manufacturers))
+	for _, manufacturer := range request.Manufacturers {
+		manufacturers[strings.ToLower(manufacturer)] = true
+	}
+	categories := make(map[uint64]bool, len(request.Categories))
+	for _, categoryID := range request.Categories {
+		categories[categoryID] = true
+	}
+
+	var byManufacturer, byCategory, byPrice []*catalog.Product
+	for _, product := range products {
+		if product.Status != catalog.ProductStatus_PS_ACTIVE && !request.IncludeInactive {
+			continue
+		}
+		if !matchesSpecifications(product, request.Specifications) {
+			continue
+		}
+		manufacturer := len(manufacturers) == 0 || manufacturers[strings.ToLower(product.Manufacturer)]
+		category := len(categories) == 0 || inCategories(membership[product.Sku], categories)
+		amount, priced := prices[product.Sku]
+		price := request.Price == nil || priced && inPriceRange(amount, request.Price)
+		if category && price {
+			byManufacturer = append(byManufacturer, product)
+		}
+		if manufacturer && price {
+			byCategory = append(byCategory, product)
+		}
+		if manufacturer && category {
+			byPrice = append(byPrice, product)
+		}
+		if manufacturer && category && price {
+			refined = append(refined, product)
+		}
+	}
+	facets = &catalog.SearchFacets{
+		Manufacturers: manufacturerFacets(byManufacturer),
+		Categories:    categoryFacets(byCategory, membership),
+		Prices:        priceFacets(byPrice, prices, priceCurrency(request.Price)),
+	}
+	return refined, facets
+}
+
+func inCategories(categoryIDs []uint64, categories map[uint64]bool) bool {
+	for _, categoryID := range categoryIDs {
+		if categories[categoryID] {
+			return true
+		}
+	}
+	return false
+}
+
+func inPriceRange(price int64, priceRange *catalog.PriceRange) bool {
+	return price >= priceRange.Min && (priceRange.Max == 0 || price < priceRange.Max)
+}
+
+func validatePriceRange(priceRange *catalog.PriceRange) bool {
+	if priceRange == nil {
+		return true
+	}
+	if priceRange.CurrencyCode != "" && !validateCurrencyCode(priceRange.CurrencyCode) {
+		return false
+	}
+	return priceRange.Min >= 0 && (priceRange.Max == 0 || priceRange.Max > priceRange.Min)
+}
+
+// priceCurrency is the currency that a search's prices are refined and counted in
+func priceCurrency(priceRange *catalog.PriceRange) string {
+	if priceRange.GetCurrencyCode() == "" {
+		return config.BaseCurrency
+	}
+	return priceRange.CurrencyCode
+}
+
+// pricedIn reports whether every product is already priced in the given currency
+func pricedIn(products []*catalog.Product, currencyCode string) bool {
+	for _, product := range products {
+		if product.Price != nil && product.Price.CurrencyCode != currencyCode {
+			return false
+		}
+	}
+	return true
+}
+
+// searchPrices expresses the price of each product in the given currency. Products whose price can't
+// be converted are left out. A product without a price counts as free.
+func searchPrices(products []*catalog.Product, currencyCode string, rates exchangeRates) map[string]int64 {
+	prices := make(map[string]int64, len(products))
+	for _, product := range products {
+		if price, ok := rates.convert(product.Price, currencyCode); ok {
+			prices[product.Sku] = price.GetAmount()
+		}
+	}
+	return prices
+}
+
+// manufacturerFacets counts the manufacturers of the given products, most common first
+func manufacturerFacets(products []*catalog.Product) (facets []*catalog.ManufacturerFacet) {
+	counts := make(map[string]uint32)
+	for _, product := range products {
+		if product.Manufacturer != "" {
+			counts[product.Manufacturer]++
+		}
+	}
+	for manufacturer, count := range counts {
+		facets = append(facets, &catalog.ManufacturerFacet{Manufacturer: manufacturer, Count: count})
+	}
+	sort.Slice(facets, func(i, j int) bool {
+		a, b := facets[i], facets[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		return a.Manufacturer < b.Manufacturer
+	})
+	return facets
+}
+
+// categoryFacets counts the categories of the given products, most common first
+func categoryFacets(products []*catalog.Product, membership map[string][]uint64) (facets []*catalog.CategoryFacet) {
+	counts := make(map[uint64]uint32)
+	for _, product := range products {
+		for _, categoryID := range membership[product.Sku] {
+			counts[categoryID]++
+		}
+	}
+	for categoryID, count := range counts {
+		facets = append(facets, &catalog.CategoryFacet{CategoryId: categoryID, Count: count})
+	}
+	sort.Slice(facets, func(i, j int) bool {
+		a, b := facets[i], facets[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		return a.CategoryId < b.CategoryId
+	})
+	return facets
+}
+
+// priceFacets counts the prices of the given products in each price bucket, cheapest first. prices
+// holds each product's price in currencyCode, which the facet's ranges are reported in.
+func priceFacets(products []*catalog.Product, prices map[string]int64, currencyCode string) (facets []*catalog.PriceFacet) {
+	counts := make([]uint32, len(priceBuckets))
+	for _, product := range products {
+		if price, ok := prices[product.Sku]; ok {
+			counts[priceBucket(price)]++
+		}
+	}
+	for i, count := range counts {
+		if count == 0 {
+			continue
+		}
+		priceRange := &catalog.PriceRange{Min: priceBuckets[i], CurrencyCode: currencyCode}
+		if i+1 < len(priceBuckets) {
+			priceRange.Max = priceBuckets[i+1]
+		}
+		facets = append(facets, &catalog.PriceFacet{Range: priceRange, Count: count})
+	}
+	return facets
+}
+
+func priceBucket(price int64) int {
+	for i := len(priceBuckets) - 1; i > 0; i-- {
+		if price >= priceBuckets[i] {
+			return i
+		}
+	}
+	return 0
+}
+
+func productSkus(products []*catalog.Product) []string {
+	skus := make([]string, 0, len(products))
+	for _, product := range products {
+		skus = append(skus, product.Sku)
+	}
+	return skus
+}
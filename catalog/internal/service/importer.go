@@ -0,0 +1,335 @@
+package service
+
+import (
+	"fmt"
+	"github.com/autodidaddict/go-shopping/catalog/internal/platform/bulk"
+	catalogerrors "github.com/autodidaddict/go-shopping/catalog/internal/platform/errors"
+	"github.com/autodidaddict/go-shopping/catalog/proto"
+	"github.com/micro/go-micro/errors"
+	"golang.org/x/net/context"
+	"sort"
+	"strconv"
+)
+
+// CatalogImporter upserts products and categories read from bulk files. Rows are saved through the
+// catalog admin service, so they are validated and announced exactly as they would be had they been
+// sent one at a time. Importing the same file twice leaves the catalog unchanged; products keep any
+// category memberships that the file doesn't mention, and any fields that a CSV file has no column
+// for.
+type CatalogImporter struct {
+	repo  catalogImportRepository
+	admin catalog.CatalogAdminHandler
+}
+
+type catalogImportRepository interface {
+	catalogAdminRepository
+	CategoryExists(categoryID uint64) (exists bool, err error)
+	CheckSpecifications(product *catalog.Product, categoryIDs []uint64) (err error)
+}
+
+// ImportReport summarizes an import. For a dry run the counts are what would have been saved.
+type ImportReport struct {
+	Created int
+	Updated int
+	Errors  []RowError
+}
+
+// RowError explains why a row of a bulk file wasn't imported
+type RowError struct {
+	Line int
+	Key  string // the row's SKU or category ID, if it has one
+	Err  string
+}
+
+func (e RowError) Error() string {
+	return fmt.Sprintf("line %d (%s): %s", e.Line, e.Key, e.Err)
+}
+
+// NewCatalogImporter creates a new bulk catalog importer
+func NewCatalogImporter(repo catalogImportRepository, publisher productChangedEventPublisher) *CatalogImporter {
+	return &CatalogImporter{repo: repo, admin: NewCatalogAdminService(repo, publisher)}
+}
+
+// ImportCategories upserts categories by ID. A category is saved after its parent when both appear
+// in the same file, regardless of the order of the rows. With dryRun set the rows are only
+// validated.
+func (i *CatalogImporter) ImportCategories(ctx context.Context, records []bulk.CategoryRecord, dryRun bool) (report ImportReport) {
+	pending := make([]bulk.CategoryRecord, 0, len(records))
+	inFile := make(map[uint64]bool, len(records))
+	for _, record := range records {
+		key := ""
+		if record.Category != nil {
+			key = strconv.FormatUint(record.Category.CategoryId, 10)
+		}
+		switch {
+		case record.Err != nil:
+			report.fail(record.Line, key, record.Err)
+		case record.Category.CategoryId == 0:
+			report.fail(record.Line, key, fmt.Errorf("category_id is required"))
+		case inFile[record.Category.CategoryId]:
+			report.fail(record.Line, key, fmt.Errorf("category appears more than once"))
+		default:
+			if err := validateCategory(record.Category); err != nil {
+				report.fail(record.Line, key, err)
+				continue
+			}
+			inFile[record.Category.CategoryId] = true
+			pending = append(pending, record)
+		}
+	}
+
+	saved := make(map[uint64]bool, len(pending))
+	settled := make(map[uint64]bool, len(pending))
+	for len(pending) > 0 {
+		var deferred []bulk.CategoryRecord
+		for _, record := range pending {
+			category := record.Category
+			key := strconv.FormatUint(category.CategoryId, 10)
+			if category.ParentId != 0 && inFile[category.ParentId] && !settled[category.ParentId] {
+				deferred = append(deferred, record)
+				continue
+			}
+			settled[category.CategoryId] = true
+			if category.ParentId != 0 && inFile[category.ParentId] && !saved[category.ParentId] {
+				report.fail(record.Line, key, catalogerrors.InvalidParentCategory)
+				continue
+			}
+			if err := i.upsertCategory(ctx, category, dryRun, inFile, &report); err != nil {
+				report.fail(record.Line, key, err)
+				continue
+			}
+			saved[category.CategoryId] = true
+		}
+		if len(deferred) == len(pending) {
+			// every remaining category is beneath another, so their parents form a cycle
+			for _, record := range deferred {
+				report.fail(record.Line, strconv.FormatUint(record.Category.CategoryId, 10), catalogerrors.InvalidParentCategory)
+			}
+			break
+		}
+		pending = deferred
+	}
+	report.sortErrors()
+	return report
+}
+
+func (i *CatalogImporter) upsertCategory(ctx context.Context, category *catalog.ProductCategory, dryRun bool,
+	inFile map[uint64]bool, report *ImportReport) error {
+
+	exists, err := i.repo.CategoryExists(category.CategoryId)
+	if err != nil {
+		return err
+	}
+	if dryRun {
+		if category.ParentId == category.CategoryId {
+			return catalogerrors.InvalidParentCategory
+		}
+		if category.ParentId != 0 && !inFile[category.ParentId] {
+			if parentExists, err := i.repo.CategoryExists(category.ParentId); err != nil || !parentExists {
+				return catalogerrors.InvalidParentCategory
+			}
+		}
+	} else if exists {
+		err = i.admin.UpdateCategory(ctx, &catalog.UpdateCategoryRequest{Category: category}, &catalog.UpdateCategoryResponse{})
+	} else {
+		err = i.admin.CreateCategory(ctx, &catalog.CreateCategoryRequest{Category: category}, &catalog.CreateCategoryResponse{})
+	}
+	if err != nil {
+		return err
+	}
+	report.count(exists)
+	return nil
+}
+
+// ImportProducts upserts products by SKU, adding each to the categories listed for it. Variants are
+// saved after their parents, and bundles after their components, when both appear in the same file.
+// With dryRun set the rows are only validated.
+func (i *CatalogImporter) ImportProducts(ctx context.Context, records []bulk.ProductRecord, dryRun bool) (report ImportReport) {
+	var parents, variants, bundles []bulk.ProductRecord
+	inFile := make(map[string]bool, len(records))
+	for _, record := range records {
+		key := ""
+		if record.Product != nil {
+			key = record.Product.Sku
+		}
+		switch {
+		case record.Err != nil:
+			report.fail(record.Line, key, record.Err)
+		case inFile[record.Product.Sku]:
+			report.fail(record.Line, key, fmt.Errorf("product appears more than once"))
+		default:
+			if err := validateProduct(record.Product); err != nil {
+				report.fail(record.Line, key, err)
+				continue
+			}
+			inFile[record.Product.Sku] = true
+			switch {
+			case record.Product.Kind == catalog.ProductKind_PK_BUNDLE:
+				bundles = append(bundles, record)
+			case record.Product.ParentSku != "":
+				variants = append(variants, record)
+			default:
+				parents = append(parents, record)
+			}
+		}
+	}
+
+	saved := make(map[string]bool, len(parents))
+	knownCategories := make(map[uint64]bool)
+	for _, record := range append(append(parents, variants...), bundles...) {
+		product := record.Product
+		if product.ParentSku != "" && inFile[product.ParentSku] && !saved[product.ParentSku] {
+			report.fail(record.Line, product.Sku, catalogerrors.InvalidParentProduct)
+			continue
+		}
+		if err := requireSavedComponents(product, inFile, saved); err != nil {
+			report.fail(record.Line, product.Sku, err)
+			continue
+		}
+		if err := i.upsertProduct(ctx, record, dryRun, saved, knownCategories, &report); err != nil {
+			report.fail(record.Line, product.Sku, err)
+			continue
+		}
+		saved[product.Sku] = true
+	}
+	report.sortErrors()
+	return report
+}
+
+func (i *CatalogImporter) upsertProduct(ctx context.Context, record bulk.ProductRecord, dryRun bool,
+	saved map[string]bool, knownCategories map[uint64]bool, report *ImportReport) error {
+
+	product := record.Product
+	exists, err := i.repo.ProductExists(product.Sku)
+	if err != nil {
+		return err
+	}
+	if exists && record.Columns != nil {
+		stored, err := i.repo.GetProduct(product.Sku)
+		if err != nil {
+			return err
+		}
+		mergeColumns(product, stored, record.Columns)
+	}
+	if dryRun {
+		if product.ParentSku != "" && !saved[product.ParentSku] {
+			if parentExists, err := i.repo.ProductExists(product.ParentSku); err != nil || !parentExists {
+				return catalogerrors.InvalidParentProduct
+			}
+		}
+		for _, component := range product.Components {
+			if !saved[component.Sku] {
+				if componentExists, err := i.repo.ProductExists(component.Sku); err != nil || !componentExists {
+					return catalogerrors.InvalidBundle
+				}
+			}
+		}
+		for _, categoryID := range record.CategoryIDs {
+			if !knownCategories[categoryID] {
+				if categoryExists, err := i.repo.CategoryExists(categoryID); err != nil || !categoryExists {
+					return catalogerrors.NoSuchCategory
+				}
+				knownCategories[categoryID] = true
+			}
+		}
+		if err = i.repo.CheckSpecifications(product, record.CategoryIDs); err != nil {
+			return err
+		}
+		report.count(exists)
+		return nil
+	}
+
+	if !exists {
+		err = i.admin.CreateProduct(ctx, &catalog.CreateProductRequest{Product: product, CategoryIds: record.CategoryIDs},
+			&catalog.CreateProductResponse{})
+		if err != nil {
+			return err
+		}
+		report.count(false)
+		return nil
+	}
+	if err = i.admin.UpdateProduct(ctx, &catalog.UpdateProductRequest{Product: product}, &catalog.UpdateProductResponse{}); err != nil {
+		return err
+	}
+	for _, categoryID := range record.CategoryIDs {
+		err = i.admin.AssignProductToCategory(ctx, &catalog.AssignProductRequest{Sku: product.Sku, CategoryId: categoryID},
+			&catalog.AssignProductResponse{})
+		if err != nil {
+			return err
+		}
+	}
+	report.count(true)
+	return nil
+}
+
+// mergeColumns fills in the fields of a product read from a CSV row that the row has no column for
+// from the product as it is stored, so that importing the row leaves them as they are
+func mergeColumns(product, stored *catalog.Product, columns map[string]bool) {
+	if !columns["name"] {
+		product.Name = stored.Name
+	}
+	if !columns["description"] {
+		product.Description = stored.Description
+	}
+	if !columns["manufacturer"] {
+		product.Manufacturer = stored.Manufacturer
+	}
+	if !columns["model"] {
+		product.Model = stored.Model
+	}
+	if !columns["price"] {
+		product.Price.Amount = stored.GetPrice().GetAmount()
+	}
+	if !columns["currency_code"] {
+		product.Price.CurrencyCode = stored.GetPrice().GetCurrencyCode()
+	}
+	if !columns["parent_sku"] {
+		product.ParentSku = stored.ParentSku
+	}
+	if !columns["attributes"] {
+		product.Attributes = stored.Attributes
+	}
+	if !columns["status"] {
+		product.Status = stored.Status
+	}
+	if !columns["components"] {
+		product.Kind, product.Components = stored.Kind, stored.Components
+	}
+	product.Media = stored.Media
+	product.Translations = stored.Translations
+	product.Specifications = stored.Specifications
+}
+
+// requireSavedComponents fails a bundle when any of its components appears in the same file but
+// wasn't saved
+func requireSavedComponents(product *catalog.Product, inFile, saved map[string]bool) error {
+	for _, component := range product.Components {
+		if inFile[component.Sku] && !saved[component.Sku] {
+			return catalogerrors.InvalidBundle
+		}
+	}
+	return nil
+}
+
+func (report *ImportReport) count(updated bool) {
+	if updated {
+		report.Updated++
+	} else {
+		report.Created++
+	}
+}
+
+// fail records a row error, reporting only the detail of errors returned by the admin service
+func (report *ImportReport) fail(line int, key string, err error) {
+	detail := err.Error()
+	if realError := errors.Parse(detail); realError != nil && realError.Detail != "" {
+		detail = realError.Detail
+	}
+	report.Errors = append(report.Errors, RowError{Line: line, Key: key, Err: detail})
+}
+
+func (report *ImportReport) sortErrors() {
+	sort.SliceStable(report.Errors, func(i, j int) bool {
+		return report.Errors[i].Line < report.Errors[j].Line
+	})
+}
@@ -0,0 +1,286 @@
+package service_test
+
+import (
+	"bytes"
+	"github.com/autodidaddict/go-shopping/catalog/internal/platform/bulk"
+	catalogerrors "github.com/autodidaddict/go-shopping/catalog/internal/platform/errors"
+	"github.com/autodidaddict/go-shopping/catalog/internal/service"
+	"github.com/autodidaddict/go-shopping/catalog/proto"
+	. "github.com/smartystreets/goconvey/convey"
+	"golang.org/x/net/context"
+	"strings"
+	"testing"
+)
+
+const productsCSV = `sku,name,price,parent_sku,attributes,category_ids
+WIDGET-L,Widget,1299,WIDGET,size=L,
+WIDGET,Widget,1299,,,42
+8675309,Jenny,1600,,,42|7
+`
+
+func readProducts(data string, format bulk.Format) []bulk.ProductRecord {
+	records, err := bulk.ReadProducts(strings.NewReader(data), format)
+	So(err, ShouldBeNil)
+	return records
+}
+
+func readCategories(data string, format bulk.Format) []bulk.CategoryRecord {
+	records, err := bulk.ReadCategories(strings.NewReader(data), format)
+	So(err, ShouldBeNil)
+	return records
+}
+
+func TestProductImport(t *testing.T) {
+	Convey("Given a catalog importer", t, func() {
+		repo := newFakeAdminRepo()
+		repo.categories[7] = &catalog.ProductCategory{CategoryId: 7, Name: "Music"}
+		repo.categoryProducts[7] = map[string]bool{}
+		publisher := &fakeProductPublisher{}
+		importer := service.NewCatalogImporter(repo, publisher)
+		ctx := context.Background()
+
+		Convey("importing products should create new products and update existing ones", func() {
+			report := importer.ImportProducts(ctx, readProducts(productsCSV, bulk.CSV), false)
+			So(report.Errors, ShouldBeEmpty)
+			So(report.Created, ShouldEqual, 2)
+			So(report.Updated, ShouldEqual, 1)
+			So(repo.products["WIDGET-L"].ParentSku, ShouldEqual, "WIDGET")
+			So(repo.products["WIDGET-L"].Attributes[0].Value, ShouldEqual, "L")
+			So(repo.products["8675309"].Price.Amount, ShouldEqual, 1600)
+			So(repo.categoryProducts[42]["WIDGET"], ShouldBeTrue)
+			So(repo.categoryProducts[7]["8675309"], ShouldBeTrue)
+			So(len(publisher.events), ShouldEqual, 3)
+		})
+
+		Convey("importing the same products twice should leave the catalog unchanged", func() {
+			importer.ImportProducts(ctx, readProducts(productsCSV, bulk.CSV), false)
+			report := importer.ImportProducts(ctx, readProducts(productsCSV, bulk.CSV), false)
+			So(report.Errors, ShouldBeEmpty)
+			So(report.Created, ShouldEqual, 0)
+			So(report.Updated, ShouldEqual, 3)
+			So(len(repo.products), ShouldEqual, 3)
+		})
+
+		Convey("importing a CSV file should keep the fields it has no column for", func() {
+			jenny := repo.products["8675309"]
+			jenny.Description = "Tommy Tutone"
+			jenny.Translations = []*catalog.Translation{&catalog.Translation{Locale: "fr", Name: "Jennifer"}}
+			jenny.Media = []*catalog.MediaAsset{&catalog.MediaAsset{Url: "https://cdn.example.com/jenny.jpg",
+				MediaType: catalog.MediaType_MT_IMAGE}}
+			data := `sku,name,price
+8675309,Jenny Jenny,1700
+`
+			report := importer.ImportProducts(ctx, readProducts(data, bulk.CSV), false)
+			So(report.Errors, ShouldBeEmpty)
+			So(report.Updated, ShouldEqual, 1)
+			So(repo.products["8675309"].Name, ShouldEqual, "Jenny Jenny")
+			So(repo.products["8675309"].Price.Amount, ShouldEqual, 1700)
+			So(repo.products["8675309"].Description, ShouldEqual, "Tommy Tutone")
+			So(repo.products["8675309"].Translations[0].Name, ShouldEqual, "Jennifer")
+			So(repo.products["8675309"].Media[0].Url, ShouldEqual, "https://cdn.example.com/jenny.jpg")
+		})
+
+		Convey("a dry run should validate products without saving them", func() {
+			report := importer.ImportProducts(ctx, readProducts(productsCSV, bulk.CSV), true)
+			So(report.Errors, ShouldBeEmpty)
+			So(report.Created, ShouldEqual, 2)
+			So(report.Updated, ShouldEqual, 1)
+			So(repo.products["WIDGET"], ShouldBeNil)
+			So(repo.products["8675309"].Price.Amount, ShouldEqual, 1500)
+			So(publisher.events, ShouldBeEmpty)
+		})
+
+		Convey("rows that can't be imported should be reported by line without stopping the import", func() {
+			data := `sku,name,price,category_ids
+GOOD,Good,100,
+,Nameless,100,
+BAD PRICE,Bad,ten,
+NOCAT,No Category,100,99
+GOOD,Again,100,
+`
+			for _, dryRun := range []bool{true, false} {
+				report := importer.ImportProducts(ctx, readProducts(data, bulk.CSV), dryRun)
+				So(len(report.Errors), ShouldEqual, 4)
+				So(report.Errors[0].Line, ShouldEqual, 3)
+				So(report.Errors[0].Err, ShouldEqual, catalogerrors.InvalidSKU.Error())
+				So(report.Errors[1].Line, ShouldEqual, 4)
+				So(report.Errors[2].Line, ShouldEqual, 5)
+				So(report.Errors[2].Key, ShouldEqual, "NOCAT")
+				So(report.Errors[2].Err, ShouldEqual, catalogerrors.NoSuchCategory.Error())
+				So(report.Errors[3].Line, ShouldEqual, 6)
+			}
+			So(repo.products["GOOD"].Name, ShouldEqual, "Good")
+		})
+
+		Convey("a variant of a product that failed to import should be reported", func() {
+			data := `{"sku":"GADGET","name":""}
+{"sku":"GADGET-L","name":"Gadget","parent_sku":"GADGET"}
+`
+			report := importer.ImportProducts(ctx, readProducts(data, bulk.JSONL), false)
+			So(len(report.Errors), ShouldEqual, 2)
+			So(report.Errors[1].Err, ShouldEqual, catalogerrors.InvalidParentProduct.Error())
+		})
+
+		Convey("products should survive a round trip through each format", func() {
+			repo.products["8675309"].Attributes = []*catalog.ProductAttribute{&catalog.ProductAttribute{Name: "color", Value: "red"}}
+			repo.products["8675309"].Status = catalog.ProductStatus_PS_HIDDEN
+			repo.products["8675309"].Translations = []*catalog.Translation{&catalog.Translation{Locale: "fr", Name: "Jennifer"}}
+			repo.products["8675309"].Media = []*catalog.MediaAsset{&catalog.MediaAsset{Url: "https://cdn.example.com/jenny.jpg",
+				MediaType: catalog.MediaType_MT_IMAGE, Width: 640, Height: 480}}
+			membership := map[string][]uint64{"8675309": []uint64{7, 42}}
+
+			var buf bytes.Buffer
+			err := bulk.WriteProducts(&buf, bulk.JSONL, []*catalog.Product{repo.products["8675309"]}, membership)
+			So(err, ShouldBeNil)
+			records := readProducts(buf.String(), bulk.JSONL)
+			So(len(records), ShouldEqual, 1)
+			So(records[0].Product.Media[0].Width, ShouldEqual, 640)
+			So(records[0].Product.Attributes[0].Value, ShouldEqual, "red")
+			So(records[0].Product.Status, ShouldEqual, catalog.ProductStatus_PS_HIDDEN)
+			So(records[0].Product.Translations[0].Name, ShouldEqual, "Jennifer")
+			So(records[0].CategoryIDs, ShouldResemble, []uint64{7, 42})
+
+			buf.Reset()
+			err = bulk.WriteProducts(&buf, bulk.CSV, []*catalog.Product{repo.products["8675309"]}, membership)
+			So(err, ShouldBeNil)
+			records = readProducts(buf.String(), bulk.CSV)
+			So(len(records), ShouldEqual, 1)
+			So(records[0].Product.Price.Amount, ShouldEqual, 1500)
+			So(records[0].Product.Attributes[0].Name, ShouldEqual, "color")
+			So(records[0].Product.Status, ShouldEqual, catalog.ProductStatus_PS_HIDDEN)
+			So(records[0].CategoryIDs, ShouldResemble, []uint64{7, 42})
+		})
+
+		Convey("bundles should be saved after their components", func() {
+			data := `{"sku":"KIT001","name":"Camera Kit","price":99900,"components":{"CAM001":1,"LENS01":2}}
+{"sku":"CAM001","name":"Camera","price":59900}
+{"sku":"LENS01","name":"Lens","price":19900}
+`
+			report := importer.ImportProducts(ctx, readProducts(data, bulk.JSONL), false)
+			So(report.Errors, ShouldBeEmpty)
+			So(report.Created, ShouldEqual, 3)
+			So(repo.products["KIT001"].Kind, ShouldEqual, catalog.ProductKind_PK_BUNDLE)
+			So(repo.products["KIT001"].Components[1].Quantity, ShouldEqual, 2)
+
+			var buf bytes.Buffer
+			err := bulk.WriteProducts(&buf, bulk.JSONL, []*catalog.Product{repo.products["KIT001"]}, nil)
+			So(err, ShouldBeNil)
+			So(buf.String(), ShouldContainSubstring, `"components":{"CAM001":1,"LENS01":2}`)
+		})
+
+		Convey("bundles should stay bundles through a CSV round trip", func() {
+			data := `{"sku":"KIT001","name":"Camera Kit","price":99900,"components":{"CAM001":1,"LENS01":2}}
+{"sku":"CAM001","name":"Camera","price":59900}
+{"sku":"LENS01","name":"Lens","price":19900}
+`
+			importer.ImportProducts(ctx, readProducts(data, bulk.JSONL), false)
+
+			var buf bytes.Buffer
+			err := bulk.WriteProducts(&buf, bulk.CSV, []*catalog.Product{repo.products["KIT001"]}, nil)
+			So(err, ShouldBeNil)
+			So(buf.String(), ShouldContainSubstring, "CAM001=1|LENS01=2")
+			records := readProducts(buf.String(), bulk.CSV)
+			So(records[0].Product.Kind, ShouldEqual, catalog.ProductKind_PK_BUNDLE)
+			So(records[0].Product.Components[1].Quantity, ShouldEqual, 2)
+
+			report := importer.ImportProducts(ctx, readProducts("sku,name,price\nKIT001,Camera Kit,89900\n", bulk.CSV), false)
+			So(report.Errors, ShouldBeEmpty)
+			So(repo.products["KIT001"].Kind, ShouldEqual, catalog.ProductKind_PK_BUNDLE)
+			So(len(repo.products["KIT001"].Components), ShouldEqual, 2)
+			So(publisher.events[len(publisher.events)-1].Product.Kind, ShouldEqual, catalog.ProductKind_PK_BUNDLE)
+		})
+
+		Convey("a bundle whose component failed to import should be reported", func() {
+			data := `{"sku":"KIT001","name":"Camera Kit","components":{"CAM001":1}}
+{"sku":"CAM001","name":""}
+`
+			for _, dryRun := range []bool{true, false} {
+				report := importer.ImportProducts(ctx, readProducts(data, bulk.JSONL), dryRun)
+				So(len(report.Errors), ShouldEqual, 2)
+				So(report.Errors[0].Err, ShouldEqual, catalogerrors.InvalidBundle.Error())
+			}
+		})
+
+		Convey("specifications should be typed by their JSON values and checked against category schemas", func() {
+			repo.categories[50] = &catalog.ProductCategory{CategoryId: 50, Name: "Appliances",
+				SpecificationSchema: []*catalog.SpecificationDefinition{
+					{Name: "wattage", Type: catalog.SpecificationType_ST_NUMBER, Unit: "W", Required: true},
+					{Name: "cordless", Type: catalog.SpecificationType_ST_BOOLEAN},
+				}}
+			repo.categoryProducts[50] = map[string]bool{}
+			data := `{"sku":"KETTLE","name":"Kettle","category_ids":[50],"specifications":{"wattage":1200,"cordless":true}}
+{"sku":"TOASTER","name":"Toaster","category_ids":[50],"specifications":{"cordless":false}}
+`
+			for _, dryRun := range []bool{true, false} {
+				report := importer.ImportProducts(ctx, readProducts(data, bulk.JSONL), dryRun)
+				So(len(report.Errors), ShouldEqual, 1)
+				So(report.Errors[0].Err, ShouldEqual, catalogerrors.MissingSpecification.Error())
+			}
+			So(repo.products["KETTLE"].Specifications[1].NumberValue, ShouldEqual, 1200)
+
+			var buf bytes.Buffer
+			err := bulk.WriteCategories(&buf, bulk.JSONL, []*catalog.ProductCategory{repo.categories[50]})
+			So(err, ShouldBeNil)
+			records := readCategories(buf.String(), bulk.JSONL)
+			So(records[0].Category.SpecificationSchema[0].Unit, ShouldEqual, "W")
+			So(records[0].Category.SpecificationSchema[1].Type, ShouldEqual, catalog.SpecificationType_ST_BOOLEAN)
+
+			buf.Reset()
+			err = bulk.WriteProducts(&buf, bulk.JSONL, []*catalog.Product{repo.products["KETTLE"]}, nil)
+			So(err, ShouldBeNil)
+			So(buf.String(), ShouldContainSubstring, `"specifications":{"cordless":true,"wattage":1200}`)
+		})
+
+		Convey("a CSV file with an unknown column should be rejected as a whole", func() {
+			_, err := bulk.ReadProducts(strings.NewReader("sku,colour\nX,red\n"), bulk.CSV)
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+func TestCategoryImport(t *testing.T) {
+	Convey("Given a catalog importer", t, func() {
+		repo := newFakeAdminRepo()
+		importer := service.NewCatalogImporter(repo, &fakeProductPublisher{})
+		ctx := context.Background()
+
+		Convey("importing categories should save parents before their children", func() {
+			data := `category_id,name,parent_id
+44,Headphones,43
+43,Audio,42
+42,Electronics and Gadgets,
+`
+			report := importer.ImportCategories(ctx, readCategories(data, bulk.CSV), false)
+			So(report.Errors, ShouldBeEmpty)
+			So(report.Created, ShouldEqual, 2)
+			So(report.Updated, ShouldEqual, 1)
+			So(repo.categories[44].ParentId, ShouldEqual, 43)
+			So(repo.categories[42].Name, ShouldEqual, "Electronics and Gadgets")
+		})
+
+		Convey("categories whose parents form a cycle should be reported", func() {
+			data := `{"category_id":50,"name":"A","parent_id":51}
+{"category_id":51,"name":"B","parent_id":50}
+{"category_id":52,"name":"C"}
+`
+			for _, dryRun := range []bool{true, false} {
+				report := importer.ImportCategories(ctx, readCategories(data, bulk.JSONL), dryRun)
+				So(len(report.Errors), ShouldEqual, 2)
+				So(report.Errors[0].Err, ShouldEqual, catalogerrors.InvalidParentCategory.Error())
+				So(report.Created, ShouldEqual, 1)
+			}
+		})
+
+		Convey("a dry run should catch a parent that doesn't exist", func() {
+			report := importer.ImportCategories(ctx, readCategories("category_id,name,parent_id\n60,Orphan,99\n", bulk.CSV), true)
+			So(len(report.Errors), ShouldEqual, 1)
+			So(report.Errors[0].Line, ShouldEqual, 2)
+			So(repo.categories[60], ShouldBeNil)
+		})
+
+		Convey("a category without an ID should be reported", func() {
+			report := importer.ImportCategories(ctx, readCategories("name\nNo ID\n", bulk.CSV), false)
+			So(len(report.Errors), ShouldEqual, 1)
+		})
+	})
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	catalogerrors "github.com/autodidaddict/go-shopping/catalog/internal/platform/errors"
+	"github.com/autodidaddict/go-shopping/catalog/proto"
+	"strings"
+)
+
+// canonicalLocale checks that a locale is a well-formed BCP 47 language tag and returns it in its
+// conventional case, e.g. fr-CA or zh-Hant-TW. Underscores are accepted in place of hyphens.
+func canonicalLocale(locale string) (canonical string, ok bool) {
+	subtags := strings.Split(strings.Replace(locale, "_", "-", -1), "-")
+	for i, subtag := range subtags {
+		if len(subtag) == 0 || len(subtag) > 8 || !isAlphanumeric(subtag) {
+			return "", false
+		}
+		switch {
+		case i == 0:
+			if len(subtag) < 2 || len(subtag) > 3 || strings.IndexAny(subtag, "0123456789") >= 0 {
+				return "", false
+			}
+			subtags[i] = strings.ToLower(subtag)
+		case i == 1 && len(subtag) == 4:
+			subtags[i] = strings.ToUpper(subtag[:1]) + strings.ToLower(subtag[1:])
+		case len(subtag) == 2:
+			subtags[i] = strings.ToUpper(subtag)
+		default:
+			subtags[i] = strings.ToLower(subtag)
+		}
+	}
+	return strings.Join(subtags, "-"), true
+}
+
+func isAlphanumeric(s string) bool {
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// localeChain returns the locales to look for translations in, from each requested locale in turn to
+// its most general form, e.g. fr-CA then fr. Empty locales ask for the catalog's own text and add
+// nothing to the chain.
+func localeChain(locales ...string) (chain []string, ok bool) {
+	seen := make(map[string]bool)
+	for _, locale := range locales {
+		if locale == "" {
+			continue
+		}
+		canonical, ok := canonicalLocale(locale)
+		if !ok {
+			return nil, false
+		}
+		for {
+			if !seen[canonical] {
+				seen[canonical] = true
+				chain = append(chain, canonical)
+			}
+			i := strings.LastIndex(canonical, "-")
+			if i < 0 {
+				break
+			}
+			canonical = canonical[:i]
+		}
+	}
+	return chain, true
+}
+
+// localize replaces a name and description with the first translation of each found along a locale
+// chain, leaving the catalog's own text where there is none
+func localize(chain []string, translations []*catalog.Translation, name, description *string) {
+	var nameFound, descriptionFound bool
+	for _, locale := range chain {
+		for _, translation := range translations {
+			if translation.Locale != locale {
+				continue
+			}
+			if !nameFound && translation.Name != "" {
+				*name, nameFound = translation.Name, true
+			}
+			if !descriptionFound && translation.Description != "" {
+				*description, descriptionFound = translation.Description, true
+			}
+		}
+	}
+}
+
+func localizeProducts(chain []string, products []*catalog.Product) {
+	for _, product := range products {
+		localize(chain, product.Translations, &product.Name, &product.Description)
+	}
+}
+
+func localizeCategories(chain []string, categories []*catalog.ProductCategory) {
+	for _, category := range categories {
+		localize(chain, category.Translations, &category.Name, &category.Description)
+	}
+}
+
+// validateTranslations checks that translations each have a distinct locale and some text, putting
+// each locale into its canonical form so that it can be found again
+func validateTranslations(translations []*catalog.Translation) error {
+	locales := make(map[string]bool, len(translations))
+	for _, translation := range translations {
+		if translation == nil {
+			return catalogerrors.InvalidTranslation
+		}
+		locale, ok := canonicalLocale(translation.Locale)
+		if !ok || locales[locale] {
+			return catalogerrors.InvalidTranslation
+		}
+		if len(strings.TrimSpace(translation.Name)) == 0 && len(strings.TrimSpace(translation.Description)) == 0 {
+			return catalogerrors.InvalidTranslation
+		}
+		translation.Locale = locale
+		locales[locale] = true
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"encoding/base64"
+	"github.com/autodidaddict/go-shopping/catalog/proto"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// Page cursors are opaque to clients. Today they carry the offset of the first item on the next
+// page; keeping them opaque means that can change without breaking anyone.
+
+func encodeCursor(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(offset)))
+}
+
+// decodeCursor returns the offset encoded in a cursor. The empty cursor refers to the first page.
+func decodeCursor(cursor string) (offset int, ok bool) {
+	if cursor == "" {
+		return 0, true
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, false
+	}
+	offset, err = strconv.Atoi(string(raw))
+	if err != nil || offset < 0 {
+		return 0, false
+	}
+	return offset, true
+}
+
+func pageSize(requested uint32) int {
+	switch {
+	case requested == 0:
+		return defaultPageSize
+	case requested > maxPageSize:
+		return maxPageSize
+	default:
+		return int(requested)
+	}
+}
+
+// nextCursor returns the cursor for the page following one that started at offset and held count
+// items, or the empty string when there are no more items.
+func nextCursor(offset, count, total int) string {
+	if count == 0 || offset+count >= total {
+		return ""
+	}
+	return encodeCursor(offset + count)
+}
+
+func validateSortOrder(order catalog.SortOrder) bool {
+	_, ok := catalog.SortOrder_name[int32(order)]
+	return ok
+}
+
+// sortRanked reorders ranked search results when a sort order other than relevance is requested
+func sortRanked(ranked []rankedResult, order catalog.SortOrder) {
+	var less func(a, b *catalog.Product) bool
+	switch order {
+	case catalog.SortOrder_SO_NAME_ASC:
+		less = func(a, b *catalog.Product) bool { return strings.ToLower(a.Name) < strings.ToLower(b.Name) }
+	case catalog.SortOrder_SO_NAME_DESC:
+		less = func(a, b *catalog.Product) bool { return strings.ToLower(a.Name) > strings.ToLower(b.Name) }
+	case catalog.SortOrder_SO_PRICE_ASC:
+		less = func(a, b *catalog.Product) bool { return a.GetPrice().GetAmount() < b.GetPrice().GetAmount() }
+	case catalog.SortOrder_SO_PRICE_DESC:
+		less = func(a, b *catalog.Product) bool { return a.GetPrice().GetAmount() > b.GetPrice().GetAmount() }
+	default:
+		return
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return less(ranked[i].product, ranked[j].product)
+	})
+}
+
+func rankedSkus(ranked []rankedResult) []string {
+	skus := make([]string, 0, len(ranked))
+	for _, result := range ranked {
+		skus = append(skus, result.hit.Sku)
+	}
+	return skus
+}
+
+// pageOf returns the slice of ranked results that fall on the page starting at offset
+func pageOf(ranked []rankedResult, offset, size int) []rankedResult {
+	if offset >= len(ranked) {
+		return nil
+	}
+	end := offset + size
+	if end > len(ranked) {
+		end = len(ranked)
+	}
+	return ranked[offset:end]
+}
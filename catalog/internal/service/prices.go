@@ -0,0 +1,42 @@
+package service
+
+import (
+	"github.com/autodidaddict/go-shopping/catalog/internal/platform/config"
+	catalogerrors "github.com/autodidaddict/go-shopping/catalog/internal/platform/errors"
+	"github.com/autodidaddict/go-shopping/catalog/proto"
+)
+
+// applyScheduledPrices puts the scheduled price in effect for each product, if any, in place of its
+// list price
+func applyScheduledPrices(products ...*catalog.Product) {
+	for _, product := range products {
+		if product.ScheduledPrice != nil {
+			product.Price, product.ScheduledPrice = product.ScheduledPrice, nil
+		}
+	}
+}
+
+// validatePrice checks a price before it is saved, filling in the base currency when it doesn't name one
+func validatePrice(price *catalog.Money) error {
+	if price.CurrencyCode == "" {
+		price.CurrencyCode = config.BaseCurrency
+	}
+	if price.CurrencyCode != config.BaseCurrency {
+		return catalogerrors.UnsupportedCurrency
+	}
+	if price.Amount < 0 {
+		return catalogerrors.InvalidPrice
+	}
+	return nil
+}
+
+// validateSchedule checks the start and end of a scheduled price, returning when it starts
+func validateSchedule(startsAt, endsAt, now int64) (int64, error) {
+	if startsAt == 0 {
+		startsAt = now
+	}
+	if startsAt < now || (endsAt != 0 && endsAt <= startsAt) {
+		return 0, catalogerrors.InvalidPriceSchedule
+	}
+	return startsAt, nil
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"github.com/autodidaddict/go-shopping/catalog/proto"
+	log "github.com/sirupsen/logrus"
+	"sort"
+	"time"
+)
+
+// StartPriceScheduler puts scheduled prices in effect as they start, and takes them out of effect as
+// they end, checking for those due at every interval. Each product repriced is announced.
+func StartPriceScheduler(repo catalogAdminRepository, publisher productChangedEventPublisher, interval time.Duration) {
+	a := &catalogAdminService{repo: repo, eventPublisher: publisher}
+	go func() {
+		for range time.Tick(interval) {
+			a.applyDuePrices()
+		}
+	}()
+}
+
+// applyDuePrices applies the scheduled prices due by now, announcing each product repriced
+func (a *catalogAdminService) applyDuePrices() {
+	previousPrices, err := a.repo.ApplyScheduledPrices(time.Now().UTC().Unix())
+	if err != nil {
+		log.Errorf("Failed to apply scheduled prices: %s", err)
+	}
+	skus := make([]string, 0, len(previousPrices))
+	for sku := range previousPrices {
+		skus = append(skus, sku)
+	}
+	sort.Strings(skus)
+	for _, sku := range skus {
+		product, err := a.repo.GetProduct(sku)
+		if err != nil {
+			log.Errorf("Failed to load repriced product %s: %s", sku, err)
+			continue
+		}
+		applyScheduledPrices(product)
+		a.publishChange(&catalog.ProductChangedEvent{
+			Sku:           sku,
+			ChangeType:    catalog.ProductChangeType_PC_REPRICED,
+			Product:       product,
+			PreviousPrice: previousPrices[sku],
+		})
+	}
+}
@@ -0,0 +1,213 @@
+package service
+
+import (
+	"bytes"
+	"github.com/autodidaddict/go-shopping/catalog/internal/platform/search"
+	"github.com/autodidaddict/go-shopping/catalog/proto"
+	"html"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	highlightOpen  = "<em>"
+	highlightClose = "</em>"
+
+	// snippetContext is the number of bytes of context kept on either side of the first match
+	// when a long field is trimmed down to a snippet
+	snippetContext = 60
+	snippetElision = "..."
+
+	// exactNameBonus is awarded when the whole search phrase appears within a product name
+	exactNameBonus = 5.0
+)
+
+// searchField describes a searchable product field that matches are highlighted in. Fields are
+// listed in the order in which their highlights are reported. How much a match within each is worth
+// is decided by the search index.
+type searchField struct {
+	name  string
+	trim  bool
+	value func(p *catalog.Product) string
+}
+
+var searchFields = []searchField{
+	{name: "name", value: func(p *catalog.Product) string { return p.Name }},
+	{name: "model", value: func(p *catalog.Product) string { return p.Model }},
+	{name: "manufacturer", value: func(p *catalog.Product) string { return p.Manufacturer }},
+	{name: "description", trim: true, value: func(p *catalog.Product) string { return p.Description }},
+}
+
+type rankedResult struct {
+	product *catalog.Product
+	hit     *catalog.SearchHit
+}
+
+// span is the byte range of a matched word within a field
+type span struct {
+	start, end int
+}
+
+// termMatcher decides whether a word matches one of the search tokens, either exactly or within
+// the typo tolerance used by the search index.
+type termMatcher struct {
+	tokens []string
+	exact  map[string]bool
+}
+
+func newTermMatcher(searchTerm string) termMatcher {
+	m := termMatcher{tokens: search.Tokenize(searchTerm), exact: make(map[string]bool)}
+	for _, token := range m.tokens {
+		m.exact[token] = true
+	}
+	return m
+}
+
+func (m termMatcher) match(word string) bool {
+	word = strings.ToLower(word)
+	if m.exact[word] {
+		return true
+	}
+	for _, token := range m.tokens {
+		if search.Similar(token, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// rankResults orders products by descending relevance, given the score of each from the search index
+// and a bonus for names containing the whole search term. Ties are broken by product name and then SKU
+// so that the ordering is stable. The hits returned are yet to be highlighted.
+func rankResults(searchTerm string, products []*catalog.Product, scores map[string]float64) []rankedResult {
+	phrase := strings.ToLower(strings.TrimSpace(searchTerm))
+
+	ranked := make([]rankedResult, 0, len(products))
+	for _, product := range products {
+		hit := &catalog.SearchHit{Sku: product.Sku, Score: scores[product.Sku]}
+		if len(phrase) > 0 && strings.Contains(strings.ToLower(product.Name), phrase) {
+			hit.Score += exactNameBonus
+		}
+		ranked = append(ranked, rankedResult{product: product, hit: hit})
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].hit.Score != ranked[j].hit.Score {
+			return ranked[i].hit.Score > ranked[j].hit.Score
+		}
+		if ranked[i].product.Name != ranked[j].product.Name {
+			return ranked[i].product.Name < ranked[j].product.Name
+		}
+		return ranked[i].product.Sku < ranked[j].product.Sku
+	})
+	return ranked
+}
+
+// highlights marks the words matching the search in each field of a product that has any
+func highlights(product *catalog.Product, matcher termMatcher) (highlights []*catalog.Highlight) {
+	for _, field := range searchFields {
+		text := field.value(product)
+		spans := matchSpans(text, matcher)
+		if len(spans) == 0 {
+			continue
+		}
+		highlights = append(highlights, &catalog.Highlight{
+			Field:   field.name,
+			Snippet: highlight(text, spans, field.trim),
+		})
+	}
+	return highlights
+}
+
+// matchSpans returns the location of every word within text that matches the search
+func matchSpans(text string, matcher termMatcher) (spans []span) {
+	start := -1
+	for i, r := range text {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		if start >= 0 {
+			spans = appendMatch(spans, text, start, i, matcher)
+		}
+		start = -1
+	}
+	if start >= 0 {
+		spans = appendMatch(spans, text, start, len(text), matcher)
+	}
+	return spans
+}
+
+func appendMatch(spans []span, text string, start, end int, matcher termMatcher) []span {
+	if matcher.match(text[start:end]) {
+		spans = append(spans, span{start: start, end: end})
+	}
+	return spans
+}
+
+// highlight wraps each matched span of text in emphasis tags, escaping the text itself so that only
+// the tags are markup. When trim is set, the text is cut down to a window surrounding the first match.
+func highlight(text string, spans []span, trim bool) string {
+	from, to := 0, len(text)
+	if trim {
+		first := spans[0]
+		from = wordBoundaryBefore(text, first.start-snippetContext)
+		to = wordBoundaryAfter(text, first.end+snippetContext)
+		if from > first.start {
+			from = first.start
+		}
+		if to < first.end {
+			to = first.end
+		}
+	}
+
+	var buf bytes.Buffer
+	if from > 0 {
+		buf.WriteString(snippetElision)
+	}
+	pos := from
+	for _, s := range spans {
+		if s.start < pos || s.end > to {
+			continue
+		}
+		buf.WriteString(html.EscapeString(text[pos:s.start]))
+		buf.WriteString(highlightOpen)
+		buf.WriteString(html.EscapeString(text[s.start:s.end]))
+		buf.WriteString(highlightClose)
+		pos = s.end
+	}
+	buf.WriteString(html.EscapeString(text[pos:to]))
+	if to < len(text) {
+		buf.WriteString(snippetElision)
+	}
+	return buf.String()
+}
+
+func wordBoundaryBefore(text string, offset int) int {
+	if offset <= 0 {
+		return 0
+	}
+	for offset > 0 && !utf8.RuneStart(text[offset]) {
+		offset--
+	}
+	if i := strings.IndexByte(text[offset:], ' '); i >= 0 {
+		return offset + i + 1
+	}
+	return offset
+}
+
+func wordBoundaryAfter(text string, offset int) int {
+	if offset >= len(text) {
+		return len(text)
+	}
+	for offset > 0 && !utf8.RuneStart(text[offset]) {
+		offset--
+	}
+	if i := strings.LastIndexByte(text[:offset], ' '); i >= 0 {
+		return i
+	}
+	return offset
+}
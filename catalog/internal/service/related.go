@@ -0,0 +1,254 @@
+package service
+
+import (
+	catalogerrors "github.com/autodidaddict/go-shopping/catalog/internal/platform/errors"
+	"github.com/autodidaddict/go-shopping/catalog/proto"
+	"github.com/micro/go-micro/errors"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"sort"
+	"strings"
+)
+
+const (
+	defaultRelatedLimit = 10
+	maxRelatedLimit     = 50
+
+	// relatedCandidates is how many products are considered from each source of related products
+	relatedCandidates = 100
+
+	// maxShipmentAttempts is how many times a shipment is recorded before giving up on concurrent
+	// shipments from the same order
+	maxShipmentAttempts = 3
+)
+
+// relatedCandidate gathers the ways in which a product is related to the one being recommended for
+type relatedCandidate struct {
+	product          *catalog.Product
+	linked           bool
+	boughtTogether   int
+	sameCategory     bool
+	sameManufacturer bool
+}
+
+// shared counts the attributes a candidate shares with the product being recommended for
+func (rc *relatedCandidate) shared() (count int) {
+	if rc.sameCategory {
+		count++
+	}
+	if rc.sameManufacturer {
+		count++
+	}
+	return count
+}
+
+func (rc *relatedCandidate) relations() (relations []catalog.Relation) {
+	if rc.linked {
+		relations = append(relations, catalog.Relation_REL_LINKED)
+	}
+	if rc.boughtTogether > 0 {
+		relations = append(relations, catalog.Relation_REL_BOUGHT_TOGETHER)
+	}
+	if rc.sameCategory {
+		relations = append(relations, catalog.Relation_REL_SAME_CATEGORY)
+	}
+	if rc.sameManufacturer {
+		relations = append(relations, catalog.Relation_REL_SAME_MANUFACTURER)
+	}
+	return relations
+}
+
+// GetRelatedProducts recommends products to go with the given product. Products linked by a
+// merchandiser come first, then those most often bought with it, then those sharing both its
+// category and manufacturer, then its category and finally its manufacturer. Of the manufacturer's
+// products, those closest to it in price are considered. Other variants of the same product and
+// products that aren't active are never recommended.
+func (c *catalogService) GetRelatedProducts(ctx context.Context, request *catalog.RelatedRequest,
+	response *catalog.RelatedResponse) error {
+
+	if request == nil {
+		return errors.BadRequest("", "Missing related products request")
+	}
+	if request.CurrencyCode != "" && !validateCurrencyCode(request.CurrencyCode) {
+		return errors.BadRequest(request.Sku, "Invalid currency code")
+	}
+	limit := int(request.Limit)
+	if limit == 0 {
+		limit = defaultRelatedLimit
+	}
+	if limit > maxRelatedLimit {
+		limit = maxRelatedLimit
+	}
+	exists, err := c.catalogRepo.ProductExists(request.Sku)
+	if err != nil {
+		return errors.InternalServerError(request.Sku, "Failed to check product existence: %s", err.Error())
+	}
+	if !exists {
+		return errors.NotFound(request.Sku, "No such product")
+	}
+	product, err := c.catalogRepo.GetProduct(request.Sku)
+	if err != nil {
+		return errors.InternalServerError(request.Sku, "Failed to load product: %s", err.Error())
+	}
+
+	candidates, err := c.relatedCandidates(product)
+	if err != nil {
+		return errors.InternalServerError(request.Sku, "Failed to find related products: %s", err.Error())
+	}
+	sortRelated(candidates)
+	if len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+	for _, candidate := range candidates {
+		applyScheduledPrices(candidate.product)
+	}
+	if request.CurrencyCode != "" {
+		table, err := c.catalogRepo.GetExchangeRates()
+		if err != nil {
+			return errors.InternalServerError(request.Sku, "Failed to load exchange rates: %s", err.Error())
+		}
+		rates := parseExchangeRates(table)
+		for _, candidate := range candidates {
+			price, ok := rates.convert(candidate.product.Price, request.CurrencyCode)
+			if !ok {
+				return errors.BadRequest(request.Sku, "Unsupported currency %s", request.CurrencyCode)
+			}
+			candidate.product.Price = price
+		}
+	}
+	for _, candidate := range candidates {
+		response.Related = append(response.Related, &catalog.RelatedProduct{
+			Product:             candidate.product,
+			Relations:           candidate.relations(),
+			BoughtTogetherCount: uint32(candidate.boughtTogether),
+		})
+	}
+	return nil
+}
+
+// relatedCandidates gathers every product related to the given product in any way
+func (c *catalogService) relatedCandidates(product *catalog.Product) (related []*relatedCandidate, err error) {
+	root := product.Sku
+	if product.ParentSku != "" {
+		root = product.ParentSku
+	}
+	family := map[string]bool{product.Sku: true, root: true}
+	variants, err := c.catalogRepo.GetProductVariants(root)
+	if err != nil {
+		return nil, err
+	}
+	for _, variant := range variants {
+		family[variant.Sku] = true
+	}
+
+	candidates := make(map[string]*relatedCandidate)
+	candidate := func(p *catalog.Product) *relatedCandidate {
+		if family[p.Sku] || p.Status != catalog.ProductStatus_PS_ACTIVE {
+			return &relatedCandidate{}
+		}
+		if candidates[p.Sku] == nil {
+			candidates[p.Sku] = &relatedCandidate{product: p}
+			related = append(related, candidates[p.Sku])
+		}
+		return candidates[p.Sku]
+	}
+
+	links, err := c.catalogRepo.GetRelatedProductLinks(product.Sku)
+	if err != nil {
+		return nil, err
+	}
+	linked, err := c.catalogRepo.GetProducts(links)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range linked {
+		candidate(p).linked = true
+	}
+
+	counts, err := c.catalogRepo.GetBoughtTogether(product.Sku, relatedCandidates)
+	if err != nil {
+		return nil, err
+	}
+	boughtTogether, err := c.catalogRepo.GetProducts(mapKeys(counts))
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range boughtTogether {
+		candidate(p).boughtTogether = counts[p.Sku]
+	}
+
+	// a variant is usually only categorized through its parent
+	membership, err := c.catalogRepo.GetCategoryMembership(mapKeys(map[string]int{product.Sku: 0, root: 0}))
+	if err != nil {
+		return nil, err
+	}
+	var categoryIDs []uint64
+	for _, ids := range membership {
+		categoryIDs = append(categoryIDs, ids...)
+	}
+	if len(categoryIDs) > 0 {
+		sameCategory, _, err := c.catalogRepo.GetProductsInCategories(categoryIDs, catalog.SortOrder_SO_DEFAULT, false,
+			0, relatedCandidates)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range sameCategory {
+			candidate(p).sameCategory = true
+		}
+	}
+
+	if strings.TrimSpace(product.Manufacturer) != "" {
+		sameManufacturer, err := c.catalogRepo.GetProductsByManufacturer(product.Manufacturer,
+			product.GetPrice().GetAmount(), relatedCandidates)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range sameManufacturer {
+			candidate(p).sameManufacturer = true
+		}
+	}
+	return related, nil
+}
+
+func sortRelated(candidates []*relatedCandidate) {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.linked != b.linked {
+			return a.linked
+		}
+		if a.boughtTogether != b.boughtTogether {
+			return a.boughtTogether > b.boughtTogether
+		}
+		if a.shared() != b.shared() {
+			return a.shared() > b.shared()
+		}
+		if a.sameCategory != b.sameCategory {
+			return a.sameCategory
+		}
+		return a.product.Sku < b.product.Sku
+	})
+}
+
+// awaitItemShippedEvents learns which products are bought together from the items shipped for
+// each order
+func (c *catalogService) awaitItemShippedEvents() {
+	for shippedEvent := range c.shipChan {
+		var err error
+		for attempt := 0; attempt < maxShipmentAttempts; attempt++ {
+			if err = c.catalogRepo.RecordShipment(shippedEvent.OrderId, shippedEvent.Sku); err != catalogerrors.ConcurrentModification {
+				break
+			}
+		}
+		if err != nil {
+			log.Errorf("Failed to record shipment of %s in order %d: %s", shippedEvent.Sku, shippedEvent.OrderId, err)
+		}
+	}
+}
+
+func mapKeys(m map[string]int) (keys []string) {
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,142 @@
+package service
+
+import (
+	catalogerrors "github.com/autodidaddict/go-shopping/catalog/internal/platform/errors"
+	"github.com/autodidaddict/go-shopping/catalog/proto"
+	"github.com/micro/go-micro/errors"
+	"golang.org/x/net/context"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	maxReviewTitleLength = 200
+	maxReviewBodyLength  = 5000
+)
+
+// SubmitReview saves a customer's review of a product. Reviews aren't shown to other customers, or
+// counted in the product's rating, until they have been approved.
+func (c *catalogService) SubmitReview(ctx context.Context, request *catalog.SubmitReviewRequest,
+	response *catalog.SubmitReviewResponse) error {
+
+	if request == nil {
+		return errors.BadRequest("", "Missing submit review request")
+	}
+	review := &catalog.Review{
+		Sku:        request.Sku,
+		CustomerId: request.CustomerId,
+		Rating:     request.Rating,
+		Title:      strings.TrimSpace(request.Title),
+		Body:       strings.TrimSpace(request.Body),
+		Status:     catalog.ReviewStatus_RS_PENDING,
+		CreatedAt:  time.Now().UTC().Unix(),
+	}
+	if err := validateReview(review); err != nil {
+		return errors.BadRequest(request.Sku, "%s", err.Error())
+	}
+	reviewID, err := c.catalogRepo.CreateReview(review)
+	if err != nil {
+		return adminError(request.Sku, err)
+	}
+	review.ReviewId = reviewID
+	response.Review = review
+	return nil
+}
+
+// GetProductReviews pages through a product's approved reviews, newest first, along with its rating
+func (c *catalogService) GetProductReviews(ctx context.Context, request *catalog.ReviewsRequest,
+	response *catalog.ReviewsResponse) error {
+
+	if request == nil {
+		return errors.BadRequest("", "Missing product reviews request")
+	}
+	offset, ok := decodeCursor(request.Cursor)
+	if !ok {
+		return errors.BadRequest("", "Invalid page cursor")
+	}
+	exists, err := c.catalogRepo.ProductExists(request.Sku)
+	if err != nil {
+		return errors.InternalServerError(request.Sku, "Failed to check product existence: %s", err.Error())
+	}
+	if !exists {
+		return errors.NotFound(request.Sku, "No such product")
+	}
+	size := pageSize(request.PageSize)
+	reviews, total, err := c.catalogRepo.GetReviews(request.Sku, offset, size)
+	if err != nil {
+		return errors.InternalServerError(request.Sku, "Failed to load reviews: %s", err.Error())
+	}
+	histogram, err := c.catalogRepo.GetRatingHistogram(request.Sku)
+	if err != nil {
+		return errors.InternalServerError(request.Sku, "Failed to load rating: %s", err.Error())
+	}
+
+	response.Reviews = reviews
+	response.TotalCount = uint32(total)
+	response.NextPageCursor = nextCursor(offset, len(reviews), total)
+	response.Rating = ratingSummary(histogram)
+	return nil
+}
+
+// VoteReview records whether a customer found an approved review helpful. Each customer has one
+// vote per review, which they may change, and can't vote on their own reviews.
+func (c *catalogService) VoteReview(ctx context.Context, request *catalog.ReviewVoteRequest,
+	response *catalog.ReviewVoteResponse) error {
+
+	if request == nil {
+		return errors.BadRequest("", "Missing review vote request")
+	}
+	id := strconv.FormatUint(request.ReviewId, 10)
+	if strings.TrimSpace(request.CustomerId) == "" {
+		return errors.BadRequest(id, "%s", catalogerrors.InvalidReview.Error())
+	}
+	review, err := c.catalogRepo.GetReview(request.ReviewId)
+	if err == nil && review.Status != catalog.ReviewStatus_RS_APPROVED {
+		err = catalogerrors.NoSuchReview
+	}
+	if err != nil {
+		return adminError(id, err)
+	}
+	if review.CustomerId == request.CustomerId {
+		return errors.BadRequest(id, "%s", catalogerrors.InvalidReviewVote.Error())
+	}
+	response.HelpfulVotes, response.UnhelpfulVotes, err = c.catalogRepo.VoteReview(request.ReviewId,
+		request.CustomerId, request.Helpful)
+	if err != nil {
+		return adminError(id, err)
+	}
+	return nil
+}
+
+func validateReview(review *catalog.Review) error {
+	if err := validateSKU(review.Sku); err != nil {
+		return err
+	}
+	if review.Rating < 1 || review.Rating > 5 {
+		return catalogerrors.InvalidRating
+	}
+	if strings.TrimSpace(review.CustomerId) == "" || review.Title == "" ||
+		utf8.RuneCountInString(review.Title) > maxReviewTitleLength ||
+		utf8.RuneCountInString(review.Body) > maxReviewBodyLength {
+		return catalogerrors.InvalidReview
+	}
+	return nil
+}
+
+// ratingSummary summarizes a histogram of the number of reviews giving each rating from 1 to 5 stars,
+// rounding the average rating to two decimal places
+func ratingSummary(histogram []uint32) *catalog.RatingSummary {
+	summary := &catalog.RatingSummary{Histogram: histogram}
+	var stars uint32
+	for i, count := range histogram {
+		summary.ReviewCount += count
+		stars += uint32(i+1) * count
+	}
+	if summary.ReviewCount > 0 {
+		summary.AverageRating = math.Round(float64(stars)/float64(summary.ReviewCount)*100) / 100
+	}
+	return summary
+}
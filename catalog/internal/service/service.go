@@ -2,28 +2,60 @@ package service
 
 import (
 	"github.com/autodidaddict/go-shopping/catalog/proto"
+	"github.com/autodidaddict/go-shopping/shipping/proto"
 	"github.com/micro/go-micro/errors"
 	"golang.org/x/net/context"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultSuggestionLimit = 10
+	maxSuggestionLimit     = 25
 )
 
 type catalogService struct {
 	catalogRepo catalogRepository
+	shipChan    chan *shipping.ItemShippedEvent
 }
 
 type catalogRepository interface {
 	GetProduct(sku string) (product *catalog.Product, err error)
+	GetProductVariants(sku string) (variants []*catalog.Product, err error)
+	GetProducts(skus []string) (products []*catalog.Product, err error)
 	GetCategories() (categories []*catalog.ProductCategory, err error)
-	GetProductsInCategory(categoryID uint64) (products []*catalog.Product, err error)
-	Find(searchTerm string, categories []uint64) (products []*catalog.Product, err error)
+	GetProductsInCategories(categoryIDs []uint64, order catalog.SortOrder, includeInactive bool,
+		offset, limit int) (products []*catalog.Product, total int, err error)
+	FindMatches(searchTerm string) (matches []*catalog.Product, scores map[string]float64, err error)
+	SuggestQuery(searchTerm string) (suggestion string, err error)
+	SuggestProducts(prefix string, limit int) (suggestions []*catalog.ProductSuggestion, err error)
+	GetCategoryMembership(skus []string) (membership map[string][]uint64, err error)
+	GetProductsByManufacturer(manufacturer string, price int64, limit int) (products []*catalog.Product, err error)
+	GetExchangeRates() (rates map[string]string, err error)
+	GetRelatedProductLinks(sku string) (skus []string, err error)
+	GetBoughtTogether(sku string, limit int) (counts map[string]int, err error)
+	RecordShipment(orderID uint64, sku string) (err error)
+	CreateReview(review *catalog.Review) (reviewID uint64, err error)
+	GetReview(reviewID uint64) (review *catalog.Review, err error)
+	GetReviews(sku string, offset, limit int) (reviews []*catalog.Review, total int, err error)
+	GetRatingHistogram(sku string) (histogram []uint32, err error)
+	VoteReview(reviewID uint64, customerID string, helpful bool) (helpfulVotes, unhelpfulVotes uint32, err error)
 	CategoryExists(categoryID uint64) (bool, error)
 	ProductExists(sku string) (bool, error)
 }
 
-// NewCatalogService creates a new catalog service
-func NewCatalogService(catalogRepo catalogRepository) catalog.CatalogHandler {
-	return &catalogService{
+// NewCatalogService creates a new catalog service, which learns which products are bought together
+// from the item shipped events placed on the given channel
+func NewCatalogService(catalogRepo catalogRepository, itemShippedChannel chan *shipping.ItemShippedEvent) catalog.CatalogHandler {
+	svc := &catalogService{
 		catalogRepo: catalogRepo,
+		shipChan:    itemShippedChannel,
+	}
+	if itemShippedChannel != nil {
+		go svc.awaitItemShippedEvents()
 	}
+	return svc
 }
 
 func (c *catalogService) GetProductDetails(ctx context.Context, request *catalog.DetailRequest,
@@ -32,6 +64,13 @@ func (c *catalogService) GetProductDetails(ctx context.Context, request *catalog
 	if request == nil {
 		return errors.BadRequest("", "Missing detail request")
 	}
+	if request.CurrencyCode != "" && !validateCurrencyCode(request.CurrencyCode) {
+		return errors.BadRequest(request.Sku, "Invalid currency code")
+	}
+	chain, ok := localeChain(append([]string{request.Locale}, request.FallbackLocales...)...)
+	if !ok {
+		return errors.BadRequest(request.Sku, "Invalid locale")
+	}
 	exists, err := c.catalogRepo.ProductExists(request.Sku)
 	if err != nil {
 		return errors.InternalServerError("", "Failed to check product existence: %s", err.Error())
@@ -43,8 +82,86 @@ func (c *catalogService) GetProductDetails(ctx context.Context, request *catalog
 	if err != nil {
 		return errors.InternalServerError(request.Sku, "Failed to fetch product: %s", err.Error())
 	}
+	membership, err := c.catalogRepo.GetCategoryMembership([]string{request.Sku})
+	if err != nil {
+		return errors.InternalServerError(request.Sku, "Failed to fetch product categories: %s", err.Error())
+	}
+	categories, err := c.catalogRepo.GetCategories()
+	if err != nil {
+		return errors.InternalServerError(request.Sku, "Failed to load categories: %s", err.Error())
+	}
+	variants, err := c.catalogRepo.GetProductVariants(request.Sku)
+	if err != nil {
+		return errors.InternalServerError(request.Sku, "Failed to fetch product variants: %s", err.Error())
+	}
+	histogram, err := c.catalogRepo.GetRatingHistogram(request.Sku)
+	if err != nil {
+		return errors.InternalServerError(request.Sku, "Failed to load rating: %s", err.Error())
+	}
+
+	// a scheduled price in effect replaces the list price of the product and of each of its variants
+	if results.ScheduledPrice != nil {
+		response.ListPrice = results.Price
+	}
+	applyScheduledPrices(append([]*catalog.Product{results}, variants...)...)
+
+	if request.CurrencyCode != "" {
+		table, err := c.catalogRepo.GetExchangeRates()
+		if err != nil {
+			return errors.InternalServerError(request.Sku, "Failed to load exchange rates: %s", err.Error())
+		}
+		rates := parseExchangeRates(table)
+		for _, product := range append([]*catalog.Product{results}, variants...) {
+			price, ok := rates.convert(product.Price, request.CurrencyCode)
+			if !ok {
+				return errors.BadRequest(request.Sku, "Unsupported currency %s", request.CurrencyCode)
+			}
+			product.Price = price
+		}
+		response.ListPrice, _ = rates.convert(response.ListPrice, request.CurrencyCode)
+	}
 
+	localizeProducts(chain, append([]*catalog.Product{results}, variants...))
+	localizeCategories(chain, categories)
 	response.Product = results
+	response.Variants = variants
+	response.Rating = ratingSummary(histogram)
+	response.Breadcrumbs = newCategoryIndex(categories).breadcrumbs(membership[request.Sku])
+	return nil
+}
+
+func (c *catalogService) GetProductVariants(ctx context.Context, request *catalog.VariantsRequest,
+	response *catalog.VariantsResponse) error {
+
+	if request == nil {
+		return errors.BadRequest("", "Missing product variants request")
+	}
+	exists, err := c.catalogRepo.ProductExists(request.Sku)
+	if err != nil {
+		return errors.InternalServerError("", "Failed to check product existence: %s", err.Error())
+	}
+	if !exists {
+		return errors.NotFound(request.Sku, "No such product")
+	}
+	parent, err := c.catalogRepo.GetProduct(request.Sku)
+	if err != nil {
+		return errors.InternalServerError(request.Sku, "Failed to fetch product: %s", err.Error())
+	}
+	if parent.ParentSku != "" {
+		parent, err = c.catalogRepo.GetProduct(parent.ParentSku)
+		if err != nil {
+			return errors.InternalServerError(request.Sku, "Failed to fetch parent product: %s", err.Error())
+		}
+	}
+	variants, err := c.catalogRepo.GetProductVariants(parent.Sku)
+	if err != nil {
+		return errors.InternalServerError(request.Sku, "Failed to fetch product variants: %s", err.Error())
+	}
+
+	applyScheduledPrices(append([]*catalog.Product{parent}, variants...)...)
+	response.Parent = parent
+	response.Variants = variants
+	response.AttributeNames = attributeNames(variants)
 	return nil
 }
 
@@ -54,33 +171,83 @@ func (c *catalogService) GetProductCategories(ctx context.Context, request *cata
 	if request == nil {
 		return errors.BadRequest("", "Missing categories request")
 	}
+	chain, ok := localeChain(request.Locale)
+	if !ok {
+		return errors.BadRequest("", "Invalid locale")
+	}
 	results, err := c.catalogRepo.GetCategories()
 	if err != nil {
 		return errors.InternalServerError("", "Failed to load categories: %s", err.Error())
 	}
+	localizeCategories(chain, results)
 	response.Categories = results
 	return nil
 }
 
+func (c *catalogService) GetCategoryTree(ctx context.Context, request *catalog.CategoryTreeRequest,
+	response *catalog.CategoryTreeResponse) error {
+
+	if request == nil {
+		return errors.BadRequest("", "Missing category tree request")
+	}
+	categories, err := c.catalogRepo.GetCategories()
+	if err != nil {
+		return errors.InternalServerError("", "Failed to load categories: %s", err.Error())
+	}
+	idx := newCategoryIndex(categories)
+	if request.RootId == 0 {
+		response.Roots = idx.tree(0)
+		return nil
+	}
+	root, ok := idx.byID[request.RootId]
+	if !ok {
+		return errors.NotFound(strconv.FormatUint(request.RootId, 10), "No such category")
+	}
+	response.Roots = []*catalog.CategoryNode{
+		&catalog.CategoryNode{Category: root, Children: idx.tree(root.CategoryId)},
+	}
+	return nil
+}
+
 func (c *catalogService) GetProductsInCategory(ctx context.Context, request *catalog.CategoryProductsRequest,
 	response *catalog.CategoryProductsResponse) error {
 
 	if request == nil {
 		return errors.BadRequest("", "Missing category products request")
 	}
+	offset, ok := decodeCursor(request.Cursor)
+	if !ok {
+		return errors.BadRequest("", "Invalid page cursor")
+	}
+	if !validateSortOrder(request.SortOrder) {
+		return errors.BadRequest("", "Invalid sort order")
+	}
 	exists, err := c.catalogRepo.CategoryExists(request.CategoryId)
 	if err != nil {
 		return errors.InternalServerError("", "Failed to check category existence: %s", err.Error())
 	}
 	if !exists {
-		return errors.NotFound(string(request.CategoryId), "No such category")
+		return errors.NotFound(strconv.FormatUint(request.CategoryId, 10), "No such category")
 	}
 
-	results, err := c.catalogRepo.GetProductsInCategory(request.CategoryId)
+	categoryIDs := []uint64{request.CategoryId}
+	if request.IncludeDescendants {
+		categories, err := c.catalogRepo.GetCategories()
+		if err != nil {
+			return errors.InternalServerError("", "Failed to load categories: %s", err.Error())
+		}
+		categoryIDs = newCategoryIndex(categories).descendants(request.CategoryId)
+	}
+
+	size := pageSize(request.PageSize)
+	results, total, err := c.catalogRepo.GetProductsInCategories(categoryIDs, request.SortOrder, request.IncludeInactive, offset, size)
 	if err != nil {
 		return errors.InternalServerError("", "Failed to load products in category: %s", err.Error())
 	}
+	applyScheduledPrices(results...)
 	response.Products = results
+	response.TotalCount = uint32(total)
+	response.NextPageCursor = nextCursor(offset, len(results), total)
 	return nil
 }
 
@@ -93,15 +260,130 @@ func (c *catalogService) ProductSearch(ctx context.Context, request *catalog.Sea
 	if !validateSearchTerm(request.SearchTerm) {
 		return errors.BadRequest("", "Invalid search term")
 	}
-	results, repoErr := c.catalogRepo.Find(request.SearchTerm, request.Categories)
+	if !validatePriceRange(request.Price) {
+		return errors.BadRequest("", "Invalid price range")
+	}
+	if !validateSpecificationFilters(request.Specifications) {
+		return errors.BadRequest("", "Invalid specification filter")
+	}
+	offset, ok := decodeCursor(request.Cursor)
+	if !ok {
+		return errors.BadRequest("", "Invalid page cursor")
+	}
+	if !validateSortOrder(request.SortOrder) {
+		return errors.BadRequest("", "Invalid sort order")
+	}
+	chain, ok := localeChain(request.Locale)
+	if !ok {
+		return errors.BadRequest("", "Invalid locale")
+	}
+	// categories are refined here rather than by the repository, so that the category facet can count
+	// the products outside of the categories being refined by. The matches carry only what they are
+	// refined, ranked and sorted by; just the products on the requested page are loaded in full.
+	matches, scores, repoErr := c.catalogRepo.FindMatches(request.SearchTerm)
 	if repoErr != nil {
 		return errors.InternalServerError("", "Failed to perform search: %s", repoErr.Error())
 	}
-	response.SearchResults = results
+	applyScheduledPrices(matches...)
+	membership, repoErr := c.catalogRepo.GetCategoryMembership(productSkus(matches))
+	if repoErr != nil {
+		return errors.InternalServerError("", "Failed to compute search facets: %s", repoErr.Error())
+	}
+	// prices are refined and counted in the price range's currency, whatever the products are priced in
+	currencyCode := priceCurrency(request.Price)
+	var rates exchangeRates
+	if !pricedIn(matches, currencyCode) {
+		table, repoErr := c.catalogRepo.GetExchangeRates()
+		if repoErr != nil {
+			return errors.InternalServerError("", "Failed to load exchange rates: %s", repoErr.Error())
+		}
+		rates = parseExchangeRates(table)
+		if rates[currencyCode] == nil {
+			return errors.BadRequest("", "Unsupported currency %s", currencyCode)
+		}
+	}
+	matches, response.Facets = refineSearch(matches, request, membership,
+		searchPrices(matches, currencyCode, rates))
+	if len(matches) == 0 {
+		suggestion, repoErr := c.catalogRepo.SuggestQuery(request.SearchTerm)
+		if repoErr != nil {
+			return errors.InternalServerError("", "Failed to suggest a search term: %s", repoErr.Error())
+		}
+		response.SuggestedSearchTerm = suggestion
+		return nil
+	}
+
+	// products are ranked, sorted and highlighted by their text in the customer's own language
+	localizeProducts(chain, matches)
+	ranked := rankResults(request.SearchTerm, matches, scores)
+	sortRanked(ranked, request.SortOrder)
+	page := pageOf(ranked, offset, pageSize(request.PageSize))
+	products, repoErr := c.catalogRepo.GetProducts(rankedSkus(page))
+	if repoErr != nil {
+		return errors.InternalServerError("", "Failed to load search results: %s", repoErr.Error())
+	}
+	applyScheduledPrices(products...)
+	localizeProducts(chain, products)
+	loaded := make(map[string]*catalog.Product, len(products))
+	for _, product := range products {
+		loaded[product.Sku] = product
+	}
+	matcher := newTermMatcher(request.SearchTerm)
+	for _, result := range page {
+		product, ok := loaded[result.hit.Sku]
+		if !ok {
+			// deleted since it was found
+			continue
+		}
+		result.hit.Highlights = highlights(product, matcher)
+		response.SearchResults = append(response.SearchResults, product)
+		response.Hits = append(response.Hits, result.hit)
+	}
+	response.TotalCount = uint32(len(ranked))
+	response.NextPageCursor = nextCursor(offset, len(page), len(ranked))
+
+	return nil
+}
+
+func (c *catalogService) SuggestProducts(ctx context.Context, request *catalog.SuggestRequest,
+	response *catalog.SuggestResponse) error {
 
+	if request == nil {
+		return errors.BadRequest("", "Missing product suggestion request")
+	}
+	if len(strings.TrimSpace(request.Prefix)) == 0 {
+		return errors.BadRequest("", "Invalid suggestion prefix")
+	}
+	limit := int(request.Limit)
+	if limit == 0 {
+		limit = defaultSuggestionLimit
+	}
+	if limit > maxSuggestionLimit {
+		limit = maxSuggestionLimit
+	}
+	results, err := c.catalogRepo.SuggestProducts(request.Prefix, limit)
+	if err != nil {
+		return errors.InternalServerError("", "Failed to suggest products: %s", err.Error())
+	}
+	response.Suggestions = results
 	return nil
 }
 
 func validateSearchTerm(term string) bool {
 	return len(term) > 2
 }
+
+// attributeNames lists every attribute used by a set of variants
+func attributeNames(variants []*catalog.Product) (names []string) {
+	seen := make(map[string]bool)
+	for _, variant := range variants {
+		for _, attribute := range variant.Attributes {
+			if !seen[attribute.Name] {
+				seen[attribute.Name] = true
+				names = append(names, attribute.Name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
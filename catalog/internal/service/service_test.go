@@ -2,19 +2,25 @@ package service_test
 
 import (
 	stderrors "errors"
+	"fmt"
+	catalogerrors "github.com/autodidaddict/go-shopping/catalog/internal/platform/errors"
+	"github.com/autodidaddict/go-shopping/catalog/internal/platform/search"
 	"github.com/autodidaddict/go-shopping/catalog/internal/service"
 	"github.com/autodidaddict/go-shopping/catalog/proto"
+	"github.com/autodidaddict/go-shopping/shipping/proto"
 	"github.com/micro/go-micro/errors"
 	. "github.com/smartystreets/goconvey/convey"
 	"golang.org/x/net/context"
 	"net/http"
+	"strings"
 	"testing"
+	"unicode/utf8"
 )
 
 func TestProductRetrieval(t *testing.T) {
 	Convey("Given a catalog service", t, func() {
 		repo := newFakeRepo()
-		svc := service.NewCatalogService(repo)
+		svc := service.NewCatalogService(repo, nil)
 		ctx := context.Background()
 
 		Convey("Querying for a single product should invoke repository", func() {
@@ -25,6 +31,22 @@ func TestProductRetrieval(t *testing.T) {
 			So(resp.Product.Sku, ShouldEqual, "8675309")
 		})
 
+		Convey("Querying for a single product should include its media in order", func() {
+			repo.media = map[string][]*catalog.MediaAsset{
+				"8675309": []*catalog.MediaAsset{
+					&catalog.MediaAsset{Url: "https://cdn.example.com/jenny.jpg", AltText: "Jenny", MediaType: catalog.MediaType_MT_IMAGE,
+						Width: 800, Height: 600},
+					&catalog.MediaAsset{Url: "https://cdn.example.com/jenny.mp4", MediaType: catalog.MediaType_MT_VIDEO},
+				},
+			}
+			var resp catalog.DetailResponse
+			err := svc.GetProductDetails(ctx, &catalog.DetailRequest{Sku: "8675309"}, &resp)
+			So(err, ShouldBeNil)
+			So(len(resp.Product.Media), ShouldEqual, 2)
+			So(resp.Product.Media[0].Url, ShouldEqual, "https://cdn.example.com/jenny.jpg")
+			So(resp.Product.Media[1].MediaType, ShouldEqual, catalog.MediaType_MT_VIDEO)
+		})
+
 		Convey("Querying for a non-existent product should produce a hinted failure", func() {
 			repo.shouldFail = false
 			var resp catalog.DetailResponse
@@ -44,10 +66,255 @@ func TestProductRetrieval(t *testing.T) {
 	})
 }
 
+func TestLocalizedProducts(t *testing.T) {
+	Convey("Given a catalog service with translated products and categories", t, func() {
+		repo := newFakeRepo()
+		svc := service.NewCatalogService(repo, nil)
+		ctx := context.Background()
+		repo.translations = map[string][]*catalog.Translation{
+			"8675309": []*catalog.Translation{
+				&catalog.Translation{Locale: "fr", Name: "Jennifer", Description: "Une chanson"},
+				&catalog.Translation{Locale: "fr-CA", Name: "Jenny du Québec"},
+			},
+		}
+		repo.categories = []*catalog.ProductCategory{
+			&catalog.ProductCategory{CategoryId: 42, Name: "Electronics", Translations: []*catalog.Translation{
+				&catalog.Translation{Locale: "fr", Name: "Électronique"},
+			}},
+		}
+		repo.membership = map[string][]uint64{"8675309": []uint64{42}}
+
+		Convey("product details should fall back through the locale chain one field at a time", func() {
+			var resp catalog.DetailResponse
+			err := svc.GetProductDetails(ctx, &catalog.DetailRequest{Sku: "8675309", Locale: "fr_ca"}, &resp)
+			So(err, ShouldBeNil)
+			So(resp.Product.Name, ShouldEqual, "Jenny du Québec")
+			So(resp.Product.Description, ShouldEqual, "Une chanson")
+			So(resp.Breadcrumbs[0].Name, ShouldEqual, "Électronique")
+		})
+
+		Convey("a locale without translations should fall back to the catalog's own text", func() {
+			var resp catalog.DetailResponse
+			err := svc.GetProductDetails(ctx, &catalog.DetailRequest{Sku: "8675309", Locale: "de-DE"}, &resp)
+			So(err, ShouldBeNil)
+			So(resp.Product.Name, ShouldBeEmpty)
+			So(resp.Breadcrumbs[0].Name, ShouldEqual, "Electronics")
+		})
+
+		Convey("fallback locales should be tried in order once the requested locale has no translation", func() {
+			var resp catalog.DetailResponse
+			err := svc.GetProductDetails(ctx, &catalog.DetailRequest{Sku: "8675309", Locale: "de-DE",
+				FallbackLocales: []string{"es", "fr-CA"}}, &resp)
+			So(err, ShouldBeNil)
+			So(resp.Product.Name, ShouldEqual, "Jenny du Québec")
+			So(resp.Product.Description, ShouldEqual, "Une chanson")
+		})
+
+		Convey("a malformed locale should be rejected", func() {
+			for _, locale := range []string{"f", "fr--CA", "français", "12-CA"} {
+				err := svc.GetProductDetails(ctx, &catalog.DetailRequest{Sku: "8675309", Locale: locale}, &catalog.DetailResponse{})
+				So(err, ShouldNotBeNil)
+				So(errors.Parse(err.Error()).Code, ShouldEqual, http.StatusBadRequest)
+			}
+		})
+
+		Convey("categories should be listed in the requested locale", func() {
+			var resp catalog.AllCategoriesResponse
+			err := svc.GetProductCategories(ctx, &catalog.AllCategoriesRequest{Locale: "fr-FR"}, &resp)
+			So(err, ShouldBeNil)
+			So(resp.Categories[0].Name, ShouldEqual, "Électronique")
+		})
+
+		Convey("search results should be ranked and returned in the requested locale", func() {
+			repo.findResults = []*catalog.Product{
+				&catalog.Product{Sku: "TV0001", Name: "Television", Description: "Un téléviseur", Translations: []*catalog.Translation{
+					&catalog.Translation{Locale: "fr", Name: "Téléviseur"},
+				}},
+				&catalog.Product{Sku: "STAND1", Name: "Stand", Translations: []*catalog.Translation{
+					&catalog.Translation{Locale: "fr", Name: "Meuble pour téléviseur"},
+				}},
+			}
+			var resp catalog.SearchResponse
+			err := svc.ProductSearch(ctx, &catalog.SearchRequest{SearchTerm: "téléviseur", Locale: "fr"}, &resp)
+			So(err, ShouldBeNil)
+			So(len(resp.SearchResults), ShouldEqual, 2)
+			So(resp.SearchResults[0].Name, ShouldEqual, "Téléviseur")
+			So(resp.SearchResults[1].Name, ShouldEqual, "Meuble pour téléviseur")
+		})
+	})
+}
+
+func TestProductPricesInOtherCurrencies(t *testing.T) {
+	Convey("Given a catalog service with exchange rates", t, func() {
+		repo := newFakeRepo()
+		svc := service.NewCatalogService(repo, nil)
+		ctx := context.Background()
+
+		Convey("product details should be priced in the base currency by default", func() {
+			var resp catalog.DetailResponse
+			err := svc.GetProductDetails(ctx, &catalog.DetailRequest{Sku: "8675309"}, &resp)
+			So(err, ShouldBeNil)
+			So(resp.Product.Price.Amount, ShouldEqual, 1999)
+			So(resp.Product.Price.CurrencyCode, ShouldEqual, "USD")
+		})
+
+		Convey("product details should be converted into a requested currency", func() {
+			var resp catalog.DetailResponse
+			err := svc.GetProductDetails(ctx, &catalog.DetailRequest{Sku: "8675309", CurrencyCode: "EUR"}, &resp)
+			So(err, ShouldBeNil)
+			So(resp.Product.Price.Amount, ShouldEqual, 1799)
+			So(resp.Product.Price.CurrencyCode, ShouldEqual, "EUR")
+		})
+
+		Convey("conversion should respect the minor units of the requested currency", func() {
+			var resp catalog.DetailResponse
+			err := svc.GetProductDetails(ctx, &catalog.DetailRequest{Sku: "8675309", CurrencyCode: "JPY"}, &resp)
+			So(err, ShouldBeNil)
+			So(resp.Product.Price.Amount, ShouldEqual, 3003)
+			So(resp.Product.Price.CurrencyCode, ShouldEqual, "JPY")
+		})
+
+		Convey("variant prices should be converted along with the product", func() {
+			repo.variants = map[string][]*catalog.Product{
+				"8675309": []*catalog.Product{&catalog.Product{Sku: "8675309-L", Price: usd(2500)}},
+			}
+			var resp catalog.DetailResponse
+			err := svc.GetProductDetails(ctx, &catalog.DetailRequest{Sku: "8675309", CurrencyCode: "EUR"}, &resp)
+			So(err, ShouldBeNil)
+			So(resp.Variants[0].Price.Amount, ShouldEqual, 2250)
+			So(resp.Variants[0].Price.CurrencyCode, ShouldEqual, "EUR")
+		})
+
+		Convey("a currency without a usable exchange rate should be rejected", func() {
+			var resp catalog.DetailResponse
+			err := svc.GetProductDetails(ctx, &catalog.DetailRequest{Sku: "8675309", CurrencyCode: "GBP"}, &resp)
+			So(err, ShouldNotBeNil)
+			realError := errors.Parse(err.Error())
+			So(realError.Code, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("a malformed currency code should be rejected", func() {
+			var resp catalog.DetailResponse
+			err := svc.GetProductDetails(ctx, &catalog.DetailRequest{Sku: "8675309", CurrencyCode: "usd"}, &resp)
+			So(err, ShouldNotBeNil)
+			realError := errors.Parse(err.Error())
+			So(realError.Code, ShouldEqual, http.StatusBadRequest)
+		})
+	})
+}
+
+func TestScheduledProductPrices(t *testing.T) {
+	Convey("Given a catalog service with scheduled prices", t, func() {
+		repo := newFakeRepo()
+		svc := service.NewCatalogService(repo, nil)
+		ctx := context.Background()
+		repo.scheduledPrices = map[string]*catalog.Money{}
+
+		Convey("the list price should stand when no scheduled price is in effect", func() {
+			var resp catalog.DetailResponse
+			err := svc.GetProductDetails(ctx, &catalog.DetailRequest{Sku: "8675309"}, &resp)
+			So(err, ShouldBeNil)
+			So(resp.Product.Price.Amount, ShouldEqual, 1999)
+			So(resp.ListPrice, ShouldBeNil)
+		})
+
+		Convey("a scheduled price in effect should replace the list price", func() {
+			repo.scheduledPrices["8675309"] = usd(1799)
+			var resp catalog.DetailResponse
+			err := svc.GetProductDetails(ctx, &catalog.DetailRequest{Sku: "8675309"}, &resp)
+			So(err, ShouldBeNil)
+			So(resp.Product.Price.Amount, ShouldEqual, 1799)
+			So(resp.Product.ScheduledPrice, ShouldBeNil)
+			So(resp.ListPrice.Amount, ShouldEqual, 1999)
+		})
+
+		Convey("both the effective and list prices should be converted into a requested currency", func() {
+			repo.scheduledPrices["8675309"] = usd(1000)
+			var resp catalog.DetailResponse
+			err := svc.GetProductDetails(ctx, &catalog.DetailRequest{Sku: "8675309", CurrencyCode: "EUR"}, &resp)
+			So(err, ShouldBeNil)
+			So(resp.Product.Price.Amount, ShouldEqual, 900)
+			So(resp.ListPrice.Amount, ShouldEqual, 1799)
+			So(resp.ListPrice.CurrencyCode, ShouldEqual, "EUR")
+		})
+
+		Convey("search results should be refined by the price in effect", func() {
+			repo.findResults = []*catalog.Product{
+				&catalog.Product{Sku: "CHEAP", Name: "Widget", Price: usd(500)},
+				&catalog.Product{Sku: "ONSALE", Name: "Widget", Price: usd(5000), ScheduledPrice: usd(900)},
+			}
+			var resp catalog.SearchResponse
+			err := svc.ProductSearch(ctx, &catalog.SearchRequest{
+				SearchTerm: "widget",
+				Price:      &catalog.PriceRange{Max: 1000},
+			}, &resp)
+			So(err, ShouldBeNil)
+			So(len(resp.SearchResults), ShouldEqual, 2)
+			for _, result := range resp.SearchResults {
+				So(result.Price.Amount, ShouldBeLessThanOrEqualTo, 1000)
+			}
+		})
+	})
+}
+
+func TestProductVariants(t *testing.T) {
+	Convey("Given a catalog service with a product that has variants", t, func() {
+		repo := newFakeRepo()
+		repo.variants = map[string][]*catalog.Product{
+			"8675309": []*catalog.Product{
+				&catalog.Product{Sku: "8675309-BLK-L", ParentSku: "8675309", Attributes: []*catalog.ProductAttribute{
+					&catalog.ProductAttribute{Name: "color", Value: "black"},
+					&catalog.ProductAttribute{Name: "size", Value: "L"},
+				}},
+				&catalog.Product{Sku: "8675309-RED-128", ParentSku: "8675309", Attributes: []*catalog.ProductAttribute{
+					&catalog.ProductAttribute{Name: "capacity", Value: "128GB"},
+					&catalog.ProductAttribute{Name: "color", Value: "red"},
+				}},
+			},
+		}
+		repo.parentSkus = map[string]string{"8675309-BLK-L": "8675309", "8675309-RED-128": "8675309"}
+		svc := service.NewCatalogService(repo, nil)
+		ctx := context.Background()
+
+		Convey("product details for the parent should include its variants", func() {
+			var resp catalog.DetailResponse
+			err := svc.GetProductDetails(ctx, &catalog.DetailRequest{Sku: "8675309"}, &resp)
+			So(err, ShouldBeNil)
+			So(len(resp.Variants), ShouldEqual, 2)
+			So(resp.Variants[0].Sku, ShouldEqual, "8675309-BLK-L")
+		})
+
+		Convey("querying variants by parent should list every variant and attribute", func() {
+			var resp catalog.VariantsResponse
+			err := svc.GetProductVariants(ctx, &catalog.VariantsRequest{Sku: "8675309"}, &resp)
+			So(err, ShouldBeNil)
+			So(resp.Parent.Sku, ShouldEqual, "8675309")
+			So(len(resp.Variants), ShouldEqual, 2)
+			So(resp.AttributeNames, ShouldResemble, []string{"capacity", "color", "size"})
+		})
+
+		Convey("querying variants by variant should resolve the parent", func() {
+			var resp catalog.VariantsResponse
+			err := svc.GetProductVariants(ctx, &catalog.VariantsRequest{Sku: "8675309-RED-128"}, &resp)
+			So(err, ShouldBeNil)
+			So(resp.Parent.Sku, ShouldEqual, "8675309")
+			So(len(resp.Variants), ShouldEqual, 2)
+		})
+
+		Convey("querying variants of a non-existent product should produce a not found error", func() {
+			var resp catalog.VariantsResponse
+			err := svc.GetProductVariants(ctx, &catalog.VariantsRequest{Sku: "DONTEXIST"}, &resp)
+			So(err, ShouldNotBeNil)
+			realError := errors.Parse(err.Error())
+			So(realError.Code, ShouldEqual, http.StatusNotFound)
+		})
+	})
+}
+
 func TestCategoriesRetrieval(t *testing.T) {
 	Convey("Given a catalog service", t, func() {
 		repo := newFakeRepo()
-		svc := service.NewCatalogService(repo)
+		svc := service.NewCatalogService(repo, nil)
 		ctx := context.Background()
 
 		Convey("Querying categories should invoke repository", func() {
@@ -71,10 +338,98 @@ func TestCategoriesRetrieval(t *testing.T) {
 	})
 }
 
+func TestCategoryHierarchy(t *testing.T) {
+	Convey("Given a catalog service with nested categories", t, func() {
+		repo := newFakeRepo()
+		repo.categories = []*catalog.ProductCategory{
+			&catalog.ProductCategory{CategoryId: 42, Name: "Electronics"},
+			&catalog.ProductCategory{CategoryId: 12, Name: "Toys"},
+			&catalog.ProductCategory{CategoryId: 9, Name: "Cameras", ParentId: 42},
+			&catalog.ProductCategory{CategoryId: 7, Name: "Audio", ParentId: 42},
+			&catalog.ProductCategory{CategoryId: 8, Name: "Headphones", ParentId: 7},
+		}
+		svc := service.NewCatalogService(repo, nil)
+		ctx := context.Background()
+
+		Convey("the category tree should nest categories beneath their parents", func() {
+			var resp catalog.CategoryTreeResponse
+			err := svc.GetCategoryTree(ctx, &catalog.CategoryTreeRequest{}, &resp)
+			So(err, ShouldBeNil)
+			So(len(resp.Roots), ShouldEqual, 2)
+			So(resp.Roots[0].Category.Name, ShouldEqual, "Electronics")
+			So(resp.Roots[1].Category.Name, ShouldEqual, "Toys")
+			electronics := resp.Roots[0].Children
+			So(len(electronics), ShouldEqual, 2)
+			So(electronics[0].Category.Name, ShouldEqual, "Audio")
+			So(electronics[1].Category.Name, ShouldEqual, "Cameras")
+			So(len(electronics[0].Children), ShouldEqual, 1)
+			So(electronics[0].Children[0].Category.Name, ShouldEqual, "Headphones")
+		})
+
+		Convey("the category tree can be rooted at any category", func() {
+			var resp catalog.CategoryTreeResponse
+			err := svc.GetCategoryTree(ctx, &catalog.CategoryTreeRequest{RootId: 7}, &resp)
+			So(err, ShouldBeNil)
+			So(len(resp.Roots), ShouldEqual, 1)
+			So(resp.Roots[0].Category.Name, ShouldEqual, "Audio")
+			So(len(resp.Roots[0].Children), ShouldEqual, 1)
+		})
+
+		Convey("rooting the category tree at a non-existent category should fail", func() {
+			var resp catalog.CategoryTreeResponse
+			err := svc.GetCategoryTree(ctx, &catalog.CategoryTreeRequest{RootId: 1}, &resp)
+			So(err, ShouldNotBeNil)
+			realError := errors.Parse(err.Error())
+			So(realError.Code, ShouldEqual, http.StatusNotFound)
+		})
+
+		Convey("a category whose parent is missing should be treated as a top-level category", func() {
+			repo.categories = append(repo.categories, &catalog.ProductCategory{CategoryId: 99, Name: "Orphans", ParentId: 1000})
+			var resp catalog.CategoryTreeResponse
+			err := svc.GetCategoryTree(ctx, &catalog.CategoryTreeRequest{}, &resp)
+			So(err, ShouldBeNil)
+			So(len(resp.Roots), ShouldEqual, 3)
+			So(resp.Roots[1].Category.Name, ShouldEqual, "Orphans")
+		})
+
+		Convey("querying products should include subcategories when asked", func() {
+			var resp catalog.CategoryProductsResponse
+			err := svc.GetProductsInCategory(ctx, &catalog.CategoryProductsRequest{
+				CategoryId:         42,
+				IncludeDescendants: true,
+			}, &resp)
+			So(err, ShouldBeNil)
+			So(repo.categoryIDs, ShouldResemble, []uint64{42, 7, 9, 8})
+
+			err = svc.GetProductsInCategory(ctx, &catalog.CategoryProductsRequest{CategoryId: 42}, &resp)
+			So(err, ShouldBeNil)
+			So(repo.categoryIDs, ShouldResemble, []uint64{42})
+		})
+
+		Convey("product details should include the deepest breadcrumb path", func() {
+			repo.membership = map[string][]uint64{"8675309": []uint64{12, 8, 42}}
+			var resp catalog.DetailResponse
+			err := svc.GetProductDetails(ctx, &catalog.DetailRequest{Sku: "8675309"}, &resp)
+			So(err, ShouldBeNil)
+			So(len(resp.Breadcrumbs), ShouldEqual, 3)
+			So(resp.Breadcrumbs[0].Name, ShouldEqual, "Electronics")
+			So(resp.Breadcrumbs[1].Name, ShouldEqual, "Audio")
+			So(resp.Breadcrumbs[2].Name, ShouldEqual, "Headphones")
+		})
+
+		Convey("a product in no category should have no breadcrumbs", func() {
+			var resp catalog.DetailResponse
+			err := svc.GetProductDetails(ctx, &catalog.DetailRequest{Sku: "8675309"}, &resp)
+			So(err, ShouldBeNil)
+			So(resp.Breadcrumbs, ShouldBeEmpty)
+		})
+	})
+}
+
 func TestProductsWithinCategory(t *testing.T) {
 	Convey("Given a catalog service", t, func() {
 		repo := newFakeRepo()
-		svc := service.NewCatalogService(repo)
+		svc := service.NewCatalogService(repo, nil)
 		ctx := context.Background()
 
 		Convey("querying products within a category should invoke repository", func() {
@@ -86,6 +441,72 @@ func TestProductsWithinCategory(t *testing.T) {
 			So(err, ShouldBeNil)
 			So(len(resp.Products), ShouldEqual, 2)
 			So(resp.Products[1].Sku, ShouldEqual, "ABC123")
+			So(resp.TotalCount, ShouldEqual, 2)
+			So(resp.NextPageCursor, ShouldBeEmpty)
+		})
+
+		Convey("querying products within a category should page through the results", func() {
+			repo.shouldFail = false
+			repo.listedProducts = []*catalog.Product{
+				&catalog.Product{Sku: "ABC000"},
+				&catalog.Product{Sku: "ABC123"},
+				&catalog.Product{Sku: "ABC456"},
+			}
+			var first catalog.CategoryProductsResponse
+			err := svc.GetProductsInCategory(ctx, &catalog.CategoryProductsRequest{
+				CategoryId: 42,
+				PageSize:   2,
+			}, &first)
+			So(err, ShouldBeNil)
+			So(len(first.Products), ShouldEqual, 2)
+			So(first.TotalCount, ShouldEqual, 3)
+			So(first.NextPageCursor, ShouldNotBeEmpty)
+
+			var second catalog.CategoryProductsResponse
+			err = svc.GetProductsInCategory(ctx, &catalog.CategoryProductsRequest{
+				CategoryId: 42,
+				PageSize:   2,
+				Cursor:     first.NextPageCursor,
+			}, &second)
+			So(err, ShouldBeNil)
+			So(repo.categoryOffset, ShouldEqual, 2)
+			So(len(second.Products), ShouldEqual, 1)
+			So(second.Products[0].Sku, ShouldEqual, "ABC456")
+			So(second.NextPageCursor, ShouldBeEmpty)
+		})
+
+		Convey("inactive products should only be listed when asked for", func() {
+			var resp catalog.CategoryProductsResponse
+			err := svc.GetProductsInCategory(ctx, &catalog.CategoryProductsRequest{
+				CategoryId:      42,
+				IncludeInactive: true,
+			}, &resp)
+			So(err, ShouldBeNil)
+			So(resp.TotalCount, ShouldEqual, 3)
+			So(resp.Products[2].Status, ShouldEqual, catalog.ProductStatus_PS_DISCONTINUED)
+		})
+
+		Convey("page sizes should default and be capped", func() {
+			repo.shouldFail = false
+			var resp catalog.CategoryProductsResponse
+			err := svc.GetProductsInCategory(ctx, &catalog.CategoryProductsRequest{CategoryId: 42}, &resp)
+			So(err, ShouldBeNil)
+			So(repo.categoryLimit, ShouldEqual, 20)
+			err = svc.GetProductsInCategory(ctx, &catalog.CategoryProductsRequest{CategoryId: 42, PageSize: 1000}, &resp)
+			So(err, ShouldBeNil)
+			So(repo.categoryLimit, ShouldEqual, 100)
+		})
+
+		Convey("a malformed cursor should be rejected", func() {
+			repo.shouldFail = false
+			var resp catalog.CategoryProductsResponse
+			err := svc.GetProductsInCategory(ctx, &catalog.CategoryProductsRequest{
+				CategoryId: 42,
+				Cursor:     "not a cursor!",
+			}, &resp)
+			So(err, ShouldNotBeNil)
+			realError := errors.Parse(err.Error())
+			So(realError.Code, ShouldEqual, http.StatusBadRequest)
 		})
 
 		Convey("querying products within a non-existent category should produce appropriate error", func() {
@@ -117,7 +538,7 @@ func TestProductsWithinCategory(t *testing.T) {
 func TestProductSearch(t *testing.T) {
 	Convey("Given a catalog service", t, func() {
 		repo := newFakeRepo()
-		svc := service.NewCatalogService(repo)
+		svc := service.NewCatalogService(repo, nil)
 		ctx := context.Background()
 
 		Convey("search should invoke repository find", func() {
@@ -160,61 +581,977 @@ func TestProductSearch(t *testing.T) {
 	})
 }
 
-type fakeRepo struct {
-	shouldFail bool
-	findCount  int
-}
+func TestProductSearchPaging(t *testing.T) {
+	Convey("Given a catalog service with several matching products", t, func() {
+		repo := newFakeRepo()
+		svc := service.NewCatalogService(repo, nil)
+		ctx := context.Background()
+		repo.findResults = []*catalog.Product{
+			&catalog.Product{Sku: "TV0001", Name: "Television A", Price: usd(30000)},
+			&catalog.Product{Sku: "TV0002", Name: "Television B", Price: usd(10000)},
+			&catalog.Product{Sku: "TV0003", Name: "Television C", Price: usd(20000)},
+		}
 
-func newFakeRepo() *fakeRepo {
-	return &fakeRepo{}
-}
+		Convey("search results should be paged with a total count", func() {
+			var first catalog.SearchResponse
+			err := svc.ProductSearch(ctx, &catalog.SearchRequest{SearchTerm: "television", PageSize: 2}, &first)
+			So(err, ShouldBeNil)
+			So(len(first.SearchResults), ShouldEqual, 2)
+			So(len(first.Hits), ShouldEqual, 2)
+			So(first.TotalCount, ShouldEqual, 3)
+			So(first.NextPageCursor, ShouldNotBeEmpty)
 
-func (r *fakeRepo) GetProduct(sku string) (product *catalog.Product, err error) {
-	if r.shouldFail {
-		return nil, stderrors.New("Faily Fail")
-	}
+			var second catalog.SearchResponse
+			err = svc.ProductSearch(ctx, &catalog.SearchRequest{
+				SearchTerm: "television",
+				PageSize:   2,
+				Cursor:     first.NextPageCursor,
+			}, &second)
+			So(err, ShouldBeNil)
+			So(len(second.SearchResults), ShouldEqual, 1)
+			So(second.SearchResults[0].Sku, ShouldEqual, "TV0003")
+			So(second.NextPageCursor, ShouldBeEmpty)
+		})
 
-	product = &catalog.Product{
-		Sku: sku,
-	}
-	return
-}
+		Convey("only the products on the requested page should be loaded", func() {
+			var resp catalog.SearchResponse
+			err := svc.ProductSearch(ctx, &catalog.SearchRequest{SearchTerm: "television", PageSize: 2}, &resp)
+			So(err, ShouldBeNil)
+			So(repo.loadedSkus, ShouldResemble, []string{"TV0001", "TV0002"})
+		})
 
-func (r *fakeRepo) GetCategories() (categories []*catalog.ProductCategory, err error) {
-	if r.shouldFail {
-		return nil, stderrors.New("Faily Fail")
-	}
-	return []*catalog.ProductCategory{
-		&catalog.ProductCategory{CategoryId: 42, Name: "Electronics", Description: "Super electronicy electronics"},
-		&catalog.ProductCategory{CategoryId: 12, Name: "Toys", Description: "Toys"},
-	}, nil
-}
+		Convey("search results should honor the requested sort order", func() {
+			var resp catalog.SearchResponse
+			err := svc.ProductSearch(ctx, &catalog.SearchRequest{
+				SearchTerm: "television",
+				SortOrder:  catalog.SortOrder_SO_PRICE_DESC,
+			}, &resp)
+			So(err, ShouldBeNil)
+			So(resp.SearchResults[0].Sku, ShouldEqual, "TV0001")
+			So(resp.SearchResults[1].Sku, ShouldEqual, "TV0003")
+			So(resp.SearchResults[2].Sku, ShouldEqual, "TV0002")
+		})
 
-func (r *fakeRepo) GetProductsInCategory(categoryID uint64) (products []*catalog.Product, err error) {
-	if r.shouldFail {
-		return nil, stderrors.New("Faily Fail")
-	}
-	if categoryID == 42 {
-		return []*catalog.Product{
-			&catalog.Product{Sku: "ABC000"},
-			&catalog.Product{Sku: "ABC123"},
-		}, nil
-	}
-	return
+		Convey("an unknown sort order should be rejected", func() {
+			var resp catalog.SearchResponse
+			err := svc.ProductSearch(ctx, &catalog.SearchRequest{SearchTerm: "television", SortOrder: 99}, &resp)
+			So(err, ShouldNotBeNil)
+			realError := errors.Parse(err.Error())
+			So(realError.Code, ShouldEqual, http.StatusBadRequest)
+		})
+	})
 }
 
-func (r *fakeRepo) CategoryExists(categoryID uint64) (bool, error) {
-	return categoryID == 42, nil
-}
+func TestProductSearchRanking(t *testing.T) {
+	Convey("Given a catalog service with several matching products", t, func() {
+		repo := newFakeRepo()
+		svc := service.NewCatalogService(repo, nil)
+		ctx := context.Background()
+		repo.findResults = []*catalog.Product{
+			&catalog.Product{Sku: "DESC01", Name: "Bluetooth Speaker", Manufacturer: "Acme",
+				Description: "Pairs with any television for room-filling sound"},
+			&catalog.Product{Sku: "NAME01", Name: "Television Stand", Manufacturer: "Acme",
+				Description: "Sturdy oak stand"},
+			&catalog.Product{Sku: "NAME02", Name: "Smart Television", Manufacturer: "Acme",
+				Description: "A television with apps"},
+		}
 
-func (r *fakeRepo) ProductExists(sku string) (bool, error) {
-	return sku == "8675309", nil
+		Convey("results should be ordered by relevance", func() {
+			var resp catalog.SearchResponse
+			err := svc.ProductSearch(ctx, &catalog.SearchRequest{SearchTerm: "television"}, &resp)
+			So(err, ShouldBeNil)
+			So(len(resp.SearchResults), ShouldEqual, 3)
+			So(resp.SearchResults[0].Sku, ShouldEqual, "NAME02")
+			So(resp.SearchResults[1].Sku, ShouldEqual, "NAME01")
+			So(resp.SearchResults[2].Sku, ShouldEqual, "DESC01")
+		})
+
+		Convey("name matches should outscore description matches", func() {
+			var resp catalog.SearchResponse
+			err := svc.ProductSearch(ctx, &catalog.SearchRequest{SearchTerm: "television"}, &resp)
+			So(err, ShouldBeNil)
+			So(len(resp.Hits), ShouldEqual, 3)
+			So(resp.Hits[1].Sku, ShouldEqual, "NAME01")
+			So(resp.Hits[2].Sku, ShouldEqual, "DESC01")
+			So(resp.Hits[1].Score, ShouldBeGreaterThan, resp.Hits[2].Score)
+		})
+
+		Convey("hits should highlight the matching fields", func() {
+			var resp catalog.SearchResponse
+			err := svc.ProductSearch(ctx, &catalog.SearchRequest{SearchTerm: "acme stand"}, &resp)
+			So(err, ShouldBeNil)
+			So(resp.Hits[0].Sku, ShouldEqual, "NAME01")
+			So(len(resp.Hits[0].Highlights), ShouldEqual, 3)
+			So(resp.Hits[0].Highlights[0].Field, ShouldEqual, "name")
+			So(resp.Hits[0].Highlights[0].Snippet, ShouldEqual, "Television <em>Stand</em>")
+			So(resp.Hits[0].Highlights[1].Field, ShouldEqual, "manufacturer")
+			So(resp.Hits[0].Highlights[2].Field, ShouldEqual, "description")
+			So(resp.Hits[0].Highlights[2].Snippet, ShouldEqual, "Sturdy oak <em>stand</em>")
+		})
+
+		Convey("long descriptions should be trimmed around the first match", func() {
+			repo.findResults = []*catalog.Product{
+				&catalog.Product{Sku: "LONG01", Name: "Receiver", Description: strings.Repeat("lorem ipsum ", 20) +
+					"with a built in amplifier " + strings.Repeat("dolor sit amet ", 20)},
+			}
+			var resp catalog.SearchResponse
+			err := svc.ProductSearch(ctx, &catalog.SearchRequest{SearchTerm: "amplifier"}, &resp)
+			So(err, ShouldBeNil)
+			snippet := resp.Hits[0].Highlights[0].Snippet
+			So(snippet, ShouldStartWith, "...")
+			So(snippet, ShouldEndWith, "...")
+			So(snippet, ShouldContainSubstring, "<em>amplifier</em>")
+		})
+
+		Convey("highlighted text should be escaped so that only the emphasis is markup", func() {
+			repo.findResults = []*catalog.Product{
+				&catalog.Product{Sku: "HTML01", Name: "<b>Stand</b> & Deliver"},
+			}
+			var resp catalog.SearchResponse
+			err := svc.ProductSearch(ctx, &catalog.SearchRequest{SearchTerm: "stand"}, &resp)
+			So(err, ShouldBeNil)
+			So(resp.Hits[0].Highlights[0].Snippet, ShouldEqual, "&lt;b&gt;<em>Stand</em>&lt;/b&gt; &amp; Deliver")
+		})
+
+		Convey("long descriptions without spaces should be trimmed between characters", func() {
+			repo.findResults = []*catalog.Product{
+				&catalog.Product{Sku: "LONG02", Name: "Receiver", Description: "amplifier," + strings.Repeat("é", 100)},
+			}
+			var resp catalog.SearchResponse
+			err := svc.ProductSearch(ctx, &catalog.SearchRequest{SearchTerm: "amplifier"}, &resp)
+			So(err, ShouldBeNil)
+			snippet := resp.Hits[0].Highlights[0].Snippet
+			So(snippet, ShouldEndWith, "...")
+			So(utf8.ValidString(snippet), ShouldBeTrue)
+		})
+	})
 }
 
-func (r *fakeRepo) Find(searchTerm string, categories []uint64) (products []*catalog.Product, err error) {
+func TestFacetedProductSearch(t *testing.T) {
+	Convey("Given a catalog service with products from several manufacturers", t, func() {
+		repo := newFakeRepo()
+		svc := service.NewCatalogService(repo, nil)
+		ctx := context.Background()
+		repo.findResults = []*catalog.Product{
+			&catalog.Product{Sku: "TV0001", Name: "Television", Manufacturer: "Samsung", Price: usd(49999)},
+			&catalog.Product{Sku: "TV0002", Name: "Television", Manufacturer: "Samsung", Price: usd(129999)},
+			&catalog.Product{Sku: "TV0003", Name: "Television", Manufacturer: "Sony", Price: usd(59999)},
+			&catalog.Product{Sku: "TV0004", Name: "Television", Manufacturer: "LG", Price: usd(1999)},
+		}
+		repo.membership = map[string][]uint64{
+			"TV0001": []uint64{42},
+			"TV0002": []uint64{42, 12},
+			"TV0003": []uint64{42},
+		}
+
+		Convey("facet counts should cover every matching product", func() {
+			var resp catalog.SearchResponse
+			err := svc.ProductSearch(ctx, &catalog.SearchRequest{SearchTerm: "television"}, &resp)
+			So(err, ShouldBeNil)
+			So(len(resp.SearchResults), ShouldEqual, 4)
+			So(resp.Facets, ShouldNotBeNil)
+			So(len(resp.Facets.Manufacturers), ShouldEqual, 3)
+			So(resp.Facets.Manufacturers[0].Manufacturer, ShouldEqual, "Samsung")
+			So(resp.Facets.Manufacturers[0].Count, ShouldEqual, 2)
+			So(len(resp.Facets.Categories), ShouldEqual, 2)
+			So(resp.Facets.Categories[0].CategoryId, ShouldEqual, 42)
+			So(resp.Facets.Categories[0].Count, ShouldEqual, 3)
+			So(len(resp.Facets.Prices), ShouldEqual, 4)
+			So(resp.Facets.Prices[0].Range.Min, ShouldEqual, 0)
+			So(resp.Facets.Prices[0].Range.Max, ShouldEqual, 2500)
+			So(resp.Facets.Prices[3].Range.Min, ShouldEqual, 100000)
+			So(resp.Facets.Prices[3].Range.Max, ShouldEqual, 0)
+		})
+
+		Convey("inactive products should be left out of results and facets unless asked for", func() {
+			repo.findResults = append(repo.findResults,
+				&catalog.Product{Sku: "TV0005", Name: "Television", Manufacturer: "Zenith", Price: usd(9999),
+					Status: catalog.ProductStatus_PS_DISCONTINUED},
+				&catalog.Product{Sku: "TV0006", Name: "Television", Manufacturer: "Zenith", Price: usd(9999),
+					Status: catalog.ProductStatus_PS_DRAFT})
+
+			var resp catalog.SearchResponse
+			err := svc.ProductSearch(ctx, &catalog.SearchRequest{SearchTerm: "television"}, &resp)
+			So(err, ShouldBeNil)
+			So(resp.TotalCount, ShouldEqual, 4)
+			So(len(resp.Facets.Manufacturers), ShouldEqual, 3)
+
+			var all catalog.SearchResponse
+			err = svc.ProductSearch(ctx, &catalog.SearchRequest{SearchTerm: "television", IncludeInactive: true}, &all)
+			So(err, ShouldBeNil)
+			So(all.TotalCount, ShouldEqual, 6)
+			So(len(all.Facets.Manufacturers), ShouldEqual, 4)
+		})
+
+		Convey("searching by manufacturer should filter results, and every facet but the manufacturers", func() {
+			var resp catalog.SearchResponse
+			err := svc.ProductSearch(ctx, &catalog.SearchRequest{
+				SearchTerm:    "television",
+				Manufacturers: []string{"samsung"},
+			}, &resp)
+			So(err, ShouldBeNil)
+			So(len(resp.SearchResults), ShouldEqual, 2)
+			So(len(resp.Facets.Manufacturers), ShouldEqual, 3)
+			So(resp.Facets.Manufacturers[0].Manufacturer, ShouldEqual, "Samsung")
+			So(resp.Facets.Manufacturers[0].Count, ShouldEqual, 2)
+			So(len(resp.Facets.Prices), ShouldEqual, 2)
+			So(resp.Facets.Categories[0].Count, ShouldEqual, 2)
+		})
+
+		Convey("searching by category should filter results, and every facet but the categories", func() {
+			var resp catalog.SearchResponse
+			err := svc.ProductSearch(ctx, &catalog.SearchRequest{
+				SearchTerm: "television",
+				Categories: []uint64{12},
+			}, &resp)
+			So(err, ShouldBeNil)
+			So(len(resp.SearchResults), ShouldEqual, 1)
+			So(resp.SearchResults[0].Sku, ShouldEqual, "TV0002")
+			So(len(resp.Facets.Categories), ShouldEqual, 2)
+			So(resp.Facets.Categories[0].CategoryId, ShouldEqual, 42)
+			So(resp.Facets.Categories[0].Count, ShouldEqual, 3)
+			So(len(resp.Facets.Manufacturers), ShouldEqual, 1)
+		})
+
+		Convey("searching by price range should filter results", func() {
+			var resp catalog.SearchResponse
+			err := svc.ProductSearch(ctx, &catalog.SearchRequest{
+				SearchTerm: "television",
+				Price:      &catalog.PriceRange{Min: 40000, Max: 100000},
+			}, &resp)
+			So(err, ShouldBeNil)
+			So(len(resp.SearchResults), ShouldEqual, 2)
+			So(len(resp.Facets.Prices), ShouldEqual, 4)
+			So(len(resp.Facets.Manufacturers), ShouldEqual, 2)
+		})
+
+		Convey("a price range in another currency should compare converted prices", func() {
+			var resp catalog.SearchResponse
+			err := svc.ProductSearch(ctx, &catalog.SearchRequest{
+				SearchTerm: "television",
+				Price:      &catalog.PriceRange{Min: 50000, Max: 100000, CurrencyCode: "EUR"},
+			}, &resp)
+			So(err, ShouldBeNil)
+			So(len(resp.SearchResults), ShouldEqual, 1)
+			So(resp.SearchResults[0].Sku, ShouldEqual, "TV0003")
+			So(len(resp.Facets.Prices), ShouldEqual, 4)
+			So(resp.Facets.Prices[0].Range.CurrencyCode, ShouldEqual, "EUR")
+		})
+
+		Convey("products priced in another currency should be refined by their converted price", func() {
+			repo.findResults = append(repo.findResults, &catalog.Product{Sku: "TV0005", Name: "Television",
+				Manufacturer: "Philips", Price: &catalog.Money{Amount: 45000, CurrencyCode: "EUR"}})
+
+			var resp catalog.SearchResponse
+			err := svc.ProductSearch(ctx, &catalog.SearchRequest{
+				SearchTerm: "television",
+				Price:      &catalog.PriceRange{Min: 40000, Max: 100000},
+			}, &resp)
+			So(err, ShouldBeNil)
+			So(len(resp.SearchResults), ShouldEqual, 3)
+			So(resp.Facets.Prices[0].Range.CurrencyCode, ShouldEqual, "USD")
+		})
+
+		Convey("a price range in an unsupported currency should be rejected", func() {
+			var resp catalog.SearchResponse
+			err := svc.ProductSearch(ctx, &catalog.SearchRequest{
+				SearchTerm: "television",
+				Price:      &catalog.PriceRange{Min: 5000, CurrencyCode: "GBP"},
+			}, &resp)
+			So(err, ShouldNotBeNil)
+			realError := errors.Parse(err.Error())
+			So(realError.Code, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("an inverted price range should be rejected", func() {
+			var resp catalog.SearchResponse
+			err := svc.ProductSearch(ctx, &catalog.SearchRequest{
+				SearchTerm: "television",
+				Price:      &catalog.PriceRange{Min: 5000, Max: 100},
+			}, &resp)
+			So(err, ShouldNotBeNil)
+			realError := errors.Parse(err.Error())
+			So(realError.Code, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("searching by specification should filter on typed values", func() {
+			screenSize := func(inches float64) *catalog.Specification {
+				return &catalog.Specification{Name: "screen_size", Type: catalog.SpecificationType_ST_NUMBER, NumberValue: inches}
+			}
+			smart := &catalog.Specification{Name: "smart", Type: catalog.SpecificationType_ST_BOOLEAN, BooleanValue: true}
+			repo.findResults[0].Specifications = []*catalog.Specification{screenSize(55), smart}
+			repo.findResults[1].Specifications = []*catalog.Specification{screenSize(65), smart}
+			repo.findResults[2].Specifications = []*catalog.Specification{screenSize(32)}
+
+			var resp catalog.SearchResponse
+			err := svc.ProductSearch(ctx, &catalog.SearchRequest{
+				SearchTerm: "television",
+				Specifications: []*catalog.SpecificationFilter{
+					{Name: "screen_size", Range: &catalog.NumberRange{Min: 50}},
+					{Name: "smart", Values: []string{"TRUE"}},
+				},
+			}, &resp)
+			So(err, ShouldBeNil)
+			So(resp.TotalCount, ShouldEqual, 2)
+
+			err = svc.ProductSearch(ctx, &catalog.SearchRequest{
+				SearchTerm:     "television",
+				Specifications: []*catalog.SpecificationFilter{{Name: "screen_size", Range: &catalog.NumberRange{Min: 30, Max: 60}}},
+			}, &resp)
+			So(err, ShouldBeNil)
+			So(resp.TotalCount, ShouldEqual, 2)
+			So(len(resp.Facets.Manufacturers), ShouldEqual, 2)
+		})
+
+		Convey("a specification filter without a name should be rejected", func() {
+			var resp catalog.SearchResponse
+			err := svc.ProductSearch(ctx, &catalog.SearchRequest{
+				SearchTerm:     "television",
+				Specifications: []*catalog.SpecificationFilter{{Values: []string{"red"}}},
+			}, &resp)
+			So(err, ShouldNotBeNil)
+			So(errors.Parse(err.Error()).Code, ShouldEqual, http.StatusBadRequest)
+		})
+	})
+}
+
+func TestTypoTolerantProductSearch(t *testing.T) {
+	Convey("Given a catalog service", t, func() {
+		repo := newFakeRepo()
+		svc := service.NewCatalogService(repo, nil)
+		ctx := context.Background()
+
+		Convey("misspelled matches should still be ranked and highlighted", func() {
+			repo.findResults = []*catalog.Product{
+				&catalog.Product{Sku: "TV0001", Name: "Television", Manufacturer: "Samsung"},
+			}
+			var resp catalog.SearchResponse
+			err := svc.ProductSearch(ctx, &catalog.SearchRequest{SearchTerm: "samsnug"}, &resp)
+			So(err, ShouldBeNil)
+			So(len(resp.Hits), ShouldEqual, 1)
+			So(resp.Hits[0].Score, ShouldBeGreaterThan, 0)
+			So(resp.Hits[0].Highlights[0].Field, ShouldEqual, "manufacturer")
+			So(resp.Hits[0].Highlights[0].Snippet, ShouldEqual, "<em>Samsung</em>")
+			So(resp.SuggestedSearchTerm, ShouldBeEmpty)
+		})
+
+		Convey("exact matches should outrank misspelled matches", func() {
+			repo.findResults = []*catalog.Product{
+				&catalog.Product{Sku: "TV0001", Name: "Tablet", Manufacturer: "Samsung"},
+				&catalog.Product{Sku: "TV0002", Name: "Tablet", Manufacturer: "Samsong"},
+			}
+			var resp catalog.SearchResponse
+			err := svc.ProductSearch(ctx, &catalog.SearchRequest{SearchTerm: "samsong"}, &resp)
+			So(err, ShouldBeNil)
+			So(resp.SearchResults[0].Sku, ShouldEqual, "TV0002")
+		})
+
+		Convey("a search with no results should suggest a corrected search term", func() {
+			repo.findResults = nil
+			repo.suggestion = "samsung television"
+			var resp catalog.SearchResponse
+			err := svc.ProductSearch(ctx, &catalog.SearchRequest{SearchTerm: "samsnug televisoin"}, &resp)
+			So(err, ShouldBeNil)
+			So(len(resp.SearchResults), ShouldEqual, 0)
+			So(resp.SuggestedSearchTerm, ShouldEqual, "samsung television")
+		})
+	})
+}
+
+func TestProductSuggestions(t *testing.T) {
+	Convey("Given a catalog service", t, func() {
+		repo := newFakeRepo()
+		svc := service.NewCatalogService(repo, nil)
+		ctx := context.Background()
+
+		Convey("suggestions should be returned for a single character prefix", func() {
+			repo.shouldFail = false
+			var resp catalog.SuggestResponse
+			err := svc.SuggestProducts(ctx, &catalog.SuggestRequest{Prefix: "t"}, &resp)
+			So(err, ShouldBeNil)
+			So(len(resp.Suggestions), ShouldEqual, 1)
+			So(resp.Suggestions[0].Sku, ShouldEqual, "TV0001")
+			So(repo.suggestLimit, ShouldEqual, 10)
+		})
+
+		Convey("the number of suggestions requested should be capped", func() {
+			repo.shouldFail = false
+			var resp catalog.SuggestResponse
+			err := svc.SuggestProducts(ctx, &catalog.SuggestRequest{Prefix: "tel", Limit: 500}, &resp)
+			So(err, ShouldBeNil)
+			So(repo.suggestLimit, ShouldEqual, 25)
+		})
+
+		Convey("a blank prefix should be rejected", func() {
+			var resp catalog.SuggestResponse
+			err := svc.SuggestProducts(ctx, &catalog.SuggestRequest{Prefix: "  "}, &resp)
+			So(err, ShouldNotBeNil)
+			realError := errors.Parse(err.Error())
+			So(realError.Code, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("suggestions should fail when the repo fails", func() {
+			repo.shouldFail = true
+			var resp catalog.SuggestResponse
+			err := svc.SuggestProducts(ctx, &catalog.SuggestRequest{Prefix: "tel"}, &resp)
+			So(err, ShouldNotBeNil)
+			realError := errors.Parse(err.Error())
+			So(realError.Code, ShouldEqual, http.StatusInternalServerError)
+		})
+	})
+}
+
+func TestProductReviews(t *testing.T) {
+	Convey("Given a catalog service", t, func() {
+		repo := newFakeRepo()
+		svc := service.NewCatalogService(repo, nil)
+		ctx := context.Background()
+
+		submit := func(customerID string, rating uint32) (*catalog.Review, error) {
+			var resp catalog.SubmitReviewResponse
+			err := svc.SubmitReview(ctx, &catalog.SubmitReviewRequest{Sku: "8675309", CustomerId: customerID, Rating: rating,
+				Title: "Great number", Body: "I got it"}, &resp)
+			return resp.Review, err
+		}
+
+		Convey("submitting a review should save it awaiting moderation", func() {
+			review, err := submit("tommy", 5)
+			So(err, ShouldBeNil)
+			So(review.ReviewId, ShouldEqual, 1)
+			So(review.Status, ShouldEqual, catalog.ReviewStatus_RS_PENDING)
+			So(review.CreatedAt, ShouldBeGreaterThan, 0)
+			So(len(repo.reviews), ShouldEqual, 1)
+		})
+
+		Convey("reviews with a bad rating, title or body should be rejected", func() {
+			for _, request := range []*catalog.SubmitReviewRequest{
+				{Sku: "8675309", CustomerId: "tommy", Rating: 0, Title: "Zero"},
+				{Sku: "8675309", CustomerId: "tommy", Rating: 6, Title: "Six"},
+				{Sku: "8675309", CustomerId: "tommy", Rating: 3, Title: "  "},
+				{Sku: "8675309", CustomerId: "", Rating: 3, Title: "Anonymous"},
+				{Sku: "8675309", CustomerId: "tommy", Rating: 3, Title: "Long", Body: strings.Repeat("x", 5001)},
+			} {
+				err := svc.SubmitReview(ctx, request, &catalog.SubmitReviewResponse{})
+				So(err, ShouldNotBeNil)
+				So(errors.Parse(err.Error()).Code, ShouldEqual, http.StatusBadRequest)
+			}
+			err := svc.SubmitReview(ctx, &catalog.SubmitReviewRequest{Sku: "8675309", CustomerId: "tommy", Rating: 0, Title: "Zero"},
+				&catalog.SubmitReviewResponse{})
+			So(errors.Parse(err.Error()).Detail, ShouldEqual, catalogerrors.InvalidRating.Error())
+			So(repo.reviews, ShouldBeEmpty)
+		})
+
+		Convey("a customer reviewing a product twice should produce a conflict", func() {
+			_, err := submit("tommy", 5)
+			So(err, ShouldBeNil)
+			_, err = submit("tommy", 1)
+			So(err, ShouldNotBeNil)
+			So(errors.Parse(err.Error()).Code, ShouldEqual, http.StatusConflict)
+		})
+
+		Convey("reviewing a non-existent product should produce a not found error", func() {
+			err := svc.SubmitReview(ctx, &catalog.SubmitReviewRequest{Sku: "DONTEXIST", CustomerId: "tommy", Rating: 4,
+				Title: "Where is it"}, &catalog.SubmitReviewResponse{})
+			So(err, ShouldNotBeNil)
+			So(errors.Parse(err.Error()).Code, ShouldEqual, http.StatusNotFound)
+		})
+
+		Convey("listing reviews should show only approved reviews, newest first, with the product's rating", func() {
+			for _, customerID := range []string{"tommy", "gina", "jenny"} {
+				_, err := submit(customerID, 4)
+				So(err, ShouldBeNil)
+			}
+			repo.reviews[0].Status = catalog.ReviewStatus_RS_APPROVED
+			repo.reviews[2].Status = catalog.ReviewStatus_RS_APPROVED
+			repo.histograms = map[string][]uint32{"8675309": []uint32{1, 0, 0, 1, 1}}
+
+			var resp catalog.ReviewsResponse
+			err := svc.GetProductReviews(ctx, &catalog.ReviewsRequest{Sku: "8675309", PageSize: 1}, &resp)
+			So(err, ShouldBeNil)
+			So(len(resp.Reviews), ShouldEqual, 1)
+			So(resp.Reviews[0].CustomerId, ShouldEqual, "jenny")
+			So(resp.TotalCount, ShouldEqual, 2)
+			So(resp.Rating.ReviewCount, ShouldEqual, 3)
+			So(resp.Rating.AverageRating, ShouldEqual, 3.33)
+			So(resp.Rating.Histogram, ShouldResemble, []uint32{1, 0, 0, 1, 1})
+
+			var next catalog.ReviewsResponse
+			err = svc.GetProductReviews(ctx, &catalog.ReviewsRequest{Sku: "8675309", PageSize: 1, Cursor: resp.NextPageCursor}, &next)
+			So(err, ShouldBeNil)
+			So(next.Reviews[0].CustomerId, ShouldEqual, "tommy")
+			So(next.NextPageCursor, ShouldBeEmpty)
+		})
+
+		Convey("product details should include the product's rating", func() {
+			repo.histograms = map[string][]uint32{"8675309": []uint32{0, 0, 0, 2, 2}}
+			var resp catalog.DetailResponse
+			err := svc.GetProductDetails(ctx, &catalog.DetailRequest{Sku: "8675309"}, &resp)
+			So(err, ShouldBeNil)
+			So(resp.Rating.AverageRating, ShouldEqual, 4.5)
+			So(resp.Rating.ReviewCount, ShouldEqual, 4)
+		})
+
+		Convey("a product without approved reviews should have no rating", func() {
+			var resp catalog.ReviewsResponse
+			err := svc.GetProductReviews(ctx, &catalog.ReviewsRequest{Sku: "8675309"}, &resp)
+			So(err, ShouldBeNil)
+			So(resp.Reviews, ShouldBeEmpty)
+			So(resp.Rating.AverageRating, ShouldEqual, 0)
+			So(resp.Rating.ReviewCount, ShouldEqual, 0)
+		})
+
+		Convey("customers should be able to vote on approved reviews written by others", func() {
+			_, err := submit("tommy", 5)
+			So(err, ShouldBeNil)
+			repo.reviews[0].Status = catalog.ReviewStatus_RS_APPROVED
+
+			var resp catalog.ReviewVoteResponse
+			err = svc.VoteReview(ctx, &catalog.ReviewVoteRequest{ReviewId: 1, CustomerId: "gina", Helpful: true}, &resp)
+			So(err, ShouldBeNil)
+			So(resp.HelpfulVotes, ShouldEqual, 1)
+			So(resp.UnhelpfulVotes, ShouldEqual, 0)
+
+			err = svc.VoteReview(ctx, &catalog.ReviewVoteRequest{ReviewId: 1, CustomerId: "tommy", Helpful: true}, &resp)
+			So(err, ShouldNotBeNil)
+			realError := errors.Parse(err.Error())
+			So(realError.Code, ShouldEqual, http.StatusBadRequest)
+			So(realError.Detail, ShouldEqual, catalogerrors.InvalidReviewVote.Error())
+		})
+
+		Convey("voting on a review that hasn't been approved should produce a not found error", func() {
+			_, err := submit("tommy", 5)
+			So(err, ShouldBeNil)
+			err = svc.VoteReview(ctx, &catalog.ReviewVoteRequest{ReviewId: 1, CustomerId: "gina", Helpful: true},
+				&catalog.ReviewVoteResponse{})
+			So(err, ShouldNotBeNil)
+			So(errors.Parse(err.Error()).Code, ShouldEqual, http.StatusNotFound)
+
+			err = svc.VoteReview(ctx, &catalog.ReviewVoteRequest{ReviewId: 99, CustomerId: "gina"}, &catalog.ReviewVoteResponse{})
+			So(err, ShouldNotBeNil)
+			So(errors.Parse(err.Error()).Code, ShouldEqual, http.StatusNotFound)
+		})
+	})
+}
+
+func TestRelatedProducts(t *testing.T) {
+	Convey("Given a catalog service with related products", t, func() {
+		repo := newFakeRepo()
+		svc := service.NewCatalogService(repo, nil)
+		ctx := context.Background()
+		repo.manufacturers = map[string]string{"8675309": "Acme"}
+		repo.variants = map[string][]*catalog.Product{"8675309": []*catalog.Product{&catalog.Product{Sku: "8675309-L"}}}
+		repo.products = map[string]*catalog.Product{
+			"LINK01":    &catalog.Product{Sku: "LINK01"},
+			"BUY001":    &catalog.Product{Sku: "BUY001"},
+			"BUY002":    &catalog.Product{Sku: "BUY002"},
+			"BUY003":    &catalog.Product{Sku: "BUY003", Status: catalog.ProductStatus_PS_DISCONTINUED},
+			"ABC123":    &catalog.Product{Sku: "ABC123"},
+			"8675309-L": &catalog.Product{Sku: "8675309-L", ParentSku: "8675309"},
+		}
+		repo.relatedLinks = map[string][]string{"8675309": []string{"LINK01", "GONE01"}}
+		repo.boughtWith = map[string]map[string]int{
+			"8675309": map[string]int{"BUY001": 3, "BUY002": 5, "BUY003": 9, "ABC123": 1, "8675309-L": 7},
+		}
+		repo.membership = map[string][]uint64{"8675309": []uint64{42}}
+		repo.listedProducts = []*catalog.Product{
+			&catalog.Product{Sku: "ABC000"},
+			&catalog.Product{Sku: "ABC123"},
+			&catalog.Product{Sku: "ABC456"},
+		}
+		repo.mfrProducts = []*catalog.Product{
+			&catalog.Product{Sku: "ACME01", Manufacturer: "Acme"},
+			&catalog.Product{Sku: "ABC456", Manufacturer: "acme"},
+			&catalog.Product{Sku: "OTHER1", Manufacturer: "Acme Corp"},
+		}
+
+		Convey("related products should be ranked by how closely they are related", func() {
+			var resp catalog.RelatedResponse
+			err := svc.GetRelatedProducts(ctx, &catalog.RelatedRequest{Sku: "8675309"}, &resp)
+			So(err, ShouldBeNil)
+			var skus []string
+			for _, related := range resp.Related {
+				skus = append(skus, related.Product.Sku)
+			}
+			So(skus, ShouldResemble, []string{"LINK01", "BUY002", "BUY001", "ABC123", "ABC456", "ABC000", "ACME01"})
+			So(resp.Related[0].Relations, ShouldResemble, []catalog.Relation{catalog.Relation_REL_LINKED})
+			So(resp.Related[1].BoughtTogetherCount, ShouldEqual, 5)
+			So(resp.Related[3].Relations, ShouldResemble,
+				[]catalog.Relation{catalog.Relation_REL_BOUGHT_TOGETHER, catalog.Relation_REL_SAME_CATEGORY})
+			So(resp.Related[4].Relations, ShouldResemble,
+				[]catalog.Relation{catalog.Relation_REL_SAME_CATEGORY, catalog.Relation_REL_SAME_MANUFACTURER})
+		})
+
+		Convey("the number of related products should be limited", func() {
+			var resp catalog.RelatedResponse
+			err := svc.GetRelatedProducts(ctx, &catalog.RelatedRequest{Sku: "8675309", Limit: 3}, &resp)
+			So(err, ShouldBeNil)
+			So(len(resp.Related), ShouldEqual, 3)
+		})
+
+		Convey("only the products from the same manufacturer closest in price should be considered", func() {
+			err := svc.GetRelatedProducts(ctx, &catalog.RelatedRequest{Sku: "8675309"}, &catalog.RelatedResponse{})
+			So(err, ShouldBeNil)
+			So(repo.mfrLimit, ShouldBeGreaterThan, 0)
+			So(repo.mfrPrice, ShouldEqual, 1999)
+		})
+
+		Convey("related products should be priced in a requested currency", func() {
+			repo.products["LINK01"].Price = usd(1000)
+			var resp catalog.RelatedResponse
+			err := svc.GetRelatedProducts(ctx, &catalog.RelatedRequest{Sku: "8675309", CurrencyCode: "EUR"}, &resp)
+			So(err, ShouldBeNil)
+			So(resp.Related[0].Product.Sku, ShouldEqual, "LINK01")
+			So(resp.Related[0].Product.Price.Amount, ShouldEqual, 900)
+			So(resp.Related[0].Product.Price.CurrencyCode, ShouldEqual, "EUR")
+
+			err = svc.GetRelatedProducts(ctx, &catalog.RelatedRequest{Sku: "8675309", CurrencyCode: "GBP"}, &resp)
+			So(err, ShouldNotBeNil)
+			So(errors.Parse(err.Error()).Code, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("related products of a non-existent product should produce a not found error", func() {
+			var resp catalog.RelatedResponse
+			err := svc.GetRelatedProducts(ctx, &catalog.RelatedRequest{Sku: "DONTEXIST"}, &resp)
+			So(err, ShouldNotBeNil)
+			realError := errors.Parse(err.Error())
+			So(realError.Code, ShouldEqual, http.StatusNotFound)
+		})
+
+		Convey("related products should fail when the repo fails", func() {
+			repo.shouldFail = true
+			var resp catalog.RelatedResponse
+			err := svc.GetRelatedProducts(ctx, &catalog.RelatedRequest{Sku: "8675309"}, &resp)
+			So(err, ShouldNotBeNil)
+		})
+	})
+
+	Convey("Given a catalog service receiving item shipped events", t, func() {
+		repo := newFakeRepo()
+		repo.shipments = make(chan string, 1)
+		shippedChannel := make(chan *shipping.ItemShippedEvent)
+		service.NewCatalogService(repo, shippedChannel)
+
+		Convey("each shipment should be recorded against its order", func() {
+			shippedChannel <- &shipping.ItemShippedEvent{Sku: "8675309", OrderId: 42}
+			So(<-repo.shipments, ShouldEqual, "42/8675309")
+		})
+
+		Convey("a shipment should be retried when another shipment in the order is recorded first", func() {
+			repo.conflicts = 2
+			shippedChannel <- &shipping.ItemShippedEvent{Sku: "8675309", OrderId: 42}
+			So(<-repo.shipments, ShouldEqual, "42/8675309")
+		})
+	})
+}
+
+type fakeRepo struct {
+	shouldFail      bool
+	categories      []*catalog.ProductCategory
+	variants        map[string][]*catalog.Product
+	parentSkus      map[string]string
+	scheduledPrices map[string]*catalog.Money
+	media           map[string][]*catalog.MediaAsset
+	manufacturers   map[string]string
+	products        map[string]*catalog.Product
+	relatedLinks    map[string][]string
+	boughtWith      map[string]map[string]int
+	conflicts       int
+	shipments       chan string
+	reviews         []*catalog.Review
+	histograms      map[string][]uint32
+	translations    map[string][]*catalog.Translation
+	categoryIDs     []uint64
+	findCount       int
+	findResults     []*catalog.Product
+	loadedSkus      []string
+	membership      map[string][]uint64
+	suggestion      string
+	suggestLimit    int
+	categoryOffset  int
+	categoryLimit   int
+	listedProducts  []*catalog.Product
+	mfrProducts     []*catalog.Product
+	mfrPrice        int64
+	mfrLimit        int
+}
+
+func newFakeRepo() *fakeRepo {
+	return &fakeRepo{}
+}
+
+func (r *fakeRepo) GetProduct(sku string) (product *catalog.Product, err error) {
 	if r.shouldFail {
 		return nil, stderrors.New("Faily Fail")
 	}
-	r.findCount++
+
+	product = &catalog.Product{
+		Sku:            sku,
+		ParentSku:      r.parentSkus[sku],
+		Manufacturer:   r.manufacturers[sku],
+		Price:          usd(1999),
+		Media:          r.media[sku],
+		Translations:   r.translations[sku],
+		ScheduledPrice: r.scheduledPrices[sku],
+	}
+	return
+}
+
+func (r *fakeRepo) GetProducts(skus []string) (products []*catalog.Product, err error) {
+	if r.shouldFail {
+		return nil, stderrors.New("Faily Fail")
+	}
+	r.loadedSkus = skus
+	for _, sku := range skus {
+		if product, ok := r.products[sku]; ok {
+			products = append(products, product)
+			continue
+		}
+		for _, product := range r.findResults {
+			if product.Sku == sku {
+				products = append(products, product)
+			}
+		}
+	}
+	return products, nil
+}
+
+func (r *fakeRepo) GetRelatedProductLinks(sku string) (skus []string, err error) {
+	if r.shouldFail {
+		return nil, stderrors.New("Faily Fail")
+	}
+	return r.relatedLinks[sku], nil
+}
+
+func (r *fakeRepo) GetBoughtTogether(sku string, limit int) (counts map[string]int, err error) {
+	if r.shouldFail {
+		return nil, stderrors.New("Faily Fail")
+	}
+	return r.boughtWith[sku], nil
+}
+
+func (r *fakeRepo) RecordShipment(orderID uint64, sku string) (err error) {
+	if r.conflicts > 0 {
+		r.conflicts--
+		return catalogerrors.ConcurrentModification
+	}
+	r.shipments <- fmt.Sprintf("%d/%s", orderID, sku)
+	return nil
+}
+
+func (r *fakeRepo) GetProductVariants(sku string) (variants []*catalog.Product, err error) {
+	if r.shouldFail {
+		return nil, stderrors.New("Faily Fail")
+	}
+	return r.variants[sku], nil
+}
+
+func (r *fakeRepo) GetCategories() (categories []*catalog.ProductCategory, err error) {
+	if r.shouldFail {
+		return nil, stderrors.New("Faily Fail")
+	}
+	if r.categories != nil {
+		return r.categories, nil
+	}
+	return []*catalog.ProductCategory{
+		&catalog.ProductCategory{CategoryId: 42, Name: "Electronics", Description: "Super electronicy electronics"},
+		&catalog.ProductCategory{CategoryId: 12, Name: "Toys", Description: "Toys"},
+	}, nil
+}
+
+func (r *fakeRepo) GetProductsInCategories(categoryIDs []uint64, order catalog.SortOrder, includeInactive bool,
+	offset, limit int) (products []*catalog.Product, total int, err error) {
+	if r.shouldFail {
+		return nil, 0, stderrors.New("Faily Fail")
+	}
+	r.categoryIDs = categoryIDs
+	if categoryIDs[0] == 42 {
+		r.categoryOffset, r.categoryLimit = offset, limit
+		all := []*catalog.Product{
+			&catalog.Product{Sku: "ABC000"},
+			&catalog.Product{Sku: "ABC123"},
+		}
+		if r.listedProducts != nil {
+			all = append([]*catalog.Product(nil), r.listedProducts...)
+		}
+		if includeInactive {
+			all = append(all, &catalog.Product{Sku: "ABC789", Status: catalog.ProductStatus_PS_DISCONTINUED})
+		}
+		end := offset + limit
+		if end > len(all) {
+			end = len(all)
+		}
+		if offset < end {
+			products = all[offset:end]
+		}
+		return products, len(all), nil
+	}
 	return
 }
+
+func (r *fakeRepo) CategoryExists(categoryID uint64) (bool, error) {
+	return categoryID == 42, nil
+}
+
+func (r *fakeRepo) CreateReview(review *catalog.Review) (reviewID uint64, err error) {
+	if r.shouldFail {
+		return 0, stderrors.New("Faily Fail")
+	}
+	if exists, _ := r.ProductExists(review.Sku); !exists {
+		return 0, catalogerrors.NoSuchProduct
+	}
+	for _, other := range r.reviews {
+		if other.Sku == review.Sku && other.CustomerId == review.CustomerId {
+			return 0, catalogerrors.DuplicateReview
+		}
+	}
+	saved := *review
+	saved.ReviewId = uint64(len(r.reviews) + 1)
+	r.reviews = append(r.reviews, &saved)
+	return saved.ReviewId, nil
+}
+
+func (r *fakeRepo) GetReview(reviewID uint64) (review *catalog.Review, err error) {
+	if r.shouldFail {
+		return nil, stderrors.New("Faily Fail")
+	}
+	if reviewID == 0 || reviewID > uint64(len(r.reviews)) {
+		return nil, catalogerrors.NoSuchReview
+	}
+	return r.reviews[reviewID-1], nil
+}
+
+func (r *fakeRepo) GetReviews(sku string, offset, limit int) (reviews []*catalog.Review, total int, err error) {
+	if r.shouldFail {
+		return nil, 0, stderrors.New("Faily Fail")
+	}
+	var approved []*catalog.Review
+	for i := len(r.reviews) - 1; i >= 0; i-- {
+		if r.reviews[i].Sku == sku && r.reviews[i].Status == catalog.ReviewStatus_RS_APPROVED {
+			approved = append(approved, r.reviews[i])
+		}
+	}
+	for i := offset; i < len(approved) && i < offset+limit; i++ {
+		reviews = append(reviews, approved[i])
+	}
+	return reviews, len(approved), nil
+}
+
+func (r *fakeRepo) GetRatingHistogram(sku string) (histogram []uint32, err error) {
+	if r.shouldFail {
+		return nil, stderrors.New("Faily Fail")
+	}
+	if histogram = r.histograms[sku]; histogram == nil {
+		histogram = make([]uint32, 5)
+	}
+	return histogram, nil
+}
+
+func (r *fakeRepo) VoteReview(reviewID uint64, customerID string, helpful bool) (helpfulVotes, unhelpfulVotes uint32, err error) {
+	review, err := r.GetReview(reviewID)
+	if err != nil {
+		return 0, 0, err
+	}
+	if helpful {
+		review.HelpfulVotes++
+	} else {
+		review.UnhelpfulVotes++
+	}
+	return review.HelpfulVotes, review.UnhelpfulVotes, nil
+}
+
+func (r *fakeRepo) ProductExists(sku string) (bool, error) {
+	return sku == "8675309" || r.parentSkus[sku] != "", nil
+}
+
+// GetProductsByManufacturer returns the products in mfrProducts from the manufacturer, as the
+// manufacturer index would list them
+func (r *fakeRepo) GetProductsByManufacturer(manufacturer string, price int64, limit int) (products []*catalog.Product,
+	err error) {
+
+	if r.shouldFail {
+		return nil, stderrors.New("Faily Fail")
+	}
+	r.mfrPrice, r.mfrLimit = price, limit
+	for _, product := range r.mfrProducts {
+		if strings.EqualFold(product.Manufacturer, manufacturer) {
+			products = append(products, product)
+		}
+	}
+	return products, nil
+}
+
+// FindMatches returns every product in findResults, with only the fields a real match carries, scored
+// as the search index would score them
+func (r *fakeRepo) FindMatches(searchTerm string) (matches []*catalog.Product, scores map[string]float64, err error) {
+	if r.shouldFail {
+		return nil, nil, stderrors.New("Faily Fail")
+	}
+	r.findCount++
+	tokens := search.Tokenize(searchTerm)
+	scores = make(map[string]float64)
+	for _, product := range r.findResults {
+		matches = append(matches, &catalog.Product{
+			Sku:            product.Sku,
+			Name:           product.Name,
+			Manufacturer:   product.Manufacturer,
+			Price:          product.Price,
+			ScheduledPrice: product.ScheduledPrice,
+			Status:         product.Status,
+			Translations:   product.Translations,
+			Specifications: product.Specifications,
+		})
+		names, descriptions := []string{product.Name}, []string{product.Description}
+		for _, translation := range product.Translations {
+			names = append(names, translation.Name)
+			descriptions = append(descriptions, translation.Description)
+		}
+		for term, weight := range search.TermWeights(names, descriptions, product.Manufacturer, product.Model) {
+			for _, token := range tokens {
+				if term == token {
+					scores[product.Sku] += weight
+					break
+				}
+				if search.Similar(token, term) {
+					scores[product.Sku] += weight * search.FuzzyMatchStrength
+					break
+				}
+			}
+		}
+	}
+	return matches, scores, nil
+}
+
+func (r *fakeRepo) GetCategoryMembership(skus []string) (membership map[string][]uint64, err error) {
+	if r.shouldFail {
+		return nil, stderrors.New("Faily Fail")
+	}
+	return r.membership, nil
+}
+
+func (r *fakeRepo) SuggestQuery(searchTerm string) (suggestion string, err error) {
+	if r.shouldFail {
+		return "", stderrors.New("Faily Fail")
+	}
+	return r.suggestion, nil
+}
+
+func (r *fakeRepo) SuggestProducts(prefix string, limit int) (suggestions []*catalog.ProductSuggestion, err error) {
+	if r.shouldFail {
+		return nil, stderrors.New("Faily Fail")
+	}
+	r.suggestLimit = limit
+	return []*catalog.ProductSuggestion{
+		&catalog.ProductSuggestion{Sku: "TV0001", Name: "Television"},
+	}, nil
+}
+
+func (r *fakeRepo) GetExchangeRates() (rates map[string]string, err error) {
+	if r.shouldFail {
+		return nil, stderrors.New("Faily Fail")
+	}
+	return map[string]string{"EUR": "0.9", "JPY": "150.25", "GBP": "not a rate"}, nil
+}
+
+func usd(amount int64) *catalog.Money {
+	return &catalog.Money{Amount: amount, CurrencyCode: "USD"}
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	catalogerrors "github.com/autodidaddict/go-shopping/catalog/internal/platform/errors"
+	"github.com/autodidaddict/go-shopping/catalog/proto"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// validateSpecifications checks that each specification has a unique name and a value of its own type
+// alone, and orders them by name
+func validateSpecifications(specifications []*catalog.Specification) error {
+	names := make(map[string]bool, len(specifications))
+	for _, specification := range specifications {
+		if specification == nil || len(strings.TrimSpace(specification.Name)) == 0 || names[specification.Name] {
+			return catalogerrors.InvalidSpecification
+		}
+		names[specification.Name] = true
+
+		var valid bool
+		switch specification.Type {
+		case catalog.SpecificationType_ST_TEXT:
+			valid = len(strings.TrimSpace(specification.TextValue)) > 0 && specification.NumberValue == 0 &&
+				!specification.BooleanValue
+		case catalog.SpecificationType_ST_NUMBER:
+			valid = specification.TextValue == "" && !specification.BooleanValue &&
+				!math.IsNaN(specification.NumberValue) && !math.IsInf(specification.NumberValue, 0)
+		case catalog.SpecificationType_ST_BOOLEAN:
+			valid = specification.TextValue == "" && specification.NumberValue == 0
+		}
+		if !valid {
+			return catalogerrors.InvalidSpecification
+		}
+	}
+	sort.Slice(specifications, func(i, j int) bool {
+		return specifications[i].Name < specifications[j].Name
+	})
+	return nil
+}
+
+// validateSpecificationSchema checks that each definition has a unique name and a known type, and
+// orders them by name
+func validateSpecificationSchema(schema []*catalog.SpecificationDefinition) error {
+	names := make(map[string]bool, len(schema))
+	for _, definition := range schema {
+		if definition == nil || len(strings.TrimSpace(definition.Name)) == 0 || names[definition.Name] {
+			return catalogerrors.InvalidSpecificationSchema
+		}
+		names[definition.Name] = true
+		if _, ok := catalog.SpecificationType_name[int32(definition.Type)]; !ok ||
+			definition.Type == catalog.SpecificationType_ST_UNKNOWN {
+			return catalogerrors.InvalidSpecificationSchema
+		}
+		if definition.Unit != "" && definition.Type != catalog.SpecificationType_ST_NUMBER {
+			return catalogerrors.InvalidSpecificationSchema
+		}
+	}
+	sort.Slice(schema, func(i, j int) bool {
+		return schema[i].Name < schema[j].Name
+	})
+	return nil
+}
+
+func validateSpecificationFilters(filters []*catalog.SpecificationFilter) bool {
+	for _, filter := range filters {
+		if filter == nil || len(strings.TrimSpace(filter.Name)) == 0 {
+			return false
+		}
+		if r := filter.Range; r != nil && (math.IsNaN(r.Min) || math.IsNaN(r.Max) || (r.Max != 0 && r.Max <= r.Min)) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesSpecifications reports whether a product has every specification filtered on, with a value
+// matching the filter. A filter without values or a range matches any value.
+func matchesSpecifications(product *catalog.Product, filters []*catalog.SpecificationFilter) bool {
+	for _, filter := range filters {
+		var specification *catalog.Specification
+		for _, s := range product.Specifications {
+			if s.Name == filter.Name {
+				specification = s
+				break
+			}
+		}
+		if specification == nil || !matchesSpecification(specification, filter) {
+			return false
+		}
+	}
+	return true
+}
+
+func matchesSpecification(specification *catalog.Specification, filter *catalog.SpecificationFilter) bool {
+	if r := filter.Range; r != nil {
+		value := specification.NumberValue
+		if specification.Type != catalog.SpecificationType_ST_NUMBER || value < r.Min || (r.Max != 0 && value >= r.Max) {
+			return false
+		}
+	}
+	if len(filter.Values) == 0 {
+		return true
+	}
+	var value string
+	switch specification.Type {
+	case catalog.SpecificationType_ST_TEXT:
+		value = specification.TextValue
+	case catalog.SpecificationType_ST_BOOLEAN:
+		value = strconv.FormatBool(specification.BooleanValue)
+	default:
+		return false
+	}
+	for _, wanted := range filter.Values {
+		if strings.EqualFold(strings.TrimSpace(wanted), value) {
+			return true
+		}
+	}
+	return false
+}
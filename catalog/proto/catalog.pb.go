@@ -12,12 +12,75 @@ It has these top-level messages:
 	DetailResponse
 	AllCategoriesRequest
 	AllCategoriesResponse
+	CategoryTreeRequest
+	CategoryTreeResponse
 	CategoryProductsRequest
 	CategoryProductsResponse
+	VariantsRequest
+	VariantsResponse
 	SearchRequest
 	SearchResponse
+	RelatedRequest
+	RelatedResponse
+	SubmitReviewRequest
+	SubmitReviewResponse
+	ReviewsRequest
+	ReviewsResponse
+	ReviewVoteRequest
+	ReviewVoteResponse
+	SuggestRequest
+	SuggestResponse
+	CreateProductRequest
+	CreateProductResponse
+	UpdateProductRequest
+	UpdateProductResponse
+	DeleteProductRequest
+	DeleteProductResponse
+	CreateCategoryRequest
+	CreateCategoryResponse
+	UpdateCategoryRequest
+	UpdateCategoryResponse
+	DeleteCategoryRequest
+	DeleteCategoryResponse
+	AssignProductRequest
+	AssignProductResponse
+	SchedulePriceRequest
+	SchedulePriceResponse
+	CancelScheduledPriceRequest
+	CancelScheduledPriceResponse
+	RelatedLinkRequest
+	RelatedLinkResponse
+	ModerateReviewRequest
+	ModerateReviewResponse
+	PendingReviewsRequest
+	PendingReviewsResponse
+	PriceHistoryRequest
+	PriceHistoryResponse
+	ProductChangedEvent
 	Product
+	BundleComponent
+	RelatedProduct
+	Money
+	PriceChange
+	Review
+	RatingSummary
+	Translation
+	Specification
+	SpecificationDefinition
+	SpecificationFilter
+	NumberRange
+	ProductAttribute
+	MediaAsset
+	SearchHit
+	Highlight
+	PriceRange
+	SearchFacets
+	ManufacturerFacet
+	CategoryFacet
+	PriceFacet
+	ProductSuggestion
 	ProductCategory
+	CategoryNode
 */
 package catalog
 
@@ -42,8 +105,259 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+type SortOrder int32
+
+const (
+	SortOrder_SO_DEFAULT    SortOrder = 0
+	SortOrder_SO_NAME_ASC   SortOrder = 1
+	SortOrder_SO_NAME_DESC  SortOrder = 2
+	SortOrder_SO_PRICE_ASC  SortOrder = 3
+	SortOrder_SO_PRICE_DESC SortOrder = 4
+)
+
+var SortOrder_name = map[int32]string{
+	0: "SO_DEFAULT",
+	1: "SO_NAME_ASC",
+	2: "SO_NAME_DESC",
+	3: "SO_PRICE_ASC",
+	4: "SO_PRICE_DESC",
+}
+var SortOrder_value = map[string]int32{
+	"SO_DEFAULT":    0,
+	"SO_NAME_ASC":   1,
+	"SO_NAME_DESC":  2,
+	"SO_PRICE_ASC":  3,
+	"SO_PRICE_DESC": 4,
+}
+
+func (x SortOrder) String() string {
+	return proto.EnumName(SortOrder_name, int32(x))
+}
+func (SortOrder) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
+type ProductChangeType int32
+
+const (
+	ProductChangeType_PC_UNKNOWN      ProductChangeType = 0
+	ProductChangeType_PC_CREATED      ProductChangeType = 1
+	ProductChangeType_PC_UPDATED      ProductChangeType = 2
+	ProductChangeType_PC_REPRICED     ProductChangeType = 3
+	ProductChangeType_PC_DISCONTINUED ProductChangeType = 4
+	ProductChangeType_PC_DELETED      ProductChangeType = 5
+)
+
+var ProductChangeType_name = map[int32]string{
+	0: "PC_UNKNOWN",
+	1: "PC_CREATED",
+	2: "PC_UPDATED",
+	3: "PC_REPRICED",
+	4: "PC_DISCONTINUED",
+	5: "PC_DELETED",
+}
+var ProductChangeType_value = map[string]int32{
+	"PC_UNKNOWN":      0,
+	"PC_CREATED":      1,
+	"PC_UPDATED":      2,
+	"PC_REPRICED":     3,
+	"PC_DISCONTINUED": 4,
+	"PC_DELETED":      5,
+}
+
+func (x ProductChangeType) String() string {
+	return proto.EnumName(ProductChangeType_name, int32(x))
+}
+func (ProductChangeType) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+// ProductStatus is where a product is in its lifecycle. Only active products are listed in categories
+// and search results, but every product can still be looked up by SKU.
+type ProductStatus int32
+
+const (
+	ProductStatus_PS_ACTIVE       ProductStatus = 0
+	ProductStatus_PS_DRAFT        ProductStatus = 1
+	ProductStatus_PS_DISCONTINUED ProductStatus = 2
+	ProductStatus_PS_HIDDEN       ProductStatus = 3
+)
+
+var ProductStatus_name = map[int32]string{
+	0: "PS_ACTIVE",
+	1: "PS_DRAFT",
+	2: "PS_DISCONTINUED",
+	3: "PS_HIDDEN",
+}
+var ProductStatus_value = map[string]int32{
+	"PS_ACTIVE":       0,
+	"PS_DRAFT":        1,
+	"PS_DISCONTINUED": 2,
+	"PS_HIDDEN":       3,
+}
+
+func (x ProductStatus) String() string {
+	return proto.EnumName(ProductStatus_name, int32(x))
+}
+func (ProductStatus) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+type ProductKind int32
+
+const (
+	ProductKind_PK_STANDARD ProductKind = 0
+	ProductKind_PK_BUNDLE   ProductKind = 1
+)
+
+var ProductKind_name = map[int32]string{
+	0: "PK_STANDARD",
+	1: "PK_BUNDLE",
+}
+var ProductKind_value = map[string]int32{
+	"PK_STANDARD": 0,
+	"PK_BUNDLE":   1,
+}
+
+func (x ProductKind) String() string {
+	return proto.EnumName(ProductKind_name, int32(x))
+}
+func (ProductKind) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+type SpecificationType int32
+
+const (
+	SpecificationType_ST_UNKNOWN SpecificationType = 0
+	SpecificationType_ST_TEXT    SpecificationType = 1
+	SpecificationType_ST_NUMBER  SpecificationType = 2
+	SpecificationType_ST_BOOLEAN SpecificationType = 3
+)
+
+var SpecificationType_name = map[int32]string{
+	0: "ST_UNKNOWN",
+	1: "ST_TEXT",
+	2: "ST_NUMBER",
+	3: "ST_BOOLEAN",
+}
+var SpecificationType_value = map[string]int32{
+	"ST_UNKNOWN": 0,
+	"ST_TEXT":    1,
+	"ST_NUMBER":  2,
+	"ST_BOOLEAN": 3,
+}
+
+func (x SpecificationType) String() string {
+	return proto.EnumName(SpecificationType_name, int32(x))
+}
+func (SpecificationType) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
+type Relation int32
+
+const (
+	Relation_REL_UNKNOWN           Relation = 0
+	Relation_REL_LINKED            Relation = 1
+	Relation_REL_BOUGHT_TOGETHER   Relation = 2
+	Relation_REL_SAME_CATEGORY     Relation = 3
+	Relation_REL_SAME_MANUFACTURER Relation = 4
+)
+
+var Relation_name = map[int32]string{
+	0: "REL_UNKNOWN",
+	1: "REL_LINKED",
+	2: "REL_BOUGHT_TOGETHER",
+	3: "REL_SAME_CATEGORY",
+	4: "REL_SAME_MANUFACTURER",
+}
+var Relation_value = map[string]int32{
+	"REL_UNKNOWN":           0,
+	"REL_LINKED":            1,
+	"REL_BOUGHT_TOGETHER":   2,
+	"REL_SAME_CATEGORY":     3,
+	"REL_SAME_MANUFACTURER": 4,
+}
+
+func (x Relation) String() string {
+	return proto.EnumName(Relation_name, int32(x))
+}
+func (Relation) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+type ReviewStatus int32
+
+const (
+	ReviewStatus_RS_UNKNOWN  ReviewStatus = 0
+	ReviewStatus_RS_PENDING  ReviewStatus = 1
+	ReviewStatus_RS_APPROVED ReviewStatus = 2
+	ReviewStatus_RS_REJECTED ReviewStatus = 3
+)
+
+var ReviewStatus_name = map[int32]string{
+	0: "RS_UNKNOWN",
+	1: "RS_PENDING",
+	2: "RS_APPROVED",
+	3: "RS_REJECTED",
+}
+var ReviewStatus_value = map[string]int32{
+	"RS_UNKNOWN":  0,
+	"RS_PENDING":  1,
+	"RS_APPROVED": 2,
+	"RS_REJECTED": 3,
+}
+
+func (x ReviewStatus) String() string {
+	return proto.EnumName(ReviewStatus_name, int32(x))
+}
+func (ReviewStatus) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+type MediaType int32
+
+const (
+	MediaType_MT_UNKNOWN  MediaType = 0
+	MediaType_MT_IMAGE    MediaType = 1
+	MediaType_MT_VIDEO    MediaType = 2
+	MediaType_MT_DOCUMENT MediaType = 3
+)
+
+var MediaType_name = map[int32]string{
+	0: "MT_UNKNOWN",
+	1: "MT_IMAGE",
+	2: "MT_VIDEO",
+	3: "MT_DOCUMENT",
+}
+var MediaType_value = map[string]int32{
+	"MT_UNKNOWN":  0,
+	"MT_IMAGE":    1,
+	"MT_VIDEO":    2,
+	"MT_DOCUMENT": 3,
+}
+
+func (x MediaType) String() string {
+	return proto.EnumName(MediaType_name, int32(x))
+}
+func (MediaType) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
+type PriceType int32
+
+const (
+	PriceType_PT_UNKNOWN   PriceType = 0
+	PriceType_PT_LIST      PriceType = 1
+	PriceType_PT_SCHEDULED PriceType = 2
+)
+
+var PriceType_name = map[int32]string{
+	0: "PT_UNKNOWN",
+	1: "PT_LIST",
+	2: "PT_SCHEDULED",
+}
+var PriceType_value = map[string]int32{
+	"PT_UNKNOWN":   0,
+	"PT_LIST":      1,
+	"PT_SCHEDULED": 2,
+}
+
+func (x PriceType) String() string {
+	return proto.EnumName(PriceType_name, int32(x))
+}
+func (PriceType) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+
 type DetailRequest struct {
-	Sku string `protobuf:"bytes,1,opt,name=sku" json:"sku,omitempty"`
+	Sku             string   `protobuf:"bytes,1,opt,name=sku" json:"sku,omitempty"`
+	CurrencyCode    string   `protobuf:"bytes,2,opt,name=currency_code,json=currencyCode" json:"currency_code,omitempty"`
+	Locale          string   `protobuf:"bytes,3,opt,name=locale" json:"locale,omitempty"`
+	FallbackLocales []string `protobuf:"bytes,4,rep,name=fallback_locales,json=fallbackLocales" json:"fallback_locales,omitempty"`
 }
 
 func (m *DetailRequest) Reset()                    { *m = DetailRequest{} }
@@ -58,8 +372,33 @@ func (m *DetailRequest) GetSku() string {
 	return ""
 }
 
+func (m *DetailRequest) GetCurrencyCode() string {
+	if m != nil {
+		return m.CurrencyCode
+	}
+	return ""
+}
+
+func (m *DetailRequest) GetLocale() string {
+	if m != nil {
+		return m.Locale
+	}
+	return ""
+}
+
+func (m *DetailRequest) GetFallbackLocales() []string {
+	if m != nil {
+		return m.FallbackLocales
+	}
+	return nil
+}
+
 type DetailResponse struct {
-	Product *Product `protobuf:"bytes,1,opt,name=product" json:"product,omitempty"`
+	Product     *Product           `protobuf:"bytes,1,opt,name=product" json:"product,omitempty"`
+	Breadcrumbs []*ProductCategory `protobuf:"bytes,2,rep,name=breadcrumbs" json:"breadcrumbs,omitempty"`
+	Variants    []*Product         `protobuf:"bytes,3,rep,name=variants" json:"variants,omitempty"`
+	ListPrice   *Money             `protobuf:"bytes,4,opt,name=list_price,json=listPrice" json:"list_price,omitempty"`
+	Rating      *RatingSummary     `protobuf:"bytes,5,opt,name=rating" json:"rating,omitempty"`
 }
 
 func (m *DetailResponse) Reset()                    { *m = DetailResponse{} }
@@ -74,8 +413,37 @@ func (m *DetailResponse) GetProduct() *Product {
 	return nil
 }
 
+func (m *DetailResponse) GetBreadcrumbs() []*ProductCategory {
+	if m != nil {
+		return m.Breadcrumbs
+	}
+	return nil
+}
+
+func (m *DetailResponse) GetVariants() []*Product {
+	if m != nil {
+		return m.Variants
+	}
+	return nil
+}
+
+func (m *DetailResponse) GetListPrice() *Money {
+	if m != nil {
+		return m.ListPrice
+	}
+	return nil
+}
+
+func (m *DetailResponse) GetRating() *RatingSummary {
+	if m != nil {
+		return m.Rating
+	}
+	return nil
+}
+
 type AllCategoriesRequest struct {
-	Unused int32 `protobuf:"varint,1,opt,name=Unused" json:"Unused,omitempty"`
+	Unused int32  `protobuf:"varint,1,opt,name=Unused" json:"Unused,omitempty"`
+	Locale string `protobuf:"bytes,2,opt,name=locale" json:"locale,omitempty"`
 }
 
 func (m *AllCategoriesRequest) Reset()                    { *m = AllCategoriesRequest{} }
@@ -90,6 +458,13 @@ func (m *AllCategoriesRequest) GetUnused() int32 {
 	return 0
 }
 
+func (m *AllCategoriesRequest) GetLocale() string {
+	if m != nil {
+		return m.Locale
+	}
+	return ""
+}
+
 type AllCategoriesResponse struct {
 	Categories []*ProductCategory `protobuf:"bytes,1,rep,name=categories" json:"categories,omitempty"`
 }
@@ -106,14 +481,51 @@ func (m *AllCategoriesResponse) GetCategories() []*ProductCategory {
 	return nil
 }
 
+type CategoryTreeRequest struct {
+	RootId uint64 `protobuf:"varint,1,opt,name=root_id,json=rootId" json:"root_id,omitempty"`
+}
+
+func (m *CategoryTreeRequest) Reset()                    { *m = CategoryTreeRequest{} }
+func (m *CategoryTreeRequest) String() string            { return proto.CompactTextString(m) }
+func (*CategoryTreeRequest) ProtoMessage()               {}
+func (*CategoryTreeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
+func (m *CategoryTreeRequest) GetRootId() uint64 {
+	if m != nil {
+		return m.RootId
+	}
+	return 0
+}
+
+type CategoryTreeResponse struct {
+	Roots []*CategoryNode `protobuf:"bytes,1,rep,name=roots" json:"roots,omitempty"`
+}
+
+func (m *CategoryTreeResponse) Reset()                    { *m = CategoryTreeResponse{} }
+func (m *CategoryTreeResponse) String() string            { return proto.CompactTextString(m) }
+func (*CategoryTreeResponse) ProtoMessage()               {}
+func (*CategoryTreeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+func (m *CategoryTreeResponse) GetRoots() []*CategoryNode {
+	if m != nil {
+		return m.Roots
+	}
+	return nil
+}
+
 type CategoryProductsRequest struct {
-	CategoryId uint64 `protobuf:"varint,1,opt,name=category_id,json=categoryId" json:"category_id,omitempty"`
+	CategoryId         uint64    `protobuf:"varint,1,opt,name=category_id,json=categoryId" json:"category_id,omitempty"`
+	PageSize           uint32    `protobuf:"varint,2,opt,name=page_size,json=pageSize" json:"page_size,omitempty"`
+	Cursor             string    `protobuf:"bytes,3,opt,name=cursor" json:"cursor,omitempty"`
+	SortOrder          SortOrder `protobuf:"varint,4,opt,name=sort_order,json=sortOrder,enum=catalog.SortOrder" json:"sort_order,omitempty"`
+	IncludeDescendants bool      `protobuf:"varint,5,opt,name=include_descendants,json=includeDescendants" json:"include_descendants,omitempty"`
+	IncludeInactive    bool      `protobuf:"varint,6,opt,name=include_inactive,json=includeInactive" json:"include_inactive,omitempty"`
 }
 
 func (m *CategoryProductsRequest) Reset()                    { *m = CategoryProductsRequest{} }
 func (m *CategoryProductsRequest) String() string            { return proto.CompactTextString(m) }
 func (*CategoryProductsRequest) ProtoMessage()               {}
-func (*CategoryProductsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+func (*CategoryProductsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
 
 func (m *CategoryProductsRequest) GetCategoryId() uint64 {
 	if m != nil {
@@ -122,14 +534,51 @@ func (m *CategoryProductsRequest) GetCategoryId() uint64 {
 	return 0
 }
 
+func (m *CategoryProductsRequest) GetPageSize() uint32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *CategoryProductsRequest) GetCursor() string {
+	if m != nil {
+		return m.Cursor
+	}
+	return ""
+}
+
+func (m *CategoryProductsRequest) GetSortOrder() SortOrder {
+	if m != nil {
+		return m.SortOrder
+	}
+	return SortOrder_SO_DEFAULT
+}
+
+func (m *CategoryProductsRequest) GetIncludeDescendants() bool {
+	if m != nil {
+		return m.IncludeDescendants
+	}
+	return false
+}
+
+func (m *CategoryProductsRequest) GetIncludeInactive() bool {
+	if m != nil {
+		return m.IncludeInactive
+	}
+	return false
+}
+
 type CategoryProductsResponse struct {
-	Products []*Product `protobuf:"bytes,1,rep,name=products" json:"products,omitempty"`
+	Products       []*Product `protobuf:"bytes,1,rep,name=products" json:"products,omitempty"`
+	NextPageCursor string     `protobuf:"bytes,2,opt,name=next_page_cursor,json=nextPageCursor" json:"next_page_cursor,omitempty"`
+	TotalCount     uint32     `protobuf:"varint,3,opt,name=total_count,json=totalCount" json:"total_count,omitempty"`
 }
 
 func (m *CategoryProductsResponse) Reset()                    { *m = CategoryProductsResponse{} }
 func (m *CategoryProductsResponse) String() string            { return proto.CompactTextString(m) }
 func (*CategoryProductsResponse) ProtoMessage()               {}
-func (*CategoryProductsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+func (*CategoryProductsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
 
 func (m *CategoryProductsResponse) GetProducts() []*Product {
 	if m != nil {
@@ -138,15 +587,85 @@ func (m *CategoryProductsResponse) GetProducts() []*Product {
 	return nil
 }
 
+func (m *CategoryProductsResponse) GetNextPageCursor() string {
+	if m != nil {
+		return m.NextPageCursor
+	}
+	return ""
+}
+
+func (m *CategoryProductsResponse) GetTotalCount() uint32 {
+	if m != nil {
+		return m.TotalCount
+	}
+	return 0
+}
+
+type VariantsRequest struct {
+	Sku string `protobuf:"bytes,1,opt,name=sku" json:"sku,omitempty"`
+}
+
+func (m *VariantsRequest) Reset()                    { *m = VariantsRequest{} }
+func (m *VariantsRequest) String() string            { return proto.CompactTextString(m) }
+func (*VariantsRequest) ProtoMessage()               {}
+func (*VariantsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+
+func (m *VariantsRequest) GetSku() string {
+	if m != nil {
+		return m.Sku
+	}
+	return ""
+}
+
+type VariantsResponse struct {
+	Parent         *Product   `protobuf:"bytes,1,opt,name=parent" json:"parent,omitempty"`
+	Variants       []*Product `protobuf:"bytes,2,rep,name=variants" json:"variants,omitempty"`
+	AttributeNames []string   `protobuf:"bytes,3,rep,name=attribute_names,json=attributeNames" json:"attribute_names,omitempty"`
+}
+
+func (m *VariantsResponse) Reset()                    { *m = VariantsResponse{} }
+func (m *VariantsResponse) String() string            { return proto.CompactTextString(m) }
+func (*VariantsResponse) ProtoMessage()               {}
+func (*VariantsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+
+func (m *VariantsResponse) GetParent() *Product {
+	if m != nil {
+		return m.Parent
+	}
+	return nil
+}
+
+func (m *VariantsResponse) GetVariants() []*Product {
+	if m != nil {
+		return m.Variants
+	}
+	return nil
+}
+
+func (m *VariantsResponse) GetAttributeNames() []string {
+	if m != nil {
+		return m.AttributeNames
+	}
+	return nil
+}
+
 type SearchRequest struct {
-	SearchTerm string   `protobuf:"bytes,1,opt,name=search_term,json=searchTerm" json:"search_term,omitempty"`
-	Categories []uint64 `protobuf:"varint,2,rep,packed,name=categories" json:"categories,omitempty"`
+	SearchTerm      string                 `protobuf:"bytes,1,opt,name=search_term,json=searchTerm" json:"search_term,omitempty"`
+	Categories      []uint64               `protobuf:"varint,2,rep,packed,name=categories" json:"categories,omitempty"`
+	Manufacturers   []string               `protobuf:"bytes,3,rep,name=manufacturers" json:"manufacturers,omitempty"`
+	Price           *PriceRange            `protobuf:"bytes,4,opt,name=price" json:"price,omitempty"`
+	PageSize        uint32                 `protobuf:"varint,5,opt,name=page_size,json=pageSize" json:"page_size,omitempty"`
+	Cursor          string                 `protobuf:"bytes,6,opt,name=cursor" json:"cursor,omitempty"`
+	SortOrder       SortOrder              `protobuf:"varint,7,opt,name=sort_order,json=sortOrder,enum=catalog.SortOrder" json:"sort_order,omitempty"`
+	IncludeInactive bool                   `protobuf:"varint,8,opt,name=include_inactive,json=includeInactive" json:"include_inactive,omitempty"`
+	Locale          string                 `protobuf:"bytes,9,opt,name=locale" json:"locale,omitempty"`
+	Specifications  []*SpecificationFilter `protobuf:"bytes,10,rep,name=specifications" json:"specifications,omitempty"`
 }
 
 func (m *SearchRequest) Reset()                    { *m = SearchRequest{} }
 func (m *SearchRequest) String() string            { return proto.CompactTextString(m) }
 func (*SearchRequest) ProtoMessage()               {}
-func (*SearchRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+func (*SearchRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
 
 func (m *SearchRequest) GetSearchTerm() string {
 	if m != nil {
@@ -162,121 +681,2007 @@ func (m *SearchRequest) GetCategories() []uint64 {
 	return nil
 }
 
-type SearchResponse struct {
-	SearchResults []*Product `protobuf:"bytes,1,rep,name=search_results,json=searchResults" json:"search_results,omitempty"`
+func (m *SearchRequest) GetManufacturers() []string {
+	if m != nil {
+		return m.Manufacturers
+	}
+	return nil
 }
 
-func (m *SearchResponse) Reset()                    { *m = SearchResponse{} }
-func (m *SearchResponse) String() string            { return proto.CompactTextString(m) }
-func (*SearchResponse) ProtoMessage()               {}
-func (*SearchResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
-
-func (m *SearchResponse) GetSearchResults() []*Product {
+func (m *SearchRequest) GetPrice() *PriceRange {
 	if m != nil {
-		return m.SearchResults
+		return m.Price
 	}
 	return nil
 }
 
-type Product struct {
-	Sku          string `protobuf:"bytes,1,opt,name=sku" json:"sku,omitempty"`
-	Name         string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
-	Description  string `protobuf:"bytes,3,opt,name=description" json:"description,omitempty"`
-	Manufacturer string `protobuf:"bytes,4,opt,name=manufacturer" json:"manufacturer,omitempty"`
-	Model        string `protobuf:"bytes,5,opt,name=model" json:"model,omitempty"`
-	Price        int64  `protobuf:"varint,6,opt,name=price" json:"price,omitempty"`
+func (m *SearchRequest) GetPageSize() uint32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
 }
 
-func (m *Product) Reset()                    { *m = Product{} }
-func (m *Product) String() string            { return proto.CompactTextString(m) }
-func (*Product) ProtoMessage()               {}
-func (*Product) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
-
-func (m *Product) GetSku() string {
+func (m *SearchRequest) GetCursor() string {
 	if m != nil {
-		return m.Sku
+		return m.Cursor
 	}
 	return ""
 }
 
-func (m *Product) GetName() string {
+func (m *SearchRequest) GetSortOrder() SortOrder {
 	if m != nil {
-		return m.Name
+		return m.SortOrder
 	}
-	return ""
+	return SortOrder_SO_DEFAULT
 }
 
-func (m *Product) GetDescription() string {
+func (m *SearchRequest) GetIncludeInactive() bool {
 	if m != nil {
-		return m.Description
+		return m.IncludeInactive
 	}
-	return ""
+	return false
 }
 
-func (m *Product) GetManufacturer() string {
+func (m *SearchRequest) GetLocale() string {
 	if m != nil {
-		return m.Manufacturer
+		return m.Locale
 	}
 	return ""
 }
 
-func (m *Product) GetModel() string {
+func (m *SearchRequest) GetSpecifications() []*SpecificationFilter {
 	if m != nil {
-		return m.Model
+		return m.Specifications
 	}
-	return ""
+	return nil
+}
+
+type SearchResponse struct {
+	SearchResults       []*Product    `protobuf:"bytes,1,rep,name=search_results,json=searchResults" json:"search_results,omitempty"`
+	Hits                []*SearchHit  `protobuf:"bytes,2,rep,name=hits" json:"hits,omitempty"`
+	Facets              *SearchFacets `protobuf:"bytes,3,opt,name=facets" json:"facets,omitempty"`
+	SuggestedSearchTerm string        `protobuf:"bytes,4,opt,name=suggested_search_term,json=suggestedSearchTerm" json:"suggested_search_term,omitempty"`
+	NextPageCursor      string        `protobuf:"bytes,5,opt,name=next_page_cursor,json=nextPageCursor" json:"next_page_cursor,omitempty"`
+	TotalCount          uint32        `protobuf:"varint,6,opt,name=total_count,json=totalCount" json:"total_count,omitempty"`
 }
 
-func (m *Product) GetPrice() int64 {
+func (m *SearchResponse) Reset()                    { *m = SearchResponse{} }
+func (m *SearchResponse) String() string            { return proto.CompactTextString(m) }
+func (*SearchResponse) ProtoMessage()               {}
+func (*SearchResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+
+func (m *SearchResponse) GetSearchResults() []*Product {
 	if m != nil {
-		return m.Price
+		return m.SearchResults
 	}
-	return 0
+	return nil
 }
 
-type ProductCategory struct {
-	CategoryId  uint64 `protobuf:"varint,1,opt,name=category_id,json=categoryId" json:"category_id,omitempty"`
-	Name        string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
-	Description string `protobuf:"bytes,3,opt,name=description" json:"description,omitempty"`
+func (m *SearchResponse) GetHits() []*SearchHit {
+	if m != nil {
+		return m.Hits
+	}
+	return nil
 }
 
-func (m *ProductCategory) Reset()                    { *m = ProductCategory{} }
-func (m *ProductCategory) String() string            { return proto.CompactTextString(m) }
-func (*ProductCategory) ProtoMessage()               {}
-func (*ProductCategory) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
-
-func (m *ProductCategory) GetCategoryId() uint64 {
+func (m *SearchResponse) GetFacets() *SearchFacets {
 	if m != nil {
-		return m.CategoryId
+		return m.Facets
 	}
-	return 0
+	return nil
 }
 
-func (m *ProductCategory) GetName() string {
+func (m *SearchResponse) GetSuggestedSearchTerm() string {
 	if m != nil {
-		return m.Name
+		return m.SuggestedSearchTerm
 	}
 	return ""
 }
 
-func (m *ProductCategory) GetDescription() string {
+func (m *SearchResponse) GetNextPageCursor() string {
 	if m != nil {
-		return m.Description
+		return m.NextPageCursor
 	}
 	return ""
 }
 
-func init() {
+func (m *SearchResponse) GetTotalCount() uint32 {
+	if m != nil {
+		return m.TotalCount
+	}
+	return 0
+}
+
+type RelatedRequest struct {
+	Sku          string `protobuf:"bytes,1,opt,name=sku" json:"sku,omitempty"`
+	Limit        uint32 `protobuf:"varint,2,opt,name=limit" json:"limit,omitempty"`
+	CurrencyCode string `protobuf:"bytes,3,opt,name=currency_code,json=currencyCode" json:"currency_code,omitempty"`
+}
+
+func (m *RelatedRequest) Reset()                    { *m = RelatedRequest{} }
+func (m *RelatedRequest) String() string            { return proto.CompactTextString(m) }
+func (*RelatedRequest) ProtoMessage()               {}
+func (*RelatedRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+
+func (m *RelatedRequest) GetSku() string {
+	if m != nil {
+		return m.Sku
+	}
+	return ""
+}
+
+func (m *RelatedRequest) GetLimit() uint32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *RelatedRequest) GetCurrencyCode() string {
+	if m != nil {
+		return m.CurrencyCode
+	}
+	return ""
+}
+
+type RelatedResponse struct {
+	Related []*RelatedProduct `protobuf:"bytes,1,rep,name=related" json:"related,omitempty"`
+}
+
+func (m *RelatedResponse) Reset()                    { *m = RelatedResponse{} }
+func (m *RelatedResponse) String() string            { return proto.CompactTextString(m) }
+func (*RelatedResponse) ProtoMessage()               {}
+func (*RelatedResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+
+func (m *RelatedResponse) GetRelated() []*RelatedProduct {
+	if m != nil {
+		return m.Related
+	}
+	return nil
+}
+
+type SubmitReviewRequest struct {
+	Sku        string `protobuf:"bytes,1,opt,name=sku" json:"sku,omitempty"`
+	CustomerId string `protobuf:"bytes,2,opt,name=customer_id,json=customerId" json:"customer_id,omitempty"`
+	Rating     uint32 `protobuf:"varint,3,opt,name=rating" json:"rating,omitempty"`
+	Title      string `protobuf:"bytes,4,opt,name=title" json:"title,omitempty"`
+	Body       string `protobuf:"bytes,5,opt,name=body" json:"body,omitempty"`
+}
+
+func (m *SubmitReviewRequest) Reset()                    { *m = SubmitReviewRequest{} }
+func (m *SubmitReviewRequest) String() string            { return proto.CompactTextString(m) }
+func (*SubmitReviewRequest) ProtoMessage()               {}
+func (*SubmitReviewRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+
+func (m *SubmitReviewRequest) GetSku() string {
+	if m != nil {
+		return m.Sku
+	}
+	return ""
+}
+
+func (m *SubmitReviewRequest) GetCustomerId() string {
+	if m != nil {
+		return m.CustomerId
+	}
+	return ""
+}
+
+func (m *SubmitReviewRequest) GetRating() uint32 {
+	if m != nil {
+		return m.Rating
+	}
+	return 0
+}
+
+func (m *SubmitReviewRequest) GetTitle() string {
+	if m != nil {
+		return m.Title
+	}
+	return ""
+}
+
+func (m *SubmitReviewRequest) GetBody() string {
+	if m != nil {
+		return m.Body
+	}
+	return ""
+}
+
+type SubmitReviewResponse struct {
+	Review *Review `protobuf:"bytes,1,opt,name=review" json:"review,omitempty"`
+}
+
+func (m *SubmitReviewResponse) Reset()                    { *m = SubmitReviewResponse{} }
+func (m *SubmitReviewResponse) String() string            { return proto.CompactTextString(m) }
+func (*SubmitReviewResponse) ProtoMessage()               {}
+func (*SubmitReviewResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+
+func (m *SubmitReviewResponse) GetReview() *Review {
+	if m != nil {
+		return m.Review
+	}
+	return nil
+}
+
+type ReviewsRequest struct {
+	Sku      string `protobuf:"bytes,1,opt,name=sku" json:"sku,omitempty"`
+	PageSize uint32 `protobuf:"varint,2,opt,name=page_size,json=pageSize" json:"page_size,omitempty"`
+	Cursor   string `protobuf:"bytes,3,opt,name=cursor" json:"cursor,omitempty"`
+}
+
+func (m *ReviewsRequest) Reset()                    { *m = ReviewsRequest{} }
+func (m *ReviewsRequest) String() string            { return proto.CompactTextString(m) }
+func (*ReviewsRequest) ProtoMessage()               {}
+func (*ReviewsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+
+func (m *ReviewsRequest) GetSku() string {
+	if m != nil {
+		return m.Sku
+	}
+	return ""
+}
+
+func (m *ReviewsRequest) GetPageSize() uint32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ReviewsRequest) GetCursor() string {
+	if m != nil {
+		return m.Cursor
+	}
+	return ""
+}
+
+type ReviewsResponse struct {
+	Reviews        []*Review      `protobuf:"bytes,1,rep,name=reviews" json:"reviews,omitempty"`
+	NextPageCursor string         `protobuf:"bytes,2,opt,name=next_page_cursor,json=nextPageCursor" json:"next_page_cursor,omitempty"`
+	TotalCount     uint32         `protobuf:"varint,3,opt,name=total_count,json=totalCount" json:"total_count,omitempty"`
+	Rating         *RatingSummary `protobuf:"bytes,4,opt,name=rating" json:"rating,omitempty"`
+}
+
+func (m *ReviewsResponse) Reset()                    { *m = ReviewsResponse{} }
+func (m *ReviewsResponse) String() string            { return proto.CompactTextString(m) }
+func (*ReviewsResponse) ProtoMessage()               {}
+func (*ReviewsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+
+func (m *ReviewsResponse) GetReviews() []*Review {
+	if m != nil {
+		return m.Reviews
+	}
+	return nil
+}
+
+func (m *ReviewsResponse) GetNextPageCursor() string {
+	if m != nil {
+		return m.NextPageCursor
+	}
+	return ""
+}
+
+func (m *ReviewsResponse) GetTotalCount() uint32 {
+	if m != nil {
+		return m.TotalCount
+	}
+	return 0
+}
+
+func (m *ReviewsResponse) GetRating() *RatingSummary {
+	if m != nil {
+		return m.Rating
+	}
+	return nil
+}
+
+type ReviewVoteRequest struct {
+	ReviewId   uint64 `protobuf:"varint,1,opt,name=review_id,json=reviewId" json:"review_id,omitempty"`
+	CustomerId string `protobuf:"bytes,2,opt,name=customer_id,json=customerId" json:"customer_id,omitempty"`
+	Helpful    bool   `protobuf:"varint,3,opt,name=helpful" json:"helpful,omitempty"`
+}
+
+func (m *ReviewVoteRequest) Reset()                    { *m = ReviewVoteRequest{} }
+func (m *ReviewVoteRequest) String() string            { return proto.CompactTextString(m) }
+func (*ReviewVoteRequest) ProtoMessage()               {}
+func (*ReviewVoteRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+
+func (m *ReviewVoteRequest) GetReviewId() uint64 {
+	if m != nil {
+		return m.ReviewId
+	}
+	return 0
+}
+
+func (m *ReviewVoteRequest) GetCustomerId() string {
+	if m != nil {
+		return m.CustomerId
+	}
+	return ""
+}
+
+func (m *ReviewVoteRequest) GetHelpful() bool {
+	if m != nil {
+		return m.Helpful
+	}
+	return false
+}
+
+type ReviewVoteResponse struct {
+	HelpfulVotes   uint32 `protobuf:"varint,1,opt,name=helpful_votes,json=helpfulVotes" json:"helpful_votes,omitempty"`
+	UnhelpfulVotes uint32 `protobuf:"varint,2,opt,name=unhelpful_votes,json=unhelpfulVotes" json:"unhelpful_votes,omitempty"`
+}
+
+func (m *ReviewVoteResponse) Reset()                    { *m = ReviewVoteResponse{} }
+func (m *ReviewVoteResponse) String() string            { return proto.CompactTextString(m) }
+func (*ReviewVoteResponse) ProtoMessage()               {}
+func (*ReviewVoteResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+
+func (m *ReviewVoteResponse) GetHelpfulVotes() uint32 {
+	if m != nil {
+		return m.HelpfulVotes
+	}
+	return 0
+}
+
+func (m *ReviewVoteResponse) GetUnhelpfulVotes() uint32 {
+	if m != nil {
+		return m.UnhelpfulVotes
+	}
+	return 0
+}
+
+type SuggestRequest struct {
+	Prefix string `protobuf:"bytes,1,opt,name=prefix" json:"prefix,omitempty"`
+	Limit  uint32 `protobuf:"varint,2,opt,name=limit" json:"limit,omitempty"`
+}
+
+func (m *SuggestRequest) Reset()                    { *m = SuggestRequest{} }
+func (m *SuggestRequest) String() string            { return proto.CompactTextString(m) }
+func (*SuggestRequest) ProtoMessage()               {}
+func (*SuggestRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+
+func (m *SuggestRequest) GetPrefix() string {
+	if m != nil {
+		return m.Prefix
+	}
+	return ""
+}
+
+func (m *SuggestRequest) GetLimit() uint32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type SuggestResponse struct {
+	Suggestions []*ProductSuggestion `protobuf:"bytes,1,rep,name=suggestions" json:"suggestions,omitempty"`
+}
+
+func (m *SuggestResponse) Reset()                    { *m = SuggestResponse{} }
+func (m *SuggestResponse) String() string            { return proto.CompactTextString(m) }
+func (*SuggestResponse) ProtoMessage()               {}
+func (*SuggestResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+
+func (m *SuggestResponse) GetSuggestions() []*ProductSuggestion {
+	if m != nil {
+		return m.Suggestions
+	}
+	return nil
+}
+
+type CreateProductRequest struct {
+	Product     *Product `protobuf:"bytes,1,opt,name=product" json:"product,omitempty"`
+	CategoryIds []uint64 `protobuf:"varint,2,rep,packed,name=category_ids,json=categoryIds" json:"category_ids,omitempty"`
+}
+
+func (m *CreateProductRequest) Reset()                    { *m = CreateProductRequest{} }
+func (m *CreateProductRequest) String() string            { return proto.CompactTextString(m) }
+func (*CreateProductRequest) ProtoMessage()               {}
+func (*CreateProductRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+
+func (m *CreateProductRequest) GetProduct() *Product {
+	if m != nil {
+		return m.Product
+	}
+	return nil
+}
+
+func (m *CreateProductRequest) GetCategoryIds() []uint64 {
+	if m != nil {
+		return m.CategoryIds
+	}
+	return nil
+}
+
+type CreateProductResponse struct {
+	Product *Product `protobuf:"bytes,1,opt,name=product" json:"product,omitempty"`
+}
+
+func (m *CreateProductResponse) Reset()                    { *m = CreateProductResponse{} }
+func (m *CreateProductResponse) String() string            { return proto.CompactTextString(m) }
+func (*CreateProductResponse) ProtoMessage()               {}
+func (*CreateProductResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+
+func (m *CreateProductResponse) GetProduct() *Product {
+	if m != nil {
+		return m.Product
+	}
+	return nil
+}
+
+type UpdateProductRequest struct {
+	Product *Product `protobuf:"bytes,1,opt,name=product" json:"product,omitempty"`
+}
+
+func (m *UpdateProductRequest) Reset()                    { *m = UpdateProductRequest{} }
+func (m *UpdateProductRequest) String() string            { return proto.CompactTextString(m) }
+func (*UpdateProductRequest) ProtoMessage()               {}
+func (*UpdateProductRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+
+func (m *UpdateProductRequest) GetProduct() *Product {
+	if m != nil {
+		return m.Product
+	}
+	return nil
+}
+
+type UpdateProductResponse struct {
+	Product *Product `protobuf:"bytes,1,opt,name=product" json:"product,omitempty"`
+}
+
+func (m *UpdateProductResponse) Reset()                    { *m = UpdateProductResponse{} }
+func (m *UpdateProductResponse) String() string            { return proto.CompactTextString(m) }
+func (*UpdateProductResponse) ProtoMessage()               {}
+func (*UpdateProductResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+
+func (m *UpdateProductResponse) GetProduct() *Product {
+	if m != nil {
+		return m.Product
+	}
+	return nil
+}
+
+type DeleteProductRequest struct {
+	Sku string `protobuf:"bytes,1,opt,name=sku" json:"sku,omitempty"`
+}
+
+func (m *DeleteProductRequest) Reset()                    { *m = DeleteProductRequest{} }
+func (m *DeleteProductRequest) String() string            { return proto.CompactTextString(m) }
+func (*DeleteProductRequest) ProtoMessage()               {}
+func (*DeleteProductRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+
+func (m *DeleteProductRequest) GetSku() string {
+	if m != nil {
+		return m.Sku
+	}
+	return ""
+}
+
+type DeleteProductResponse struct {
+	Success bool `protobuf:"varint,1,opt,name=success" json:"success,omitempty"`
+}
+
+func (m *DeleteProductResponse) Reset()                    { *m = DeleteProductResponse{} }
+func (m *DeleteProductResponse) String() string            { return proto.CompactTextString(m) }
+func (*DeleteProductResponse) ProtoMessage()               {}
+func (*DeleteProductResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
+
+func (m *DeleteProductResponse) GetSuccess() bool {
+	if m != nil {
+		return m.Success
+	}
+	return false
+}
+
+type CreateCategoryRequest struct {
+	Category *ProductCategory `protobuf:"bytes,1,opt,name=category" json:"category,omitempty"`
+}
+
+func (m *CreateCategoryRequest) Reset()                    { *m = CreateCategoryRequest{} }
+func (m *CreateCategoryRequest) String() string            { return proto.CompactTextString(m) }
+func (*CreateCategoryRequest) ProtoMessage()               {}
+func (*CreateCategoryRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
+
+func (m *CreateCategoryRequest) GetCategory() *ProductCategory {
+	if m != nil {
+		return m.Category
+	}
+	return nil
+}
+
+type CreateCategoryResponse struct {
+	Category *ProductCategory `protobuf:"bytes,1,opt,name=category" json:"category,omitempty"`
+}
+
+func (m *CreateCategoryResponse) Reset()                    { *m = CreateCategoryResponse{} }
+func (m *CreateCategoryResponse) String() string            { return proto.CompactTextString(m) }
+func (*CreateCategoryResponse) ProtoMessage()               {}
+func (*CreateCategoryResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
+
+func (m *CreateCategoryResponse) GetCategory() *ProductCategory {
+	if m != nil {
+		return m.Category
+	}
+	return nil
+}
+
+type UpdateCategoryRequest struct {
+	Category *ProductCategory `protobuf:"bytes,1,opt,name=category" json:"category,omitempty"`
+}
+
+func (m *UpdateCategoryRequest) Reset()                    { *m = UpdateCategoryRequest{} }
+func (m *UpdateCategoryRequest) String() string            { return proto.CompactTextString(m) }
+func (*UpdateCategoryRequest) ProtoMessage()               {}
+func (*UpdateCategoryRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
+
+func (m *UpdateCategoryRequest) GetCategory() *ProductCategory {
+	if m != nil {
+		return m.Category
+	}
+	return nil
+}
+
+type UpdateCategoryResponse struct {
+	Category *ProductCategory `protobuf:"bytes,1,opt,name=category" json:"category,omitempty"`
+}
+
+func (m *UpdateCategoryResponse) Reset()                    { *m = UpdateCategoryResponse{} }
+func (m *UpdateCategoryResponse) String() string            { return proto.CompactTextString(m) }
+func (*UpdateCategoryResponse) ProtoMessage()               {}
+func (*UpdateCategoryResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
+
+func (m *UpdateCategoryResponse) GetCategory() *ProductCategory {
+	if m != nil {
+		return m.Category
+	}
+	return nil
+}
+
+type DeleteCategoryRequest struct {
+	CategoryId uint64 `protobuf:"varint,1,opt,name=category_id,json=categoryId" json:"category_id,omitempty"`
+}
+
+func (m *DeleteCategoryRequest) Reset()                    { *m = DeleteCategoryRequest{} }
+func (m *DeleteCategoryRequest) String() string            { return proto.CompactTextString(m) }
+func (*DeleteCategoryRequest) ProtoMessage()               {}
+func (*DeleteCategoryRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
+
+func (m *DeleteCategoryRequest) GetCategoryId() uint64 {
+	if m != nil {
+		return m.CategoryId
+	}
+	return 0
+}
+
+type DeleteCategoryResponse struct {
+	Success bool `protobuf:"varint,1,opt,name=success" json:"success,omitempty"`
+}
+
+func (m *DeleteCategoryResponse) Reset()                    { *m = DeleteCategoryResponse{} }
+func (m *DeleteCategoryResponse) String() string            { return proto.CompactTextString(m) }
+func (*DeleteCategoryResponse) ProtoMessage()               {}
+func (*DeleteCategoryResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
+
+func (m *DeleteCategoryResponse) GetSuccess() bool {
+	if m != nil {
+		return m.Success
+	}
+	return false
+}
+
+type AssignProductRequest struct {
+	Sku        string `protobuf:"bytes,1,opt,name=sku" json:"sku,omitempty"`
+	CategoryId uint64 `protobuf:"varint,2,opt,name=category_id,json=categoryId" json:"category_id,omitempty"`
+}
+
+func (m *AssignProductRequest) Reset()                    { *m = AssignProductRequest{} }
+func (m *AssignProductRequest) String() string            { return proto.CompactTextString(m) }
+func (*AssignProductRequest) ProtoMessage()               {}
+func (*AssignProductRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
+
+func (m *AssignProductRequest) GetSku() string {
+	if m != nil {
+		return m.Sku
+	}
+	return ""
+}
+
+func (m *AssignProductRequest) GetCategoryId() uint64 {
+	if m != nil {
+		return m.CategoryId
+	}
+	return 0
+}
+
+type AssignProductResponse struct {
+	Success bool `protobuf:"varint,1,opt,name=success" json:"success,omitempty"`
+}
+
+func (m *AssignProductResponse) Reset()                    { *m = AssignProductResponse{} }
+func (m *AssignProductResponse) String() string            { return proto.CompactTextString(m) }
+func (*AssignProductResponse) ProtoMessage()               {}
+func (*AssignProductResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
+
+func (m *AssignProductResponse) GetSuccess() bool {
+	if m != nil {
+		return m.Success
+	}
+	return false
+}
+
+type SchedulePriceRequest struct {
+	Sku      string `protobuf:"bytes,1,opt,name=sku" json:"sku,omitempty"`
+	Price    *Money `protobuf:"bytes,2,opt,name=price" json:"price,omitempty"`
+	StartsAt int64  `protobuf:"varint,3,opt,name=starts_at,json=startsAt" json:"starts_at,omitempty"`
+	EndsAt   int64  `protobuf:"varint,4,opt,name=ends_at,json=endsAt" json:"ends_at,omitempty"`
+}
+
+func (m *SchedulePriceRequest) Reset()                    { *m = SchedulePriceRequest{} }
+func (m *SchedulePriceRequest) String() string            { return proto.CompactTextString(m) }
+func (*SchedulePriceRequest) ProtoMessage()               {}
+func (*SchedulePriceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
+
+func (m *SchedulePriceRequest) GetSku() string {
+	if m != nil {
+		return m.Sku
+	}
+	return ""
+}
+
+func (m *SchedulePriceRequest) GetPrice() *Money {
+	if m != nil {
+		return m.Price
+	}
+	return nil
+}
+
+func (m *SchedulePriceRequest) GetStartsAt() int64 {
+	if m != nil {
+		return m.StartsAt
+	}
+	return 0
+}
+
+func (m *SchedulePriceRequest) GetEndsAt() int64 {
+	if m != nil {
+		return m.EndsAt
+	}
+	return 0
+}
+
+type SchedulePriceResponse struct {
+	PriceChange *PriceChange `protobuf:"bytes,1,opt,name=price_change,json=priceChange" json:"price_change,omitempty"`
+}
+
+func (m *SchedulePriceResponse) Reset()                    { *m = SchedulePriceResponse{} }
+func (m *SchedulePriceResponse) String() string            { return proto.CompactTextString(m) }
+func (*SchedulePriceResponse) ProtoMessage()               {}
+func (*SchedulePriceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{37} }
+
+func (m *SchedulePriceResponse) GetPriceChange() *PriceChange {
+	if m != nil {
+		return m.PriceChange
+	}
+	return nil
+}
+
+type CancelScheduledPriceRequest struct {
+	Sku           string `protobuf:"bytes,1,opt,name=sku" json:"sku,omitempty"`
+	PriceChangeId uint64 `protobuf:"varint,2,opt,name=price_change_id,json=priceChangeId" json:"price_change_id,omitempty"`
+}
+
+func (m *CancelScheduledPriceRequest) Reset()                    { *m = CancelScheduledPriceRequest{} }
+func (m *CancelScheduledPriceRequest) String() string            { return proto.CompactTextString(m) }
+func (*CancelScheduledPriceRequest) ProtoMessage()               {}
+func (*CancelScheduledPriceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{38} }
+
+func (m *CancelScheduledPriceRequest) GetSku() string {
+	if m != nil {
+		return m.Sku
+	}
+	return ""
+}
+
+func (m *CancelScheduledPriceRequest) GetPriceChangeId() uint64 {
+	if m != nil {
+		return m.PriceChangeId
+	}
+	return 0
+}
+
+type CancelScheduledPriceResponse struct {
+	Success bool `protobuf:"varint,1,opt,name=success" json:"success,omitempty"`
+}
+
+func (m *CancelScheduledPriceResponse) Reset()                    { *m = CancelScheduledPriceResponse{} }
+func (m *CancelScheduledPriceResponse) String() string            { return proto.CompactTextString(m) }
+func (*CancelScheduledPriceResponse) ProtoMessage()               {}
+func (*CancelScheduledPriceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{39} }
+
+func (m *CancelScheduledPriceResponse) GetSuccess() bool {
+	if m != nil {
+		return m.Success
+	}
+	return false
+}
+
+type RelatedLinkRequest struct {
+	Sku        string `protobuf:"bytes,1,opt,name=sku" json:"sku,omitempty"`
+	RelatedSku string `protobuf:"bytes,2,opt,name=related_sku,json=relatedSku" json:"related_sku,omitempty"`
+}
+
+func (m *RelatedLinkRequest) Reset()                    { *m = RelatedLinkRequest{} }
+func (m *RelatedLinkRequest) String() string            { return proto.CompactTextString(m) }
+func (*RelatedLinkRequest) ProtoMessage()               {}
+func (*RelatedLinkRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{40} }
+
+func (m *RelatedLinkRequest) GetSku() string {
+	if m != nil {
+		return m.Sku
+	}
+	return ""
+}
+
+func (m *RelatedLinkRequest) GetRelatedSku() string {
+	if m != nil {
+		return m.RelatedSku
+	}
+	return ""
+}
+
+type RelatedLinkResponse struct {
+	Success bool `protobuf:"varint,1,opt,name=success" json:"success,omitempty"`
+}
+
+func (m *RelatedLinkResponse) Reset()                    { *m = RelatedLinkResponse{} }
+func (m *RelatedLinkResponse) String() string            { return proto.CompactTextString(m) }
+func (*RelatedLinkResponse) ProtoMessage()               {}
+func (*RelatedLinkResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{41} }
+
+func (m *RelatedLinkResponse) GetSuccess() bool {
+	if m != nil {
+		return m.Success
+	}
+	return false
+}
+
+type ModerateReviewRequest struct {
+	ReviewId uint64       `protobuf:"varint,1,opt,name=review_id,json=reviewId" json:"review_id,omitempty"`
+	Status   ReviewStatus `protobuf:"varint,2,opt,name=status,enum=catalog.ReviewStatus" json:"status,omitempty"`
+}
+
+func (m *ModerateReviewRequest) Reset()                    { *m = ModerateReviewRequest{} }
+func (m *ModerateReviewRequest) String() string            { return proto.CompactTextString(m) }
+func (*ModerateReviewRequest) ProtoMessage()               {}
+func (*ModerateReviewRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{42} }
+
+func (m *ModerateReviewRequest) GetReviewId() uint64 {
+	if m != nil {
+		return m.ReviewId
+	}
+	return 0
+}
+
+func (m *ModerateReviewRequest) GetStatus() ReviewStatus {
+	if m != nil {
+		return m.Status
+	}
+	return ReviewStatus_RS_UNKNOWN
+}
+
+type ModerateReviewResponse struct {
+	Success bool `protobuf:"varint,1,opt,name=success" json:"success,omitempty"`
+}
+
+func (m *ModerateReviewResponse) Reset()                    { *m = ModerateReviewResponse{} }
+func (m *ModerateReviewResponse) String() string            { return proto.CompactTextString(m) }
+func (*ModerateReviewResponse) ProtoMessage()               {}
+func (*ModerateReviewResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{43} }
+
+func (m *ModerateReviewResponse) GetSuccess() bool {
+	if m != nil {
+		return m.Success
+	}
+	return false
+}
+
+type PendingReviewsRequest struct {
+	PageSize uint32 `protobuf:"varint,1,opt,name=page_size,json=pageSize" json:"page_size,omitempty"`
+	Cursor   string `protobuf:"bytes,2,opt,name=cursor" json:"cursor,omitempty"`
+}
+
+func (m *PendingReviewsRequest) Reset()                    { *m = PendingReviewsRequest{} }
+func (m *PendingReviewsRequest) String() string            { return proto.CompactTextString(m) }
+func (*PendingReviewsRequest) ProtoMessage()               {}
+func (*PendingReviewsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{44} }
+
+func (m *PendingReviewsRequest) GetPageSize() uint32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *PendingReviewsRequest) GetCursor() string {
+	if m != nil {
+		return m.Cursor
+	}
+	return ""
+}
+
+type PendingReviewsResponse struct {
+	Reviews        []*Review `protobuf:"bytes,1,rep,name=reviews" json:"reviews,omitempty"`
+	NextPageCursor string    `protobuf:"bytes,2,opt,name=next_page_cursor,json=nextPageCursor" json:"next_page_cursor,omitempty"`
+	TotalCount     uint32    `protobuf:"varint,3,opt,name=total_count,json=totalCount" json:"total_count,omitempty"`
+}
+
+func (m *PendingReviewsResponse) Reset()                    { *m = PendingReviewsResponse{} }
+func (m *PendingReviewsResponse) String() string            { return proto.CompactTextString(m) }
+func (*PendingReviewsResponse) ProtoMessage()               {}
+func (*PendingReviewsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{45} }
+
+func (m *PendingReviewsResponse) GetReviews() []*Review {
+	if m != nil {
+		return m.Reviews
+	}
+	return nil
+}
+
+func (m *PendingReviewsResponse) GetNextPageCursor() string {
+	if m != nil {
+		return m.NextPageCursor
+	}
+	return ""
+}
+
+func (m *PendingReviewsResponse) GetTotalCount() uint32 {
+	if m != nil {
+		return m.TotalCount
+	}
+	return 0
+}
+
+type PriceHistoryRequest struct {
+	Sku  string `protobuf:"bytes,1,opt,name=sku" json:"sku,omitempty"`
+	From int64  `protobuf:"varint,2,opt,name=from" json:"from,omitempty"`
+	To   int64  `protobuf:"varint,3,opt,name=to" json:"to,omitempty"`
+}
+
+func (m *PriceHistoryRequest) Reset()                    { *m = PriceHistoryRequest{} }
+func (m *PriceHistoryRequest) String() string            { return proto.CompactTextString(m) }
+func (*PriceHistoryRequest) ProtoMessage()               {}
+func (*PriceHistoryRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{46} }
+
+func (m *PriceHistoryRequest) GetSku() string {
+	if m != nil {
+		return m.Sku
+	}
+	return ""
+}
+
+func (m *PriceHistoryRequest) GetFrom() int64 {
+	if m != nil {
+		return m.From
+	}
+	return 0
+}
+
+func (m *PriceHistoryRequest) GetTo() int64 {
+	if m != nil {
+		return m.To
+	}
+	return 0
+}
+
+type PriceHistoryResponse struct {
+	PriceChanges []*PriceChange `protobuf:"bytes,1,rep,name=price_changes,json=priceChanges" json:"price_changes,omitempty"`
+}
+
+func (m *PriceHistoryResponse) Reset()                    { *m = PriceHistoryResponse{} }
+func (m *PriceHistoryResponse) String() string            { return proto.CompactTextString(m) }
+func (*PriceHistoryResponse) ProtoMessage()               {}
+func (*PriceHistoryResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{47} }
+
+func (m *PriceHistoryResponse) GetPriceChanges() []*PriceChange {
+	if m != nil {
+		return m.PriceChanges
+	}
+	return nil
+}
+
+type ProductChangedEvent struct {
+	Sku           string            `protobuf:"bytes,1,opt,name=sku" json:"sku,omitempty"`
+	ChangeType    ProductChangeType `protobuf:"varint,2,opt,name=change_type,json=changeType,enum=catalog.ProductChangeType" json:"change_type,omitempty"`
+	Product       *Product          `protobuf:"bytes,3,opt,name=product" json:"product,omitempty"`
+	PreviousPrice *Money            `protobuf:"bytes,6,opt,name=previous_price,json=previousPrice" json:"previous_price,omitempty"`
+	Timestamp     int64             `protobuf:"varint,5,opt,name=timestamp" json:"timestamp,omitempty"`
+}
+
+func (m *ProductChangedEvent) Reset()                    { *m = ProductChangedEvent{} }
+func (m *ProductChangedEvent) String() string            { return proto.CompactTextString(m) }
+func (*ProductChangedEvent) ProtoMessage()               {}
+func (*ProductChangedEvent) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{48} }
+
+func (m *ProductChangedEvent) GetSku() string {
+	if m != nil {
+		return m.Sku
+	}
+	return ""
+}
+
+func (m *ProductChangedEvent) GetChangeType() ProductChangeType {
+	if m != nil {
+		return m.ChangeType
+	}
+	return ProductChangeType_PC_UNKNOWN
+}
+
+func (m *ProductChangedEvent) GetProduct() *Product {
+	if m != nil {
+		return m.Product
+	}
+	return nil
+}
+
+func (m *ProductChangedEvent) GetPreviousPrice() *Money {
+	if m != nil {
+		return m.PreviousPrice
+	}
+	return nil
+}
+
+func (m *ProductChangedEvent) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+type Product struct {
+	Sku            string              `protobuf:"bytes,1,opt,name=sku" json:"sku,omitempty"`
+	Name           string              `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	Description    string              `protobuf:"bytes,3,opt,name=description" json:"description,omitempty"`
+	Manufacturer   string              `protobuf:"bytes,4,opt,name=manufacturer" json:"manufacturer,omitempty"`
+	Model          string              `protobuf:"bytes,5,opt,name=model" json:"model,omitempty"`
+	Price          *Money              `protobuf:"bytes,9,opt,name=price" json:"price,omitempty"`
+	ParentSku      string              `protobuf:"bytes,7,opt,name=parent_sku,json=parentSku" json:"parent_sku,omitempty"`
+	Attributes     []*ProductAttribute `protobuf:"bytes,8,rep,name=attributes" json:"attributes,omitempty"`
+	Media          []*MediaAsset       `protobuf:"bytes,10,rep,name=media" json:"media,omitempty"`
+	Status         ProductStatus       `protobuf:"varint,11,opt,name=status,enum=catalog.ProductStatus" json:"status,omitempty"`
+	Translations   []*Translation      `protobuf:"bytes,12,rep,name=translations" json:"translations,omitempty"`
+	Kind           ProductKind         `protobuf:"varint,13,opt,name=kind,enum=catalog.ProductKind" json:"kind,omitempty"`
+	Components     []*BundleComponent  `protobuf:"bytes,14,rep,name=components" json:"components,omitempty"`
+	Specifications []*Specification    `protobuf:"bytes,15,rep,name=specifications" json:"specifications,omitempty"`
+	ScheduledPrice *Money              `protobuf:"bytes,16,opt,name=scheduled_price,json=scheduledPrice" json:"scheduled_price,omitempty"`
+}
+
+func (m *Product) Reset()                    { *m = Product{} }
+func (m *Product) String() string            { return proto.CompactTextString(m) }
+func (*Product) ProtoMessage()               {}
+func (*Product) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{49} }
+
+func (m *Product) GetSku() string {
+	if m != nil {
+		return m.Sku
+	}
+	return ""
+}
+
+func (m *Product) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Product) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *Product) GetManufacturer() string {
+	if m != nil {
+		return m.Manufacturer
+	}
+	return ""
+}
+
+func (m *Product) GetModel() string {
+	if m != nil {
+		return m.Model
+	}
+	return ""
+}
+
+func (m *Product) GetPrice() *Money {
+	if m != nil {
+		return m.Price
+	}
+	return nil
+}
+
+func (m *Product) GetParentSku() string {
+	if m != nil {
+		return m.ParentSku
+	}
+	return ""
+}
+
+func (m *Product) GetAttributes() []*ProductAttribute {
+	if m != nil {
+		return m.Attributes
+	}
+	return nil
+}
+
+func (m *Product) GetMedia() []*MediaAsset {
+	if m != nil {
+		return m.Media
+	}
+	return nil
+}
+
+func (m *Product) GetStatus() ProductStatus {
+	if m != nil {
+		return m.Status
+	}
+	return ProductStatus_PS_ACTIVE
+}
+
+func (m *Product) GetTranslations() []*Translation {
+	if m != nil {
+		return m.Translations
+	}
+	return nil
+}
+
+func (m *Product) GetKind() ProductKind {
+	if m != nil {
+		return m.Kind
+	}
+	return ProductKind_PK_STANDARD
+}
+
+func (m *Product) GetComponents() []*BundleComponent {
+	if m != nil {
+		return m.Components
+	}
+	return nil
+}
+
+func (m *Product) GetSpecifications() []*Specification {
+	if m != nil {
+		return m.Specifications
+	}
+	return nil
+}
+
+func (m *Product) GetScheduledPrice() *Money {
+	if m != nil {
+		return m.ScheduledPrice
+	}
+	return nil
+}
+
+type BundleComponent struct {
+	Sku      string `protobuf:"bytes,1,opt,name=sku" json:"sku,omitempty"`
+	Quantity uint32 `protobuf:"varint,2,opt,name=quantity" json:"quantity,omitempty"`
+}
+
+func (m *BundleComponent) Reset()                    { *m = BundleComponent{} }
+func (m *BundleComponent) String() string            { return proto.CompactTextString(m) }
+func (*BundleComponent) ProtoMessage()               {}
+func (*BundleComponent) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{50} }
+
+func (m *BundleComponent) GetSku() string {
+	if m != nil {
+		return m.Sku
+	}
+	return ""
+}
+
+func (m *BundleComponent) GetQuantity() uint32 {
+	if m != nil {
+		return m.Quantity
+	}
+	return 0
+}
+
+type RelatedProduct struct {
+	Product             *Product   `protobuf:"bytes,1,opt,name=product" json:"product,omitempty"`
+	Relations           []Relation `protobuf:"varint,2,rep,packed,name=relations,enum=catalog.Relation" json:"relations,omitempty"`
+	BoughtTogetherCount uint32     `protobuf:"varint,3,opt,name=bought_together_count,json=boughtTogetherCount" json:"bought_together_count,omitempty"`
+}
+
+func (m *RelatedProduct) Reset()                    { *m = RelatedProduct{} }
+func (m *RelatedProduct) String() string            { return proto.CompactTextString(m) }
+func (*RelatedProduct) ProtoMessage()               {}
+func (*RelatedProduct) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{51} }
+
+func (m *RelatedProduct) GetProduct() *Product {
+	if m != nil {
+		return m.Product
+	}
+	return nil
+}
+
+func (m *RelatedProduct) GetRelations() []Relation {
+	if m != nil {
+		return m.Relations
+	}
+	return nil
+}
+
+func (m *RelatedProduct) GetBoughtTogetherCount() uint32 {
+	if m != nil {
+		return m.BoughtTogetherCount
+	}
+	return 0
+}
+
+type Money struct {
+	Amount       int64  `protobuf:"varint,1,opt,name=amount" json:"amount,omitempty"`
+	CurrencyCode string `protobuf:"bytes,2,opt,name=currency_code,json=currencyCode" json:"currency_code,omitempty"`
+}
+
+func (m *Money) Reset()                    { *m = Money{} }
+func (m *Money) String() string            { return proto.CompactTextString(m) }
+func (*Money) ProtoMessage()               {}
+func (*Money) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{52} }
+
+func (m *Money) GetAmount() int64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *Money) GetCurrencyCode() string {
+	if m != nil {
+		return m.CurrencyCode
+	}
+	return ""
+}
+
+type PriceChange struct {
+	PriceChangeId uint64    `protobuf:"varint,1,opt,name=price_change_id,json=priceChangeId" json:"price_change_id,omitempty"`
+	Sku           string    `protobuf:"bytes,2,opt,name=sku" json:"sku,omitempty"`
+	PriceType     PriceType `protobuf:"varint,3,opt,name=price_type,json=priceType,enum=catalog.PriceType" json:"price_type,omitempty"`
+	Price         *Money    `protobuf:"bytes,4,opt,name=price" json:"price,omitempty"`
+	StartsAt      int64     `protobuf:"varint,5,opt,name=starts_at,json=startsAt" json:"starts_at,omitempty"`
+	EndsAt        int64     `protobuf:"varint,6,opt,name=ends_at,json=endsAt" json:"ends_at,omitempty"`
+	CreatedAt     int64     `protobuf:"varint,7,opt,name=created_at,json=createdAt" json:"created_at,omitempty"`
+	CancelledAt   int64     `protobuf:"varint,8,opt,name=cancelled_at,json=cancelledAt" json:"cancelled_at,omitempty"`
+}
+
+func (m *PriceChange) Reset()                    { *m = PriceChange{} }
+func (m *PriceChange) String() string            { return proto.CompactTextString(m) }
+func (*PriceChange) ProtoMessage()               {}
+func (*PriceChange) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{53} }
+
+func (m *PriceChange) GetPriceChangeId() uint64 {
+	if m != nil {
+		return m.PriceChangeId
+	}
+	return 0
+}
+
+func (m *PriceChange) GetSku() string {
+	if m != nil {
+		return m.Sku
+	}
+	return ""
+}
+
+func (m *PriceChange) GetPriceType() PriceType {
+	if m != nil {
+		return m.PriceType
+	}
+	return PriceType_PT_UNKNOWN
+}
+
+func (m *PriceChange) GetPrice() *Money {
+	if m != nil {
+		return m.Price
+	}
+	return nil
+}
+
+func (m *PriceChange) GetStartsAt() int64 {
+	if m != nil {
+		return m.StartsAt
+	}
+	return 0
+}
+
+func (m *PriceChange) GetEndsAt() int64 {
+	if m != nil {
+		return m.EndsAt
+	}
+	return 0
+}
+
+func (m *PriceChange) GetCreatedAt() int64 {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return 0
+}
+
+func (m *PriceChange) GetCancelledAt() int64 {
+	if m != nil {
+		return m.CancelledAt
+	}
+	return 0
+}
+
+type Review struct {
+	ReviewId       uint64       `protobuf:"varint,1,opt,name=review_id,json=reviewId" json:"review_id,omitempty"`
+	Sku            string       `protobuf:"bytes,2,opt,name=sku" json:"sku,omitempty"`
+	CustomerId     string       `protobuf:"bytes,3,opt,name=customer_id,json=customerId" json:"customer_id,omitempty"`
+	Rating         uint32       `protobuf:"varint,4,opt,name=rating" json:"rating,omitempty"`
+	Title          string       `protobuf:"bytes,5,opt,name=title" json:"title,omitempty"`
+	Body           string       `protobuf:"bytes,6,opt,name=body" json:"body,omitempty"`
+	Status         ReviewStatus `protobuf:"varint,7,opt,name=status,enum=catalog.ReviewStatus" json:"status,omitempty"`
+	CreatedAt      int64        `protobuf:"varint,8,opt,name=created_at,json=createdAt" json:"created_at,omitempty"`
+	ModeratedAt    int64        `protobuf:"varint,9,opt,name=moderated_at,json=moderatedAt" json:"moderated_at,omitempty"`
+	HelpfulVotes   uint32       `protobuf:"varint,10,opt,name=helpful_votes,json=helpfulVotes" json:"helpful_votes,omitempty"`
+	UnhelpfulVotes uint32       `protobuf:"varint,11,opt,name=unhelpful_votes,json=unhelpfulVotes" json:"unhelpful_votes,omitempty"`
+}
+
+func (m *Review) Reset()                    { *m = Review{} }
+func (m *Review) String() string            { return proto.CompactTextString(m) }
+func (*Review) ProtoMessage()               {}
+func (*Review) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{54} }
+
+func (m *Review) GetReviewId() uint64 {
+	if m != nil {
+		return m.ReviewId
+	}
+	return 0
+}
+
+func (m *Review) GetSku() string {
+	if m != nil {
+		return m.Sku
+	}
+	return ""
+}
+
+func (m *Review) GetCustomerId() string {
+	if m != nil {
+		return m.CustomerId
+	}
+	return ""
+}
+
+func (m *Review) GetRating() uint32 {
+	if m != nil {
+		return m.Rating
+	}
+	return 0
+}
+
+func (m *Review) GetTitle() string {
+	if m != nil {
+		return m.Title
+	}
+	return ""
+}
+
+func (m *Review) GetBody() string {
+	if m != nil {
+		return m.Body
+	}
+	return ""
+}
+
+func (m *Review) GetStatus() ReviewStatus {
+	if m != nil {
+		return m.Status
+	}
+	return ReviewStatus_RS_UNKNOWN
+}
+
+func (m *Review) GetCreatedAt() int64 {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return 0
+}
+
+func (m *Review) GetModeratedAt() int64 {
+	if m != nil {
+		return m.ModeratedAt
+	}
+	return 0
+}
+
+func (m *Review) GetHelpfulVotes() uint32 {
+	if m != nil {
+		return m.HelpfulVotes
+	}
+	return 0
+}
+
+func (m *Review) GetUnhelpfulVotes() uint32 {
+	if m != nil {
+		return m.UnhelpfulVotes
+	}
+	return 0
+}
+
+type RatingSummary struct {
+	AverageRating float64  `protobuf:"fixed64,1,opt,name=average_rating,json=averageRating" json:"average_rating,omitempty"`
+	ReviewCount   uint32   `protobuf:"varint,2,opt,name=review_count,json=reviewCount" json:"review_count,omitempty"`
+	Histogram     []uint32 `protobuf:"varint,3,rep,packed,name=histogram" json:"histogram,omitempty"`
+}
+
+func (m *RatingSummary) Reset()                    { *m = RatingSummary{} }
+func (m *RatingSummary) String() string            { return proto.CompactTextString(m) }
+func (*RatingSummary) ProtoMessage()               {}
+func (*RatingSummary) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{55} }
+
+func (m *RatingSummary) GetAverageRating() float64 {
+	if m != nil {
+		return m.AverageRating
+	}
+	return 0
+}
+
+func (m *RatingSummary) GetReviewCount() uint32 {
+	if m != nil {
+		return m.ReviewCount
+	}
+	return 0
+}
+
+func (m *RatingSummary) GetHistogram() []uint32 {
+	if m != nil {
+		return m.Histogram
+	}
+	return nil
+}
+
+// Translation replaces a product's or category's name and description in one locale. Either may be
+// left empty to fall back to a more general locale for that text alone.
+type Translation struct {
+	Locale      string `protobuf:"bytes,1,opt,name=locale" json:"locale,omitempty"`
+	Name        string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	Description string `protobuf:"bytes,3,opt,name=description" json:"description,omitempty"`
+}
+
+func (m *Translation) Reset()                    { *m = Translation{} }
+func (m *Translation) String() string            { return proto.CompactTextString(m) }
+func (*Translation) ProtoMessage()               {}
+func (*Translation) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{56} }
+
+func (m *Translation) GetLocale() string {
+	if m != nil {
+		return m.Locale
+	}
+	return ""
+}
+
+func (m *Translation) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Translation) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+// Specification is a typed value describing a product, e.g. a wattage of 1200 or a screen size of 55.
+// Only the value field matching its type is set.
+type Specification struct {
+	Name         string            `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Type         SpecificationType `protobuf:"varint,2,opt,name=type,enum=catalog.SpecificationType" json:"type,omitempty"`
+	TextValue    string            `protobuf:"bytes,3,opt,name=text_value,json=textValue" json:"text_value,omitempty"`
+	NumberValue  float64           `protobuf:"fixed64,4,opt,name=number_value,json=numberValue" json:"number_value,omitempty"`
+	BooleanValue bool              `protobuf:"varint,5,opt,name=boolean_value,json=booleanValue" json:"boolean_value,omitempty"`
+}
+
+func (m *Specification) Reset()                    { *m = Specification{} }
+func (m *Specification) String() string            { return proto.CompactTextString(m) }
+func (*Specification) ProtoMessage()               {}
+func (*Specification) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{57} }
+
+func (m *Specification) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Specification) GetType() SpecificationType {
+	if m != nil {
+		return m.Type
+	}
+	return SpecificationType_ST_UNKNOWN
+}
+
+func (m *Specification) GetTextValue() string {
+	if m != nil {
+		return m.TextValue
+	}
+	return ""
+}
+
+func (m *Specification) GetNumberValue() float64 {
+	if m != nil {
+		return m.NumberValue
+	}
+	return 0
+}
+
+func (m *Specification) GetBooleanValue() bool {
+	if m != nil {
+		return m.BooleanValue
+	}
+	return false
+}
+
+// SpecificationDefinition describes a specification of the products in a category. Products are checked
+// against the schemas of their categories, and their parent's categories, whenever they are saved.
+type SpecificationDefinition struct {
+	Name     string            `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Type     SpecificationType `protobuf:"varint,2,opt,name=type,enum=catalog.SpecificationType" json:"type,omitempty"`
+	Unit     string            `protobuf:"bytes,3,opt,name=unit" json:"unit,omitempty"`
+	Required bool              `protobuf:"varint,4,opt,name=required" json:"required,omitempty"`
+}
+
+func (m *SpecificationDefinition) Reset()                    { *m = SpecificationDefinition{} }
+func (m *SpecificationDefinition) String() string            { return proto.CompactTextString(m) }
+func (*SpecificationDefinition) ProtoMessage()               {}
+func (*SpecificationDefinition) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{58} }
+
+func (m *SpecificationDefinition) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *SpecificationDefinition) GetType() SpecificationType {
+	if m != nil {
+		return m.Type
+	}
+	return SpecificationType_ST_UNKNOWN
+}
+
+func (m *SpecificationDefinition) GetUnit() string {
+	if m != nil {
+		return m.Unit
+	}
+	return ""
+}
+
+func (m *SpecificationDefinition) GetRequired() bool {
+	if m != nil {
+		return m.Required
+	}
+	return false
+}
+
+type SpecificationFilter struct {
+	Name   string       `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Values []string     `protobuf:"bytes,2,rep,name=values" json:"values,omitempty"`
+	Range  *NumberRange `protobuf:"bytes,3,opt,name=range" json:"range,omitempty"`
+}
+
+func (m *SpecificationFilter) Reset()                    { *m = SpecificationFilter{} }
+func (m *SpecificationFilter) String() string            { return proto.CompactTextString(m) }
+func (*SpecificationFilter) ProtoMessage()               {}
+func (*SpecificationFilter) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{59} }
+
+func (m *SpecificationFilter) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *SpecificationFilter) GetValues() []string {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
+func (m *SpecificationFilter) GetRange() *NumberRange {
+	if m != nil {
+		return m.Range
+	}
+	return nil
+}
+
+type NumberRange struct {
+	Min float64 `protobuf:"fixed64,1,opt,name=min" json:"min,omitempty"`
+	Max float64 `protobuf:"fixed64,2,opt,name=max" json:"max,omitempty"`
+}
+
+func (m *NumberRange) Reset()                    { *m = NumberRange{} }
+func (m *NumberRange) String() string            { return proto.CompactTextString(m) }
+func (*NumberRange) ProtoMessage()               {}
+func (*NumberRange) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{60} }
+
+func (m *NumberRange) GetMin() float64 {
+	if m != nil {
+		return m.Min
+	}
+	return 0
+}
+
+func (m *NumberRange) GetMax() float64 {
+	if m != nil {
+		return m.Max
+	}
+	return 0
+}
+
+type ProductAttribute struct {
+	Name  string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Value string `protobuf:"bytes,2,opt,name=value" json:"value,omitempty"`
+}
+
+func (m *ProductAttribute) Reset()                    { *m = ProductAttribute{} }
+func (m *ProductAttribute) String() string            { return proto.CompactTextString(m) }
+func (*ProductAttribute) ProtoMessage()               {}
+func (*ProductAttribute) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{61} }
+
+func (m *ProductAttribute) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ProductAttribute) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+type MediaAsset struct {
+	Url       string    `protobuf:"bytes,1,opt,name=url" json:"url,omitempty"`
+	AltText   string    `protobuf:"bytes,2,opt,name=alt_text,json=altText" json:"alt_text,omitempty"`
+	MediaType MediaType `protobuf:"varint,3,opt,name=media_type,json=mediaType,enum=catalog.MediaType" json:"media_type,omitempty"`
+	Width     uint32    `protobuf:"varint,4,opt,name=width" json:"width,omitempty"`
+	Height    uint32    `protobuf:"varint,5,opt,name=height" json:"height,omitempty"`
+}
+
+func (m *MediaAsset) Reset()                    { *m = MediaAsset{} }
+func (m *MediaAsset) String() string            { return proto.CompactTextString(m) }
+func (*MediaAsset) ProtoMessage()               {}
+func (*MediaAsset) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{62} }
+
+func (m *MediaAsset) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
+}
+
+func (m *MediaAsset) GetAltText() string {
+	if m != nil {
+		return m.AltText
+	}
+	return ""
+}
+
+func (m *MediaAsset) GetMediaType() MediaType {
+	if m != nil {
+		return m.MediaType
+	}
+	return MediaType_MT_UNKNOWN
+}
+
+func (m *MediaAsset) GetWidth() uint32 {
+	if m != nil {
+		return m.Width
+	}
+	return 0
+}
+
+func (m *MediaAsset) GetHeight() uint32 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+type SearchHit struct {
+	Sku        string       `protobuf:"bytes,1,opt,name=sku" json:"sku,omitempty"`
+	Score      float64      `protobuf:"fixed64,2,opt,name=score" json:"score,omitempty"`
+	Highlights []*Highlight `protobuf:"bytes,3,rep,name=highlights" json:"highlights,omitempty"`
+}
+
+func (m *SearchHit) Reset()                    { *m = SearchHit{} }
+func (m *SearchHit) String() string            { return proto.CompactTextString(m) }
+func (*SearchHit) ProtoMessage()               {}
+func (*SearchHit) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{63} }
+
+func (m *SearchHit) GetSku() string {
+	if m != nil {
+		return m.Sku
+	}
+	return ""
+}
+
+func (m *SearchHit) GetScore() float64 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+func (m *SearchHit) GetHighlights() []*Highlight {
+	if m != nil {
+		return m.Highlights
+	}
+	return nil
+}
+
+type Highlight struct {
+	Field   string `protobuf:"bytes,1,opt,name=field" json:"field,omitempty"`
+	Snippet string `protobuf:"bytes,2,opt,name=snippet" json:"snippet,omitempty"`
+}
+
+func (m *Highlight) Reset()                    { *m = Highlight{} }
+func (m *Highlight) String() string            { return proto.CompactTextString(m) }
+func (*Highlight) ProtoMessage()               {}
+func (*Highlight) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{64} }
+
+func (m *Highlight) GetField() string {
+	if m != nil {
+		return m.Field
+	}
+	return ""
+}
+
+func (m *Highlight) GetSnippet() string {
+	if m != nil {
+		return m.Snippet
+	}
+	return ""
+}
+
+type PriceRange struct {
+	Min          int64  `protobuf:"varint,1,opt,name=min" json:"min,omitempty"`
+	Max          int64  `protobuf:"varint,2,opt,name=max" json:"max,omitempty"`
+	CurrencyCode string `protobuf:"bytes,3,opt,name=currency_code,json=currencyCode" json:"currency_code,omitempty"`
+}
+
+func (m *PriceRange) Reset()                    { *m = PriceRange{} }
+func (m *PriceRange) String() string            { return proto.CompactTextString(m) }
+func (*PriceRange) ProtoMessage()               {}
+func (*PriceRange) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{65} }
+
+func (m *PriceRange) GetMin() int64 {
+	if m != nil {
+		return m.Min
+	}
+	return 0
+}
+
+func (m *PriceRange) GetMax() int64 {
+	if m != nil {
+		return m.Max
+	}
+	return 0
+}
+
+func (m *PriceRange) GetCurrencyCode() string {
+	if m != nil {
+		return m.CurrencyCode
+	}
+	return ""
+}
+
+type SearchFacets struct {
+	Manufacturers []*ManufacturerFacet `protobuf:"bytes,1,rep,name=manufacturers" json:"manufacturers,omitempty"`
+	Categories    []*CategoryFacet     `protobuf:"bytes,2,rep,name=categories" json:"categories,omitempty"`
+	Prices        []*PriceFacet        `protobuf:"bytes,3,rep,name=prices" json:"prices,omitempty"`
+}
+
+func (m *SearchFacets) Reset()                    { *m = SearchFacets{} }
+func (m *SearchFacets) String() string            { return proto.CompactTextString(m) }
+func (*SearchFacets) ProtoMessage()               {}
+func (*SearchFacets) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{66} }
+
+func (m *SearchFacets) GetManufacturers() []*ManufacturerFacet {
+	if m != nil {
+		return m.Manufacturers
+	}
+	return nil
+}
+
+func (m *SearchFacets) GetCategories() []*CategoryFacet {
+	if m != nil {
+		return m.Categories
+	}
+	return nil
+}
+
+func (m *SearchFacets) GetPrices() []*PriceFacet {
+	if m != nil {
+		return m.Prices
+	}
+	return nil
+}
+
+type ManufacturerFacet struct {
+	Manufacturer string `protobuf:"bytes,1,opt,name=manufacturer" json:"manufacturer,omitempty"`
+	Count        uint32 `protobuf:"varint,2,opt,name=count" json:"count,omitempty"`
+}
+
+func (m *ManufacturerFacet) Reset()                    { *m = ManufacturerFacet{} }
+func (m *ManufacturerFacet) String() string            { return proto.CompactTextString(m) }
+func (*ManufacturerFacet) ProtoMessage()               {}
+func (*ManufacturerFacet) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{67} }
+
+func (m *ManufacturerFacet) GetManufacturer() string {
+	if m != nil {
+		return m.Manufacturer
+	}
+	return ""
+}
+
+func (m *ManufacturerFacet) GetCount() uint32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+type CategoryFacet struct {
+	CategoryId uint64 `protobuf:"varint,1,opt,name=category_id,json=categoryId" json:"category_id,omitempty"`
+	Count      uint32 `protobuf:"varint,2,opt,name=count" json:"count,omitempty"`
+}
+
+func (m *CategoryFacet) Reset()                    { *m = CategoryFacet{} }
+func (m *CategoryFacet) String() string            { return proto.CompactTextString(m) }
+func (*CategoryFacet) ProtoMessage()               {}
+func (*CategoryFacet) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{68} }
+
+func (m *CategoryFacet) GetCategoryId() uint64 {
+	if m != nil {
+		return m.CategoryId
+	}
+	return 0
+}
+
+func (m *CategoryFacet) GetCount() uint32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+type PriceFacet struct {
+	Range *PriceRange `protobuf:"bytes,1,opt,name=range" json:"range,omitempty"`
+	Count uint32      `protobuf:"varint,2,opt,name=count" json:"count,omitempty"`
+}
+
+func (m *PriceFacet) Reset()                    { *m = PriceFacet{} }
+func (m *PriceFacet) String() string            { return proto.CompactTextString(m) }
+func (*PriceFacet) ProtoMessage()               {}
+func (*PriceFacet) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{69} }
+
+func (m *PriceFacet) GetRange() *PriceRange {
+	if m != nil {
+		return m.Range
+	}
+	return nil
+}
+
+func (m *PriceFacet) GetCount() uint32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+type ProductSuggestion struct {
+	Sku  string `protobuf:"bytes,1,opt,name=sku" json:"sku,omitempty"`
+	Name string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+}
+
+func (m *ProductSuggestion) Reset()                    { *m = ProductSuggestion{} }
+func (m *ProductSuggestion) String() string            { return proto.CompactTextString(m) }
+func (*ProductSuggestion) ProtoMessage()               {}
+func (*ProductSuggestion) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{70} }
+
+func (m *ProductSuggestion) GetSku() string {
+	if m != nil {
+		return m.Sku
+	}
+	return ""
+}
+
+func (m *ProductSuggestion) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type ProductCategory struct {
+	CategoryId          uint64                     `protobuf:"varint,1,opt,name=category_id,json=categoryId" json:"category_id,omitempty"`
+	Name                string                     `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	Description         string                     `protobuf:"bytes,3,opt,name=description" json:"description,omitempty"`
+	ParentId            uint64                     `protobuf:"varint,4,opt,name=parent_id,json=parentId" json:"parent_id,omitempty"`
+	Translations        []*Translation             `protobuf:"bytes,5,rep,name=translations" json:"translations,omitempty"`
+	SpecificationSchema []*SpecificationDefinition `protobuf:"bytes,6,rep,name=specification_schema,json=specificationSchema" json:"specification_schema,omitempty"`
+}
+
+func (m *ProductCategory) Reset()                    { *m = ProductCategory{} }
+func (m *ProductCategory) String() string            { return proto.CompactTextString(m) }
+func (*ProductCategory) ProtoMessage()               {}
+func (*ProductCategory) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{71} }
+
+func (m *ProductCategory) GetCategoryId() uint64 {
+	if m != nil {
+		return m.CategoryId
+	}
+	return 0
+}
+
+func (m *ProductCategory) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ProductCategory) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *ProductCategory) GetParentId() uint64 {
+	if m != nil {
+		return m.ParentId
+	}
+	return 0
+}
+
+func (m *ProductCategory) GetTranslations() []*Translation {
+	if m != nil {
+		return m.Translations
+	}
+	return nil
+}
+
+func (m *ProductCategory) GetSpecificationSchema() []*SpecificationDefinition {
+	if m != nil {
+		return m.SpecificationSchema
+	}
+	return nil
+}
+
+type CategoryNode struct {
+	Category *ProductCategory `protobuf:"bytes,1,opt,name=category" json:"category,omitempty"`
+	Children []*CategoryNode  `protobuf:"bytes,2,rep,name=children" json:"children,omitempty"`
+}
+
+func (m *CategoryNode) Reset()                    { *m = CategoryNode{} }
+func (m *CategoryNode) String() string            { return proto.CompactTextString(m) }
+func (*CategoryNode) ProtoMessage()               {}
+func (*CategoryNode) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{72} }
+
+func (m *CategoryNode) GetCategory() *ProductCategory {
+	if m != nil {
+		return m.Category
+	}
+	return nil
+}
+
+func (m *CategoryNode) GetChildren() []*CategoryNode {
+	if m != nil {
+		return m.Children
+	}
+	return nil
+}
+
+func init() {
 	proto.RegisterType((*DetailRequest)(nil), "catalog.DetailRequest")
 	proto.RegisterType((*DetailResponse)(nil), "catalog.DetailResponse")
 	proto.RegisterType((*AllCategoriesRequest)(nil), "catalog.AllCategoriesRequest")
 	proto.RegisterType((*AllCategoriesResponse)(nil), "catalog.AllCategoriesResponse")
+	proto.RegisterType((*CategoryTreeRequest)(nil), "catalog.CategoryTreeRequest")
+	proto.RegisterType((*CategoryTreeResponse)(nil), "catalog.CategoryTreeResponse")
 	proto.RegisterType((*CategoryProductsRequest)(nil), "catalog.CategoryProductsRequest")
 	proto.RegisterType((*CategoryProductsResponse)(nil), "catalog.CategoryProductsResponse")
+	proto.RegisterType((*VariantsRequest)(nil), "catalog.VariantsRequest")
+	proto.RegisterType((*VariantsResponse)(nil), "catalog.VariantsResponse")
 	proto.RegisterType((*SearchRequest)(nil), "catalog.SearchRequest")
 	proto.RegisterType((*SearchResponse)(nil), "catalog.SearchResponse")
+	proto.RegisterType((*RelatedRequest)(nil), "catalog.RelatedRequest")
+	proto.RegisterType((*RelatedResponse)(nil), "catalog.RelatedResponse")
+	proto.RegisterType((*SubmitReviewRequest)(nil), "catalog.SubmitReviewRequest")
+	proto.RegisterType((*SubmitReviewResponse)(nil), "catalog.SubmitReviewResponse")
+	proto.RegisterType((*ReviewsRequest)(nil), "catalog.ReviewsRequest")
+	proto.RegisterType((*ReviewsResponse)(nil), "catalog.ReviewsResponse")
+	proto.RegisterType((*ReviewVoteRequest)(nil), "catalog.ReviewVoteRequest")
+	proto.RegisterType((*ReviewVoteResponse)(nil), "catalog.ReviewVoteResponse")
+	proto.RegisterType((*SuggestRequest)(nil), "catalog.SuggestRequest")
+	proto.RegisterType((*SuggestResponse)(nil), "catalog.SuggestResponse")
+	proto.RegisterType((*CreateProductRequest)(nil), "catalog.CreateProductRequest")
+	proto.RegisterType((*CreateProductResponse)(nil), "catalog.CreateProductResponse")
+	proto.RegisterType((*UpdateProductRequest)(nil), "catalog.UpdateProductRequest")
+	proto.RegisterType((*UpdateProductResponse)(nil), "catalog.UpdateProductResponse")
+	proto.RegisterType((*DeleteProductRequest)(nil), "catalog.DeleteProductRequest")
+	proto.RegisterType((*DeleteProductResponse)(nil), "catalog.DeleteProductResponse")
+	proto.RegisterType((*CreateCategoryRequest)(nil), "catalog.CreateCategoryRequest")
+	proto.RegisterType((*CreateCategoryResponse)(nil), "catalog.CreateCategoryResponse")
+	proto.RegisterType((*UpdateCategoryRequest)(nil), "catalog.UpdateCategoryRequest")
+	proto.RegisterType((*UpdateCategoryResponse)(nil), "catalog.UpdateCategoryResponse")
+	proto.RegisterType((*DeleteCategoryRequest)(nil), "catalog.DeleteCategoryRequest")
+	proto.RegisterType((*DeleteCategoryResponse)(nil), "catalog.DeleteCategoryResponse")
+	proto.RegisterType((*AssignProductRequest)(nil), "catalog.AssignProductRequest")
+	proto.RegisterType((*AssignProductResponse)(nil), "catalog.AssignProductResponse")
+	proto.RegisterType((*SchedulePriceRequest)(nil), "catalog.SchedulePriceRequest")
+	proto.RegisterType((*SchedulePriceResponse)(nil), "catalog.SchedulePriceResponse")
+	proto.RegisterType((*CancelScheduledPriceRequest)(nil), "catalog.CancelScheduledPriceRequest")
+	proto.RegisterType((*CancelScheduledPriceResponse)(nil), "catalog.CancelScheduledPriceResponse")
+	proto.RegisterType((*RelatedLinkRequest)(nil), "catalog.RelatedLinkRequest")
+	proto.RegisterType((*RelatedLinkResponse)(nil), "catalog.RelatedLinkResponse")
+	proto.RegisterType((*ModerateReviewRequest)(nil), "catalog.ModerateReviewRequest")
+	proto.RegisterType((*ModerateReviewResponse)(nil), "catalog.ModerateReviewResponse")
+	proto.RegisterType((*PendingReviewsRequest)(nil), "catalog.PendingReviewsRequest")
+	proto.RegisterType((*PendingReviewsResponse)(nil), "catalog.PendingReviewsResponse")
+	proto.RegisterType((*PriceHistoryRequest)(nil), "catalog.PriceHistoryRequest")
+	proto.RegisterType((*PriceHistoryResponse)(nil), "catalog.PriceHistoryResponse")
+	proto.RegisterType((*ProductChangedEvent)(nil), "catalog.ProductChangedEvent")
 	proto.RegisterType((*Product)(nil), "catalog.Product")
+	proto.RegisterType((*BundleComponent)(nil), "catalog.BundleComponent")
+	proto.RegisterType((*RelatedProduct)(nil), "catalog.RelatedProduct")
+	proto.RegisterType((*Money)(nil), "catalog.Money")
+	proto.RegisterType((*PriceChange)(nil), "catalog.PriceChange")
+	proto.RegisterType((*Review)(nil), "catalog.Review")
+	proto.RegisterType((*RatingSummary)(nil), "catalog.RatingSummary")
+	proto.RegisterType((*Translation)(nil), "catalog.Translation")
+	proto.RegisterType((*Specification)(nil), "catalog.Specification")
+	proto.RegisterType((*SpecificationDefinition)(nil), "catalog.SpecificationDefinition")
+	proto.RegisterType((*SpecificationFilter)(nil), "catalog.SpecificationFilter")
+	proto.RegisterType((*NumberRange)(nil), "catalog.NumberRange")
+	proto.RegisterType((*ProductAttribute)(nil), "catalog.ProductAttribute")
+	proto.RegisterType((*MediaAsset)(nil), "catalog.MediaAsset")
+	proto.RegisterType((*SearchHit)(nil), "catalog.SearchHit")
+	proto.RegisterType((*Highlight)(nil), "catalog.Highlight")
+	proto.RegisterType((*PriceRange)(nil), "catalog.PriceRange")
+	proto.RegisterType((*SearchFacets)(nil), "catalog.SearchFacets")
+	proto.RegisterType((*ManufacturerFacet)(nil), "catalog.ManufacturerFacet")
+	proto.RegisterType((*CategoryFacet)(nil), "catalog.CategoryFacet")
+	proto.RegisterType((*PriceFacet)(nil), "catalog.PriceFacet")
+	proto.RegisterType((*ProductSuggestion)(nil), "catalog.ProductSuggestion")
 	proto.RegisterType((*ProductCategory)(nil), "catalog.ProductCategory")
+	proto.RegisterType((*CategoryNode)(nil), "catalog.CategoryNode")
+	proto.RegisterEnum("catalog.SortOrder", SortOrder_name, SortOrder_value)
+	proto.RegisterEnum("catalog.ProductChangeType", ProductChangeType_name, ProductChangeType_value)
+	proto.RegisterEnum("catalog.ProductStatus", ProductStatus_name, ProductStatus_value)
+	proto.RegisterEnum("catalog.ProductKind", ProductKind_name, ProductKind_value)
+	proto.RegisterEnum("catalog.SpecificationType", SpecificationType_name, SpecificationType_value)
+	proto.RegisterEnum("catalog.Relation", Relation_name, Relation_value)
+	proto.RegisterEnum("catalog.ReviewStatus", ReviewStatus_name, ReviewStatus_value)
+	proto.RegisterEnum("catalog.MediaType", MediaType_name, MediaType_value)
+	proto.RegisterEnum("catalog.PriceType", PriceType_name, PriceType_value)
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -289,8 +2694,15 @@ var _ server.Option
 type CatalogClient interface {
 	GetProductDetails(ctx context.Context, in *DetailRequest, opts ...client.CallOption) (*DetailResponse, error)
 	GetProductCategories(ctx context.Context, in *AllCategoriesRequest, opts ...client.CallOption) (*AllCategoriesResponse, error)
+	GetCategoryTree(ctx context.Context, in *CategoryTreeRequest, opts ...client.CallOption) (*CategoryTreeResponse, error)
 	GetProductsInCategory(ctx context.Context, in *CategoryProductsRequest, opts ...client.CallOption) (*CategoryProductsResponse, error)
+	GetProductVariants(ctx context.Context, in *VariantsRequest, opts ...client.CallOption) (*VariantsResponse, error)
 	ProductSearch(ctx context.Context, in *SearchRequest, opts ...client.CallOption) (*SearchResponse, error)
+	SuggestProducts(ctx context.Context, in *SuggestRequest, opts ...client.CallOption) (*SuggestResponse, error)
+	GetRelatedProducts(ctx context.Context, in *RelatedRequest, opts ...client.CallOption) (*RelatedResponse, error)
+	SubmitReview(ctx context.Context, in *SubmitReviewRequest, opts ...client.CallOption) (*SubmitReviewResponse, error)
+	GetProductReviews(ctx context.Context, in *ReviewsRequest, opts ...client.CallOption) (*ReviewsResponse, error)
+	VoteReview(ctx context.Context, in *ReviewVoteRequest, opts ...client.CallOption) (*ReviewVoteResponse, error)
 }
 
 type catalogClient struct {
@@ -331,6 +2743,16 @@ func (c *catalogClient) GetProductCategories(ctx context.Context, in *AllCategor
 	return out, nil
 }
 
+func (c *catalogClient) GetCategoryTree(ctx context.Context, in *CategoryTreeRequest, opts ...client.CallOption) (*CategoryTreeResponse, error) {
+	req := c.c.NewRequest(c.serviceName, "Catalog.GetCategoryTree", in)
+	out := new(CategoryTreeResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *catalogClient) GetProductsInCategory(ctx context.Context, in *CategoryProductsRequest, opts ...client.CallOption) (*CategoryProductsResponse, error) {
 	req := c.c.NewRequest(c.serviceName, "Catalog.GetProductsInCategory", in)
 	out := new(CategoryProductsResponse)
@@ -341,6 +2763,16 @@ func (c *catalogClient) GetProductsInCategory(ctx context.Context, in *CategoryP
 	return out, nil
 }
 
+func (c *catalogClient) GetProductVariants(ctx context.Context, in *VariantsRequest, opts ...client.CallOption) (*VariantsResponse, error) {
+	req := c.c.NewRequest(c.serviceName, "Catalog.GetProductVariants", in)
+	out := new(VariantsResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *catalogClient) ProductSearch(ctx context.Context, in *SearchRequest, opts ...client.CallOption) (*SearchResponse, error) {
 	req := c.c.NewRequest(c.serviceName, "Catalog.ProductSearch", in)
 	out := new(SearchResponse)
@@ -351,13 +2783,70 @@ func (c *catalogClient) ProductSearch(ctx context.Context, in *SearchRequest, op
 	return out, nil
 }
 
+func (c *catalogClient) SuggestProducts(ctx context.Context, in *SuggestRequest, opts ...client.CallOption) (*SuggestResponse, error) {
+	req := c.c.NewRequest(c.serviceName, "Catalog.SuggestProducts", in)
+	out := new(SuggestResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogClient) GetRelatedProducts(ctx context.Context, in *RelatedRequest, opts ...client.CallOption) (*RelatedResponse, error) {
+	req := c.c.NewRequest(c.serviceName, "Catalog.GetRelatedProducts", in)
+	out := new(RelatedResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogClient) SubmitReview(ctx context.Context, in *SubmitReviewRequest, opts ...client.CallOption) (*SubmitReviewResponse, error) {
+	req := c.c.NewRequest(c.serviceName, "Catalog.SubmitReview", in)
+	out := new(SubmitReviewResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogClient) GetProductReviews(ctx context.Context, in *ReviewsRequest, opts ...client.CallOption) (*ReviewsResponse, error) {
+	req := c.c.NewRequest(c.serviceName, "Catalog.GetProductReviews", in)
+	out := new(ReviewsResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogClient) VoteReview(ctx context.Context, in *ReviewVoteRequest, opts ...client.CallOption) (*ReviewVoteResponse, error) {
+	req := c.c.NewRequest(c.serviceName, "Catalog.VoteReview", in)
+	out := new(ReviewVoteResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for Catalog service
 
 type CatalogHandler interface {
 	GetProductDetails(context.Context, *DetailRequest, *DetailResponse) error
 	GetProductCategories(context.Context, *AllCategoriesRequest, *AllCategoriesResponse) error
+	GetCategoryTree(context.Context, *CategoryTreeRequest, *CategoryTreeResponse) error
 	GetProductsInCategory(context.Context, *CategoryProductsRequest, *CategoryProductsResponse) error
+	GetProductVariants(context.Context, *VariantsRequest, *VariantsResponse) error
 	ProductSearch(context.Context, *SearchRequest, *SearchResponse) error
+	SuggestProducts(context.Context, *SuggestRequest, *SuggestResponse) error
+	GetRelatedProducts(context.Context, *RelatedRequest, *RelatedResponse) error
+	SubmitReview(context.Context, *SubmitReviewRequest, *SubmitReviewResponse) error
+	GetProductReviews(context.Context, *ReviewsRequest, *ReviewsResponse) error
+	VoteReview(context.Context, *ReviewVoteRequest, *ReviewVoteResponse) error
 }
 
 func RegisterCatalogHandler(s server.Server, hdlr CatalogHandler, opts ...server.HandlerOption) {
@@ -376,46 +2865,528 @@ func (h *Catalog) GetProductCategories(ctx context.Context, in *AllCategoriesReq
 	return h.CatalogHandler.GetProductCategories(ctx, in, out)
 }
 
+func (h *Catalog) GetCategoryTree(ctx context.Context, in *CategoryTreeRequest, out *CategoryTreeResponse) error {
+	return h.CatalogHandler.GetCategoryTree(ctx, in, out)
+}
+
 func (h *Catalog) GetProductsInCategory(ctx context.Context, in *CategoryProductsRequest, out *CategoryProductsResponse) error {
 	return h.CatalogHandler.GetProductsInCategory(ctx, in, out)
 }
 
+func (h *Catalog) GetProductVariants(ctx context.Context, in *VariantsRequest, out *VariantsResponse) error {
+	return h.CatalogHandler.GetProductVariants(ctx, in, out)
+}
+
 func (h *Catalog) ProductSearch(ctx context.Context, in *SearchRequest, out *SearchResponse) error {
 	return h.CatalogHandler.ProductSearch(ctx, in, out)
 }
 
+func (h *Catalog) SuggestProducts(ctx context.Context, in *SuggestRequest, out *SuggestResponse) error {
+	return h.CatalogHandler.SuggestProducts(ctx, in, out)
+}
+
+func (h *Catalog) GetRelatedProducts(ctx context.Context, in *RelatedRequest, out *RelatedResponse) error {
+	return h.CatalogHandler.GetRelatedProducts(ctx, in, out)
+}
+
+func (h *Catalog) SubmitReview(ctx context.Context, in *SubmitReviewRequest, out *SubmitReviewResponse) error {
+	return h.CatalogHandler.SubmitReview(ctx, in, out)
+}
+
+func (h *Catalog) GetProductReviews(ctx context.Context, in *ReviewsRequest, out *ReviewsResponse) error {
+	return h.CatalogHandler.GetProductReviews(ctx, in, out)
+}
+
+func (h *Catalog) VoteReview(ctx context.Context, in *ReviewVoteRequest, out *ReviewVoteResponse) error {
+	return h.CatalogHandler.VoteReview(ctx, in, out)
+}
+
+// Client API for CatalogAdmin service
+
+type CatalogAdminClient interface {
+	CreateProduct(ctx context.Context, in *CreateProductRequest, opts ...client.CallOption) (*CreateProductResponse, error)
+	UpdateProduct(ctx context.Context, in *UpdateProductRequest, opts ...client.CallOption) (*UpdateProductResponse, error)
+	DeleteProduct(ctx context.Context, in *DeleteProductRequest, opts ...client.CallOption) (*DeleteProductResponse, error)
+	CreateCategory(ctx context.Context, in *CreateCategoryRequest, opts ...client.CallOption) (*CreateCategoryResponse, error)
+	UpdateCategory(ctx context.Context, in *UpdateCategoryRequest, opts ...client.CallOption) (*UpdateCategoryResponse, error)
+	DeleteCategory(ctx context.Context, in *DeleteCategoryRequest, opts ...client.CallOption) (*DeleteCategoryResponse, error)
+	AssignProductToCategory(ctx context.Context, in *AssignProductRequest, opts ...client.CallOption) (*AssignProductResponse, error)
+	SchedulePrice(ctx context.Context, in *SchedulePriceRequest, opts ...client.CallOption) (*SchedulePriceResponse, error)
+	CancelScheduledPrice(ctx context.Context, in *CancelScheduledPriceRequest, opts ...client.CallOption) (*CancelScheduledPriceResponse, error)
+	GetPriceHistory(ctx context.Context, in *PriceHistoryRequest, opts ...client.CallOption) (*PriceHistoryResponse, error)
+	LinkRelatedProduct(ctx context.Context, in *RelatedLinkRequest, opts ...client.CallOption) (*RelatedLinkResponse, error)
+	UnlinkRelatedProduct(ctx context.Context, in *RelatedLinkRequest, opts ...client.CallOption) (*RelatedLinkResponse, error)
+	ModerateReview(ctx context.Context, in *ModerateReviewRequest, opts ...client.CallOption) (*ModerateReviewResponse, error)
+	GetPendingReviews(ctx context.Context, in *PendingReviewsRequest, opts ...client.CallOption) (*PendingReviewsResponse, error)
+}
+
+type catalogAdminClient struct {
+	c           client.Client
+	serviceName string
+}
+
+func NewCatalogAdminClient(serviceName string, c client.Client) CatalogAdminClient {
+	if c == nil {
+		c = client.NewClient()
+	}
+	if len(serviceName) == 0 {
+		serviceName = "catalog"
+	}
+	return &catalogAdminClient{
+		c:           c,
+		serviceName: serviceName,
+	}
+}
+
+func (c *catalogAdminClient) CreateProduct(ctx context.Context, in *CreateProductRequest, opts ...client.CallOption) (*CreateProductResponse, error) {
+	req := c.c.NewRequest(c.serviceName, "CatalogAdmin.CreateProduct", in)
+	out := new(CreateProductResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogAdminClient) UpdateProduct(ctx context.Context, in *UpdateProductRequest, opts ...client.CallOption) (*UpdateProductResponse, error) {
+	req := c.c.NewRequest(c.serviceName, "CatalogAdmin.UpdateProduct", in)
+	out := new(UpdateProductResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogAdminClient) DeleteProduct(ctx context.Context, in *DeleteProductRequest, opts ...client.CallOption) (*DeleteProductResponse, error) {
+	req := c.c.NewRequest(c.serviceName, "CatalogAdmin.DeleteProduct", in)
+	out := new(DeleteProductResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogAdminClient) CreateCategory(ctx context.Context, in *CreateCategoryRequest, opts ...client.CallOption) (*CreateCategoryResponse, error) {
+	req := c.c.NewRequest(c.serviceName, "CatalogAdmin.CreateCategory", in)
+	out := new(CreateCategoryResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogAdminClient) UpdateCategory(ctx context.Context, in *UpdateCategoryRequest, opts ...client.CallOption) (*UpdateCategoryResponse, error) {
+	req := c.c.NewRequest(c.serviceName, "CatalogAdmin.UpdateCategory", in)
+	out := new(UpdateCategoryResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogAdminClient) DeleteCategory(ctx context.Context, in *DeleteCategoryRequest, opts ...client.CallOption) (*DeleteCategoryResponse, error) {
+	req := c.c.NewRequest(c.serviceName, "CatalogAdmin.DeleteCategory", in)
+	out := new(DeleteCategoryResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogAdminClient) AssignProductToCategory(ctx context.Context, in *AssignProductRequest, opts ...client.CallOption) (*AssignProductResponse, error) {
+	req := c.c.NewRequest(c.serviceName, "CatalogAdmin.AssignProductToCategory", in)
+	out := new(AssignProductResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogAdminClient) SchedulePrice(ctx context.Context, in *SchedulePriceRequest, opts ...client.CallOption) (*SchedulePriceResponse, error) {
+	req := c.c.NewRequest(c.serviceName, "CatalogAdmin.SchedulePrice", in)
+	out := new(SchedulePriceResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogAdminClient) CancelScheduledPrice(ctx context.Context, in *CancelScheduledPriceRequest, opts ...client.CallOption) (*CancelScheduledPriceResponse, error) {
+	req := c.c.NewRequest(c.serviceName, "CatalogAdmin.CancelScheduledPrice", in)
+	out := new(CancelScheduledPriceResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogAdminClient) GetPriceHistory(ctx context.Context, in *PriceHistoryRequest, opts ...client.CallOption) (*PriceHistoryResponse, error) {
+	req := c.c.NewRequest(c.serviceName, "CatalogAdmin.GetPriceHistory", in)
+	out := new(PriceHistoryResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogAdminClient) LinkRelatedProduct(ctx context.Context, in *RelatedLinkRequest, opts ...client.CallOption) (*RelatedLinkResponse, error) {
+	req := c.c.NewRequest(c.serviceName, "CatalogAdmin.LinkRelatedProduct", in)
+	out := new(RelatedLinkResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogAdminClient) UnlinkRelatedProduct(ctx context.Context, in *RelatedLinkRequest, opts ...client.CallOption) (*RelatedLinkResponse, error) {
+	req := c.c.NewRequest(c.serviceName, "CatalogAdmin.UnlinkRelatedProduct", in)
+	out := new(RelatedLinkResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogAdminClient) ModerateReview(ctx context.Context, in *ModerateReviewRequest, opts ...client.CallOption) (*ModerateReviewResponse, error) {
+	req := c.c.NewRequest(c.serviceName, "CatalogAdmin.ModerateReview", in)
+	out := new(ModerateReviewResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogAdminClient) GetPendingReviews(ctx context.Context, in *PendingReviewsRequest, opts ...client.CallOption) (*PendingReviewsResponse, error) {
+	req := c.c.NewRequest(c.serviceName, "CatalogAdmin.GetPendingReviews", in)
+	out := new(PendingReviewsResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for CatalogAdmin service
+
+type CatalogAdminHandler interface {
+	CreateProduct(context.Context, *CreateProductRequest, *CreateProductResponse) error
+	UpdateProduct(context.Context, *UpdateProductRequest, *UpdateProductResponse) error
+	DeleteProduct(context.Context, *DeleteProductRequest, *DeleteProductResponse) error
+	CreateCategory(context.Context, *CreateCategoryRequest, *CreateCategoryResponse) error
+	UpdateCategory(context.Context, *UpdateCategoryRequest, *UpdateCategoryResponse) error
+	DeleteCategory(context.Context, *DeleteCategoryRequest, *DeleteCategoryResponse) error
+	AssignProductToCategory(context.Context, *AssignProductRequest, *AssignProductResponse) error
+	SchedulePrice(context.Context, *SchedulePriceRequest, *SchedulePriceResponse) error
+	CancelScheduledPrice(context.Context, *CancelScheduledPriceRequest, *CancelScheduledPriceResponse) error
+	GetPriceHistory(context.Context, *PriceHistoryRequest, *PriceHistoryResponse) error
+	LinkRelatedProduct(context.Context, *RelatedLinkRequest, *RelatedLinkResponse) error
+	UnlinkRelatedProduct(context.Context, *RelatedLinkRequest, *RelatedLinkResponse) error
+	ModerateReview(context.Context, *ModerateReviewRequest, *ModerateReviewResponse) error
+	GetPendingReviews(context.Context, *PendingReviewsRequest, *PendingReviewsResponse) error
+}
+
+func RegisterCatalogAdminHandler(s server.Server, hdlr CatalogAdminHandler, opts ...server.HandlerOption) {
+	s.Handle(s.NewHandler(&CatalogAdmin{hdlr}, opts...))
+}
+
+type CatalogAdmin struct {
+	CatalogAdminHandler
+}
+
+func (h *CatalogAdmin) CreateProduct(ctx context.Context, in *CreateProductRequest, out *CreateProductResponse) error {
+	return h.CatalogAdminHandler.CreateProduct(ctx, in, out)
+}
+
+func (h *CatalogAdmin) UpdateProduct(ctx context.Context, in *UpdateProductRequest, out *UpdateProductResponse) error {
+	return h.CatalogAdminHandler.UpdateProduct(ctx, in, out)
+}
+
+func (h *CatalogAdmin) DeleteProduct(ctx context.Context, in *DeleteProductRequest, out *DeleteProductResponse) error {
+	return h.CatalogAdminHandler.DeleteProduct(ctx, in, out)
+}
+
+func (h *CatalogAdmin) CreateCategory(ctx context.Context, in *CreateCategoryRequest, out *CreateCategoryResponse) error {
+	return h.CatalogAdminHandler.CreateCategory(ctx, in, out)
+}
+
+func (h *CatalogAdmin) UpdateCategory(ctx context.Context, in *UpdateCategoryRequest, out *UpdateCategoryResponse) error {
+	return h.CatalogAdminHandler.UpdateCategory(ctx, in, out)
+}
+
+func (h *CatalogAdmin) DeleteCategory(ctx context.Context, in *DeleteCategoryRequest, out *DeleteCategoryResponse) error {
+	return h.CatalogAdminHandler.DeleteCategory(ctx, in, out)
+}
+
+func (h *CatalogAdmin) AssignProductToCategory(ctx context.Context, in *AssignProductRequest, out *AssignProductResponse) error {
+	return h.CatalogAdminHandler.AssignProductToCategory(ctx, in, out)
+}
+
+func (h *CatalogAdmin) SchedulePrice(ctx context.Context, in *SchedulePriceRequest, out *SchedulePriceResponse) error {
+	return h.CatalogAdminHandler.SchedulePrice(ctx, in, out)
+}
+
+func (h *CatalogAdmin) CancelScheduledPrice(ctx context.Context, in *CancelScheduledPriceRequest, out *CancelScheduledPriceResponse) error {
+	return h.CatalogAdminHandler.CancelScheduledPrice(ctx, in, out)
+}
+
+func (h *CatalogAdmin) GetPriceHistory(ctx context.Context, in *PriceHistoryRequest, out *PriceHistoryResponse) error {
+	return h.CatalogAdminHandler.GetPriceHistory(ctx, in, out)
+}
+
+func (h *CatalogAdmin) LinkRelatedProduct(ctx context.Context, in *RelatedLinkRequest, out *RelatedLinkResponse) error {
+	return h.CatalogAdminHandler.LinkRelatedProduct(ctx, in, out)
+}
+
+func (h *CatalogAdmin) UnlinkRelatedProduct(ctx context.Context, in *RelatedLinkRequest, out *RelatedLinkResponse) error {
+	return h.CatalogAdminHandler.UnlinkRelatedProduct(ctx, in, out)
+}
+
+func (h *CatalogAdmin) ModerateReview(ctx context.Context, in *ModerateReviewRequest, out *ModerateReviewResponse) error {
+	return h.CatalogAdminHandler.ModerateReview(ctx, in, out)
+}
+
+func (h *CatalogAdmin) GetPendingReviews(ctx context.Context, in *PendingReviewsRequest, out *PendingReviewsResponse) error {
+	return h.CatalogAdminHandler.GetPendingReviews(ctx, in, out)
+}
+
 func init() { proto.RegisterFile("catalog.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 469 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x54, 0x4d, 0x6f, 0xd3, 0x40,
-	0x10, 0x95, 0xe3, 0x7c, 0xc0, 0x04, 0x87, 0xb2, 0x4a, 0xdb, 0x55, 0x24, 0x8a, 0xbb, 0xa7, 0x08,
-	0xa1, 0x1c, 0xc2, 0x01, 0x84, 0x38, 0x80, 0x5a, 0x09, 0x72, 0x2b, 0x86, 0xde, 0x90, 0x2a, 0x63,
-	0x0f, 0xad, 0x85, 0xbf, 0xd8, 0x5d, 0x1f, 0xf2, 0x73, 0xf8, 0x33, 0xfc, 0x2e, 0x94, 0xf5, 0x78,
-	0x63, 0x27, 0x34, 0x48, 0xdc, 0xbc, 0x6f, 0xde, 0xbc, 0x79, 0x33, 0x3b, 0x6b, 0xf0, 0xa2, 0x50,
-	0x87, 0x69, 0x71, 0xbb, 0x28, 0x65, 0xa1, 0x0b, 0x36, 0xa2, 0xa3, 0x38, 0x07, 0xef, 0x12, 0x75,
-	0x98, 0xa4, 0x01, 0xfe, 0xac, 0x50, 0x69, 0x76, 0x04, 0xae, 0xfa, 0x51, 0x71, 0xc7, 0x77, 0xe6,
-	0x0f, 0x83, 0xcd, 0xa7, 0x78, 0x0b, 0x93, 0x86, 0xa2, 0xca, 0x22, 0x57, 0xc8, 0x9e, 0xc3, 0xa8,
-	0x94, 0x45, 0x5c, 0x45, 0xda, 0xf0, 0xc6, 0xcb, 0xa3, 0x45, 0x23, 0x7f, 0x55, 0xe3, 0x41, 0x43,
-	0x10, 0x0b, 0x98, 0xbe, 0x4f, 0xd3, 0x8b, 0x50, 0xe3, 0x6d, 0x21, 0x13, 0x54, 0x4d, 0x9d, 0x13,
-	0x18, 0x5e, 0xe7, 0x95, 0xc2, 0xd8, 0x48, 0x0c, 0x02, 0x3a, 0x89, 0x4f, 0x70, 0xbc, 0xc3, 0xa7,
-	0xa2, 0xaf, 0x01, 0x22, 0x8b, 0x72, 0xc7, 0x77, 0xe7, 0xe3, 0x25, 0xdf, 0xad, 0x4b, 0x79, 0xeb,
-	0xa0, 0xc5, 0x15, 0x6f, 0xe0, 0xb4, 0xc1, 0x89, 0x66, 0x5d, 0x3c, 0x83, 0x31, 0x11, 0xd7, 0x37,
-	0x49, 0x6d, 0xa5, 0x6f, 0x73, 0xd7, 0xab, 0x58, 0x7c, 0x04, 0xbe, 0x9f, 0x4b, 0x8e, 0x5e, 0xc0,
-	0x03, 0xea, 0xb2, 0xf1, 0xb3, 0x3f, 0x07, 0xcb, 0x10, 0x57, 0xe0, 0x7d, 0xc6, 0x50, 0x46, 0x77,
-	0xad, 0xda, 0xca, 0x00, 0x37, 0x1a, 0x65, 0x46, 0x13, 0x87, 0x1a, 0xfa, 0x82, 0x32, 0x63, 0x67,
-	0x9d, 0x8e, 0x7b, 0xbe, 0xdb, 0xf2, 0xb6, 0xe9, 0x6b, 0x05, 0x93, 0x46, 0x91, 0x1c, 0xbd, 0x82,
-	0x09, 0x49, 0x4a, 0x54, 0x55, 0x7a, 0xc0, 0x97, 0xa7, 0x9a, 0xcc, 0x0d, 0x4d, 0xfc, 0x72, 0x60,
-	0x44, 0xa1, 0xfd, 0x0d, 0x60, 0x0c, 0xfa, 0x79, 0x98, 0x21, 0xef, 0x19, 0xc8, 0x7c, 0x33, 0x1f,
-	0xc6, 0x31, 0xaa, 0x48, 0x26, 0xa5, 0x4e, 0x8a, 0x9c, 0xbb, 0x26, 0xd4, 0x86, 0x98, 0x80, 0x47,
-	0x59, 0x98, 0x57, 0xdf, 0xc3, 0x48, 0x57, 0x12, 0x25, 0xef, 0x1b, 0x4a, 0x07, 0x63, 0x53, 0x18,
-	0x64, 0x45, 0x8c, 0x29, 0x1f, 0x98, 0x60, 0x7d, 0xd8, 0xa0, 0xa5, 0x4c, 0x22, 0xe4, 0x43, 0xdf,
-	0x99, 0xbb, 0x41, 0x7d, 0x10, 0x77, 0xf0, 0x78, 0xe7, 0x96, 0xff, 0x79, 0x7d, 0xff, 0xe7, 0x7c,
-	0xf9, 0xbb, 0x07, 0xa3, 0x8b, 0x7a, 0x60, 0xec, 0x12, 0x9e, 0x7c, 0x40, 0x4d, 0x85, 0xeb, 0x77,
-	0xa0, 0xd8, 0x89, 0x9d, 0x67, 0xe7, 0xf1, 0xcc, 0x4e, 0xf7, 0x70, 0xba, 0x98, 0x6b, 0x98, 0x6e,
-	0x55, 0xb6, 0xcb, 0xcd, 0x9e, 0xda, 0x84, 0xbf, 0x3d, 0x92, 0xd9, 0xd9, 0x7d, 0x61, 0x92, 0xfd,
-	0x0a, 0xc7, 0x5b, 0x59, 0xb5, 0xca, 0xed, 0x60, 0x7c, 0x9b, 0x78, 0xcf, 0xe6, 0xcf, 0xce, 0x0f,
-	0x30, 0x48, 0xfd, 0x1d, 0x78, 0x84, 0xd5, 0x6b, 0xd6, 0x6a, 0xbb, 0xb3, 0xc9, 0xad, 0xb6, 0xbb,
-	0xfb, 0xf8, 0x6d, 0x68, 0xfe, 0x36, 0x2f, 0xff, 0x04, 0x00, 0x00, 0xff, 0xff, 0x5d, 0x19, 0xe1,
-	0x91, 0x7e, 0x04, 0x00, 0x00,
+	// 3581 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x3a, 0x5d, 0x6f, 0xe3, 0x48,
+	0x72, 0xab, 0x4f, 0x4b, 0xa5, 0xcf, 0xa1, 0x65, 0x5b, 0xab, 0xf9, 0x3c, 0xee, 0xde, 0xdd, 0xac,
+	0xef, 0x76, 0x16, 0xe3, 0x7c, 0xdc, 0xee, 0xdd, 0xe1, 0xee, 0x34, 0x92, 0x6c, 0x6b, 0xc7, 0xfa,
+	0x58, 0x52, 0x9e, 0xbd, 0x20, 0x09, 0x08, 0x9a, 0x6c, 0xcb, 0xc4, 0x50, 0xa4, 0x96, 0x6c, 0x7a,
+	0xc7, 0xf7, 0x9a, 0xe4, 0x21, 0x01, 0x92, 0x87, 0x24, 0x0f, 0x41, 0x5e, 0x82, 0x20, 0xbf, 0x21,
+	0x08, 0x90, 0xdf, 0x90, 0x3f, 0x90, 0xdf, 0x90, 0xff, 0x10, 0x04, 0xfd, 0x41, 0xb2, 0x49, 0x51,
+	0xb6, 0x77, 0x6e, 0x81, 0xbc, 0xb1, 0xab, 0xaa, 0xab, 0xab, 0xab, 0xaa, 0xab, 0xab, 0xab, 0x08,
+	0x0d, 0x43, 0xc7, 0xba, 0xed, 0x2e, 0x5f, 0xac, 0x3d, 0x17, 0xbb, 0xd2, 0x0e, 0x1f, 0xca, 0x7f,
+	0x9d, 0x83, 0xc6, 0x10, 0x61, 0xdd, 0xb2, 0x15, 0xf4, 0x4d, 0x80, 0x7c, 0x2c, 0xb5, 0xa1, 0xe0,
+	0xbf, 0x0d, 0xba, 0xb9, 0x67, 0xb9, 0xe7, 0x55, 0x85, 0x7c, 0x4a, 0x1f, 0x41, 0xc3, 0x08, 0x3c,
+	0x0f, 0x39, 0xc6, 0x8d, 0x66, 0xb8, 0x26, 0xea, 0xe6, 0x29, 0xae, 0x1e, 0x02, 0x07, 0xae, 0x89,
+	0xa4, 0x7d, 0x28, 0xdb, 0xae, 0xa1, 0xdb, 0xa8, 0x5b, 0xa0, 0x58, 0x3e, 0x92, 0x3e, 0x81, 0xf6,
+	0xa5, 0x6e, 0xdb, 0x17, 0xba, 0xf1, 0x56, 0x63, 0x20, 0xbf, 0x5b, 0x7c, 0x56, 0x78, 0x5e, 0x55,
+	0x5a, 0x21, 0xfc, 0x8c, 0x81, 0xe5, 0xbf, 0xca, 0x43, 0x33, 0x94, 0xc5, 0x5f, 0xbb, 0x8e, 0x8f,
+	0xa4, 0x43, 0xd8, 0x59, 0x7b, 0xae, 0x19, 0x18, 0x98, 0x0a, 0x54, 0x3b, 0x6a, 0xbf, 0x08, 0x37,
+	0x32, 0x67, 0x70, 0x25, 0x24, 0x90, 0x7e, 0x0e, 0xb5, 0x0b, 0x0f, 0xe9, 0xa6, 0xe1, 0x05, 0xab,
+	0x0b, 0xbf, 0x9b, 0x7f, 0x56, 0x78, 0x5e, 0x3b, 0xea, 0xa6, 0xe9, 0x07, 0x3a, 0x46, 0x4b, 0xd7,
+	0xbb, 0x51, 0x44, 0x62, 0xe9, 0xa7, 0x50, 0xb9, 0xd6, 0x3d, 0x4b, 0x77, 0xb0, 0xdf, 0x2d, 0x3c,
+	0x2b, 0x64, 0x2e, 0x14, 0x51, 0x48, 0x9f, 0x02, 0xd8, 0x96, 0x8f, 0xb5, 0xb5, 0x67, 0x19, 0xa8,
+	0x5b, 0xa4, 0x82, 0x35, 0x23, 0xfa, 0x89, 0xeb, 0xa0, 0x1b, 0xa5, 0x4a, 0x28, 0xe6, 0x84, 0x40,
+	0x7a, 0x01, 0x65, 0x4f, 0xc7, 0x96, 0xb3, 0xec, 0x96, 0x28, 0xe9, 0x7e, 0x44, 0xaa, 0x50, 0xb0,
+	0x1a, 0xac, 0x56, 0xba, 0x77, 0xa3, 0x70, 0x2a, 0xf9, 0x18, 0x3a, 0x7d, 0xdb, 0xe6, 0x82, 0x5a,
+	0xc8, 0x0f, 0x2d, 0xb3, 0x0f, 0xe5, 0x73, 0x27, 0xf0, 0x91, 0x49, 0x75, 0x51, 0x52, 0xf8, 0x48,
+	0x50, 0x7d, 0x5e, 0x54, 0xbd, 0xfc, 0x15, 0xec, 0xa5, 0xf8, 0x70, 0xad, 0x7e, 0x0e, 0x60, 0x44,
+	0xd0, 0x6e, 0xee, 0x0e, 0x45, 0x09, 0xb4, 0xf2, 0x0b, 0xd8, 0x0d, 0xe1, 0x0b, 0x0f, 0xa1, 0x50,
+	0xb2, 0x03, 0xd8, 0xf1, 0x5c, 0x17, 0x6b, 0x16, 0x13, 0xad, 0xa8, 0x94, 0xc9, 0x70, 0x6c, 0xca,
+	0x03, 0xe8, 0x24, 0xe9, 0xb9, 0x04, 0x3f, 0x81, 0x12, 0xa1, 0x08, 0x17, 0xdf, 0x8b, 0x16, 0x0f,
+	0xa9, 0xa7, 0xae, 0x89, 0x14, 0x46, 0x23, 0xff, 0x6f, 0x0e, 0x0e, 0x42, 0x38, 0x17, 0x2e, 0xd2,
+	0xc9, 0x53, 0xa8, 0x71, 0xf1, 0x6e, 0xe2, 0xd5, 0x43, 0x89, 0x6f, 0xc6, 0xa6, 0xf4, 0x10, 0xaa,
+	0x6b, 0x7d, 0x89, 0x34, 0xdf, 0xfa, 0x1d, 0xd3, 0x4f, 0x43, 0xa9, 0x10, 0x80, 0x6a, 0xfd, 0x8e,
+	0x3a, 0xad, 0x11, 0x78, 0xbe, 0xeb, 0x85, 0x4e, 0xcb, 0x46, 0xd2, 0x4b, 0x00, 0xdf, 0xf5, 0xb0,
+	0xe6, 0x7a, 0x26, 0xf2, 0xa8, 0x81, 0x9b, 0x47, 0x52, 0x24, 0xa3, 0xea, 0x7a, 0x78, 0x46, 0x30,
+	0x4a, 0xd5, 0x0f, 0x3f, 0xa5, 0xcf, 0x60, 0xd7, 0x72, 0x0c, 0x3b, 0x30, 0x91, 0x66, 0x22, 0xdf,
+	0x40, 0x8e, 0x49, 0x9d, 0x89, 0x58, 0xbc, 0xa2, 0x48, 0x1c, 0x35, 0x8c, 0x31, 0xe4, 0x60, 0x84,
+	0x13, 0x2c, 0x47, 0x37, 0xb0, 0x75, 0x8d, 0xba, 0x65, 0x4a, 0xdd, 0xe2, 0xf0, 0x31, 0x07, 0xcb,
+	0xff, 0x90, 0x83, 0xee, 0xa6, 0x02, 0xb8, 0x2a, 0x7f, 0x0a, 0x15, 0x7e, 0x02, 0x42, 0x6d, 0x66,
+	0xb8, 0x6e, 0x48, 0x21, 0x3d, 0x87, 0xb6, 0x83, 0xde, 0x61, 0x8d, 0xea, 0x84, 0xef, 0x9d, 0x79,
+	0x4d, 0x93, 0xc0, 0xe7, 0xfa, 0x12, 0x0d, 0x98, 0x0e, 0x9e, 0x42, 0x0d, 0xbb, 0x58, 0xb7, 0x35,
+	0xc3, 0x0d, 0x1c, 0x4c, 0x15, 0xd4, 0x50, 0x80, 0x82, 0x06, 0x04, 0x22, 0x7f, 0x04, 0xad, 0x37,
+	0xfc, 0x44, 0x6c, 0x8d, 0x1d, 0x44, 0xf4, 0x76, 0x4c, 0xc5, 0x45, 0x7e, 0x0e, 0xe5, 0xb5, 0xee,
+	0x21, 0x67, 0xfb, 0xa1, 0xe6, 0xf8, 0xc4, 0xb9, 0xcc, 0xdf, 0x79, 0x2e, 0x7f, 0x0c, 0x2d, 0x1d,
+	0x63, 0xcf, 0xba, 0x08, 0x30, 0xd2, 0x1c, 0x7d, 0x85, 0xd8, 0x61, 0xae, 0x2a, 0xcd, 0x08, 0x3c,
+	0x25, 0x50, 0xf9, 0xef, 0x0b, 0xd0, 0x50, 0x91, 0xee, 0x19, 0x57, 0x82, 0x1f, 0xf9, 0x14, 0xa0,
+	0x61, 0xe4, 0xad, 0xf8, 0x0e, 0x80, 0x81, 0x16, 0xc8, 0x5b, 0x49, 0x4f, 0x12, 0x67, 0x86, 0xc8,
+	0x52, 0x14, 0x4f, 0x86, 0xf4, 0x31, 0x34, 0x56, 0xba, 0x13, 0x5c, 0xea, 0x06, 0x0e, 0x3c, 0xe4,
+	0x85, 0x2b, 0x27, 0x81, 0xd2, 0x27, 0x50, 0x12, 0x83, 0xc6, 0xae, 0xb0, 0x19, 0xcb, 0x40, 0x8a,
+	0xee, 0x2c, 0x91, 0xc2, 0x28, 0x92, 0x8e, 0x5b, 0xda, 0xea, 0xb8, 0xe5, 0x5b, 0x1c, 0x77, 0xe7,
+	0x3e, 0x8e, 0x9b, 0xe5, 0x87, 0x95, 0x4c, 0x3f, 0x14, 0x02, 0x4d, 0x35, 0x11, 0xe3, 0x87, 0xd0,
+	0xf4, 0xd7, 0xc8, 0xb0, 0x2e, 0x2d, 0x43, 0xc7, 0x96, 0xeb, 0xf8, 0x5d, 0xa0, 0xb6, 0x7a, 0x14,
+	0xaf, 0x2c, 0xa2, 0x8f, 0x2d, 0x1b, 0x23, 0x4f, 0x49, 0xcd, 0x91, 0xff, 0x25, 0x0f, 0xcd, 0xd0,
+	0x28, 0xdc, 0x51, 0x7e, 0x06, 0x4d, 0x6e, 0x15, 0x0f, 0xf9, 0x81, 0x7d, 0x8b, 0x87, 0x37, 0xfc,
+	0x70, 0x26, 0x21, 0x93, 0x7e, 0x04, 0xc5, 0x2b, 0x2b, 0xf2, 0x19, 0x41, 0x03, 0x94, 0xea, 0xd4,
+	0xc2, 0x0a, 0xc5, 0x4b, 0x9f, 0x42, 0xf9, 0x52, 0x37, 0x10, 0x8d, 0xfa, 0xb9, 0x44, 0x20, 0x62,
+	0x94, 0xc7, 0x14, 0xa9, 0x70, 0x22, 0xe9, 0x08, 0xf6, 0xfc, 0x60, 0xb9, 0x44, 0x3e, 0x46, 0xa6,
+	0x26, 0xfa, 0x4b, 0x91, 0xea, 0x63, 0x37, 0x42, 0xaa, 0xb1, 0xe3, 0x64, 0x9d, 0xb8, 0xd2, 0x7d,
+	0x4e, 0x5c, 0x79, 0xe3, 0xc4, 0xfd, 0x39, 0x34, 0x15, 0x64, 0xeb, 0x18, 0x99, 0xdb, 0x2f, 0xeb,
+	0x0e, 0x94, 0x6c, 0x6b, 0x65, 0x61, 0x1e, 0xeb, 0xd8, 0x60, 0xf3, 0x0a, 0x2f, 0x6c, 0x5e, 0xe1,
+	0xf2, 0x10, 0x5a, 0x11, 0x7b, 0x6e, 0x80, 0x97, 0xb0, 0xe3, 0x31, 0x10, 0xd7, 0xfc, 0x41, 0x7c,
+	0x77, 0x31, 0x78, 0x74, 0x0d, 0x73, 0x3a, 0xf9, 0x6f, 0x72, 0xb0, 0xab, 0x06, 0x17, 0x2b, 0x0b,
+	0x2b, 0xe8, 0xda, 0x42, 0xdf, 0x6e, 0x17, 0x95, 0xc4, 0xee, 0xc0, 0xc7, 0xee, 0x0a, 0x79, 0x24,
+	0x76, 0xb3, 0x30, 0x04, 0x21, 0x68, 0x4c, 0x2f, 0x36, 0x7e, 0x71, 0xb2, 0xe8, 0xc3, 0x47, 0x64,
+	0x8f, 0xd8, 0xc2, 0x36, 0xe2, 0x6a, 0x67, 0x03, 0x49, 0x82, 0xe2, 0x85, 0x6b, 0xde, 0x70, 0xe5,
+	0xd2, 0x6f, 0xf9, 0xd7, 0xd0, 0x49, 0xca, 0xc2, 0xf7, 0xf5, 0x63, 0x28, 0x7b, 0x14, 0xc2, 0x23,
+	0x50, 0x4b, 0xd8, 0x16, 0x25, 0xe4, 0x68, 0xf9, 0x6b, 0xa2, 0x72, 0xf2, 0xb5, 0x3d, 0xc6, 0xbd,
+	0xd7, 0x15, 0x23, 0xff, 0x47, 0x0e, 0x5a, 0x11, 0x67, 0x2e, 0xd5, 0x27, 0x44, 0xdb, 0x14, 0xc4,
+	0xb5, 0xbd, 0x21, 0x56, 0x88, 0xff, 0x1e, 0xe3, 0xb8, 0x90, 0x9e, 0x14, 0xef, 0x95, 0x9e, 0xbc,
+	0x85, 0x07, 0x4c, 0x9a, 0x37, 0x2e, 0x8e, 0x32, 0x80, 0x87, 0x50, 0x65, 0xa2, 0xc5, 0xb7, 0x70,
+	0x85, 0x01, 0xc6, 0xe6, 0xdd, 0x86, 0xee, 0xc2, 0xce, 0x15, 0xb2, 0xd7, 0x97, 0x81, 0x4d, 0xe5,
+	0xab, 0x28, 0xe1, 0x50, 0xbe, 0x00, 0x49, 0x5c, 0x8c, 0x2b, 0xea, 0x23, 0x68, 0x70, 0x02, 0xed,
+	0xda, 0xc5, 0x34, 0x87, 0x21, 0xbb, 0xaa, 0x73, 0x20, 0xa1, 0xa5, 0xb7, 0x41, 0xe0, 0x24, 0xc9,
+	0x98, 0x71, 0x9a, 0x81, 0x23, 0x12, 0xca, 0xbf, 0x82, 0xa6, 0xca, 0x0e, 0xae, 0x90, 0x69, 0xad,
+	0x3d, 0x74, 0x69, 0xbd, 0xe3, 0x66, 0xe6, 0xa3, 0xec, 0xc3, 0x25, 0xcf, 0xa0, 0x15, 0xcd, 0xe7,
+	0x02, 0xfe, 0x12, 0x6a, 0x3c, 0x16, 0xd0, 0x70, 0xc8, 0xac, 0xd9, 0x4b, 0x47, 0x2d, 0x35, 0x22,
+	0x51, 0x44, 0x72, 0x19, 0x41, 0x67, 0xe0, 0x21, 0x1d, 0xa3, 0xf0, 0x70, 0x71, 0xb1, 0xbe, 0x4b,
+	0x36, 0xfc, 0x03, 0xa8, 0x0b, 0x89, 0x51, 0x78, 0x63, 0xd5, 0xe2, 0xcc, 0xc8, 0x97, 0x07, 0xb0,
+	0x97, 0x5a, 0xe6, 0xbb, 0x67, 0xdd, 0xf2, 0x2b, 0xe8, 0x9c, 0xaf, 0xcd, 0xdf, 0x4b, 0x56, 0x22,
+	0x48, 0x8a, 0xc7, 0x7b, 0x08, 0xf2, 0x1c, 0x3a, 0x43, 0x64, 0xa3, 0x0d, 0x41, 0x36, 0x73, 0x92,
+	0x97, 0xb0, 0x97, 0xa2, 0xe4, 0xcb, 0x75, 0x61, 0xc7, 0x0f, 0x0c, 0x03, 0xf9, 0xcc, 0xa1, 0x2a,
+	0x4a, 0x38, 0x94, 0x27, 0xa1, 0xaa, 0xa2, 0xac, 0x98, 0x73, 0xff, 0x43, 0xa8, 0x84, 0x2a, 0xe5,
+	0x22, 0x6e, 0x4f, 0xa4, 0x23, 0x4a, 0x79, 0x0a, 0xfb, 0x69, 0x76, 0x5c, 0x84, 0xf7, 0xe3, 0x37,
+	0x09, 0x15, 0xf8, 0xbd, 0x89, 0x97, 0x66, 0xf7, 0x7b, 0x89, 0xf7, 0x79, 0xa8, 0xf0, 0xb4, 0x78,
+	0x77, 0x65, 0xef, 0xf2, 0x11, 0xec, 0xa7, 0x67, 0xde, 0x69, 0xab, 0x31, 0x74, 0xfa, 0xbe, 0x6f,
+	0x2d, 0x9d, 0xbb, 0x1c, 0x21, 0xbd, 0x7c, 0x7e, 0x63, 0xf9, 0x97, 0xb0, 0x97, 0x62, 0x75, 0xe7,
+	0xea, 0x7f, 0x99, 0x83, 0x8e, 0x6a, 0x5c, 0x21, 0x33, 0xb0, 0x11, 0x4b, 0xea, 0xb6, 0x2e, 0xff,
+	0x71, 0x98, 0x0c, 0xe6, 0x33, 0x5f, 0x90, 0x71, 0x1e, 0xe8, 0x63, 0xdd, 0xc3, 0xbe, 0xa6, 0xb3,
+	0xe8, 0x5d, 0x50, 0x2a, 0x0c, 0xd0, 0xa7, 0x0f, 0x2f, 0xe4, 0x98, 0x14, 0x55, 0xa4, 0xa8, 0x32,
+	0x19, 0xf6, 0xb1, 0x3c, 0x87, 0xbd, 0x94, 0x14, 0x51, 0x4a, 0x55, 0xa7, 0x7c, 0x35, 0xe3, 0x8a,
+	0x64, 0x9b, 0xdc, 0x8a, 0x9d, 0x64, 0x22, 0x3a, 0xa0, 0x38, 0xa5, 0xb6, 0x8e, 0x07, 0xf2, 0xd7,
+	0xf0, 0x70, 0xa0, 0x3b, 0x06, 0xb2, 0x43, 0xbe, 0xe6, 0x1d, 0xdb, 0xfb, 0x11, 0xb4, 0xc4, 0x95,
+	0x62, 0x0d, 0x37, 0x04, 0xb6, 0x63, 0x53, 0xfe, 0x1c, 0x1e, 0x65, 0x33, 0xbe, 0x53, 0xd7, 0x27,
+	0xe4, 0x72, 0xa0, 0x59, 0xc7, 0x99, 0xe5, 0xbc, 0xbd, 0xd5, 0xce, 0x3c, 0x3b, 0xd1, 0x08, 0x86,
+	0xdf, 0x3f, 0x1c, 0xa4, 0xbe, 0x0d, 0xe4, 0xcf, 0x60, 0x37, 0xc1, 0xe8, 0xce, 0x95, 0x0d, 0xd8,
+	0x9b, 0xb8, 0x26, 0xf2, 0x74, 0x8c, 0xd8, 0xf5, 0x74, 0xaf, 0x7b, 0xf0, 0x53, 0x28, 0xfb, 0x58,
+	0xc7, 0x01, 0xbb, 0x88, 0x9a, 0x42, 0xb6, 0xc9, 0x98, 0xa8, 0x14, 0xa9, 0x70, 0x22, 0xe2, 0xfc,
+	0xe9, 0x45, 0xee, 0x14, 0xec, 0x0c, 0xf6, 0xe6, 0xc8, 0x31, 0x2d, 0x67, 0x99, 0x4a, 0x5b, 0x12,
+	0x49, 0x4a, 0x6e, 0x6b, 0x92, 0x92, 0x4f, 0x24, 0x29, 0x7f, 0x97, 0x83, 0xfd, 0x34, 0xbb, 0xff,
+	0xcf, 0x5c, 0x45, 0x7e, 0x0d, 0xbb, 0xd4, 0x39, 0x4e, 0x2d, 0x1f, 0x0b, 0x71, 0x64, 0xd3, 0xe4,
+	0x12, 0x14, 0x2f, 0x3d, 0x77, 0x45, 0xd7, 0x29, 0x28, 0xf4, 0x5b, 0x6a, 0x42, 0x1e, 0xbb, 0xfc,
+	0x08, 0xe5, 0xb1, 0x2b, 0x7f, 0x05, 0x9d, 0x24, 0x33, 0xbe, 0xb5, 0x2f, 0xa0, 0x21, 0x3a, 0x6e,
+	0xb8, 0xc1, 0xec, 0x33, 0x52, 0x17, 0x9c, 0xd9, 0x97, 0xff, 0x27, 0x07, 0xbb, 0x3c, 0x56, 0x30,
+	0x90, 0x39, 0xba, 0x46, 0x4e, 0x96, 0x80, 0xbf, 0x80, 0x1a, 0x3f, 0x17, 0xf8, 0x66, 0x8d, 0xb8,
+	0x43, 0x6c, 0x64, 0x08, 0x8c, 0xc9, 0xe2, 0x66, 0x8d, 0x14, 0x30, 0xa2, 0x6f, 0xf1, 0x5e, 0x2c,
+	0xdc, 0x95, 0x08, 0xfc, 0x11, 0x34, 0xd7, 0xc4, 0x12, 0x6e, 0xe0, 0xf3, 0x82, 0x55, 0x39, 0x33,
+	0xdc, 0x34, 0x42, 0x2a, 0xba, 0x3b, 0xe9, 0x11, 0x54, 0xb1, 0xb5, 0x42, 0x3e, 0xd6, 0x57, 0x6b,
+	0x9a, 0x52, 0x17, 0x94, 0x18, 0xf0, 0x65, 0xb1, 0x52, 0x6c, 0x97, 0xe4, 0xbf, 0x28, 0xc1, 0x0e,
+	0x5f, 0x2f, 0xdb, 0x04, 0xe4, 0x0d, 0xce, 0x4d, 0x4d, 0xbf, 0xa5, 0x67, 0x50, 0x23, 0xd5, 0x11,
+	0xcf, 0x5a, 0x93, 0x4c, 0x87, 0xa7, 0xc4, 0x22, 0x48, 0x92, 0xa1, 0x2e, 0x3e, 0x99, 0x79, 0x8a,
+	0x9f, 0x80, 0x91, 0x34, 0x6c, 0xe5, 0x9a, 0xc8, 0xe6, 0xa9, 0x3e, 0x1b, 0xc4, 0xe1, 0xb4, 0x7a,
+	0x5b, 0x38, 0x7d, 0x0c, 0xc0, 0x6a, 0x0b, 0x34, 0x14, 0xec, 0x50, 0x06, 0x55, 0x06, 0x51, 0xdf,
+	0x06, 0xd2, 0x17, 0x00, 0x51, 0xad, 0xc0, 0xef, 0x56, 0xa8, 0xe1, 0x3f, 0x4c, 0x2b, 0xb7, 0x1f,
+	0x52, 0x28, 0x02, 0x31, 0x79, 0xdb, 0xaf, 0x90, 0x69, 0xe9, 0xfc, 0xf1, 0x1b, 0xbf, 0xed, 0x27,
+	0x04, 0xda, 0xf7, 0x7d, 0x84, 0x15, 0x46, 0x41, 0x52, 0x6e, 0x1e, 0x08, 0x6a, 0xd4, 0xee, 0xfb,
+	0x1b, 0x99, 0x61, 0x22, 0x12, 0x48, 0x9f, 0x43, 0x1d, 0x7b, 0xba, 0xe3, 0xdb, 0xfc, 0x79, 0x5d,
+	0x4f, 0x39, 0xe4, 0x22, 0x46, 0x2a, 0x09, 0x4a, 0xe9, 0x39, 0x14, 0xdf, 0x5a, 0x8e, 0xd9, 0x6d,
+	0xd0, 0x75, 0x3a, 0xe9, 0x75, 0x5e, 0x5b, 0x8e, 0xa9, 0x50, 0x0a, 0x5a, 0x14, 0x74, 0x57, 0x6b,
+	0xd7, 0x41, 0xa4, 0xd8, 0xd2, 0x4c, 0x15, 0x05, 0x5f, 0x05, 0x8e, 0x69, 0xa3, 0x41, 0x48, 0xa0,
+	0x08, 0xb4, 0xd2, 0xaf, 0x36, 0x9e, 0xff, 0x2d, 0x3a, 0x7b, 0x3f, 0xfb, 0xf9, 0x9f, 0x7e, 0xf8,
+	0x4b, 0x3f, 0x83, 0x96, 0x1f, 0x86, 0x7e, 0xee, 0xa2, 0xed, 0x4c, 0x13, 0x36, 0xfd, 0xc4, 0x0d,
+	0xf1, 0x65, 0xb1, 0x52, 0x6e, 0xef, 0xc8, 0xbf, 0x86, 0x56, 0x4a, 0xba, 0x0c, 0x67, 0xec, 0x41,
+	0xe5, 0x9b, 0x40, 0x77, 0xb0, 0x85, 0x6f, 0xc2, 0x27, 0x5a, 0x38, 0x96, 0xff, 0x35, 0x17, 0xbd,
+	0xab, 0x43, 0x6f, 0xfe, 0x2e, 0x99, 0xf6, 0x67, 0x24, 0xe4, 0x87, 0x96, 0x21, 0x69, 0x76, 0xf3,
+	0xe8, 0x41, 0xf2, 0x95, 0x4c, 0x36, 0x1d, 0xd3, 0x90, 0x2a, 0xc2, 0x85, 0x1b, 0x2c, 0xaf, 0xb0,
+	0x86, 0xdd, 0x25, 0xc2, 0x57, 0xc8, 0x4b, 0xc4, 0xbb, 0x5d, 0x86, 0x5c, 0x70, 0x1c, 0x0b, 0x7c,
+	0x43, 0x28, 0x51, 0x1d, 0x90, 0x50, 0xad, 0xaf, 0x28, 0x75, 0x8e, 0x5d, 0xf8, 0x6c, 0x74, 0xaf,
+	0x22, 0xbd, 0xfc, 0x4f, 0x79, 0xa8, 0x09, 0xc1, 0x2b, 0xeb, 0x8a, 0xce, 0x65, 0x5c, 0xd1, 0xa1,
+	0x3e, 0xf3, 0xb1, 0x3e, 0x5f, 0x02, 0xb0, 0x99, 0x34, 0x7a, 0x15, 0x52, 0x85, 0x26, 0xba, 0x06,
+	0x8d, 0x5a, 0xd5, 0x75, 0xf8, 0x19, 0x9f, 0xcf, 0xe2, 0xbd, 0xd3, 0x9d, 0xd2, 0xf6, 0x74, 0xa7,
+	0x2c, 0xa6, 0x3b, 0xe4, 0x54, 0x1b, 0x34, 0xa1, 0x36, 0x09, 0x6e, 0x87, 0x85, 0x2b, 0x0e, 0xe9,
+	0xf3, 0xc7, 0x10, 0x49, 0x31, 0x6c, 0x46, 0x50, 0xa1, 0x04, 0xb5, 0x08, 0xd6, 0xc7, 0xf2, 0x7f,
+	0xe7, 0xa1, 0xcc, 0x2e, 0xae, 0xdb, 0xef, 0xf0, 0x4d, 0x55, 0xa4, 0x5e, 0xb7, 0x85, 0x5b, 0xca,
+	0x18, 0xc5, 0xec, 0x32, 0x46, 0x29, 0xab, 0x8c, 0x51, 0x8e, 0xcb, 0x18, 0x42, 0xe2, 0xb0, 0x73,
+	0x8f, 0xc4, 0x21, 0xa5, 0x8d, 0x4a, 0x86, 0x36, 0x56, 0x3c, 0xaf, 0xa0, 0x04, 0x55, 0xa6, 0x8d,
+	0x08, 0xd6, 0xc7, 0x9b, 0x0f, 0x6c, 0xb8, 0xdf, 0x03, 0xbb, 0x96, 0xf9, 0xc0, 0xfe, 0x16, 0x1a,
+	0x89, 0x52, 0x82, 0xf4, 0x43, 0x68, 0xea, 0xd7, 0xc8, 0x23, 0xf9, 0x00, 0xd7, 0x0c, 0x51, 0x73,
+	0x4e, 0x69, 0x70, 0x28, 0xa3, 0x26, 0x82, 0x72, 0x43, 0xb0, 0xf3, 0xc1, 0x0e, 0x6e, 0x8d, 0xc1,
+	0x58, 0xf1, 0xe2, 0x11, 0x54, 0xaf, 0xc8, 0xf5, 0xbd, 0xf4, 0xf4, 0x15, 0x2d, 0xb9, 0x36, 0x94,
+	0x18, 0x20, 0xff, 0x29, 0xd4, 0x84, 0xd0, 0x28, 0xd4, 0x2f, 0x73, 0x89, 0xfa, 0xe5, 0x7b, 0xdd,
+	0x54, 0xf2, 0x7f, 0xe6, 0xa0, 0x91, 0x08, 0x6c, 0x11, 0x9f, 0x9c, 0xc0, 0xe7, 0x05, 0x14, 0x33,
+	0x2f, 0xf8, 0xc4, 0x4c, 0x7a, 0x54, 0x28, 0x1d, 0xb1, 0x1d, 0x26, 0xc9, 0xd2, 0xb5, 0x6e, 0x07,
+	0x61, 0x99, 0xae, 0x4a, 0x20, 0x6f, 0x08, 0x80, 0xa8, 0xc4, 0x09, 0x56, 0x17, 0xc8, 0xe3, 0x04,
+	0x45, 0xaa, 0xb7, 0x1a, 0x83, 0x31, 0x92, 0x8f, 0xa0, 0x71, 0xe1, 0xba, 0x36, 0xd2, 0x1d, 0x4e,
+	0xc3, 0x7a, 0x10, 0x75, 0x0e, 0xa4, 0x44, 0xf2, 0xdf, 0xe6, 0xe0, 0x20, 0x21, 0xc2, 0x10, 0x5d,
+	0x5a, 0x8e, 0xf5, 0xbd, 0x6d, 0x43, 0x82, 0x62, 0xe0, 0x58, 0x98, 0x6f, 0x80, 0x7e, 0x93, 0x18,
+	0xec, 0xa1, 0x6f, 0x02, 0xcb, 0x43, 0x26, 0x95, 0xbb, 0xa2, 0x44, 0x63, 0x79, 0x05, 0xbb, 0x19,
+	0x35, 0xe2, 0x4c, 0x51, 0xf6, 0xa1, 0x4c, 0xf7, 0xc5, 0x82, 0x6d, 0x55, 0xe1, 0x23, 0xe9, 0x10,
+	0x4a, 0x1e, 0x7d, 0xd2, 0x14, 0x52, 0x4f, 0x9a, 0x29, 0x55, 0x0e, 0x2f, 0xae, 0x53, 0x12, 0xf9,
+	0x25, 0xd4, 0x04, 0x28, 0x39, 0xd4, 0x2b, 0xcb, 0xe1, 0x4e, 0x48, 0x3e, 0x29, 0x44, 0x7f, 0xd7,
+	0xcd, 0x73, 0x88, 0xfe, 0x4e, 0xfe, 0x25, 0xb4, 0xd3, 0xd7, 0x7f, 0xa6, 0x78, 0x1d, 0x28, 0x31,
+	0xb5, 0x33, 0x6f, 0x62, 0x03, 0xf9, 0x9f, 0x73, 0x00, 0x71, 0x1e, 0x40, 0xd8, 0x07, 0x9e, 0x1d,
+	0x5e, 0x50, 0x81, 0x67, 0x4b, 0x1f, 0x42, 0x45, 0xb7, 0xb1, 0x46, 0x2c, 0xcd, 0x67, 0xee, 0xe8,
+	0x36, 0x5e, 0xa0, 0x77, 0x98, 0xc4, 0x5a, 0x9a, 0x36, 0x64, 0xc7, 0x5a, 0xca, 0x95, 0xc5, 0xda,
+	0x55, 0xf8, 0x49, 0x84, 0xf8, 0xd6, 0x32, 0xf1, 0x15, 0x8f, 0x38, 0x6c, 0x40, 0x34, 0x77, 0x85,
+	0xac, 0xe5, 0x15, 0xe6, 0xfd, 0x04, 0x3e, 0x92, 0x97, 0x50, 0x8d, 0x0a, 0xe3, 0xd9, 0x25, 0x65,
+	0xdf, 0x70, 0x3d, 0xc4, 0xb5, 0xc1, 0x06, 0xd2, 0x11, 0xc0, 0x95, 0xb5, 0xbc, 0xb2, 0x09, 0x87,
+	0xb0, 0x69, 0x1a, 0x4b, 0x75, 0x1a, 0xa2, 0x14, 0x81, 0x4a, 0xfe, 0x05, 0x54, 0x23, 0x04, 0x61,
+	0x7b, 0x69, 0x21, 0xdb, 0xe4, 0x4b, 0xb1, 0x01, 0x7d, 0xda, 0x38, 0xd6, 0x7a, 0x8d, 0x22, 0x35,
+	0xf0, 0xa1, 0x7c, 0x0e, 0x10, 0x77, 0x49, 0x44, 0x93, 0x15, 0x36, 0x4c, 0x56, 0xa0, 0x26, 0xbb,
+	0x5f, 0xd5, 0xfb, 0xdf, 0x73, 0x50, 0x17, 0x8b, 0xfd, 0xd2, 0x6f, 0xd2, 0x9d, 0x9c, 0x74, 0xf5,
+	0x6e, 0x22, 0x60, 0xe9, 0x9c, 0x74, 0x97, 0xe7, 0x8f, 0x37, 0x7a, 0x45, 0x62, 0x32, 0x14, 0x96,
+	0x32, 0xd8, 0x54, 0x81, 0x52, 0xfa, 0x09, 0x29, 0x3b, 0x5a, 0x06, 0x0a, 0xd5, 0x99, 0x6a, 0x0f,
+	0xb1, 0x09, 0x9c, 0x44, 0x9e, 0xc0, 0x83, 0x0d, 0x41, 0x36, 0xb2, 0xe7, 0x5c, 0x76, 0xf6, 0x2c,
+	0x86, 0x53, 0x36, 0x90, 0x8f, 0xa1, 0x91, 0x10, 0xec, 0xee, 0xce, 0x6a, 0x36, 0x9f, 0x09, 0xb7,
+	0x12, 0x63, 0xf2, 0x49, 0x78, 0x26, 0x73, 0xb7, 0xf4, 0xbb, 0x28, 0xc5, 0x16, 0x76, 0x5f, 0xc0,
+	0x83, 0x8d, 0x62, 0xe9, 0xfd, 0xde, 0x1a, 0xf2, 0x3f, 0xe6, 0xa1, 0x95, 0xaa, 0x49, 0xdd, 0xbd,
+	0xa9, 0xf7, 0x7b, 0xb4, 0x3c, 0x04, 0xfe, 0x84, 0x20, 0x4c, 0x8b, 0x2c, 0x63, 0x60, 0x80, 0xb1,
+	0xb9, 0x91, 0xbc, 0x97, 0xee, 0x9d, 0xbc, 0xab, 0xd0, 0x49, 0xa4, 0xca, 0x1a, 0xc9, 0x7f, 0x57,
+	0x7a, 0xb7, 0x4c, 0x39, 0x3c, 0xcb, 0x0e, 0xc2, 0x71, 0x20, 0x57, 0x76, 0x13, 0xb3, 0x55, 0x3a,
+	0x59, 0xfe, 0x16, 0xea, 0x62, 0x93, 0xfd, 0xfd, 0x4a, 0x7a, 0xd2, 0x4b, 0xa8, 0x18, 0x57, 0x96,
+	0x6d, 0x7a, 0xc8, 0xe1, 0x0e, 0xbe, 0xa5, 0x87, 0x1f, 0x91, 0x1d, 0x1a, 0x50, 0x8d, 0x1a, 0x90,
+	0x52, 0x13, 0x40, 0x9d, 0x69, 0xc3, 0xd1, 0x71, 0xff, 0xfc, 0x6c, 0xd1, 0xfe, 0x40, 0x6a, 0x41,
+	0x4d, 0x9d, 0x69, 0xd3, 0xfe, 0x64, 0xa4, 0xf5, 0xd5, 0x41, 0x3b, 0x27, 0xb5, 0xa1, 0x1e, 0x02,
+	0x86, 0x23, 0x75, 0xd0, 0xce, 0x73, 0xc8, 0x5c, 0x19, 0x0f, 0x18, 0x4d, 0x41, 0x7a, 0x00, 0x8d,
+	0x08, 0x42, 0x89, 0x8a, 0x87, 0x37, 0x91, 0xbf, 0xc4, 0x4f, 0x67, 0xb2, 0xd8, 0x7c, 0xa0, 0x9d,
+	0x4f, 0x5f, 0x4f, 0x67, 0x5f, 0x4f, 0xdb, 0x1f, 0xf0, 0xf1, 0x40, 0x19, 0xf5, 0x17, 0xa3, 0x61,
+	0x3b, 0x17, 0xe2, 0xe7, 0x43, 0x3a, 0xce, 0x13, 0x61, 0xe6, 0x03, 0x4d, 0x19, 0x51, 0xce, 0xc3,
+	0x76, 0x41, 0xda, 0x85, 0xd6, 0x7c, 0xa0, 0x0d, 0xc7, 0xea, 0x60, 0x36, 0x5d, 0x8c, 0xa7, 0xe7,
+	0xa3, 0x61, 0xbb, 0xc8, 0x67, 0x0d, 0x47, 0x67, 0x23, 0x32, 0xab, 0x74, 0x38, 0x87, 0x46, 0xe2,
+	0xf5, 0x26, 0x35, 0xa0, 0x3a, 0x57, 0xb5, 0xfe, 0x60, 0x31, 0x7e, 0x33, 0x6a, 0x7f, 0x20, 0xd5,
+	0xa1, 0x32, 0x57, 0xb5, 0xa1, 0xd2, 0x3f, 0x5e, 0xb4, 0x73, 0x94, 0xa5, 0x9a, 0x64, 0x99, 0xe7,
+	0x33, 0x4e, 0xc7, 0xc3, 0xe1, 0x68, 0xda, 0x2e, 0x1c, 0x7e, 0x0a, 0x35, 0xce, 0x91, 0xbc, 0xd3,
+	0xa8, 0x58, 0xaf, 0x35, 0x75, 0xd1, 0x9f, 0x0e, 0xfb, 0xca, 0xb0, 0xfd, 0x01, 0x25, 0x7f, 0xad,
+	0xbd, 0x3a, 0x9f, 0x0e, 0xcf, 0x46, 0xed, 0xdc, 0xe1, 0x0c, 0x1e, 0x6c, 0x5c, 0xc7, 0x54, 0xd1,
+	0x0b, 0x61, 0xef, 0x35, 0xd8, 0x51, 0x17, 0xda, 0x62, 0xf4, 0x5b, 0x22, 0x44, 0x03, 0xaa, 0xea,
+	0x42, 0x9b, 0x9e, 0x4f, 0x5e, 0x8d, 0x94, 0x76, 0x9e, 0xd3, 0xbe, 0x9a, 0xcd, 0xce, 0x46, 0x7d,
+	0xb2, 0xfe, 0x35, 0x54, 0xc2, 0xf7, 0x0b, 0x59, 0x5c, 0x19, 0x9d, 0x25, 0x95, 0x48, 0x00, 0x67,
+	0xe3, 0xe9, 0x6b, 0xaa, 0xc4, 0x03, 0xd8, 0x25, 0xe3, 0x57, 0xb3, 0xf3, 0x93, 0xd3, 0x85, 0xb6,
+	0x98, 0x9d, 0x8c, 0x16, 0xa7, 0x94, 0xeb, 0x1e, 0x3c, 0x20, 0x08, 0x95, 0x98, 0x72, 0xd0, 0x5f,
+	0x8c, 0x4e, 0x66, 0xca, 0x9f, 0xb4, 0x0b, 0xd2, 0x87, 0xb0, 0x17, 0x81, 0x27, 0xfd, 0xe9, 0xf9,
+	0x71, 0x7f, 0xb0, 0x38, 0x57, 0x46, 0x4a, 0xbb, 0x78, 0x38, 0x87, 0xba, 0x98, 0xd7, 0xd2, 0xa5,
+	0xd4, 0xd4, 0xd2, 0xaa, 0x36, 0x1f, 0x4d, 0x87, 0xe3, 0xe9, 0x49, 0x3b, 0x47, 0x65, 0x53, 0xb5,
+	0xfe, 0x7c, 0xae, 0xcc, 0xde, 0x84, 0x06, 0x54, 0x54, 0x4d, 0x19, 0x7d, 0x39, 0x1a, 0x10, 0xdb,
+	0x14, 0x0e, 0x4f, 0xa1, 0x1a, 0xdd, 0x93, 0x64, 0xfa, 0x44, 0x54, 0x49, 0x1d, 0x2a, 0x93, 0x85,
+	0x36, 0x9e, 0xf4, 0x4f, 0x46, 0xed, 0x1c, 0x1f, 0xbd, 0x19, 0x0f, 0x47, 0x33, 0xc6, 0x69, 0xb2,
+	0xd0, 0x86, 0xb3, 0xc1, 0xf9, 0x64, 0x34, 0x5d, 0xb4, 0x0b, 0x87, 0x3f, 0x87, 0x6a, 0xf4, 0xba,
+	0xa1, 0x2e, 0x90, 0x52, 0xee, 0x7c, 0xa1, 0x9d, 0x8d, 0xd5, 0x05, 0xf3, 0xe0, 0xf9, 0x42, 0x53,
+	0x07, 0xa7, 0xa3, 0xe1, 0xf9, 0x19, 0x11, 0xeb, 0xe8, 0xbf, 0xca, 0xb0, 0x33, 0x60, 0x87, 0x44,
+	0x1a, 0xc2, 0x83, 0x13, 0x84, 0xb9, 0x79, 0xd9, 0x5f, 0x4f, 0xbe, 0x14, 0x5f, 0x12, 0x89, 0x7f,
+	0xb2, 0x7a, 0x07, 0x1b, 0x70, 0x5e, 0xaa, 0x3a, 0x87, 0x4e, 0xcc, 0x25, 0xfe, 0xd3, 0x47, 0x7a,
+	0x1c, 0x4d, 0xc8, 0xfa, 0x93, 0xa8, 0xf7, 0x64, 0x1b, 0x9a, 0xb3, 0x9d, 0x42, 0xeb, 0x04, 0x61,
+	0xf1, 0xcf, 0x1d, 0xe9, 0xd1, 0xc6, 0xf1, 0x16, 0x7e, 0x00, 0xea, 0x3d, 0xde, 0x82, 0xe5, 0xfc,
+	0xfe, 0x0c, 0xf6, 0x62, 0x31, 0xfd, 0xb1, 0x13, 0x12, 0x49, 0xcf, 0x36, 0xe6, 0xa5, 0x7e, 0xf0,
+	0xe9, 0xfd, 0xe0, 0x16, 0x0a, 0xce, 0x7d, 0x0c, 0x52, 0xcc, 0x3d, 0xfc, 0xd9, 0x44, 0x8a, 0xa3,
+	0x58, 0xea, 0x2f, 0x95, 0xde, 0x87, 0x19, 0x18, 0xce, 0xea, 0x37, 0xf1, 0x19, 0xa6, 0x29, 0x81,
+	0x60, 0x91, 0xc4, 0xff, 0x22, 0xbd, 0x83, 0x0d, 0x38, 0xe7, 0x30, 0x8c, 0x9a, 0x81, 0xa1, 0x9c,
+	0x92, 0x40, 0x9b, 0x68, 0x33, 0xf6, 0xba, 0x9b, 0x08, 0xce, 0xe5, 0x84, 0x6e, 0x29, 0x59, 0x94,
+	0x10, 0x19, 0x25, 0x7f, 0x03, 0xe8, 0x75, 0x37, 0x11, 0x9c, 0xd1, 0x6b, 0xa8, 0x8b, 0x0d, 0x70,
+	0xc1, 0x8c, 0x19, 0x3d, 0xfa, 0xde, 0xe3, 0x2d, 0x58, 0xce, 0xec, 0x58, 0xf4, 0x59, 0x85, 0x57,
+	0x77, 0x0f, 0x52, 0x6f, 0x51, 0x3f, 0x4b, 0xa8, 0x64, 0xed, 0x78, 0x04, 0xc0, 0xda, 0xb9, 0x54,
+	0xa4, 0x5e, 0x8a, 0x4e, 0x68, 0x2b, 0xf7, 0x1e, 0x66, 0xe2, 0x18, 0x9b, 0xa3, 0x7f, 0xab, 0xd2,
+	0xab, 0x8c, 0xa0, 0xfb, 0x26, 0xc9, 0x00, 0xa7, 0xd0, 0x48, 0x34, 0x34, 0x85, 0x63, 0x90, 0xd5,
+	0x4f, 0xed, 0x3d, 0xd9, 0x86, 0x8e, 0x8e, 0x41, 0x23, 0xd1, 0x97, 0x14, 0xf8, 0x65, 0xf5, 0x3c,
+	0x7b, 0x4f, 0xb6, 0xa1, 0x63, 0x7e, 0x89, 0xc6, 0xa3, 0xc0, 0x2f, 0xab, 0x75, 0xd9, 0x7b, 0xb2,
+	0x0d, 0xcd, 0xf9, 0x7d, 0x05, 0xcd, 0x64, 0x1b, 0x51, 0x4a, 0xef, 0x28, 0xd5, 0x70, 0xeb, 0x3d,
+	0xdd, 0x8a, 0x8f, 0x59, 0x26, 0x5b, 0x7f, 0x52, 0x7a, 0x53, 0xdb, 0x59, 0x6e, 0xe9, 0x19, 0x7e,
+	0x05, 0x4d, 0x26, 0x7e, 0x06, 0xcb, 0xcc, 0xb6, 0x60, 0xef, 0xe9, 0x56, 0x3c, 0x67, 0xf9, 0x5b,
+	0x38, 0x48, 0xf4, 0xe5, 0x16, 0x6e, 0xc4, 0x5b, 0x88, 0x7c, 0x19, 0x4d, 0xc0, 0xde, 0x93, 0x6d,
+	0xe8, 0xd8, 0x44, 0x89, 0xbe, 0x99, 0xc0, 0x2f, 0xab, 0xab, 0xd7, 0x7b, 0xb2, 0x0d, 0xcd, 0xf9,
+	0x19, 0xe4, 0x07, 0xc8, 0xcd, 0xe6, 0x96, 0xf4, 0xb1, 0x10, 0xd6, 0xb6, 0x36, 0xd5, 0x7a, 0x3f,
+	0xbc, 0x83, 0x2a, 0x11, 0xae, 0xc5, 0x5e, 0x86, 0x70, 0xce, 0x33, 0xfa, 0x25, 0xbd, 0xc7, 0x5b,
+	0xb0, 0x9c, 0xdf, 0x0c, 0x24, 0xd6, 0x07, 0x4b, 0xd4, 0x44, 0x1f, 0xa6, 0x83, 0x8c, 0xd0, 0x74,
+	0xeb, 0x3d, 0xca, 0x46, 0x46, 0x2e, 0xd0, 0x39, 0x77, 0xec, 0xef, 0x99, 0x65, 0x33, 0xd9, 0x1c,
+	0x13, 0xbc, 0x2a, 0xb3, 0x35, 0xd7, 0x7b, 0xba, 0x15, 0xcf, 0x59, 0x2e, 0x58, 0x78, 0x4b, 0xf4,
+	0xbb, 0x04, 0xae, 0x99, 0x7d, 0xb5, 0xde, 0xd3, 0xad, 0x78, 0xc6, 0xf5, 0xa2, 0x4c, 0xff, 0xb8,
+	0xfe, 0x83, 0xff, 0x1b, 0x00, 0xf0, 0xd5, 0x6d, 0xde, 0x82, 0x2d, 0x00, 0x00,
 }
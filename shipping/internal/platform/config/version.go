@@ -6,3 +6,6 @@ var Version = "(undefined)"
 
 // ServiceName is the discovery identity for this service.
 const ServiceName = "go.shopping.srv.shipping"
+
+// BaseCurrency is the ISO-4217 code of the currency in which shipping costs are quoted.
+var BaseCurrency = "USD"
@@ -2,6 +2,7 @@ package redis
 
 import (
 	"fmt"
+	"github.com/autodidaddict/go-shopping/shipping/internal/platform/config"
 	"github.com/autodidaddict/go-shopping/shipping/proto"
 	"github.com/garyburd/redigo/redis"
 	"math/rand"
@@ -23,19 +24,19 @@ func (r *ShippingRepository) GetShippingCosts(sku string, zipCode string) (costs
 	// just going to return some bogus values here.
 	return []*shipping.ShippingCost{
 		&shipping.ShippingCost{
-			Price:  2500,
+			Price:  &shipping.Money{Amount: 2500, CurrencyCode: config.BaseCurrency},
 			Method: shipping.ShippingMethod_SM_FEDEX,
 		},
 		&shipping.ShippingCost{
-			Price:  1000,
+			Price:  &shipping.Money{Amount: 1000, CurrencyCode: config.BaseCurrency},
 			Method: shipping.ShippingMethod_SM_RAVEN,
 		},
 	}, nil
-	return
 }
 
-// MarkShipped marks a particular product within an order as shipped
-func (r *ShippingRepository) MarkShipped(sku string, orderID uint64, note string, shippingMethod shipping.ShippingMethod) (trackingNumber string, err error) {
+// MarkShipped marks a quantity of a particular product within an order as shipped
+func (r *ShippingRepository) MarkShipped(sku string, orderID uint64, note string, shippingMethod shipping.ShippingMethod,
+	quantity uint32) (trackingNumber string, err error) {
 	c, err := redis.Dial("tcp", r.redisDialString)
 	if err != nil {
 		return "", err
@@ -47,6 +48,7 @@ func (r *ShippingRepository) MarkShipped(sku string, orderID uint64, note string
 		Shipped:        true,
 		TrackingNumber: randStringBytes(6),
 		ShippingMethod: uint(shippingMethod),
+		Quantity:       quantity,
 	}
 
 	_, err = c.Do("HMSET", redis.Args{}.Add(itemKey).AddFlat(&itemStatus)...)
@@ -91,6 +93,11 @@ func (r *ShippingRepository) GetShippingStatus(orderID uint64, sku string) (ship
 		ShippingMethod: shipping.ShippingMethod(itemStatus.ShippingMethod),
 		TrackingNumber: itemStatus.TrackingNumber,
 		Shipped:        itemStatus.Shipped,
+		Quantity:       itemStatus.Quantity,
+	}
+	// items marked as shipped before quantities were recorded were always a single unit
+	if shippingStatus.Shipped && shippingStatus.Quantity == 0 {
+		shippingStatus.Quantity = 1
 	}
 	return shippingStatus, nil
 }
@@ -123,6 +130,7 @@ type redisShippingStatus struct {
 	Shipped        bool   `redis:"shipped"`
 	TrackingNumber string `redis:"tracking_number"`
 	ShippingMethod uint   `redis:"shipping_method"`
+	Quantity       uint32 `redis:"quantity"`
 }
 
 const letterBytes = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
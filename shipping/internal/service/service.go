@@ -4,6 +4,7 @@ import (
 	"github.com/autodidaddict/go-shopping/shipping/proto"
 	"github.com/micro/go-micro/errors"
 	"golang.org/x/net/context"
+	"strconv"
 	"time"
 )
 
@@ -14,7 +15,8 @@ type shippingService struct {
 
 type shippingRepository interface {
 	GetShippingCosts(sku string, zipCode string) (costs []*shipping.ShippingCost, err error)
-	MarkShipped(sku string, orderID uint64, note string, shippingMethod shipping.ShippingMethod) (trackingNumber string, err error)
+	MarkShipped(sku string, orderID uint64, note string, shippingMethod shipping.ShippingMethod, quantity uint32) (trackingNumber string,
+		err error)
 	GetShippingStatus(orderID uint64, sku string) (shippingStatus *shipping.ShippingStatus, err error)
 	ProductExists(sku string) (exists bool, err error)
 	OrderExists(orderID uint64) (exists bool, err error)
@@ -60,16 +62,23 @@ func (s *shippingService) MarkItemShipped(ctx context.Context, request *shipping
 	if request.ShippingMethod == shipping.ShippingMethod_SM_UNKNOWN {
 		return errors.BadRequest("", "Must supply a valid shipping method")
 	}
+	quantity := request.Quantity
+	if quantity == 0 {
+		quantity = 1
+	}
+	if quantity > shipping.MaxShipmentQuantity {
+		return errors.BadRequest("", "Can't ship more than %d units at once", shipping.MaxShipmentQuantity)
+	}
 	exists, err := s.repo.OrderExists(request.OrderId)
 	if err != nil {
 		return errors.InternalServerError("", "Failed to check order existence: %s", err.Error())
 	}
 	if !exists {
-		return errors.NotFound(string(request.OrderId), "No such order")
+		return errors.NotFound(strconv.FormatUint(request.OrderId, 10), "No such order")
 	}
-	tracking, err := s.repo.MarkShipped(request.Sku, request.OrderId, request.Note, request.ShippingMethod)
+	tracking, err := s.repo.MarkShipped(request.Sku, request.OrderId, request.Note, request.ShippingMethod, quantity)
 	if err != nil {
-		return errors.InternalServerError(string(request.OrderId), "Failed to mark item as shipped: %s", err.Error())
+		return errors.InternalServerError(strconv.FormatUint(request.OrderId, 10), "Failed to mark item as shipped: %s", err.Error())
 	}
 	response.TrackingNumber = tracking
 
@@ -79,6 +88,8 @@ func (s *shippingService) MarkItemShipped(ctx context.Context, request *shipping
 		Note:           request.Note,
 		ShippingMethod: request.ShippingMethod,
 		Sku:            request.Sku,
+		LocationId:     request.LocationId,
+		Quantity:       quantity,
 		Timestamp:      time.Now().UTC().Unix(),
 	})
 	response.Success = err == nil
@@ -97,7 +108,7 @@ func (s *shippingService) GetShippingStatus(ctx context.Context, request *shippi
 		return errors.InternalServerError("", "Failed to check order existence: %s", err)
 	}
 	if !exists {
-		return errors.NotFound(string(request.OrderId), "No such order")
+		return errors.NotFound(strconv.FormatUint(request.OrderId, 10), "No such order")
 	}
 
 	status, err := s.repo.GetShippingStatus(request.OrderId, request.Sku)
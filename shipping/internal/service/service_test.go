@@ -26,7 +26,8 @@ func TestShippingService_GetShippingCost(t *testing.T) {
 			So(err, ShouldBeNil)
 			So(len(resp.ShippingCosts), ShouldEqual, 2)
 			So(resp.ShippingCosts[0].Method, ShouldEqual, shipping.ShippingMethod_SM_FEDEX)
-			So(resp.ShippingCosts[0].Price, ShouldEqual, 2500)
+			So(resp.ShippingCosts[0].Price.Amount, ShouldEqual, 2500)
+			So(resp.ShippingCosts[0].Price.CurrencyCode, ShouldEqual, "USD")
 		})
 
 		Convey("requesting a shipping cost for a non-existent sku should give us an appropriate error", func() {
@@ -121,11 +122,35 @@ func TestShippingService_MarkItemShipped(t *testing.T) {
 			repo.shouldFail = false
 			pub.publishCount = 0
 			var resp shipping.MarkShippedResponse
-			err := svc.MarkItemShipped(ctx, &shipping.MarkShippedRequest{OrderId: 42, ShippingMethod: shipping.ShippingMethod_SM_UPS, Sku: "8675309"}, &resp)
+			err := svc.MarkItemShipped(ctx, &shipping.MarkShippedRequest{OrderId: 42, ShippingMethod: shipping.ShippingMethod_SM_UPS, Sku: "8675309",
+				LocationId: "reno"}, &resp)
 			So(err, ShouldBeNil)
 			So(resp.TrackingNumber, ShouldEqual, "111111")
 			So(resp.Success, ShouldEqual, true)
 			So(pub.publishCount, ShouldEqual, 1)
+			So(pub.lastEvent.LocationId, ShouldEqual, "reno")
+			So(pub.lastEvent.Quantity, ShouldEqual, 1)
+			So(repo.quantity, ShouldEqual, 1)
+		})
+
+		Convey("marking several units as shipped should carry the quantity through to the event", func() {
+			repo.shouldFail = false
+			var resp shipping.MarkShippedResponse
+			err := svc.MarkItemShipped(ctx, &shipping.MarkShippedRequest{OrderId: 42, ShippingMethod: shipping.ShippingMethod_SM_UPS, Sku: "8675309",
+				Quantity: 5}, &resp)
+			So(err, ShouldBeNil)
+			So(pub.lastEvent.Quantity, ShouldEqual, 5)
+			So(repo.quantity, ShouldEqual, 5)
+		})
+
+		Convey("marking too many units as shipped should fail with a bad request", func() {
+			repo.shouldFail = false
+			var resp shipping.MarkShippedResponse
+			err := svc.MarkItemShipped(ctx, &shipping.MarkShippedRequest{OrderId: 42, ShippingMethod: shipping.ShippingMethod_SM_UPS, Sku: "8675309",
+				Quantity: 10001}, &resp)
+			So(err, ShouldNotBeNil)
+			So(errors.Parse(err.Error()).Code, ShouldEqual, http.StatusBadRequest)
+			So(pub.publishCount, ShouldEqual, 0)
 		})
 
 		Convey("marking an item as shipped on non-existent order should fail", func() {
@@ -171,6 +196,7 @@ func TestShippingService_MarkItemShipped(t *testing.T) {
 
 type fakeRepo struct {
 	shouldFail bool
+	quantity   uint32
 }
 
 func (r *fakeRepo) GetShippingCosts(sku string, zipCode string) (costs []*shipping.ShippingCost, err error) {
@@ -179,20 +205,23 @@ func (r *fakeRepo) GetShippingCosts(sku string, zipCode string) (costs []*shippi
 	}
 	return []*shipping.ShippingCost{
 		&shipping.ShippingCost{
-			Price:  2500,
+			Price:  &shipping.Money{Amount: 2500, CurrencyCode: "USD"},
 			Method: shipping.ShippingMethod_SM_FEDEX,
 		},
 		&shipping.ShippingCost{
-			Price:  1000,
+			Price:  &shipping.Money{Amount: 1000, CurrencyCode: "USD"},
 			Method: shipping.ShippingMethod_SM_RAVEN,
 		},
 	}, nil
 }
 
-func (r *fakeRepo) MarkShipped(sku string, orderID uint64, note string, shippingMethod shipping.ShippingMethod) (trackingNumber string, err error) {
+func (r *fakeRepo) MarkShipped(sku string, orderID uint64, note string, shippingMethod shipping.ShippingMethod, quantity uint32) (trackingNumber string,
+	err error) {
+
 	if r.shouldFail {
 		return "", stderrors.New("Faily Fail")
 	}
+	r.quantity = quantity
 	return "111111", nil
 }
 
@@ -221,6 +250,7 @@ func (r *fakeRepo) OrderExists(orderID uint64) (exists bool, err error) {
 type fakePublisher struct {
 	shouldFail   bool
 	publishCount int
+	lastEvent    *shipping.ItemShippedEvent
 }
 
 func (p *fakePublisher) PublishItemShippedEvent(event *shipping.ItemShippedEvent) (err error) {
@@ -228,5 +258,6 @@ func (p *fakePublisher) PublishItemShippedEvent(event *shipping.ItemShippedEvent
 		return stderrors.New("Faily Fail")
 	}
 	p.publishCount++
+	p.lastEvent = event
 	return nil
 }
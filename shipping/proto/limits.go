@@ -0,0 +1,5 @@
+package shipping
+
+// MaxShipmentQuantity is the most units of a SKU that can be shipped at once. The shipping service
+// refuses to mark larger shipments, and consumers of item shipped events can rely on it.
+const MaxShipmentQuantity = 10000
@@ -16,6 +16,7 @@ It has these top-level messages:
 	ShippingStatusResponse
 	ShippingStatus
 	ShippingCost
+	Money
 	ItemShippedEvent
 */
 package shipping
@@ -119,6 +120,8 @@ type MarkShippedRequest struct {
 	OrderId        uint64         `protobuf:"varint,2,opt,name=order_id,json=orderId" json:"order_id,omitempty"`
 	Note           string         `protobuf:"bytes,3,opt,name=note" json:"note,omitempty"`
 	ShippingMethod ShippingMethod `protobuf:"varint,4,opt,name=shipping_method,json=shippingMethod,enum=shipping.ShippingMethod" json:"shipping_method,omitempty"`
+	LocationId     string         `protobuf:"bytes,5,opt,name=location_id,json=locationId" json:"location_id,omitempty"`
+	Quantity       uint32         `protobuf:"varint,6,opt,name=quantity" json:"quantity,omitempty"`
 }
 
 func (m *MarkShippedRequest) Reset()                    { *m = MarkShippedRequest{} }
@@ -154,6 +157,20 @@ func (m *MarkShippedRequest) GetShippingMethod() ShippingMethod {
 	return ShippingMethod_SM_UNKNOWN
 }
 
+func (m *MarkShippedRequest) GetLocationId() string {
+	if m != nil {
+		return m.LocationId
+	}
+	return ""
+}
+
+func (m *MarkShippedRequest) GetQuantity() uint32 {
+	if m != nil {
+		return m.Quantity
+	}
+	return 0
+}
+
 type MarkShippedResponse struct {
 	Success        bool   `protobuf:"varint,1,opt,name=success" json:"success,omitempty"`
 	TrackingNumber string `protobuf:"bytes,2,opt,name=tracking_number,json=trackingNumber" json:"tracking_number,omitempty"`
@@ -222,6 +239,7 @@ type ShippingStatus struct {
 	TrackingNumber string         `protobuf:"bytes,1,opt,name=tracking_number,json=trackingNumber" json:"tracking_number,omitempty"`
 	ShippingMethod ShippingMethod `protobuf:"varint,2,opt,name=shipping_method,json=shippingMethod,enum=shipping.ShippingMethod" json:"shipping_method,omitempty"`
 	Shipped        bool           `protobuf:"varint,3,opt,name=shipped" json:"shipped,omitempty"`
+	Quantity       uint32         `protobuf:"varint,4,opt,name=quantity" json:"quantity,omitempty"`
 }
 
 func (m *ShippingStatus) Reset()                    { *m = ShippingStatus{} }
@@ -250,9 +268,16 @@ func (m *ShippingStatus) GetShipped() bool {
 	return false
 }
 
+func (m *ShippingStatus) GetQuantity() uint32 {
+	if m != nil {
+		return m.Quantity
+	}
+	return 0
+}
+
 type ShippingCost struct {
 	Method ShippingMethod `protobuf:"varint,1,opt,name=method,enum=shipping.ShippingMethod" json:"method,omitempty"`
-	Price  int64          `protobuf:"varint,2,opt,name=price" json:"price,omitempty"`
+	Price  *Money         `protobuf:"bytes,3,opt,name=price" json:"price,omitempty"`
 }
 
 func (m *ShippingCost) Reset()                    { *m = ShippingCost{} }
@@ -267,13 +292,37 @@ func (m *ShippingCost) GetMethod() ShippingMethod {
 	return ShippingMethod_SM_UNKNOWN
 }
 
-func (m *ShippingCost) GetPrice() int64 {
+func (m *ShippingCost) GetPrice() *Money {
 	if m != nil {
 		return m.Price
 	}
+	return nil
+}
+
+type Money struct {
+	Amount       int64  `protobuf:"varint,1,opt,name=amount" json:"amount,omitempty"`
+	CurrencyCode string `protobuf:"bytes,2,opt,name=currency_code,json=currencyCode" json:"currency_code,omitempty"`
+}
+
+func (m *Money) Reset()                    { *m = Money{} }
+func (m *Money) String() string            { return proto.CompactTextString(m) }
+func (*Money) ProtoMessage()               {}
+func (*Money) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+
+func (m *Money) GetAmount() int64 {
+	if m != nil {
+		return m.Amount
+	}
 	return 0
 }
 
+func (m *Money) GetCurrencyCode() string {
+	if m != nil {
+		return m.CurrencyCode
+	}
+	return ""
+}
+
 type ItemShippedEvent struct {
 	Sku            string         `protobuf:"bytes,1,opt,name=sku" json:"sku,omitempty"`
 	OrderId        uint64         `protobuf:"varint,2,opt,name=order_id,json=orderId" json:"order_id,omitempty"`
@@ -281,12 +330,14 @@ type ItemShippedEvent struct {
 	ShippingMethod ShippingMethod `protobuf:"varint,4,opt,name=shipping_method,json=shippingMethod,enum=shipping.ShippingMethod" json:"shipping_method,omitempty"`
 	TrackingNumber string         `protobuf:"bytes,5,opt,name=tracking_number,json=trackingNumber" json:"tracking_number,omitempty"`
 	Timestamp      int64          `protobuf:"varint,6,opt,name=timestamp" json:"timestamp,omitempty"`
+	LocationId     string         `protobuf:"bytes,7,opt,name=location_id,json=locationId" json:"location_id,omitempty"`
+	Quantity       uint32         `protobuf:"varint,8,opt,name=quantity" json:"quantity,omitempty"`
 }
 
 func (m *ItemShippedEvent) Reset()                    { *m = ItemShippedEvent{} }
 func (m *ItemShippedEvent) String() string            { return proto.CompactTextString(m) }
 func (*ItemShippedEvent) ProtoMessage()               {}
-func (*ItemShippedEvent) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+func (*ItemShippedEvent) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
 
 func (m *ItemShippedEvent) GetSku() string {
 	if m != nil {
@@ -330,6 +381,20 @@ func (m *ItemShippedEvent) GetTimestamp() int64 {
 	return 0
 }
 
+func (m *ItemShippedEvent) GetLocationId() string {
+	if m != nil {
+		return m.LocationId
+	}
+	return ""
+}
+
+func (m *ItemShippedEvent) GetQuantity() uint32 {
+	if m != nil {
+		return m.Quantity
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*ShippingCostRequest)(nil), "shipping.ShippingCostRequest")
 	proto.RegisterType((*ShippingCostResponse)(nil), "shipping.ShippingCostResponse")
@@ -339,6 +404,7 @@ func init() {
 	proto.RegisterType((*ShippingStatusResponse)(nil), "shipping.ShippingStatusResponse")
 	proto.RegisterType((*ShippingStatus)(nil), "shipping.ShippingStatus")
 	proto.RegisterType((*ShippingCost)(nil), "shipping.ShippingCost")
+	proto.RegisterType((*Money)(nil), "shipping.Money")
 	proto.RegisterType((*ItemShippedEvent)(nil), "shipping.ItemShippedEvent")
 	proto.RegisterEnum("shipping.ShippingMethod", ShippingMethod_name, ShippingMethod_value)
 }
@@ -435,40 +501,46 @@ func (h *Shipping) GetShippingStatus(ctx context.Context, in *ShippingStatusRequ
 func init() { proto.RegisterFile("shipping.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 549 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x54, 0x41, 0x6f, 0xd3, 0x30,
-	0x14, 0xc6, 0x6d, 0xd7, 0x76, 0xaf, 0x5b, 0x9b, 0x79, 0x63, 0x0a, 0xd3, 0x06, 0x55, 0x2e, 0x54,
-	0x1c, 0x26, 0x54, 0xce, 0x1c, 0xc6, 0x5a, 0xa0, 0x42, 0xc9, 0xa2, 0x84, 0x95, 0x49, 0x1c, 0xa2,
-	0x2e, 0xb1, 0x68, 0x54, 0x25, 0x0e, 0xb1, 0xc3, 0x61, 0x67, 0x7e, 0x03, 0xe2, 0xcf, 0xf1, 0x5f,
-	0x50, 0x1c, 0x27, 0x4d, 0x58, 0x02, 0xe2, 0xb6, 0x9b, 0x3f, 0xfb, 0xf9, 0xbd, 0xef, 0xfb, 0xde,
-	0xb3, 0x61, 0xc8, 0xd6, 0x7e, 0x14, 0xf9, 0xe1, 0x97, 0xf3, 0x28, 0xa6, 0x9c, 0xe2, 0x7e, 0x8e,
-	0xb5, 0x37, 0x70, 0x68, 0xcb, 0xf5, 0x25, 0x65, 0xdc, 0x22, 0x5f, 0x13, 0xc2, 0x38, 0x56, 0xa0,
-	0xcd, 0x36, 0x89, 0x8a, 0xc6, 0x68, 0xb2, 0x6b, 0xa5, 0x4b, 0xfc, 0x04, 0xfa, 0x77, 0x7e, 0xe4,
-	0xb8, 0xd4, 0x23, 0x6a, 0x4b, 0x6c, 0xf7, 0xee, 0xfc, 0xe8, 0x92, 0x7a, 0x44, 0xbb, 0x86, 0xa3,
-	0x6a, 0x0e, 0x16, 0xd1, 0x90, 0x11, 0xfc, 0x7a, 0x5b, 0xd7, 0x71, 0x29, 0xe3, 0x4c, 0x45, 0xe3,
-	0xf6, 0x64, 0x30, 0x3d, 0x3e, 0x2f, 0xe8, 0x54, 0xee, 0xed, 0xb3, 0x12, 0x62, 0xda, 0x4f, 0x04,
-	0x58, 0x5f, 0xc5, 0x1b, 0x11, 0x43, 0xbc, 0xbf, 0x52, 0xa3, 0xb1, 0x47, 0x62, 0xc7, 0xf7, 0x04,
-	0xb5, 0x8e, 0xd5, 0x13, 0x78, 0xe1, 0x61, 0x0c, 0x9d, 0x90, 0x72, 0xa2, 0xb6, 0x45, 0xb4, 0x58,
-	0xe3, 0x0b, 0x18, 0x15, 0xb4, 0x02, 0xc2, 0xd7, 0xd4, 0x53, 0x3b, 0x63, 0x34, 0x19, 0x4e, 0xd5,
-	0xfb, 0xbc, 0x74, 0x71, 0x6e, 0x15, 0x3a, 0x32, 0xac, 0xdd, 0xc0, 0x61, 0x85, 0x99, 0x14, 0xac,
-	0x42, 0x8f, 0x25, 0xae, 0x4b, 0x18, 0x13, 0xf4, 0xfa, 0x56, 0x0e, 0xf1, 0x73, 0x18, 0xf1, 0x78,
-	0xe5, 0x6e, 0xd2, 0x9a, 0x61, 0x12, 0xdc, 0x92, 0x58, 0x9a, 0x38, 0xcc, 0xb7, 0x0d, 0xb1, 0xab,
-	0xcd, 0xe0, 0x71, 0x5e, 0xdb, 0xe6, 0x2b, 0x9e, 0xb0, 0x5c, 0x76, 0x59, 0x24, 0xaa, 0x8a, 0x94,
-	0x8e, 0xb4, 0x0a, 0x47, 0xb4, 0xcf, 0x70, 0xfc, 0x67, 0x16, 0x49, 0xb1, 0x2c, 0x9e, 0x89, 0x23,
-	0x91, 0x6d, 0x50, 0x27, 0x5e, 0x5e, 0x2d, 0xc4, 0x67, 0x58, 0xfb, 0x81, 0x60, 0x58, 0x0d, 0xa9,
-	0x93, 0x87, 0xea, 0xe4, 0xd5, 0x79, 0xdf, 0xfa, 0x3f, 0xef, 0x85, 0xc9, 0x99, 0xef, 0xa2, 0xab,
-	0xa9, 0xc9, 0x19, 0xd4, 0x96, 0xb0, 0x57, 0x9e, 0x27, 0xfc, 0x12, 0xba, 0xb2, 0x06, 0xfa, 0x47,
-	0x0d, 0x19, 0x87, 0x8f, 0x60, 0x27, 0x8a, 0x7d, 0x37, 0x9b, 0xf0, 0xb6, 0x95, 0x01, 0xed, 0x17,
-	0x02, 0x65, 0xc1, 0x49, 0x20, 0xdb, 0x3d, 0xff, 0x46, 0xc2, 0x07, 0x31, 0x86, 0x75, 0xb6, 0xef,
-	0xd4, 0xda, 0x7e, 0x0a, 0xbb, 0xdc, 0x0f, 0x08, 0xe3, 0xab, 0x20, 0x52, 0xbb, 0x42, 0xdb, 0x76,
-	0xe3, 0xc5, 0x7a, 0xdb, 0x4f, 0x99, 0x78, 0x08, 0x60, 0xeb, 0xce, 0xb5, 0xf1, 0xc1, 0xb8, 0xfa,
-	0x64, 0x28, 0x8f, 0xf0, 0x00, 0x7a, 0x29, 0xb6, 0x4d, 0x5b, 0x41, 0x18, 0xa0, 0x9b, 0x02, 0xd3,
-	0x56, 0x5a, 0x78, 0x0f, 0xfa, 0xb6, 0xee, 0xbc, 0x9d, 0xcf, 0xe6, 0x37, 0x4a, 0x5b, 0x22, 0xeb,
-	0x62, 0x39, 0x37, 0x94, 0x0e, 0x3e, 0x80, 0x7d, 0x5b, 0x77, 0x8c, 0xab, 0x8f, 0xf6, 0xfb, 0x85,
-	0x69, 0xce, 0x67, 0x0a, 0x4c, 0xbf, 0xb7, 0xa0, 0x9f, 0x97, 0xc2, 0x26, 0x8c, 0xde, 0x11, 0x5e,
-	0xe9, 0xd8, 0x59, 0xc3, 0xcf, 0x90, 0xbd, 0x81, 0x93, 0xa7, 0x4d, 0xc7, 0x72, 0xb8, 0x0d, 0x18,
-	0xa5, 0xcf, 0xb2, 0xd4, 0x2b, 0x7c, 0xba, 0xbd, 0x72, 0xff, 0x2f, 0x39, 0x39, 0x6b, 0x38, 0x95,
-	0xf9, 0x96, 0x70, 0x50, 0x62, 0x28, 0x67, 0xfd, 0x59, 0xe3, 0x43, 0x91, 0x49, 0xc7, 0xcd, 0x01,
-	0x59, 0xde, 0xdb, 0xae, 0xf8, 0x85, 0x5f, 0xfd, 0x0e, 0x00, 0x00, 0xff, 0xff, 0xf2, 0x66, 0x40,
-	0x4d, 0x97, 0x05, 0x00, 0x00,
+	// 644 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x55, 0xcd, 0x6e, 0xd3, 0x40,
+	0x10, 0xc6, 0x76, 0x7e, 0xdc, 0x49, 0xe3, 0xb8, 0x5b, 0xa8, 0x4c, 0xd5, 0xd2, 0xc8, 0x08, 0x11,
+	0x71, 0xa8, 0x50, 0x38, 0x73, 0x28, 0x4d, 0x80, 0x80, 0xe2, 0x46, 0x36, 0x2d, 0x95, 0x38, 0x44,
+	0xae, 0xbd, 0xa2, 0x56, 0xb1, 0xd7, 0xf5, 0xae, 0x91, 0xda, 0x33, 0x4f, 0xc1, 0x63, 0xf0, 0x2e,
+	0xbc, 0x0f, 0xf2, 0x7a, 0x9d, 0xd8, 0xa9, 0x53, 0xc4, 0x8d, 0x9b, 0xbf, 0xd9, 0xdd, 0x99, 0x6f,
+	0xbf, 0x99, 0x6f, 0x0d, 0x1a, 0xbd, 0x0c, 0xe2, 0x38, 0x88, 0xbe, 0x1e, 0xc6, 0x09, 0x61, 0x04,
+	0xa9, 0x05, 0x36, 0xdf, 0xc0, 0xb6, 0x23, 0xbe, 0x8f, 0x09, 0x65, 0x36, 0xbe, 0x4e, 0x31, 0x65,
+	0x48, 0x07, 0x85, 0x5e, 0xa5, 0x86, 0xd4, 0x97, 0x06, 0x1b, 0x76, 0xf6, 0x89, 0x1e, 0x83, 0x7a,
+	0x1b, 0xc4, 0x73, 0x8f, 0xf8, 0xd8, 0x90, 0x79, 0xb8, 0x7d, 0x1b, 0xc4, 0xc7, 0xc4, 0xc7, 0xe6,
+	0x29, 0x3c, 0xac, 0xe6, 0xa0, 0x31, 0x89, 0x28, 0x46, 0xaf, 0x97, 0x75, 0xe7, 0x1e, 0xa1, 0x8c,
+	0x1a, 0x52, 0x5f, 0x19, 0x74, 0x86, 0x3b, 0x87, 0x0b, 0x3a, 0x95, 0x73, 0x5d, 0x5a, 0x42, 0xd4,
+	0xfc, 0x2d, 0x01, 0x9a, 0xba, 0xc9, 0x15, 0xdf, 0x83, 0xfd, 0x7b, 0xa9, 0x91, 0xc4, 0xc7, 0xc9,
+	0x3c, 0xf0, 0x39, 0xb5, 0x86, 0xdd, 0xe6, 0x78, 0xe2, 0x23, 0x04, 0x8d, 0x88, 0x30, 0x6c, 0x28,
+	0x7c, 0x37, 0xff, 0x46, 0x47, 0xd0, 0x5b, 0xd0, 0x0a, 0x31, 0xbb, 0x24, 0xbe, 0xd1, 0xe8, 0x4b,
+	0x03, 0x6d, 0x68, 0xdc, 0xe5, 0x35, 0xe5, 0xeb, 0xb6, 0x46, 0x2b, 0x18, 0x1d, 0x40, 0xe7, 0x1b,
+	0xf1, 0x5c, 0x16, 0x90, 0x28, 0x2b, 0xda, 0xe4, 0xd9, 0xa1, 0x08, 0x4d, 0x7c, 0xb4, 0x0b, 0xea,
+	0x75, 0xea, 0x46, 0x2c, 0x60, 0x37, 0x46, 0xab, 0x2f, 0x0d, 0xba, 0xf6, 0x02, 0x9b, 0xe7, 0xb0,
+	0x5d, 0xb9, 0x96, 0x50, 0xcb, 0x80, 0x36, 0x4d, 0x3d, 0x0f, 0x53, 0xca, 0xef, 0xa6, 0xda, 0x05,
+	0x44, 0xcf, 0xa1, 0xc7, 0x12, 0xd7, 0xbb, 0xca, 0x08, 0x47, 0x69, 0x78, 0x81, 0x13, 0xd1, 0x01,
+	0xad, 0x08, 0x5b, 0x3c, 0x6a, 0x8e, 0xe0, 0x51, 0x41, 0xdc, 0x61, 0x2e, 0x4b, 0x69, 0xa1, 0x59,
+	0x59, 0x21, 0xa9, 0xaa, 0x90, 0x90, 0x53, 0x5e, 0xc8, 0x69, 0x7e, 0x81, 0x9d, 0xd5, 0x2c, 0x82,
+	0x62, 0x59, 0x39, 0xca, 0x97, 0x78, 0xb6, 0x4e, 0x9d, 0x72, 0xe2, 0xa8, 0x46, 0x2b, 0xd8, 0xfc,
+	0x25, 0x81, 0x56, 0xdd, 0x52, 0x77, 0x3d, 0xa9, 0xee, 0x7a, 0x75, 0x8d, 0x93, 0xff, 0xb1, 0x71,
+	0x99, 0xc8, 0xb9, 0xee, 0x86, 0x22, 0x44, 0xce, 0x61, 0xa5, 0x63, 0x8d, 0x95, 0x8e, 0x85, 0xb0,
+	0x59, 0x1e, 0x54, 0xf4, 0x12, 0x5a, 0xa2, 0xbe, 0xf4, 0x97, 0xfa, 0x62, 0x1f, 0x7a, 0x06, 0xcd,
+	0x38, 0x09, 0xbc, 0x7c, 0x10, 0x3b, 0xc3, 0xde, 0xf2, 0xc0, 0x94, 0x44, 0xf8, 0xc6, 0xce, 0x57,
+	0x3f, 0x34, 0x54, 0x59, 0x57, 0xcc, 0x11, 0x34, 0x79, 0x14, 0xed, 0x40, 0xcb, 0x0d, 0x49, 0x1a,
+	0x31, 0x5e, 0x47, 0xb1, 0x05, 0x42, 0x4f, 0xa1, 0xeb, 0xa5, 0x49, 0x82, 0x23, 0xef, 0xa6, 0x6c,
+	0xc8, 0xcd, 0x22, 0xc8, 0x5d, 0xf9, 0x53, 0x06, 0x7d, 0xc2, 0x70, 0x28, 0xe6, 0x6c, 0xfc, 0x1d,
+	0x47, 0xff, 0x87, 0x79, 0x6a, 0xfa, 0xdd, 0xac, 0xed, 0xf7, 0x1e, 0x6c, 0xb0, 0x20, 0xc4, 0x94,
+	0xb9, 0x61, 0xcc, 0x5d, 0xa4, 0xd8, 0xcb, 0xc0, 0xaa, 0x07, 0xdb, 0xf7, 0x7a, 0x50, 0xad, 0x76,
+	0xf4, 0xc5, 0xe5, 0x72, 0x0a, 0x05, 0x2b, 0x0d, 0xc0, 0x99, 0xce, 0x4f, 0xad, 0x8f, 0xd6, 0xc9,
+	0x67, 0x4b, 0x7f, 0x80, 0x3a, 0xd0, 0xce, 0xb0, 0x33, 0x73, 0x74, 0x09, 0x01, 0xb4, 0x32, 0x30,
+	0x73, 0x74, 0x19, 0x6d, 0x82, 0xea, 0x4c, 0xe7, 0x6f, 0xc7, 0xa3, 0xf1, 0xb9, 0xae, 0x08, 0x64,
+	0x1f, 0x9d, 0x8d, 0x2d, 0xbd, 0x81, 0xb6, 0xa0, 0xeb, 0x4c, 0xe7, 0xd6, 0xc9, 0x27, 0xe7, 0xfd,
+	0x64, 0x36, 0x1b, 0x8f, 0x74, 0x18, 0xfe, 0x90, 0x41, 0x2d, 0x4a, 0xa1, 0x19, 0xf4, 0xde, 0x61,
+	0x56, 0x99, 0xa5, 0xfd, 0x35, 0x8f, 0x61, 0xee, 0xdc, 0xdd, 0x27, 0xeb, 0x96, 0x85, 0x25, 0x2d,
+	0xe8, 0x65, 0x8f, 0x49, 0xa9, 0xd1, 0x68, 0xaf, 0x34, 0x5c, 0x77, 0x9e, 0xcf, 0xdd, 0xfd, 0x35,
+	0xab, 0x22, 0xdf, 0x19, 0x6c, 0x95, 0x18, 0x0a, 0x87, 0x1e, 0xac, 0xb5, 0xb7, 0x48, 0xda, 0x5f,
+	0xbf, 0x21, 0xcf, 0x7b, 0xd1, 0xe2, 0x3f, 0x9e, 0x57, 0x7f, 0x06, 0x00, 0x4a, 0x88, 0xb8, 0x57,
+	0x8a, 0x06, 0x00, 0x00,
 }
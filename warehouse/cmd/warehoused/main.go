@@ -1,6 +1,7 @@
 package main
 
 import (
+	"github.com/autodidaddict/go-shopping/catalog/proto"
 	"github.com/autodidaddict/go-shopping/shipping/proto"
 	"github.com/autodidaddict/go-shopping/warehouse/internal/platform/broker"
 	"github.com/autodidaddict/go-shopping/warehouse/internal/platform/config"
@@ -24,8 +25,16 @@ func main() {
 	}
 	itemShippedChannel := make(chan *shipping.ItemShippedEvent)
 	broker.CreateEventConsumer(itemShippedChannel)
+	productChangedChannel := make(chan *catalog.ProductChangedEvent)
+	broker.CreateProductChangedConsumer(productChangedChannel)
 
 	repo := redis.NewWarehouseRepository(":6379")
+	opened, err := repo.OpenLedgers()
+	if err != nil {
+		log.Logf("Failed to open stock ledgers: %s", err)
+	} else {
+		log.Logf("Opened the stock ledgers of %d SKUs", opened)
+	}
 
 	svc := grpc.NewService(
 		micro.Name(config.ServiceName),
@@ -35,7 +44,7 @@ func main() {
 	)
 	svc.Init()
 
-	warehouse.RegisterWarehouseHandler(svc.Server(), service.NewWarehouseService(repo, itemShippedChannel))
+	warehouse.RegisterWarehouseHandler(svc.Server(), service.NewWarehouseService(repo, itemShippedChannel, productChangedChannel))
 
 	if err := svc.Run(); err != nil {
 		panic(err)
@@ -1,6 +1,7 @@
 package broker
 
 import (
+	"github.com/autodidaddict/go-shopping/catalog/proto"
 	"github.com/autodidaddict/go-shopping/shipping/proto"
 	"github.com/micro/go-log"
 	"github.com/micro/go-micro/broker"
@@ -8,7 +9,8 @@ import (
 )
 
 const (
-	topic = "go.shopping.item.shipped"
+	topic               = "go.shopping.item.shipped"
+	productChangedTopic = "go.shopping.product.changed"
 )
 
 // CreateEventConsumer creates a broker subscription that converts broker messages into
@@ -34,3 +36,18 @@ func CreateEventConsumer(itemShippedChannel chan *shipping.ItemShippedEvent) (er
 
 	return nil
 }
+
+// CreateProductChangedConsumer creates a broker subscription that places the catalog's product
+// changed events on the given channel, so that the warehouse can learn which SKUs are bundles
+func CreateProductChangedConsumer(productChangedChannel chan *catalog.ProductChangedEvent) (err error) {
+	_, err = broker.Subscribe(productChangedTopic, func(p broker.Publication) error {
+		var changedEvent catalog.ProductChangedEvent
+		if err := proto.Unmarshal(p.Message().Body, &changedEvent); err != nil {
+			log.Logf("Failed to unmarshal broker message: %s", err)
+			return err
+		}
+		productChangedChannel <- &changedEvent
+		return nil
+	})
+	return err
+}
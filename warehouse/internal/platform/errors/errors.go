@@ -0,0 +1,35 @@
+package errors
+
+// Error is a handy type alias that lets us create constant error messages
+type Error string
+
+// Error is the "stringer" function, which completes our error interface implementation.
+func (e Error) Error() string {
+	return string(e)
+}
+
+const (
+	// NoSuchLocation indicates stock movement at a warehouse location that doesn't exist
+	NoSuchLocation = Error("No such warehouse location")
+
+	// DuplicateLocation indicates a warehouse location that has already been created
+	DuplicateLocation = Error("Warehouse location already exists")
+
+	// NoSuchReservation indicates a reservation that never existed, or that has expired or been released
+	NoSuchReservation = Error("No such reservation")
+
+	// InsufficientStock indicates a reservation for more stock than is available
+	InsufficientStock = Error("Insufficient stock available")
+
+	// ReservationCommitted indicates a reservation already committed to another order
+	ReservationCommitted = Error("Reservation already committed to another order")
+
+	// DuplicateReservation indicates an order that already holds a reservation for a SKU
+	DuplicateReservation = Error("Order already holds a reservation for this SKU")
+
+	// ShipmentTooLarge indicates a shipment of more units than can ship at once
+	ShipmentTooLarge = Error("Shipment is larger than can ship at once")
+
+	// BundleHasNoStock indicates stock moving in or out of a bundle, rather than its components
+	BundleHasNoStock = Error("Bundles have no stock of their own")
+)
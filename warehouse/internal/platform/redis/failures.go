@@ -0,0 +1,105 @@
+package redis
+
+import (
+	"fmt"
+	"github.com/autodidaddict/go-shopping/warehouse/proto"
+	"github.com/garyburd/redigo/redis"
+	"strconv"
+)
+
+// Shipments that can't be taken from stock are set aside in the warehouse:shipments:failed stream
+// rather than dropped, so that the stock can be put right by hand. Only the latest failures are kept.
+
+const (
+	failedShipmentsKey = "warehouse:shipments:failed"
+
+	// maxFailedShipments is roughly how many failures the stream keeps
+	maxFailedShipments = 10000
+)
+
+// RecordFailedShipment sets aside a shipment that couldn't be taken from stock, returning it with the
+// ID of its failure
+func (r *WarehouseRepository) RecordFailedShipment(shipment *warehouse.FailedShipment) (recorded *warehouse.FailedShipment,
+	err error) {
+
+	c, err := redis.Dial("tcp", r.redisDialString)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	failureID, err := redis.String(c.Do("XADD", failedShipmentsKey, "MAXLEN", "~", maxFailedShipments, "*",
+		"order", shipment.OrderId, "sku", shipment.Sku, "location", shipment.LocationId,
+		"tracking", shipment.TrackingNumber, "quantity", shipment.Quantity, "reason", shipment.Reason,
+		"timestamp", shipment.Timestamp))
+	if err != nil {
+		return nil, err
+	}
+	recorded = &warehouse.FailedShipment{}
+	*recorded = *shipment
+	recorded.FailureId = failureID
+	return recorded, nil
+}
+
+// GetFailedShipments lists the latest shipments that couldn't be taken from stock, newest first
+func (r *WarehouseRepository) GetFailedShipments(limit uint32) (shipments []*warehouse.FailedShipment, err error) {
+	c, err := redis.Dial("tcp", r.redisDialString)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	entries, err := redis.Values(c.Do("XREVRANGE", failedShipmentsKey, "+", "-", "COUNT", limit))
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		shipment, err := parseFailedShipment(entry)
+		if err != nil {
+			return nil, err
+		}
+		shipments = append(shipments, shipment)
+	}
+	return shipments, nil
+}
+
+// parseFailedShipment reads a failure, which XREVRANGE returns as its ID followed by its fields
+func parseFailedShipment(entry interface{}) (shipment *warehouse.FailedShipment, err error) {
+	values, err := redis.Values(entry, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(values) != 2 {
+		return nil, fmt.Errorf("malformed failed shipment: %v", values)
+	}
+	id, err := redis.String(values[0], nil)
+	if err != nil {
+		return nil, err
+	}
+	fields, err := redis.StringMap(values[1], nil)
+	if err != nil {
+		return nil, err
+	}
+	orderID, err := strconv.ParseUint(fields["order"], 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	quantity, err := strconv.ParseUint(fields["quantity"], 10, 32)
+	if err != nil {
+		return nil, err
+	}
+	timestamp, err := strconv.ParseInt(fields["timestamp"], 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &warehouse.FailedShipment{
+		FailureId:      id,
+		OrderId:        orderID,
+		Sku:            fields["sku"],
+		LocationId:     fields["location"],
+		TrackingNumber: fields["tracking"],
+		Quantity:       uint32(quantity),
+		Reason:         fields["reason"],
+		Timestamp:      timestamp,
+	}, nil
+}
@@ -0,0 +1,53 @@
+package redis
+
+import (
+	"github.com/autodidaddict/go-shopping/warehouse/internal/platform/errors"
+	"github.com/autodidaddict/go-shopping/warehouse/proto"
+	"github.com/garyburd/redigo/redis"
+	"sort"
+)
+
+// CreateLocation registers a warehouse location so that stock can be kept there. A location can't
+// be created twice, and the main warehouse always exists.
+func (r *WarehouseRepository) CreateLocation(locationID, name string) (location *warehouse.Location, err error) {
+	c, err := redis.Dial("tcp", r.redisDialString)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	if locationID == DefaultLocation {
+		return nil, errors.DuplicateLocation
+	}
+	created, err := redis.Bool(c.Do("HSETNX", locationsKey, locationID, name))
+	if err != nil {
+		return nil, err
+	}
+	if !created {
+		return nil, errors.DuplicateLocation
+	}
+	return &warehouse.Location{LocationId: locationID, Name: name}, nil
+}
+
+// GetLocations lists every warehouse location, including the main warehouse, in order of location ID
+func (r *WarehouseRepository) GetLocations() (locations []*warehouse.Location, err error) {
+	c, err := redis.Dial("tcp", r.redisDialString)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	names, err := loadLocations(c)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(names))
+	for id := range names {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		locations = append(locations, &warehouse.Location{LocationId: id, Name: names[id]})
+	}
+	return locations, nil
+}
@@ -0,0 +1,326 @@
+package redis
+
+import (
+	"fmt"
+	"github.com/autodidaddict/go-shopping/warehouse/internal/platform/errors"
+	"github.com/autodidaddict/go-shopping/warehouse/proto"
+	"github.com/garyburd/redigo/redis"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Every change to a SKU's stock, at any location, is appended to the warehouse:{sku}:movements
+// stream in the same script that makes it. Entries are never changed or removed, so adding up their
+// deltas rebuilds the stock. Rather than add up the whole ledger each time it is checked, the stock it
+// rebuilds at each location is kept in the warehouse:{sku}:movements:totals hash, which covers every
+// entry up to the one whose ID is in warehouse:{sku}:movements:checkpoint. Stock counted before the
+// ledger was kept is entered in it as an opening adjustment, referenced openingBalanceReference.
+
+const openingBalanceReference = "opening balance"
+
+var movementReasons = map[warehouse.MovementReason]string{
+	warehouse.MovementReason_MR_SHIPMENT:   "shipment",
+	warehouse.MovementReason_MR_RECEIPT:    "receipt",
+	warehouse.MovementReason_MR_ADJUSTMENT: "adjustment",
+	warehouse.MovementReason_MR_RETURN:     "return",
+}
+
+// recordScript moves stock in or out of a SKU at a location and records the movement, returning the
+// ledger entry's ID. It returns nothing, changing nothing, if the stock would go below zero.
+//
+// KEYS: the stock, the ledger
+// ARGV: SKU, location, delta, reason, reference, timestamp
+var recordScript = redis.NewScript(2, `
+local delta = tonumber(ARGV[3])
+if tonumber(redis.call('GET', KEYS[1]) or 0) + delta < 0 then
+	return false
+end
+redis.call('INCRBY', KEYS[1], delta)
+return redis.call('XADD', KEYS[2], '*', 'sku', ARGV[1], 'location', ARGV[2], 'delta', delta, 'reason', ARGV[4],
+	'reference', ARGV[5], 'timestamp', ARGV[6])
+`)
+
+// ledgerScript brings the ledger's totals up to date from its checkpoint, then counts the stock at
+// each location given and returns it along with the stock rebuilt from the ledger there, and the latest
+// movements, newest first, at the location asked for.
+//
+// KEYS: the ledger, its checkpoint, its totals, then the stock key of each location counted
+// ARGV: how many movements to return, the location to return them for or empty for every location,
+// then the ID of each location counted
+var ledgerScript = redis.NewScript(-1, `
+local function field(entry, name)
+	for i = 1, #entry[2], 2 do
+		if entry[2][i] == name then
+			return entry[2][i + 1]
+		end
+	end
+end
+
+local checkpoint = redis.call('GET', KEYS[2]) or '0-0'
+local caughtUp = false
+while not caughtUp do
+	caughtUp = true
+	for _, entry in ipairs(redis.call('XRANGE', KEYS[1], checkpoint, '+', 'COUNT', 1000)) do
+		if entry[1] ~= checkpoint then
+			redis.call('HINCRBY', KEYS[3], field(entry, 'location'), field(entry, 'delta'))
+			redis.call('SET', KEYS[2], entry[1])
+			checkpoint = entry[1]
+			caughtUp = false
+		end
+	end
+end
+
+local stock, ledger = 0, 0
+for i = 4, #KEYS do
+	stock = stock + tonumber(redis.call('GET', KEYS[i]) or 0)
+	ledger = ledger + tonumber(redis.call('HGET', KEYS[3], ARGV[i - 1]) or 0)
+end
+
+local limit = tonumber(ARGV[1])
+local movements = {}
+local before = '+'
+local exhausted = false
+while #movements < limit and not exhausted do
+	exhausted = true
+	for _, entry in ipairs(redis.call('XREVRANGE', KEYS[1], before, '-', 'COUNT', limit + 1)) do
+		if entry[1] ~= before and #movements < limit then
+			if ARGV[2] == '' or field(entry, 'location') == ARGV[2] then
+				table.insert(movements, entry)
+			end
+			before = entry[1]
+			exhausted = false
+		end
+	end
+end
+return {stock, ledger, movements}
+`)
+
+// openScript enters the stock of a SKU at each location in the SKU's ledger as an opening adjustment,
+// provided the ledger is yet to be started. It returns how many movements it recorded.
+//
+// KEYS: the ledger, then the stock key of each location
+// ARGV: SKU, reference, timestamp, then the ID of each location
+var openScript = redis.NewScript(-1, `
+if redis.call('EXISTS', KEYS[1]) == 1 then
+	return 0
+end
+local opened = 0
+for i = 2, #KEYS do
+	local stock = tonumber(redis.call('GET', KEYS[i]) or 0)
+	if stock ~= 0 then
+		redis.call('XADD', KEYS[1], '*', 'sku', ARGV[1], 'location', ARGV[i + 2], 'delta', stock, 'reason', 'adjustment',
+			'reference', ARGV[2], 'timestamp', ARGV[3])
+		opened = opened + 1
+	end
+end
+return opened
+`)
+
+func movementsKey(sku string) string {
+	return fmt.Sprintf("warehouse:%s:movements", sku)
+}
+
+func movementsCheckpointKey(sku string) string {
+	return fmt.Sprintf("warehouse:%s:movements:checkpoint", sku)
+}
+
+func movementsTotalsKey(sku string) string {
+	return fmt.Sprintf("warehouse:%s:movements:totals", sku)
+}
+
+// RecordStockMovement moves stock in or out of a SKU at a location, such as when it is received,
+// returned or counted. Stock is never taken below zero; a movement that would fails with
+// InsufficientStock.
+func (r *WarehouseRepository) RecordStockMovement(sku, locationID string, delta int32, reason warehouse.MovementReason,
+	referenceID string) (movement *warehouse.StockMovement, err error) {
+
+	c, err := redis.Dial("tcp", r.redisDialString)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	if locationID == "" {
+		locationID = DefaultLocation
+	}
+	if err = requireLocation(c, locationID); err != nil {
+		return nil, err
+	}
+	isBundle, err := redis.Bool(c.Do("EXISTS", componentsKey(sku)))
+	if err != nil {
+		return nil, err
+	}
+	if isBundle {
+		return nil, errors.BundleHasNoStock
+	}
+
+	movement = &warehouse.StockMovement{
+		Sku:         sku,
+		LocationId:  locationID,
+		Delta:       delta,
+		Reason:      reason,
+		ReferenceId: referenceID,
+		Timestamp:   time.Now().UTC().Unix(),
+	}
+	movement.MovementId, err = redis.String(recordScript.Do(c, stockKey(sku, locationID), movementsKey(sku), sku, locationID,
+		delta, movementReasons[reason], referenceID, movement.Timestamp))
+	if err == redis.ErrNil {
+		return nil, errors.InsufficientStock
+	}
+	if err != nil {
+		return nil, err
+	}
+	return movement, nil
+}
+
+// GetStockMovements reads the latest movements in the ledger of a SKU's stock at a location, or at
+// every location when none is given, oldest first. Along with them it returns the stock counted there
+// now and the stock rebuilt there from the whole ledger, both read at the same moment.
+func (r *WarehouseRepository) GetStockMovements(sku, locationID string, limit int) (movements []*warehouse.StockMovement,
+	stock, ledgerStock int64, err error) {
+
+	c, err := redis.Dial("tcp", r.redisDialString)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	defer c.Close()
+
+	locations, err := loadLocations(c)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	if locationID != "" {
+		if _, ok := locations[locationID]; !ok {
+			return nil, 0, 0, errors.NoSuchLocation
+		}
+		locations = map[string]string{locationID: locations[locationID]}
+	}
+	keys := redis.Args{}.Add(movementsKey(sku), movementsCheckpointKey(sku), movementsTotalsKey(sku))
+	args := redis.Args{}.Add(limit, locationID)
+	for id := range locations {
+		keys = keys.Add(stockKey(sku, id))
+		args = args.Add(id)
+	}
+	reply, err := redis.Values(ledgerScript.Do(c, append(append(redis.Args{len(keys)}, keys...), args...)...))
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	var entries []interface{}
+	if _, err = redis.Scan(reply, &stock, &ledgerStock, &entries); err != nil {
+		return nil, 0, 0, err
+	}
+	movements = make([]*warehouse.StockMovement, len(entries))
+	for i, entry := range entries {
+		// the script returns the newest first
+		if movements[len(entries)-1-i], err = parseMovement(entry); err != nil {
+			return nil, 0, 0, err
+		}
+	}
+	return movements, stock, ledgerStock, nil
+}
+
+// OpenLedgers starts the ledger of every SKU stocked before the ledger was kept with the stock it had
+// at each location, so that it balances, returning how many ledgers it started
+func (r *WarehouseRepository) OpenLedgers() (opened int, err error) {
+	c, err := redis.Dial("tcp", r.redisDialString)
+	if err != nil {
+		return 0, err
+	}
+	defer c.Close()
+
+	locations, err := loadLocations(c)
+	if err != nil {
+		return 0, err
+	}
+	skus, err := scanStockedSkus(c)
+	if err != nil {
+		return 0, err
+	}
+	now := time.Now().UTC().Unix()
+	for _, sku := range skus {
+		keys := redis.Args{}.Add(movementsKey(sku))
+		args := redis.Args{}.Add(sku, openingBalanceReference, now)
+		for id := range locations {
+			keys = keys.Add(stockKey(sku, id))
+			args = args.Add(id)
+		}
+		recorded, err := redis.Int(openScript.Do(c, append(append(redis.Args{len(keys)}, keys...), args...)...))
+		if err != nil {
+			return opened, err
+		}
+		if recorded > 0 {
+			opened++
+		}
+	}
+	return opened, nil
+}
+
+// scanStockedSkus walks the keyspace for the SKUs with stock counted at any location
+func scanStockedSkus(c redis.Conn) (skus []string, err error) {
+	seen := make(map[string]bool)
+	cursor := 0
+	for {
+		v, err := redis.Values(c.Do("SCAN", cursor, "MATCH", "warehouse:*:stock*", "COUNT", 100))
+		if err != nil {
+			return nil, err
+		}
+		var keys []string
+		if _, err = redis.Scan(v, &cursor, &keys); err != nil {
+			return nil, err
+		}
+		for _, key := range keys {
+			// warehouse:{sku}:stock or warehouse:{sku}:stock:{location}
+			parts := strings.Split(strings.TrimPrefix(key, "warehouse:"), ":")
+			if (len(parts) == 2 || len(parts) == 3) && parts[1] == "stock" && !seen[parts[0]] {
+				seen[parts[0]] = true
+				skus = append(skus, parts[0])
+			}
+		}
+		if cursor == 0 {
+			return skus, nil
+		}
+	}
+}
+
+// parseMovement reads a ledger entry, which XRANGE and XREVRANGE return as its ID followed by its
+// fields
+func parseMovement(entry interface{}) (movement *warehouse.StockMovement, err error) {
+	values, err := redis.Values(entry, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(values) != 2 {
+		return nil, fmt.Errorf("malformed ledger entry: %v", values)
+	}
+	id, err := redis.String(values[0], nil)
+	if err != nil {
+		return nil, err
+	}
+	fields, err := redis.StringMap(values[1], nil)
+	if err != nil {
+		return nil, err
+	}
+	delta, err := strconv.ParseInt(fields["delta"], 10, 32)
+	if err != nil {
+		return nil, err
+	}
+	timestamp, err := strconv.ParseInt(fields["timestamp"], 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	movement = &warehouse.StockMovement{
+		MovementId:  id,
+		Sku:         fields["sku"],
+		LocationId:  fields["location"],
+		Delta:       int32(delta),
+		ReferenceId: fields["reference"],
+		Timestamp:   timestamp,
+	}
+	for reason, name := range movementReasons {
+		if name == fields["reason"] {
+			movement.Reason = reason
+		}
+	}
+	return movement, nil
+}
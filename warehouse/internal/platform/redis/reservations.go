@@ -0,0 +1,299 @@
+package redis
+
+import (
+	"fmt"
+	"github.com/autodidaddict/go-shopping/warehouse/internal/platform/errors"
+	"github.com/autodidaddict/go-shopping/warehouse/proto"
+	"github.com/garyburd/redigo/redis"
+	"sort"
+	"strings"
+	"time"
+)
+
+// A reservation holds stock at a single location. Its record, warehouse:reservation:{id}, expires
+// along with the reservation. The stock it holds of each SKU is kept in two places so that it can be
+// totted up without visiting every reservation: the warehouse:{sku}:reserved hash maps the
+// reservation's ID to the quantity held, and the warehouse:{sku}:reservations sorted set scores it by
+// the unix time it expires at. Like stock, both carry a :{location} suffix away from the main
+// warehouse. Committed reservations never expire, and warehouse:order:{id}:reservations maps each
+// SKU to the reservation committed to the order for it.
+
+const reservationIDKey = "warehouse:reservation:next"
+
+// reserveScript holds the stock of every SKU in a reservation, provided each has enough stock that
+// isn't already reserved. Expired reservations are dropped as it goes.
+//
+// KEYS: the reservation's record, then the stock, reserved and reservations keys of each SKU held
+// ARGV: now, expires at, reservation ID, SKU, location, quantity, TTL, SKUs held, then the quantity
+// of each SKU held
+var reserveScript = redis.NewScript(-1, `
+local held = (#KEYS - 1) / 3
+for i = 0, held - 1 do
+	local stock, reserved, reservations = KEYS[2 + 3 * i], KEYS[3 + 3 * i], KEYS[4 + 3 * i]
+	local expired = redis.call('ZRANGEBYSCORE', reservations, '-inf', ARGV[1])
+	if #expired > 0 then
+		redis.call('HDEL', reserved, unpack(expired))
+		redis.call('ZREMRANGEBYSCORE', reservations, '-inf', ARGV[1])
+	end
+	local available = tonumber(redis.call('GET', stock) or 0)
+	for _, quantity in ipairs(redis.call('HVALS', reserved)) do
+		available = available - tonumber(quantity)
+	end
+	if available < tonumber(ARGV[9 + i]) then
+		return 0
+	end
+end
+for i = 0, held - 1 do
+	redis.call('HSET', KEYS[3 + 3 * i], ARGV[3], ARGV[9 + i])
+	redis.call('ZADD', KEYS[4 + 3 * i], ARGV[2], ARGV[3])
+end
+redis.call('HMSET', KEYS[1], 'sku', ARGV[4], 'location', ARGV[5], 'quantity', ARGV[6], 'expires', ARGV[2], 'holds', ARGV[8])
+redis.call('EXPIRE', KEYS[1], ARGV[7])
+return 1
+`)
+
+// commitScript commits a reservation to an order, provided the reservation hasn't expired and isn't
+// committed to another order, and the order doesn't already hold another reservation for the SKU. It
+// returns 1 once committed, 0 when the reservation has expired or been released, -1 when it is
+// committed to another order and -2 when the order holds another reservation.
+//
+// KEYS: the reservation's record, the order's reservations, then the reservations key of each SKU held
+// ARGV: now, reservation ID, order ID, SKU
+var commitScript = redis.NewScript(-1, `
+local expires = redis.call('HGET', KEYS[1], 'expires')
+if not expires or (expires ~= '0' and tonumber(expires) <= tonumber(ARGV[1])) then
+	return 0
+end
+local order = redis.call('HGET', KEYS[1], 'order')
+if order and order ~= '0' and order ~= ARGV[3] then
+	return -1
+end
+local committed = redis.call('HGET', KEYS[2], ARGV[4])
+if committed and committed ~= ARGV[2] then
+	return -2
+end
+for i = 3, #KEYS do
+	redis.call('ZADD', KEYS[i], '+inf', ARGV[2])
+end
+redis.call('PERSIST', KEYS[1])
+redis.call('HMSET', KEYS[1], 'order', ARGV[3], 'expires', 0)
+redis.call('HSET', KEYS[2], ARGV[4], ARGV[2])
+return 1
+`)
+
+func reservationKey(reservationID uint64) string {
+	return fmt.Sprintf("warehouse:reservation:%d", reservationID)
+}
+
+func reservedKey(sku, locationID string) string {
+	if locationID == "" || locationID == DefaultLocation {
+		return fmt.Sprintf("warehouse:%s:reserved", sku)
+	}
+	return fmt.Sprintf("warehouse:%s:reserved:%s", sku, locationID)
+}
+
+func reservationsKey(sku, locationID string) string {
+	if locationID == "" || locationID == DefaultLocation {
+		return fmt.Sprintf("warehouse:%s:reservations", sku)
+	}
+	return fmt.Sprintf("warehouse:%s:reservations:%s", sku, locationID)
+}
+
+func orderReservationsKey(orderID uint64) string {
+	return fmt.Sprintf("warehouse:order:%d:reservations", orderID)
+}
+
+// ReserveStock holds a quantity of a SKU at a location for the given time. Reserving a bundle holds
+// the stock of each of its components instead.
+func (r *WarehouseRepository) ReserveStock(sku, locationID string, quantity uint32, ttl time.Duration) (reservation *warehouse.Reservation,
+	err error) {
+
+	c, err := redis.Dial("tcp", r.redisDialString)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	if locationID == "" {
+		locationID = DefaultLocation
+	}
+	if err = requireLocation(c, locationID); err != nil {
+		return nil, err
+	}
+	holds, err := redis.IntMap(c.Do("HGETALL", componentsKey(sku)))
+	if err != nil {
+		return nil, err
+	}
+	if len(holds) == 0 {
+		holds = map[string]int{sku: 1}
+	}
+	reservationID, err := redis.Uint64(c.Do("INCR", reservationIDKey))
+	if err != nil {
+		return nil, err
+	}
+
+	record := &redisReservation{
+		SKU:        sku,
+		LocationID: locationID,
+		Quantity:   quantity,
+		ExpiresAt:  time.Now().Add(ttl).Unix(),
+	}
+	skus := make([]string, 0, len(holds))
+	for held := range holds {
+		skus = append(skus, held)
+	}
+	sort.Strings(skus)
+	record.Holds = strings.Join(skus, " ")
+
+	keys := redis.Args{}.Add(reservationKey(reservationID))
+	args := redis.Args{}.Add(time.Now().Unix(), record.ExpiresAt, reservationID, sku, locationID, quantity,
+		int64(ttl/time.Second), record.Holds)
+	for _, held := range skus {
+		keys = keys.Add(stockKey(held, locationID), reservedKey(held, locationID), reservationsKey(held, locationID))
+		args = args.Add(holds[held] * int(quantity))
+	}
+	reserved, err := redis.Bool(reserveScript.Do(c, append(append(redis.Args{len(keys)}, keys...), args...)...))
+	if err != nil {
+		return nil, err
+	}
+	if !reserved {
+		return nil, errors.InsufficientStock
+	}
+	return record.toProto(reservationID), nil
+}
+
+// ReleaseReservation frees the stock held by a reservation, whether or not it has been committed
+func (r *WarehouseRepository) ReleaseReservation(reservationID uint64) (err error) {
+	c, err := redis.Dial("tcp", r.redisDialString)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	for {
+		if _, err = c.Do("WATCH", reservationKey(reservationID)); err != nil {
+			return err
+		}
+		record, err := loadReservation(c, reservationID)
+		if err != nil {
+			c.Do("UNWATCH")
+			return err
+		}
+		c.Send("MULTI")
+		queueRelease(c, reservationID, record)
+		reply, err := c.Do("EXEC")
+		if err != nil {
+			return err
+		}
+		if reply != nil {
+			return nil
+		}
+		// the reservation was committed, expired or released elsewhere while being released, so it
+		// has to be looked at again
+	}
+}
+
+// CommitReservation stops a reservation from expiring, holding its stock for an order until the
+// order's item ships. Committing a reservation to the order it is already committed to changes
+// nothing.
+func (r *WarehouseRepository) CommitReservation(reservationID, orderID uint64) (reservation *warehouse.Reservation, err error) {
+	c, err := redis.Dial("tcp", r.redisDialString)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	record, err := loadReservation(c, reservationID)
+	if err != nil {
+		return nil, err
+	}
+	keys := redis.Args{}.Add(reservationKey(reservationID), orderReservationsKey(orderID))
+	for _, held := range strings.Fields(record.Holds) {
+		keys = keys.Add(reservationsKey(held, record.LocationID))
+	}
+	committed, err := redis.Int(commitScript.Do(c, append(append(redis.Args{len(keys)}, keys...), time.Now().Unix(),
+		reservationID, orderID, record.SKU)...))
+	if err != nil {
+		return nil, err
+	}
+	switch committed {
+	case 0:
+		return nil, errors.NoSuchReservation
+	case -1:
+		return nil, errors.ReservationCommitted
+	case -2:
+		return nil, errors.DuplicateReservation
+	}
+	record.OrderID = orderID
+	record.ExpiresAt = 0
+	return record.toProto(reservationID), nil
+}
+
+// loadReservation loads the record of a reservation that is still active. A reservation that has
+// expired is no longer active, even if its record hasn't expired yet.
+func loadReservation(c redis.Conn, reservationID uint64) (record *redisReservation, err error) {
+	res, err := redis.Values(c.Do("HGETALL", reservationKey(reservationID)))
+	if err != nil {
+		return nil, err
+	}
+	if len(res) == 0 {
+		return nil, errors.NoSuchReservation
+	}
+	record = &redisReservation{}
+	if err = redis.ScanStruct(res, record); err != nil {
+		return nil, err
+	}
+	if record.ExpiresAt != 0 && record.ExpiresAt <= time.Now().Unix() {
+		return nil, errors.NoSuchReservation
+	}
+	return record, nil
+}
+
+// queueRelease queues the commands that free the stock held by a reservation within a transaction
+func queueRelease(c redis.Conn, reservationID uint64, record *redisReservation) {
+	for _, held := range strings.Fields(record.Holds) {
+		c.Send("HDEL", reservedKey(held, record.LocationID), reservationID)
+		c.Send("ZREM", reservationsKey(held, record.LocationID), reservationID)
+	}
+	c.Send("DEL", reservationKey(reservationID))
+	if record.OrderID != 0 {
+		c.Send("HDEL", orderReservationsKey(record.OrderID), record.SKU)
+	}
+}
+
+// reservedStock totals the stock of a SKU at a location held by active reservations
+func reservedStock(c redis.Conn, sku, locationID string) (reserved int, err error) {
+	reservationIDs, err := redis.Strings(c.Do("ZRANGEBYSCORE", reservationsKey(sku, locationID),
+		fmt.Sprintf("(%d", time.Now().Unix()), "+inf"))
+	if err != nil || len(reservationIDs) == 0 {
+		return 0, err
+	}
+	quantities, err := redis.Ints(c.Do("HMGET", redis.Args{}.Add(reservedKey(sku, locationID)).AddFlat(reservationIDs)...))
+	if err != nil {
+		return 0, err
+	}
+	for _, quantity := range quantities {
+		reserved += quantity
+	}
+	return reserved, nil
+}
+
+type redisReservation struct {
+	SKU        string `redis:"sku"`
+	LocationID string `redis:"location"`
+	Quantity   uint32 `redis:"quantity"`
+	ExpiresAt  int64  `redis:"expires"`
+	OrderID    uint64 `redis:"order"`
+	Holds      string `redis:"holds"` // the SKUs whose stock is held, separated by spaces
+}
+
+func (record *redisReservation) toProto(reservationID uint64) *warehouse.Reservation {
+	return &warehouse.Reservation{
+		ReservationId: reservationID,
+		Sku:           record.SKU,
+		Quantity:      record.Quantity,
+		LocationId:    record.LocationID,
+		ExpiresAt:     record.ExpiresAt,
+		OrderId:       record.OrderID,
+	}
+}
@@ -2,10 +2,97 @@ package redis
 
 import (
 	"fmt"
+	"github.com/autodidaddict/go-shopping/warehouse/internal/platform/errors"
 	"github.com/autodidaddict/go-shopping/warehouse/proto"
 	"github.com/garyburd/redigo/redis"
+	"sort"
+	"strings"
+	"time"
 )
 
+// Stock is counted separately at each warehouse location. The warehouse:locations hash maps each
+// location's ID to its name, and the stock of a SKU at a location is kept in
+// warehouse:{sku}:stock:{location}. The main warehouse predates the others, so its stock is still kept
+// in the original warehouse:{sku}:stock counter.
+//
+// Bundles are sold as one item but never stocked as one. A bundle has the usual warehouse:{sku}
+// details, no stock counter, and a warehouse:{sku}:components hash of component SKU to the
+// quantity of that component in each bundle.
+//
+// Each shipment taken from stock is recorded in warehouse:shipment:{order}:{sku}:{tracking}, so that
+// a redelivered item shipped event doesn't take it again.
+
+// DefaultLocation is the ID of the main warehouse, which items shipped without a location are taken from
+const DefaultLocation = "main"
+
+const locationsKey = "warehouse:locations"
+
+// shipmentRecordTTL is how long a shipment is remembered for, which must outlast any redelivery of
+// its item shipped event
+const shipmentRecordTTL = 30 * 24 * time.Hour
+
+// shipScript takes a shipment from stock, recording it in the ledger of each SKU shipped, and draws
+// down the reservation committed to it, releasing the reservation once all of it has shipped. Stock
+// held by other reservations isn't available to the shipment; expired reservations are dropped as it
+// goes. It returns 0 without changing anything when the shipment has been taken already, and -1 when
+// there isn't enough stock to take it from.
+//
+// KEYS: the shipment's record, the stock, ledger, reserved and reservations keys of each SKU shipped,
+// then, when there is a reservation to draw down, the reserved and reservations keys of each SKU it
+// holds, its record and the order's reservations
+// ARGV: the shipment record's TTL, the location, the shipment's reference, the timestamp, the number
+// of SKUs shipped and each SKU followed by the quantity of it shipped, then the reservation's ID, SKU
+// and the quantity of it shipped, followed by the quantity of each SKU it holds that shipped. The
+// reservation's ID and SKU are empty when there is none.
+var shipScript = redis.NewScript(-1, `
+if redis.call('EXISTS', KEYS[1]) == 1 then
+	return 0
+end
+local shipped = tonumber(ARGV[5])
+local r = 6 + 2 * shipped
+local reservation = ARGV[r]
+for i = 1, shipped do
+	local stock, reserved, reservations = KEYS[4 * i - 2], KEYS[4 * i], KEYS[4 * i + 1]
+	local expired = redis.call('ZRANGEBYSCORE', reservations, '-inf', ARGV[4])
+	if #expired > 0 then
+		redis.call('HDEL', reserved, unpack(expired))
+		redis.call('ZREMRANGEBYSCORE', reservations, '-inf', ARGV[4])
+	end
+	local available = tonumber(redis.call('GET', stock) or 0)
+	local holds = redis.call('HGETALL', reserved)
+	for j = 1, #holds, 2 do
+		if holds[j] ~= reservation then
+			available = available - tonumber(holds[j + 1])
+		end
+	end
+	if available < tonumber(ARGV[5 + 2 * i]) then
+		return -1
+	end
+end
+redis.call('SET', KEYS[1], 1, 'EX', ARGV[1])
+for i = 1, shipped do
+	local quantity = tonumber(ARGV[5 + 2 * i])
+	redis.call('DECRBY', KEYS[4 * i - 2], quantity)
+	redis.call('XADD', KEYS[4 * i - 1], '*', 'sku', ARGV[4 + 2 * i], 'location', ARGV[2], 'delta', -quantity,
+		'reason', 'shipment', 'reference', ARGV[3], 'timestamp', ARGV[4])
+end
+if reservation ~= '' then
+	local first = 2 + 4 * shipped
+	for i = first, #KEYS - 2, 2 do
+		local held = tonumber(ARGV[r + 3 + (i - first) / 2])
+		if redis.call('HINCRBY', KEYS[i], reservation, -held) <= 0 then
+			redis.call('HDEL', KEYS[i], reservation)
+			redis.call('ZREM', KEYS[i + 1], reservation)
+		end
+	end
+	if redis.call('HINCRBY', KEYS[#KEYS - 1], 'quantity', -tonumber(ARGV[r + 2])) <= 0 then
+		redis.call('DEL', KEYS[#KEYS - 1])
+		redis.call('HDEL', KEYS[#KEYS], ARGV[r + 1])
+	end
+end
+return 1
+`)
+
 // WarehouseRepository represents a redis repository over warehouse data
 type WarehouseRepository struct {
 	redisDialString string
@@ -16,34 +103,143 @@ func NewWarehouseRepository(redisDialString string) *WarehouseRepository {
 	return &WarehouseRepository{redisDialString: redisDialString}
 }
 
-// GetWarehouseDetails queries the information about physical inventory in the warehouse for a given SKU
+func warehouseKey(sku string) string {
+	return fmt.Sprintf("warehouse:%s", sku)
+}
+
+func stockKey(sku, locationID string) string {
+	if locationID == "" || locationID == DefaultLocation {
+		return fmt.Sprintf("warehouse:%s:stock", sku)
+	}
+	return fmt.Sprintf("warehouse:%s:stock:%s", sku, locationID)
+}
+
+func componentsKey(sku string) string {
+	return fmt.Sprintf("warehouse:%s:components", sku)
+}
+
+func shipmentKey(orderID uint64, sku, trackingNumber string) string {
+	return fmt.Sprintf("warehouse:shipment:%d:%s:%s", orderID, sku, trackingNumber)
+}
+
+// GetWarehouseDetails queries the information about physical inventory in the warehouse for a given SKU,
+// broken down by location. The details of a bundle list the stock of each of its components instead
+// of its own.
 func (r *WarehouseRepository) GetWarehouseDetails(sku string) (details *warehouse.WarehouseDetails, err error) {
 	c, err := redis.Dial("tcp", r.redisDialString)
 	if err != nil {
 		return nil, err
 	}
 	defer c.Close()
-	warehouseKey := fmt.Sprintf("warehouse:%s", sku)
-	res, err := redis.Values(c.Do("HGETALL", warehouseKey))
+	res, err := redis.Values(c.Do("HGETALL", warehouseKey(sku)))
 	var itemDetails redisWarehouseDetails
 	err = redis.ScanStruct(res, &itemDetails)
 	if err != nil {
 		return nil, err
 	}
-	stockKey := fmt.Sprintf("warehouse:%s:stock", sku)
-	stockCount, err := redis.Int(c.Do("GET", stockKey))
+	details = &warehouse.WarehouseDetails{
+		Sku:          itemDetails.SKU,
+		Manufacturer: itemDetails.Manufacturer,
+		ModelNumber:  itemDetails.ModelNumber,
+	}
+
+	locations, err := loadLocations(c)
 	if err != nil {
 		return nil, err
 	}
-	details = &warehouse.WarehouseDetails{
-		Sku:            itemDetails.SKU,
-		Manufacturer:   itemDetails.Manufacturer,
-		ModelNumber:    itemDetails.ModelNumber,
-		StockRemaining: uint32(stockCount),
+	components, err := redis.IntMap(c.Do("HGETALL", componentsKey(sku)))
+	if err != nil {
+		return nil, err
+	}
+	if len(components) > 0 {
+		details.Components, err = componentStock(c, components, locations)
+		return details, err
+	}
+
+	details.Locations, err = locationStock(c, sku, locations)
+	if err != nil {
+		return nil, err
 	}
+	details.StockRemaining, details.StockReserved, details.StockAvailable = totalStock(details.Locations)
 	return details, nil
 }
 
+// loadLocations maps the ID of every warehouse location, including the main warehouse, to its name
+func loadLocations(c redis.Conn) (locations map[string]string, err error) {
+	locations, err = redis.StringMap(c.Do("HGETALL", locationsKey))
+	if err != nil {
+		return nil, err
+	}
+	if locations[DefaultLocation] == "" {
+		locations[DefaultLocation] = DefaultLocation
+	}
+	return locations, nil
+}
+
+// locationStock breaks down the stock of a SKU by location, in order of location ID. Locations that
+// have never stocked the SKU are left out.
+func locationStock(c redis.Conn, sku string, locations map[string]string) (stock []*warehouse.LocationStock, err error) {
+
+	ids := make([]string, 0, len(locations))
+	for id := range locations {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		stockCount, err := redis.Int(c.Do("GET", stockKey(sku, id)))
+		if err == redis.ErrNil {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		reserved, err := reservedStock(c, sku, id)
+		if err != nil {
+			return nil, err
+		}
+		location := &warehouse.LocationStock{LocationId: id, Name: locations[id], StockReserved: uint32(reserved)}
+		if stockCount > 0 {
+			location.StockRemaining = uint32(stockCount)
+		}
+		if stockCount > reserved {
+			location.StockAvailable = uint32(stockCount - reserved)
+		}
+		stock = append(stock, location)
+	}
+	return stock, nil
+}
+
+// totalStock adds up the stock at each location
+func totalStock(locations []*warehouse.LocationStock) (remaining, reserved, available uint32) {
+	for _, location := range locations {
+		remaining += location.StockRemaining
+		reserved += location.StockReserved
+		available += location.StockAvailable
+	}
+	return remaining, reserved, available
+}
+
+// componentStock looks up the stock of each of a bundle's components, ordered by SKU
+func componentStock(c redis.Conn, components map[string]int, locations map[string]string) (stock []*warehouse.ComponentStock,
+	err error) {
+
+	skus := make([]string, 0, len(components))
+	for sku := range components {
+		skus = append(skus, sku)
+	}
+	sort.Strings(skus)
+	for _, sku := range skus {
+		component := &warehouse.ComponentStock{Sku: sku, Quantity: uint32(components[sku])}
+		component.Locations, err = locationStock(c, sku, locations)
+		if err != nil {
+			return nil, err
+		}
+		component.StockRemaining, component.StockReserved, component.StockAvailable = totalStock(component.Locations)
+		stock = append(stock, component)
+	}
+	return stock, nil
+}
+
 // SkuExists indicates whether the SKU exists in the warehouse inventory (regardless of in-stock quantity)
 func (r *WarehouseRepository) SkuExists(sku string) (exists bool, err error) {
 	c, err := redis.Dial("tcp", r.redisDialString)
@@ -51,25 +247,146 @@ func (r *WarehouseRepository) SkuExists(sku string) (exists bool, err error) {
 		return false, err
 	}
 	defer c.Close()
-	warehouseKey := fmt.Sprintf("warehouse:%s", sku)
-	exists, err = redis.Bool(c.Do("EXISTS", warehouseKey))
+	exists, err = redis.Bool(c.Do("EXISTS", warehouseKey(sku)))
 	return exists, err
 }
 
-// DecrementStock will reduce the on-hand quantity of a SKU by 1
-func (r *WarehouseRepository) DecrementStock(sku string) (err error) {
+// DecrementStock will reduce the on-hand quantity of a SKU at a location by the quantity shipped,
+// taking it from the main warehouse when no location is given. Shipping a bundle reduces the on-hand
+// quantity of each of its components at the location by the number of that component in the shipped
+// bundles. Stock is never taken below zero; a shipment there isn't enough stock for fails with
+// InsufficientStock, leaving stock as it was, and stock held by reservations other than the order's
+// own isn't there for the taking. The shipment is recorded in the ledger of each SKU taken from stock,
+// referenced by the order and tracking number. Any reservation committed to the order for the SKU at
+// the location shipped from is drawn down by the quantity shipped, as the stock it held has now left.
+//
+// A shipment is identified by its order, SKU and tracking number, and is only ever taken from stock
+// once. Shipping it again changes nothing and reports it as a duplicate.
+func (r *WarehouseRepository) DecrementStock(sku string, locationID string, orderID uint64, trackingNumber string,
+	quantity uint32) (duplicate bool, err error) {
+
+	c, err := redis.Dial("tcp", r.redisDialString)
+	if err != nil {
+		return false, err
+	}
+	defer c.Close()
+
+	if locationID == "" {
+		locationID = DefaultLocation
+	}
+	if err = requireLocation(c, locationID); err != nil {
+		return false, err
+	}
+	components, err := redis.IntMap(c.Do("HGETALL", componentsKey(sku)))
+	if err != nil {
+		return false, err
+	}
+	if len(components) == 0 {
+		components = map[string]int{sku: 1}
+	}
+	reservationID, err := redis.Uint64(c.Do("HGET", orderReservationsKey(orderID), sku))
+	if err != nil && err != redis.ErrNil {
+		return false, err
+	}
+	var reservation *redisReservation
+	if reservationID != 0 {
+		reservation, err = loadReservation(c, reservationID)
+		if err != nil && err != errors.NoSuchReservation {
+			return false, err
+		}
+		// a reservation holds stock at a single location, so shipping from another leaves it be
+		if reservation != nil && reservation.LocationID != locationID {
+			reservation = nil
+		}
+	}
+
+	skus := make([]string, 0, len(components))
+	for component := range components {
+		skus = append(skus, component)
+	}
+	sort.Strings(skus)
+	keys := redis.Args{}.Add(shipmentKey(orderID, sku, trackingNumber))
+	args := redis.Args{}.Add(int64(shipmentRecordTTL/time.Second), locationID, fmt.Sprintf("%d/%s", orderID, trackingNumber),
+		time.Now().UTC().Unix(), len(skus))
+	for _, component := range skus {
+		keys = keys.Add(stockKey(component, locationID), movementsKey(component), reservedKey(component, locationID),
+			reservationsKey(component, locationID))
+		args = args.Add(component, components[component]*int(quantity))
+	}
+	if reservation == nil {
+		args = args.Add("", "", 0)
+	} else {
+		args = args.Add(reservationID, sku, quantity)
+		for _, held := range strings.Fields(reservation.Holds) {
+			keys = keys.Add(reservedKey(held, reservation.LocationID), reservationsKey(held, reservation.LocationID))
+			args = args.Add(components[held] * int(quantity))
+		}
+		keys = keys.Add(reservationKey(reservationID), orderReservationsKey(orderID))
+	}
+	shipped, err := redis.Int(shipScript.Do(c, append(append(redis.Args{len(keys)}, keys...), args...)...))
+	if err != nil {
+		return false, err
+	}
+	if shipped < 0 {
+		return false, errors.InsufficientStock
+	}
+	return shipped == 0, nil
+}
+
+// requireLocation fails with NoSuchLocation unless the location is the main warehouse or has been
+// registered
+func requireLocation(c redis.Conn, locationID string) error {
+	if locationID == "" || locationID == DefaultLocation {
+		return nil
+	}
+	exists, err := redis.Bool(c.Do("HEXISTS", locationsKey, locationID))
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return errors.NoSuchLocation
+	}
+	return nil
+}
+
+// SaveBundle records a bundle and the quantity of each component it holds, replacing any
+// components it held before. A bundle saved without components is stocked like any other SKU.
+func (r *WarehouseRepository) SaveBundle(sku, manufacturer, model string, components map[string]uint32) (err error) {
 	c, err := redis.Dial("tcp", r.redisDialString)
 	if err != nil {
 		return err
 	}
 	defer c.Close()
-	warehouseKey := fmt.Sprintf("warehouse:%s:stock", sku)
-	_, err = c.Do("INCRBY", warehouseKey, "-1")
+
+	c.Send("MULTI")
+	c.Send("HMSET", redis.Args{}.Add(warehouseKey(sku)).AddFlat(&redisWarehouseDetails{
+		SKU:          sku,
+		Manufacturer: manufacturer,
+		ModelNumber:  model,
+	})...)
+	c.Send("DEL", componentsKey(sku))
+	if len(components) > 0 {
+		c.Send("HMSET", redis.Args{}.Add(componentsKey(sku)).AddFlat(components)...)
+	}
+	_, err = c.Do("EXEC")
+	return err
+}
+
+// RemoveBundle forgets a bundle. SKUs that aren't bundles are left alone, as their stock is
+// managed by the warehouse rather than the catalog.
+func (r *WarehouseRepository) RemoveBundle(sku string) (err error) {
+	c, err := redis.Dial("tcp", r.redisDialString)
 	if err != nil {
 		return err
 	}
+	defer c.Close()
 
-	return nil
+	isBundle, err := redis.Bool(c.Do("EXISTS", componentsKey(sku)))
+	if err != nil || !isBundle {
+		return err
+	}
+	_, err = c.Do("DEL", componentsKey(sku), warehouseKey(sku))
+	return err
 }
 
 type redisWarehouseDetails struct {
@@ -0,0 +1,380 @@
+package redis_test
+
+import (
+	"fmt"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/autodidaddict/go-shopping/warehouse/internal/platform/errors"
+	"github.com/autodidaddict/go-shopping/warehouse/internal/platform/redis"
+	"github.com/autodidaddict/go-shopping/warehouse/proto"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+	"time"
+)
+
+func TestBundles(t *testing.T) {
+	Convey("Given a warehouse repository with a bundle in stock", t, func() {
+		server := miniredis.RunT(t)
+		repo := redis.NewWarehouseRepository(server.Addr())
+		So(repo.SaveBundle("KIT001", "Acme", "K1", map[string]uint32{"CAM001": 1, "LENS01": 2}), ShouldBeNil)
+		_, err := repo.RecordStockMovement("CAM001", "", 5, warehouse.MovementReason_MR_RECEIPT, "PO-1")
+		So(err, ShouldBeNil)
+		_, err = repo.RecordStockMovement("LENS01", "", 10, warehouse.MovementReason_MR_RECEIPT, "PO-1")
+		So(err, ShouldBeNil)
+
+		Convey("shipping bundles should take each of their components from stock", func() {
+			duplicate, err := repo.DecrementStock("KIT001", "", 42, "1Z999", 2)
+			So(err, ShouldBeNil)
+			So(duplicate, ShouldBeFalse)
+
+			details, err := repo.GetWarehouseDetails("KIT001")
+			So(err, ShouldBeNil)
+			So(len(details.Components), ShouldEqual, 2)
+			So(details.Components[0].Sku, ShouldEqual, "CAM001")
+			So(details.Components[0].StockRemaining, ShouldEqual, 3)
+			So(details.Components[1].Sku, ShouldEqual, "LENS01")
+			So(details.Components[1].StockRemaining, ShouldEqual, 6)
+
+			movements, _, _, err := repo.GetStockMovements("LENS01", "", 10)
+			So(err, ShouldBeNil)
+			So(len(movements), ShouldEqual, 2)
+			So(movements[1].Delta, ShouldEqual, -4)
+			So(movements[1].Reason, ShouldEqual, warehouse.MovementReason_MR_SHIPMENT)
+			So(movements[1].ReferenceId, ShouldEqual, "42/1Z999")
+		})
+
+		Convey("a bundle whose components are short should not be shipped", func() {
+			_, err := repo.DecrementStock("KIT001", "", 42, "1Z999", 6)
+			So(err, ShouldEqual, errors.InsufficientStock)
+
+			details, err := repo.GetWarehouseDetails("KIT001")
+			So(err, ShouldBeNil)
+			So(details.Components[0].StockRemaining, ShouldEqual, 5)
+			So(details.Components[1].StockRemaining, ShouldEqual, 10)
+		})
+
+		Convey("a bundle saved without components should be stocked like any other SKU", func() {
+			So(repo.SaveBundle("KIT001", "Acme", "K1", map[string]uint32{}), ShouldBeNil)
+
+			details, err := repo.GetWarehouseDetails("KIT001")
+			So(err, ShouldBeNil)
+			So(details.Components, ShouldBeEmpty)
+		})
+	})
+}
+
+func TestShipments(t *testing.T) {
+	Convey("Given a warehouse repository with stock", t, func() {
+		server := miniredis.RunT(t)
+		repo := redis.NewWarehouseRepository(server.Addr())
+		_, err := repo.RecordStockMovement("CAM001", "", 5, warehouse.MovementReason_MR_RECEIPT, "PO-1")
+		So(err, ShouldBeNil)
+
+		Convey("a redelivered shipment should only be taken from stock once", func() {
+			duplicate, err := repo.DecrementStock("CAM001", "", 42, "1Z999", 2)
+			So(err, ShouldBeNil)
+			So(duplicate, ShouldBeFalse)
+			duplicate, err = repo.DecrementStock("CAM001", "", 42, "1Z999", 2)
+			So(err, ShouldBeNil)
+			So(duplicate, ShouldBeTrue)
+
+			details, err := repo.GetWarehouseDetails("CAM001")
+			So(err, ShouldBeNil)
+			So(details.StockRemaining, ShouldEqual, 3)
+			movements, _, _, err := repo.GetStockMovements("CAM001", "", 10)
+			So(err, ShouldBeNil)
+			So(len(movements), ShouldEqual, 2)
+		})
+
+		Convey("another shipment of the same order should be taken from stock", func() {
+			_, err := repo.DecrementStock("CAM001", "", 42, "1Z999", 2)
+			So(err, ShouldBeNil)
+			duplicate, err := repo.DecrementStock("CAM001", "", 42, "1Z998", 2)
+			So(err, ShouldBeNil)
+			So(duplicate, ShouldBeFalse)
+
+			details, err := repo.GetWarehouseDetails("CAM001")
+			So(err, ShouldBeNil)
+			So(details.StockRemaining, ShouldEqual, 1)
+		})
+
+		Convey("a shipment that was short should be taken once there is stock", func() {
+			_, err := repo.DecrementStock("CAM001", "", 42, "1Z999", 6)
+			So(err, ShouldEqual, errors.InsufficientStock)
+			_, err = repo.RecordStockMovement("CAM001", "", 1, warehouse.MovementReason_MR_RECEIPT, "PO-2")
+			So(err, ShouldBeNil)
+			duplicate, err := repo.DecrementStock("CAM001", "", 42, "1Z999", 6)
+			So(err, ShouldBeNil)
+			So(duplicate, ShouldBeFalse)
+		})
+	})
+}
+
+func TestStockMovements(t *testing.T) {
+	Convey("Given a warehouse repository with stock moved in and out at two locations", t, func() {
+		server := miniredis.RunT(t)
+		repo := redis.NewWarehouseRepository(server.Addr())
+		_, err := repo.CreateLocation("reno", "Reno")
+		So(err, ShouldBeNil)
+		for _, movement := range []struct {
+			location string
+			delta    int32
+			reason   warehouse.MovementReason
+		}{
+			{"", 10, warehouse.MovementReason_MR_RECEIPT},
+			{"reno", 4, warehouse.MovementReason_MR_RECEIPT},
+			{"", -3, warehouse.MovementReason_MR_ADJUSTMENT},
+			{"reno", 1, warehouse.MovementReason_MR_RETURN},
+		} {
+			_, err = repo.RecordStockMovement("CAM001", movement.location, movement.delta, movement.reason, "REF-1")
+			So(err, ShouldBeNil)
+		}
+
+		Convey("the latest movements should be returned oldest first, read back as they were recorded", func() {
+			movements, stock, ledgerStock, err := repo.GetStockMovements("CAM001", "", 2)
+			So(err, ShouldBeNil)
+			So(stock, ShouldEqual, 12)
+			So(ledgerStock, ShouldEqual, 12)
+			So(len(movements), ShouldEqual, 2)
+			So(movements[0].LocationId, ShouldEqual, "main")
+			So(movements[0].Delta, ShouldEqual, -3)
+			So(movements[0].Reason, ShouldEqual, warehouse.MovementReason_MR_ADJUSTMENT)
+			So(movements[0].ReferenceId, ShouldEqual, "REF-1")
+			So(movements[0].Timestamp, ShouldBeGreaterThan, 0)
+			So(movements[1].LocationId, ShouldEqual, "reno")
+			So(movements[1].Reason, ShouldEqual, warehouse.MovementReason_MR_RETURN)
+			So(movements[0].MovementId, ShouldBeLessThan, movements[1].MovementId)
+		})
+
+		Convey("the movements at one location should be returned with the stock there", func() {
+			movements, stock, ledgerStock, err := repo.GetStockMovements("CAM001", "reno", 10)
+			So(err, ShouldBeNil)
+			So(stock, ShouldEqual, 5)
+			So(ledgerStock, ShouldEqual, 5)
+			So(len(movements), ShouldEqual, 2)
+			So(movements[0].Delta, ShouldEqual, 4)
+			So(movements[1].Delta, ShouldEqual, 1)
+		})
+
+		Convey("the stock rebuilt from the ledger should keep up with later movements", func() {
+			_, _, _, err := repo.GetStockMovements("CAM001", "", 1)
+			So(err, ShouldBeNil)
+			_, err = repo.DecrementStock("CAM001", "reno", 42, "1Z999", 2)
+			So(err, ShouldBeNil)
+
+			_, stock, ledgerStock, err := repo.GetStockMovements("CAM001", "", 1)
+			So(err, ShouldBeNil)
+			So(stock, ShouldEqual, 10)
+			So(ledgerStock, ShouldEqual, 10)
+		})
+
+		Convey("stock changed outside the ledger should not balance", func() {
+			server.Set("warehouse:CAM001:stock", "9")
+
+			_, stock, ledgerStock, err := repo.GetStockMovements("CAM001", "main", 10)
+			So(err, ShouldBeNil)
+			So(stock, ShouldEqual, 9)
+			So(ledgerStock, ShouldEqual, 7)
+		})
+
+		Convey("a movement that would take stock below zero should change nothing", func() {
+			_, err := repo.RecordStockMovement("CAM001", "reno", -6, warehouse.MovementReason_MR_ADJUSTMENT, "COUNT-1")
+			So(err, ShouldEqual, errors.InsufficientStock)
+
+			movements, stock, _, err := repo.GetStockMovements("CAM001", "reno", 10)
+			So(err, ShouldBeNil)
+			So(stock, ShouldEqual, 5)
+			So(len(movements), ShouldEqual, 2)
+		})
+	})
+}
+
+func TestOpeningBalances(t *testing.T) {
+	Convey("Given a warehouse repository with stock counted before the ledger was kept", t, func() {
+		server := miniredis.RunT(t)
+		repo := redis.NewWarehouseRepository(server.Addr())
+		_, err := repo.CreateLocation("reno", "Reno")
+		So(err, ShouldBeNil)
+		server.Set("warehouse:CAM001:stock", "7")
+		server.Set("warehouse:CAM001:stock:reno", "2")
+		server.Set("warehouse:LENS01:stock:reno", "3")
+		_, err = repo.RecordStockMovement("TRIPOD", "", 5, warehouse.MovementReason_MR_RECEIPT, "PO-1")
+		So(err, ShouldBeNil)
+
+		Convey("each ledger should be opened with the stock at every location, once", func() {
+			opened, err := repo.OpenLedgers()
+			So(err, ShouldBeNil)
+			So(opened, ShouldEqual, 2)
+
+			movements, stock, ledgerStock, err := repo.GetStockMovements("CAM001", "", 10)
+			So(err, ShouldBeNil)
+			So(len(movements), ShouldEqual, 2)
+			So(movements[0].Reason, ShouldEqual, warehouse.MovementReason_MR_ADJUSTMENT)
+			So(movements[0].ReferenceId, ShouldEqual, "opening balance")
+			So(stock, ShouldEqual, 9)
+			So(ledgerStock, ShouldEqual, 9)
+
+			_, stock, ledgerStock, err = repo.GetStockMovements("TRIPOD", "", 10)
+			So(err, ShouldBeNil)
+			So(ledgerStock, ShouldEqual, stock)
+
+			opened, err = repo.OpenLedgers()
+			So(err, ShouldBeNil)
+			So(opened, ShouldEqual, 0)
+			_, _, ledgerStock, err = repo.GetStockMovements("LENS01", "", 10)
+			So(err, ShouldBeNil)
+			So(ledgerStock, ShouldEqual, 3)
+		})
+	})
+}
+
+func TestLocations(t *testing.T) {
+	Convey("Given a warehouse repository", t, func() {
+		server := miniredis.RunT(t)
+		repo := redis.NewWarehouseRepository(server.Addr())
+
+		Convey("a created location should be listed after the main warehouse and hold stock", func() {
+			location, err := repo.CreateLocation("reno", "Reno")
+			So(err, ShouldBeNil)
+			So(location.Name, ShouldEqual, "Reno")
+
+			locations, err := repo.GetLocations()
+			So(err, ShouldBeNil)
+			So(locations, ShouldResemble, []*warehouse.Location{
+				{LocationId: "main", Name: "main"},
+				{LocationId: "reno", Name: "Reno"},
+			})
+
+			_, err = repo.RecordStockMovement("CAM001", "reno", 5, warehouse.MovementReason_MR_RECEIPT, "PO-1")
+			So(err, ShouldBeNil)
+		})
+
+		Convey("a location should not be created twice", func() {
+			_, err := repo.CreateLocation("reno", "Reno")
+			So(err, ShouldBeNil)
+			_, err = repo.CreateLocation("reno", "Reno, Nevada")
+			So(err, ShouldEqual, errors.DuplicateLocation)
+			_, err = repo.CreateLocation("main", "Main Warehouse")
+			So(err, ShouldEqual, errors.DuplicateLocation)
+		})
+
+		Convey("failed shipments should be listed newest first", func() {
+			for _, orderID := range []uint64{1, 2, 3} {
+				_, err := repo.RecordFailedShipment(&warehouse.FailedShipment{OrderId: orderID, Sku: "CAM001",
+					LocationId: "atlantis", TrackingNumber: "1Z999", Quantity: 2, Reason: "No such warehouse location",
+					Timestamp: 1500000000})
+				So(err, ShouldBeNil)
+			}
+
+			shipments, err := repo.GetFailedShipments(2)
+			So(err, ShouldBeNil)
+			So(len(shipments), ShouldEqual, 2)
+			So(shipments[0].FailureId, ShouldNotBeEmpty)
+			shipments[0].FailureId = ""
+			So(shipments[0], ShouldResemble, &warehouse.FailedShipment{OrderId: 3, Sku: "CAM001", LocationId: "atlantis",
+				TrackingNumber: "1Z999", Quantity: 2, Reason: "No such warehouse location", Timestamp: 1500000000})
+			So(shipments[1].OrderId, ShouldEqual, 2)
+		})
+	})
+}
+
+func TestReservations(t *testing.T) {
+	Convey("Given a warehouse repository with a reservation", t, func() {
+		server := miniredis.RunT(t)
+		repo := redis.NewWarehouseRepository(server.Addr())
+		_, err := repo.RecordStockMovement("CAM001", "", 5, warehouse.MovementReason_MR_RECEIPT, "PO-1")
+		So(err, ShouldBeNil)
+		reservation, err := repo.ReserveStock("CAM001", "", 2, time.Minute)
+		So(err, ShouldBeNil)
+
+		Convey("committing it should hold its stock for the order, however often it is committed", func() {
+			committed, err := repo.CommitReservation(reservation.ReservationId, 42)
+			So(err, ShouldBeNil)
+			So(committed.OrderId, ShouldEqual, 42)
+			So(committed.ExpiresAt, ShouldEqual, 0)
+			_, err = repo.CommitReservation(reservation.ReservationId, 42)
+			So(err, ShouldBeNil)
+
+			details, err := repo.GetWarehouseDetails("CAM001")
+			So(err, ShouldBeNil)
+			So(details.StockReserved, ShouldEqual, 2)
+		})
+
+		Convey("it should not be committed to a second order", func() {
+			_, err := repo.CommitReservation(reservation.ReservationId, 42)
+			So(err, ShouldBeNil)
+			_, err = repo.CommitReservation(reservation.ReservationId, 43)
+			So(err, ShouldEqual, errors.ReservationCommitted)
+		})
+
+		Convey("an order should not hold two reservations for a SKU", func() {
+			_, err := repo.CommitReservation(reservation.ReservationId, 42)
+			So(err, ShouldBeNil)
+			second, err := repo.ReserveStock("CAM001", "", 1, time.Minute)
+			So(err, ShouldBeNil)
+			_, err = repo.CommitReservation(second.ReservationId, 42)
+			So(err, ShouldEqual, errors.DuplicateReservation)
+		})
+
+		Convey("once it has expired it should neither be committed nor released", func() {
+			server.HSet(fmt.Sprintf("warehouse:reservation:%d", reservation.ReservationId), "expires", "1")
+			server.ZAdd("warehouse:CAM001:reservations", 1, fmt.Sprint(reservation.ReservationId))
+
+			_, err := repo.CommitReservation(reservation.ReservationId, 42)
+			So(err, ShouldEqual, errors.NoSuchReservation)
+			So(repo.ReleaseReservation(reservation.ReservationId), ShouldEqual, errors.NoSuchReservation)
+
+			details, err := repo.GetWarehouseDetails("CAM001")
+			So(err, ShouldBeNil)
+			So(details.StockReserved, ShouldEqual, 0)
+		})
+
+		Convey("releasing it once committed should free its stock and its order", func() {
+			_, err := repo.CommitReservation(reservation.ReservationId, 42)
+			So(err, ShouldBeNil)
+			So(repo.ReleaseReservation(reservation.ReservationId), ShouldBeNil)
+			So(server.Exists("warehouse:order:42:reservations"), ShouldBeFalse)
+
+			details, err := repo.GetWarehouseDetails("CAM001")
+			So(err, ShouldBeNil)
+			So(details.StockReserved, ShouldEqual, 0)
+			So(repo.ReleaseReservation(reservation.ReservationId), ShouldEqual, errors.NoSuchReservation)
+		})
+
+		Convey("the stock it holds should not be shipped to another order", func() {
+			_, err := repo.DecrementStock("CAM001", "", 99, "T1", 4)
+			So(err, ShouldEqual, errors.InsufficientStock)
+			_, err = repo.DecrementStock("CAM001", "", 99, "T1", 3)
+			So(err, ShouldBeNil)
+		})
+
+		Convey("the stock it holds should be shipped to the order it is committed to", func() {
+			_, err := repo.CommitReservation(reservation.ReservationId, 42)
+			So(err, ShouldBeNil)
+			_, err = repo.DecrementStock("CAM001", "", 42, "T1", 5)
+			So(err, ShouldBeNil)
+
+			details, err := repo.GetWarehouseDetails("CAM001")
+			So(err, ShouldBeNil)
+			So(details.StockRemaining, ShouldEqual, 0)
+			So(details.StockReserved, ShouldEqual, 0)
+		})
+
+		Convey("shipping its order from another location should leave it be", func() {
+			_, err := repo.CreateLocation("east", "East Coast")
+			So(err, ShouldBeNil)
+			_, err = repo.RecordStockMovement("CAM001", "east", 3, warehouse.MovementReason_MR_RECEIPT, "PO-2")
+			So(err, ShouldBeNil)
+			_, err = repo.CommitReservation(reservation.ReservationId, 42)
+			So(err, ShouldBeNil)
+			_, err = repo.DecrementStock("CAM001", "east", 42, "T1", 2)
+			So(err, ShouldBeNil)
+
+			details, err := repo.GetWarehouseDetails("CAM001")
+			So(err, ShouldBeNil)
+			So(details.Locations[0].LocationId, ShouldEqual, "east")
+			So(details.Locations[0].StockRemaining, ShouldEqual, 1)
+			So(details.Locations[1].StockRemaining, ShouldEqual, 5)
+			So(details.Locations[1].StockReserved, ShouldEqual, 2)
+		})
+	})
+}
@@ -1,28 +1,67 @@
 package service
 
 import (
+	"github.com/autodidaddict/go-shopping/catalog/proto"
 	"github.com/autodidaddict/go-shopping/shipping/proto"
+	warehouseerrors "github.com/autodidaddict/go-shopping/warehouse/internal/platform/errors"
 	"github.com/autodidaddict/go-shopping/warehouse/proto"
 	"github.com/micro/go-log"
 	"github.com/micro/go-micro/errors"
 	"golang.org/x/net/context"
+	"net/http"
+	"regexp"
+	"strconv"
+	"time"
 )
 
+const (
+	defaultReservationTTL = 15 * time.Minute
+	maxReservationTTL     = 24 * time.Hour
+
+	defaultMovementsLimit = 100
+	maxMovementsLimit     = 1000
+
+	defaultFailedShipmentsLimit = 100
+	maxFailedShipmentsLimit     = 1000
+)
+
+// locationIDPattern matches the IDs locations can be created with, which become part of their stock
+// keys
+var locationIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,32}$`)
+
 type warehouseService struct {
-	repo     warehouseRepository
-	shipChan chan *shipping.ItemShippedEvent
+	repo        warehouseRepository
+	shipChan    chan *shipping.ItemShippedEvent
+	productChan chan *catalog.ProductChangedEvent
 }
 
 type warehouseRepository interface {
 	GetWarehouseDetails(sku string) (details *warehouse.WarehouseDetails, err error)
 	SkuExists(sku string) (exists bool, err error)
-	DecrementStock(sku string) (err error)
+	DecrementStock(sku string, locationID string, orderID uint64, trackingNumber string, quantity uint32) (duplicate bool,
+		err error)
+	ReserveStock(sku, locationID string, quantity uint32, ttl time.Duration) (reservation *warehouse.Reservation, err error)
+	ReleaseReservation(reservationID uint64) (err error)
+	CommitReservation(reservationID, orderID uint64) (reservation *warehouse.Reservation, err error)
+	RecordStockMovement(sku, locationID string, delta int32, reason warehouse.MovementReason, referenceID string) (movement *warehouse.StockMovement,
+		err error)
+	GetStockMovements(sku, locationID string, limit int) (movements []*warehouse.StockMovement, stock, ledgerStock int64,
+		err error)
+	SaveBundle(sku, manufacturer, model string, components map[string]uint32) (err error)
+	RemoveBundle(sku string) (err error)
+	CreateLocation(locationID, name string) (location *warehouse.Location, err error)
+	GetLocations() (locations []*warehouse.Location, err error)
+	RecordFailedShipment(shipment *warehouse.FailedShipment) (recorded *warehouse.FailedShipment, err error)
+	GetFailedShipments(limit uint32) (shipments []*warehouse.FailedShipment, err error)
 }
 
 // NewWarehouseService returns an instance of a warehouse handler
-func NewWarehouseService(repo warehouseRepository, itemShippedChannel chan *shipping.ItemShippedEvent) warehouse.WarehouseHandler {
-	svc := &warehouseService{repo: repo, shipChan: itemShippedChannel}
+func NewWarehouseService(repo warehouseRepository, itemShippedChannel chan *shipping.ItemShippedEvent,
+	productChangedChannel chan *catalog.ProductChangedEvent) warehouse.WarehouseHandler {
+
+	svc := &warehouseService{repo: repo, shipChan: itemShippedChannel, productChan: productChangedChannel}
 	go svc.awaitItemShippedEvents()
+	go svc.awaitProductChangedEvents()
 	return svc
 }
 
@@ -48,14 +87,343 @@ func (w *warehouseService) GetWarehouseDetails(ctx context.Context, request *war
 		return errors.InternalServerError(request.Sku, "Failed to query warehouse details: %s", err)
 	}
 
+	if len(details.Components) > 0 {
+		details.Locations = bundleLocations(details.Components)
+		details.StockRemaining, details.StockReserved, details.StockAvailable = 0, 0, 0
+		for _, location := range details.Locations {
+			details.StockRemaining += location.StockRemaining
+			details.StockReserved += location.StockReserved
+			details.StockAvailable += location.StockAvailable
+		}
+	}
 	response.Details = details
 
 	return nil
 }
 
+// bundleLocations works out how many whole bundles can be made up at each location from the stock of
+// their components there. A shipped bundle is taken from a single location, so the stock of a
+// component at one location can't make up for its shortage at another. The bundles reserved are
+// those that could be made up from the stock remaining but not from the stock available.
+func bundleLocations(components []*warehouse.ComponentStock) (locations []*warehouse.LocationStock) {
+	for _, location := range components[0].Locations {
+		bundles := &warehouse.LocationStock{LocationId: location.LocationId, Name: location.Name}
+		for i, component := range components {
+			var remaining, available uint32
+			for _, componentLocation := range component.Locations {
+				if componentLocation.LocationId == location.LocationId && component.Quantity > 0 {
+					remaining = componentLocation.StockRemaining / component.Quantity
+					available = componentLocation.StockAvailable / component.Quantity
+				}
+			}
+			if i == 0 || remaining < bundles.StockRemaining {
+				bundles.StockRemaining = remaining
+			}
+			if i == 0 || available < bundles.StockAvailable {
+				bundles.StockAvailable = available
+			}
+		}
+		bundles.StockReserved = bundles.StockRemaining - bundles.StockAvailable
+		locations = append(locations, bundles)
+	}
+	return locations
+}
+
+// ReserveStock holds stock for a customer while they check out, so that it can't be sold to anyone
+// else. The reservation expires unless it is committed to an order in time.
+func (w *warehouseService) ReserveStock(ctx context.Context, request *warehouse.ReserveStockRequest,
+	response *warehouse.ReserveStockResponse) error {
+
+	if request == nil {
+		return errors.BadRequest("", "Missing reserve stock request")
+	}
+	if len(request.Sku) < 6 {
+		return errors.BadRequest("", "Invalid SKU")
+	}
+	if request.Quantity == 0 {
+		return errors.BadRequest(request.Sku, "Must reserve a quantity of at least one")
+	}
+	ttl := time.Duration(request.TtlSeconds) * time.Second
+	if ttl == 0 {
+		ttl = defaultReservationTTL
+	}
+	if ttl > maxReservationTTL {
+		return errors.BadRequest(request.Sku, "Reservations can't be held for longer than %s", maxReservationTTL)
+	}
+	exists, err := w.repo.SkuExists(request.Sku)
+	if err != nil {
+		return errors.InternalServerError(request.Sku, "Failed to check for SKU existence: %s", err)
+	}
+	if !exists {
+		return errors.NotFound(request.Sku, "No such SKU")
+	}
+
+	reservation, err := w.repo.ReserveStock(request.Sku, request.LocationId, request.Quantity, ttl)
+	if err != nil {
+		return stockError(request.Sku, err)
+	}
+	response.Reservation = reservation
+	return nil
+}
+
+// ReleaseReservation frees the stock held by a reservation, such as when a checkout is abandoned or
+// an order is cancelled
+func (w *warehouseService) ReleaseReservation(ctx context.Context, request *warehouse.ReleaseReservationRequest,
+	response *warehouse.ReleaseReservationResponse) error {
+
+	if request == nil {
+		return errors.BadRequest("", "Missing release reservation request")
+	}
+	if request.ReservationId == 0 {
+		return errors.BadRequest("", "Invalid reservation ID")
+	}
+	if err := w.repo.ReleaseReservation(request.ReservationId); err != nil {
+		return stockError(strconv.FormatUint(request.ReservationId, 10), err)
+	}
+	return nil
+}
+
+// CommitReservation holds a reservation's stock for an order until the order's item ships
+func (w *warehouseService) CommitReservation(ctx context.Context, request *warehouse.CommitReservationRequest,
+	response *warehouse.CommitReservationResponse) error {
+
+	if request == nil {
+		return errors.BadRequest("", "Missing commit reservation request")
+	}
+	if request.ReservationId == 0 {
+		return errors.BadRequest("", "Invalid reservation ID")
+	}
+	id := strconv.FormatUint(request.ReservationId, 10)
+	if request.OrderId == 0 {
+		return errors.BadRequest(id, "Must supply the order to commit the reservation to")
+	}
+	reservation, err := w.repo.CommitReservation(request.ReservationId, request.OrderId)
+	if err != nil {
+		return stockError(id, err)
+	}
+	response.Reservation = reservation
+	return nil
+}
+
+// RecordStockMovement moves stock in or out of the warehouse other than by shipping it, recording
+// the movement in the SKU's ledger. Receipts and returns bring stock in, while adjustments correct
+// the count in either direction.
+func (w *warehouseService) RecordStockMovement(ctx context.Context, request *warehouse.RecordMovementRequest,
+	response *warehouse.RecordMovementResponse) error {
+
+	if request == nil {
+		return errors.BadRequest("", "Missing record movement request")
+	}
+	if len(request.Sku) < 6 {
+		return errors.BadRequest("", "Invalid SKU")
+	}
+	switch request.Reason {
+	case warehouse.MovementReason_MR_RECEIPT, warehouse.MovementReason_MR_RETURN:
+		if request.Delta <= 0 {
+			return errors.BadRequest(request.Sku, "Receipts and returns must bring stock in")
+		}
+	case warehouse.MovementReason_MR_ADJUSTMENT:
+		if request.Delta == 0 {
+			return errors.BadRequest(request.Sku, "Adjustments must change the stock")
+		}
+	case warehouse.MovementReason_MR_SHIPMENT:
+		return errors.BadRequest(request.Sku, "Shipments are recorded as items ship")
+	default:
+		return errors.BadRequest(request.Sku, "Invalid movement reason")
+	}
+	exists, err := w.repo.SkuExists(request.Sku)
+	if err != nil {
+		return errors.InternalServerError(request.Sku, "Failed to check for SKU existence: %s", err)
+	}
+	if !exists {
+		return errors.NotFound(request.Sku, "No such SKU")
+	}
+
+	movement, err := w.repo.RecordStockMovement(request.Sku, request.LocationId, request.Delta, request.Reason, request.ReferenceId)
+	if err != nil {
+		return stockError(request.Sku, err)
+	}
+	response.Movement = movement
+	return nil
+}
+
+// GetStockMovements returns the latest movements in a SKU's ledger, and checks the stock counted
+// now against the stock rebuilt from the whole ledger
+func (w *warehouseService) GetStockMovements(ctx context.Context, request *warehouse.StockMovementsRequest,
+	response *warehouse.StockMovementsResponse) error {
+
+	if request == nil {
+		return errors.BadRequest("", "Missing stock movements request")
+	}
+	if len(request.Sku) < 6 {
+		return errors.BadRequest("", "Invalid SKU")
+	}
+	limit := int(request.Limit)
+	if limit == 0 {
+		limit = defaultMovementsLimit
+	}
+	if limit > maxMovementsLimit {
+		limit = maxMovementsLimit
+	}
+	exists, err := w.repo.SkuExists(request.Sku)
+	if err != nil {
+		return errors.InternalServerError(request.Sku, "Failed to check for SKU existence: %s", err)
+	}
+	if !exists {
+		return errors.NotFound(request.Sku, "No such SKU")
+	}
+
+	movements, stock, ledgerStock, err := w.repo.GetStockMovements(request.Sku, request.LocationId, limit)
+	if err == warehouseerrors.NoSuchLocation {
+		return errors.NotFound(request.Sku, "%s", err.Error())
+	}
+	if err != nil {
+		return errors.InternalServerError(request.Sku, "Failed to query stock movements: %s", err)
+	}
+	response.Movements = movements
+	response.LedgerStock = ledgerStock
+	response.StockRemaining = stock
+	response.Balanced = ledgerStock == stock
+	return nil
+}
+
+// CreateLocation opens a warehouse location that stock can be received at and shipped from
+func (w *warehouseService) CreateLocation(ctx context.Context, request *warehouse.CreateLocationRequest,
+	response *warehouse.CreateLocationResponse) error {
+
+	if request == nil || request.Location == nil {
+		return errors.BadRequest("", "Missing create location request")
+	}
+	if !locationIDPattern.MatchString(request.Location.LocationId) {
+		return errors.BadRequest("", "Invalid location ID")
+	}
+	if request.Location.Name == "" {
+		return errors.BadRequest(request.Location.LocationId, "Locations must have a name")
+	}
+	location, err := w.repo.CreateLocation(request.Location.LocationId, request.Location.Name)
+	if err == warehouseerrors.DuplicateLocation {
+		return errors.New(request.Location.LocationId, err.Error(), http.StatusConflict)
+	}
+	if err != nil {
+		return errors.InternalServerError(request.Location.LocationId, "Failed to create location: %s", err)
+	}
+	response.Location = location
+	return nil
+}
+
+// GetLocations lists every warehouse location, including the main warehouse
+func (w *warehouseService) GetLocations(ctx context.Context, request *warehouse.LocationsRequest,
+	response *warehouse.LocationsResponse) error {
+
+	locations, err := w.repo.GetLocations()
+	if err != nil {
+		return errors.InternalServerError("", "Failed to query locations: %s", err)
+	}
+	response.Locations = locations
+	return nil
+}
+
+// GetFailedShipments lists the latest shipments that couldn't be taken from stock, so that the stock
+// can be put right by hand
+func (w *warehouseService) GetFailedShipments(ctx context.Context, request *warehouse.FailedShipmentsRequest,
+	response *warehouse.FailedShipmentsResponse) error {
+
+	if request == nil {
+		return errors.BadRequest("", "Missing failed shipments request")
+	}
+	limit := request.Limit
+	if limit == 0 {
+		limit = defaultFailedShipmentsLimit
+	}
+	if limit > maxFailedShipmentsLimit {
+		limit = maxFailedShipmentsLimit
+	}
+	shipments, err := w.repo.GetFailedShipments(limit)
+	if err != nil {
+		return errors.InternalServerError("", "Failed to query failed shipments: %s", err)
+	}
+	response.FailedShipments = shipments
+	return nil
+}
+
+func stockError(id string, err error) error {
+	switch err {
+	case warehouseerrors.BundleHasNoStock:
+		return errors.BadRequest(id, "%s", err.Error())
+	case warehouseerrors.NoSuchLocation, warehouseerrors.NoSuchReservation:
+		return errors.NotFound(id, "%s", err.Error())
+	case warehouseerrors.InsufficientStock, warehouseerrors.ReservationCommitted, warehouseerrors.DuplicateReservation:
+		return errors.New(id, err.Error(), http.StatusConflict)
+	default:
+		return errors.InternalServerError(id, "Failed to update stock: %s", err.Error())
+	}
+}
+
+// awaitItemShippedEvents takes shipped items from stock. The broker may deliver an event more than
+// once, so the repository only takes each shipment once. Events published before shipments had a
+// quantity were for a single unit. Shipments that can't be taken from stock, because they are from a
+// location the warehouse doesn't know of, are short of stock, or are larger than can ship at once, are
+// set aside as failed shipments.
 func (w *warehouseService) awaitItemShippedEvents() {
 	for shippedEvent := range w.shipChan {
 		log.Logf("Received an item shipped event! %+v\n", shippedEvent)
-		w.repo.DecrementStock(shippedEvent.Sku)
+		quantity := shippedEvent.Quantity
+		if quantity == 0 {
+			quantity = 1
+		}
+		if quantity > shipping.MaxShipmentQuantity {
+			w.failShipment(shippedEvent, quantity, warehouseerrors.ShipmentTooLarge)
+			continue
+		}
+		duplicate, err := w.repo.DecrementStock(shippedEvent.Sku, shippedEvent.LocationId, shippedEvent.OrderId,
+			shippedEvent.TrackingNumber, quantity)
+		if err == warehouseerrors.NoSuchLocation || err == warehouseerrors.InsufficientStock {
+			w.failShipment(shippedEvent, quantity, err)
+		} else if err != nil {
+			log.Logf("Failed to decrement stock of %s at %q: %s", shippedEvent.Sku, shippedEvent.LocationId, err)
+		} else if duplicate {
+			log.Logf("Ignoring duplicate shipment of %s in order %d (%s)", shippedEvent.Sku, shippedEvent.OrderId,
+				shippedEvent.TrackingNumber)
+		}
+	}
+}
+
+// failShipment sets aside a shipment that can't be taken from stock
+func (w *warehouseService) failShipment(shippedEvent *shipping.ItemShippedEvent, quantity uint32, reason error) {
+	log.Logf("Setting aside shipment of %d units of %s at %q in order %d: %s", quantity, shippedEvent.Sku,
+		shippedEvent.LocationId, shippedEvent.OrderId, reason)
+	_, err := w.repo.RecordFailedShipment(&warehouse.FailedShipment{
+		OrderId:        shippedEvent.OrderId,
+		Sku:            shippedEvent.Sku,
+		LocationId:     shippedEvent.LocationId,
+		TrackingNumber: shippedEvent.TrackingNumber,
+		Quantity:       quantity,
+		Reason:         reason.Error(),
+		Timestamp:      time.Now().UTC().Unix(),
+	})
+	if err != nil {
+		log.Logf("Failed to record failed shipment of %s in order %d: %s", shippedEvent.Sku, shippedEvent.OrderId, err)
+	}
+}
+
+// awaitProductChangedEvents keeps the warehouse's record of each bundle's components in step with
+// the catalog
+func (w *warehouseService) awaitProductChangedEvents() {
+	for changedEvent := range w.productChan {
+		var err error
+		if product := changedEvent.Product; product != nil && product.Kind == catalog.ProductKind_PK_BUNDLE {
+			components := make(map[string]uint32, len(product.Components))
+			for _, component := range product.Components {
+				if component.Quantity > 0 {
+					components[component.Sku] = component.Quantity
+				}
+			}
+			err = w.repo.SaveBundle(product.Sku, product.Manufacturer, product.Model, components)
+		} else {
+			err = w.repo.RemoveBundle(changedEvent.Sku)
+		}
+		if err != nil {
+			log.Logf("Failed to update bundle %s: %s", changedEvent.Sku, err)
+		}
 	}
 }
@@ -6,20 +6,27 @@ import (
 	"testing"
 
 	stderrors "errors"
+	"fmt"
+	"github.com/autodidaddict/go-shopping/catalog/proto"
 	"github.com/autodidaddict/go-shopping/shipping/proto"
+	warehouseerrors "github.com/autodidaddict/go-shopping/warehouse/internal/platform/errors"
 	"github.com/autodidaddict/go-shopping/warehouse/internal/service"
 	"github.com/autodidaddict/go-shopping/warehouse/proto"
 	"github.com/micro/go-micro/errors"
 	"net/http"
+	"sort"
+	"time"
 )
 
 func TestWarehouseService_GetWarehouseDetails(t *testing.T) {
 	Convey("Given a warehouse service", t, func() {
 		ctx := context.Background()
 		stockChan := make(chan string)
-		repo := &fakeRepo{stockChan: stockChan}
+		bundleChan := make(chan string)
+		repo := &fakeRepo{stockChan: stockChan, bundleChan: bundleChan}
 		shippedChannel := make(chan *shipping.ItemShippedEvent)
-		svc := service.NewWarehouseService(repo, shippedChannel)
+		productChannel := make(chan *catalog.ProductChangedEvent)
+		svc := service.NewWarehouseService(repo, shippedChannel, productChannel)
 
 		Convey("requesting warehouse details should invoke the repository", func() {
 			repo.shouldFail = false
@@ -81,7 +88,346 @@ func TestWarehouseService_GetWarehouseDetails(t *testing.T) {
 			}
 			shippedChannel <- evt
 			s := <-stockChan
-			So(s, ShouldEqual, "111111")
+			So(s, ShouldEqual, "111111@")
+		})
+
+		Convey("an item shipped from a location should be taken from that location's stock", func() {
+			repo.shouldFail = false
+			shippedChannel <- &shipping.ItemShippedEvent{Sku: "111111", LocationId: "reno", TrackingNumber: "abc1233"}
+			So(<-stockChan, ShouldEqual, "111111@reno")
+		})
+
+		Convey("an item shipped from an unknown location should be set aside as a failed shipment", func() {
+			repo.shouldFail = false
+			shippedChannel <- &shipping.ItemShippedEvent{Sku: "111111", OrderId: 7, LocationId: "atlantis", TrackingNumber: "abc1233",
+				Quantity: 3}
+			So(<-stockChan, ShouldEqual, "failed 111111: "+warehouseerrors.NoSuchLocation.Error())
+			So(len(repo.failed), ShouldEqual, 1)
+			So(repo.failed[0].OrderId, ShouldEqual, 7)
+			So(repo.failed[0].LocationId, ShouldEqual, "atlantis")
+			So(repo.failed[0].TrackingNumber, ShouldEqual, "abc1233")
+			So(repo.failed[0].Quantity, ShouldEqual, 3)
+			So(repo.decrements["111111"], ShouldEqual, 0)
+		})
+
+		Convey("a redelivered item shipped event should only be taken from stock once", func() {
+			repo.shouldFail = false
+			evt := &shipping.ItemShippedEvent{Sku: "111111", OrderId: 7, TrackingNumber: "abc1233"}
+			shippedChannel <- evt
+			So(<-stockChan, ShouldEqual, "111111@")
+			shippedChannel <- evt
+			So(<-stockChan, ShouldEqual, "duplicate 111111")
+			So(repo.decrements["111111"], ShouldEqual, 1)
+
+			shippedChannel <- &shipping.ItemShippedEvent{Sku: "111111", OrderId: 7, TrackingNumber: "xyz9876"}
+			So(<-stockChan, ShouldEqual, "111111@")
+			So(repo.decrements["111111"], ShouldEqual, 2)
+		})
+
+		Convey("an item shipped event should take every unit shipped from stock", func() {
+			repo.shouldFail = false
+			shippedChannel <- &shipping.ItemShippedEvent{Sku: "111111", OrderId: 7, TrackingNumber: "abc1233", Quantity: 5}
+			So(<-stockChan, ShouldEqual, "111111@")
+			So(repo.decrements["111111"], ShouldEqual, 5)
+		})
+
+		Convey("shipping more than is in stock should leave stock alone", func() {
+			repo.shouldFail = false
+			shippedChannel <- &shipping.ItemShippedEvent{Sku: "111111", OrderId: 7, TrackingNumber: "abc1233", Quantity: 43}
+			So(<-stockChan, ShouldEqual, "insufficient 111111")
+			So(<-stockChan, ShouldEqual, "failed 111111: "+warehouseerrors.InsufficientStock.Error())
+			So(repo.failed[0].Quantity, ShouldEqual, 43)
+			So(repo.decrements["111111"], ShouldEqual, 0)
+		})
+
+		Convey("a shipment larger than can ship at once should be set aside without touching stock", func() {
+			repo.shouldFail = false
+			shippedChannel <- &shipping.ItemShippedEvent{Sku: "111111", OrderId: 7, TrackingNumber: "abc1233",
+				Quantity: shipping.MaxShipmentQuantity + 1}
+			So(<-stockChan, ShouldEqual, "failed 111111: "+warehouseerrors.ShipmentTooLarge.Error())
+			So(repo.decrements["111111"], ShouldEqual, 0)
+		})
+
+		Convey("a bundle's stock should be the number of whole bundles its components can make up", func() {
+			repo.shouldFail = false
+			var resp warehouse.DetailsResponse
+			err := svc.GetWarehouseDetails(ctx, &warehouse.DetailsRequest{Sku: "KIT001"}, &resp)
+			So(err, ShouldBeNil)
+			So(len(resp.Details.Components), ShouldEqual, 2)
+			So(resp.Details.StockRemaining, ShouldEqual, 3)
+		})
+
+		Convey("a bundle can only be made up from components stocked at the same location", func() {
+			repo.shouldFail = false
+			var resp warehouse.DetailsResponse
+			err := svc.GetWarehouseDetails(ctx, &warehouse.DetailsRequest{Sku: "KIT001"}, &resp)
+			So(err, ShouldBeNil)
+			So(resp.Details.Locations, ShouldResemble, []*warehouse.LocationStock{
+				{LocationId: "main", Name: "Main Warehouse", StockRemaining: 3, StockReserved: 1, StockAvailable: 2},
+				{LocationId: "reno", Name: "Reno", StockRemaining: 0},
+			})
+			So(resp.Details.StockReserved, ShouldEqual, 1)
+			So(resp.Details.StockAvailable, ShouldEqual, 2)
+		})
+
+		Convey("when a bundle changes in the catalog, its components should be saved", func() {
+			productChannel <- &catalog.ProductChangedEvent{
+				Sku:        "KIT001",
+				ChangeType: catalog.ProductChangeType_PC_UPDATED,
+				Product: &catalog.Product{Sku: "KIT001", Kind: catalog.ProductKind_PK_BUNDLE, Components: []*catalog.BundleComponent{
+					{Sku: "111111", Quantity: 1},
+					{Sku: "222222", Quantity: 2},
+				}},
+			}
+			So(<-bundleChan, ShouldEqual, "saved KIT001")
+			So(repo.components, ShouldResemble, map[string]uint32{"111111": 1, "222222": 2})
+		})
+
+		Convey("when a product is discontinued or stops being a bundle, it should be removed as a bundle", func() {
+			productChannel <- &catalog.ProductChangedEvent{Sku: "KIT001", ChangeType: catalog.ProductChangeType_PC_DISCONTINUED}
+			So(<-bundleChan, ShouldEqual, "removed KIT001")
+			productChannel <- &catalog.ProductChangedEvent{Sku: "111111", ChangeType: catalog.ProductChangeType_PC_UPDATED,
+				Product: &catalog.Product{Sku: "111111"}}
+			So(<-bundleChan, ShouldEqual, "removed 111111")
+		})
+	})
+}
+
+func TestWarehouseService_Reservations(t *testing.T) {
+	Convey("Given a warehouse service", t, func() {
+		ctx := context.Background()
+		repo := &fakeRepo{}
+		svc := service.NewWarehouseService(repo, make(chan *shipping.ItemShippedEvent), make(chan *catalog.ProductChangedEvent))
+
+		Convey("reserving stock should hold it for the default time", func() {
+			var resp warehouse.ReserveStockResponse
+			err := svc.ReserveStock(ctx, &warehouse.ReserveStockRequest{Sku: "111111", Quantity: 2}, &resp)
+			So(err, ShouldBeNil)
+			So(resp.Reservation.ReservationId, ShouldEqual, 1)
+			So(resp.Reservation.Quantity, ShouldEqual, 2)
+			So(repo.ttl, ShouldEqual, 15*time.Minute)
+		})
+
+		Convey("reserving stock should hold it for the time requested", func() {
+			var resp warehouse.ReserveStockResponse
+			err := svc.ReserveStock(ctx, &warehouse.ReserveStockRequest{Sku: "111111", Quantity: 1, TtlSeconds: 60}, &resp)
+			So(err, ShouldBeNil)
+			So(repo.ttl, ShouldEqual, time.Minute)
+		})
+
+		Convey("reserving more stock than is available should fail with a conflict", func() {
+			var resp warehouse.ReserveStockResponse
+			err := svc.ReserveStock(ctx, &warehouse.ReserveStockRequest{Sku: "111111", Quantity: 43}, &resp)
+			So(err, ShouldNotBeNil)
+			So(errors.Parse(err.Error()).Code, ShouldEqual, http.StatusConflict)
+		})
+
+		Convey("reserving stock should fail with a 400 for a bad request", func() {
+			var resp warehouse.ReserveStockResponse
+			for _, request := range []*warehouse.ReserveStockRequest{
+				nil,
+				{Sku: "1111", Quantity: 1},
+				{Sku: "111111"},
+				{Sku: "111111", Quantity: 1, TtlSeconds: 90000},
+			} {
+				err := svc.ReserveStock(ctx, request, &resp)
+				So(err, ShouldNotBeNil)
+				So(errors.Parse(err.Error()).Code, ShouldEqual, http.StatusBadRequest)
+			}
+		})
+
+		Convey("reserving stock of an unknown SKU or at an unknown location should fail with a 404", func() {
+			var resp warehouse.ReserveStockResponse
+			err := svc.ReserveStock(ctx, &warehouse.ReserveStockRequest{Sku: "nevergonnahappen", Quantity: 1}, &resp)
+			So(errors.Parse(err.Error()).Code, ShouldEqual, http.StatusNotFound)
+			err = svc.ReserveStock(ctx, &warehouse.ReserveStockRequest{Sku: "111111", Quantity: 1, LocationId: "atlantis"}, &resp)
+			So(errors.Parse(err.Error()).Code, ShouldEqual, http.StatusNotFound)
+		})
+
+		Convey("releasing a reservation should invoke the repository", func() {
+			err := svc.ReleaseReservation(ctx, &warehouse.ReleaseReservationRequest{ReservationId: 1}, &warehouse.ReleaseReservationResponse{})
+			So(err, ShouldBeNil)
+			So(repo.released, ShouldEqual, 1)
+		})
+
+		Convey("releasing an expired reservation should fail with a 404", func() {
+			err := svc.ReleaseReservation(ctx, &warehouse.ReleaseReservationRequest{ReservationId: 99}, &warehouse.ReleaseReservationResponse{})
+			So(err, ShouldNotBeNil)
+			So(errors.Parse(err.Error()).Code, ShouldEqual, http.StatusNotFound)
+		})
+
+		Convey("committing a reservation should hold it for the order", func() {
+			var resp warehouse.CommitReservationResponse
+			err := svc.CommitReservation(ctx, &warehouse.CommitReservationRequest{ReservationId: 1, OrderId: 42}, &resp)
+			So(err, ShouldBeNil)
+			So(resp.Reservation.OrderId, ShouldEqual, 42)
+			So(resp.Reservation.ExpiresAt, ShouldEqual, 0)
+		})
+
+		Convey("committing a reservation should fail without an order", func() {
+			var resp warehouse.CommitReservationResponse
+			err := svc.CommitReservation(ctx, &warehouse.CommitReservationRequest{ReservationId: 1}, &resp)
+			So(err, ShouldNotBeNil)
+			So(errors.Parse(err.Error()).Code, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("committing an expired reservation should fail with a 404", func() {
+			var resp warehouse.CommitReservationResponse
+			err := svc.CommitReservation(ctx, &warehouse.CommitReservationRequest{ReservationId: 99, OrderId: 42}, &resp)
+			So(err, ShouldNotBeNil)
+			So(errors.Parse(err.Error()).Code, ShouldEqual, http.StatusNotFound)
+		})
+
+		Convey("committing a reservation already committed to another order should fail with a conflict", func() {
+			var resp warehouse.CommitReservationResponse
+			err := svc.CommitReservation(ctx, &warehouse.CommitReservationRequest{ReservationId: 2, OrderId: 42}, &resp)
+			So(err, ShouldNotBeNil)
+			So(errors.Parse(err.Error()).Code, ShouldEqual, http.StatusConflict)
+		})
+	})
+}
+
+func TestWarehouseService_StockMovements(t *testing.T) {
+	Convey("Given a warehouse service", t, func() {
+		ctx := context.Background()
+		repo := &fakeRepo{}
+		svc := service.NewWarehouseService(repo, make(chan *shipping.ItemShippedEvent), make(chan *catalog.ProductChangedEvent))
+
+		Convey("receiving stock should record the movement", func() {
+			var resp warehouse.RecordMovementResponse
+			err := svc.RecordStockMovement(ctx, &warehouse.RecordMovementRequest{Sku: "111111", Delta: 10,
+				Reason: warehouse.MovementReason_MR_RECEIPT, ReferenceId: "PO-1138"}, &resp)
+			So(err, ShouldBeNil)
+			So(resp.Movement.Delta, ShouldEqual, 10)
+			So(resp.Movement.Reason, ShouldEqual, warehouse.MovementReason_MR_RECEIPT)
+			So(resp.Movement.ReferenceId, ShouldEqual, "PO-1138")
+		})
+
+		Convey("an adjustment should be able to take stock out", func() {
+			var resp warehouse.RecordMovementResponse
+			err := svc.RecordStockMovement(ctx, &warehouse.RecordMovementRequest{Sku: "111111", Delta: -2,
+				Reason: warehouse.MovementReason_MR_ADJUSTMENT}, &resp)
+			So(err, ShouldBeNil)
+			So(resp.Movement.Delta, ShouldEqual, -2)
+		})
+
+		Convey("recording a movement should fail with a 400 for a bad request", func() {
+			var resp warehouse.RecordMovementResponse
+			for _, request := range []*warehouse.RecordMovementRequest{
+				nil,
+				{Sku: "1111", Delta: 1, Reason: warehouse.MovementReason_MR_RECEIPT},
+				{Sku: "111111", Delta: -1, Reason: warehouse.MovementReason_MR_RECEIPT},
+				{Sku: "111111", Delta: 0, Reason: warehouse.MovementReason_MR_RETURN},
+				{Sku: "111111", Delta: 0, Reason: warehouse.MovementReason_MR_ADJUSTMENT},
+				{Sku: "111111", Delta: -1, Reason: warehouse.MovementReason_MR_SHIPMENT},
+				{Sku: "111111", Delta: 1},
+				{Sku: "KIT001", Delta: 1, Reason: warehouse.MovementReason_MR_RECEIPT},
+			} {
+				err := svc.RecordStockMovement(ctx, request, &resp)
+				So(err, ShouldNotBeNil)
+				So(errors.Parse(err.Error()).Code, ShouldEqual, http.StatusBadRequest)
+			}
+		})
+
+		Convey("taking out more stock than remains should fail with a conflict", func() {
+			var resp warehouse.RecordMovementResponse
+			err := svc.RecordStockMovement(ctx, &warehouse.RecordMovementRequest{Sku: "111111", Delta: -43,
+				Reason: warehouse.MovementReason_MR_ADJUSTMENT}, &resp)
+			So(err, ShouldNotBeNil)
+			So(errors.Parse(err.Error()).Code, ShouldEqual, http.StatusConflict)
+		})
+
+		Convey("the stock rebuilt from the ledger should balance with the stock counted", func() {
+			var resp warehouse.StockMovementsResponse
+			err := svc.GetStockMovements(ctx, &warehouse.StockMovementsRequest{Sku: "111111"}, &resp)
+			So(err, ShouldBeNil)
+			So(len(resp.Movements), ShouldEqual, 3)
+			So(resp.LedgerStock, ShouldEqual, 42)
+			So(resp.StockRemaining, ShouldEqual, 42)
+			So(resp.Balanced, ShouldBeTrue)
+		})
+
+		Convey("only the latest movements should be returned, while the whole ledger is rebuilt", func() {
+			var resp warehouse.StockMovementsResponse
+			err := svc.GetStockMovements(ctx, &warehouse.StockMovementsRequest{Sku: "111111", Limit: 1}, &resp)
+			So(err, ShouldBeNil)
+			So(len(resp.Movements), ShouldEqual, 1)
+			So(resp.Movements[0].MovementId, ShouldEqual, "3-0")
+			So(resp.LedgerStock, ShouldEqual, 42)
+		})
+
+		Convey("stock counted without being recorded in the ledger should fail to balance", func() {
+			var resp warehouse.StockMovementsResponse
+			err := svc.GetStockMovements(ctx, &warehouse.StockMovementsRequest{Sku: "111111", LocationId: "reno"}, &resp)
+			So(err, ShouldBeNil)
+			So(resp.LedgerStock, ShouldEqual, 0)
+			So(resp.StockRemaining, ShouldEqual, 2)
+			So(resp.Balanced, ShouldBeFalse)
+		})
+
+		Convey("requesting the movements of an unknown SKU or location should fail with a 404", func() {
+			var resp warehouse.StockMovementsResponse
+			err := svc.GetStockMovements(ctx, &warehouse.StockMovementsRequest{Sku: "nevergonnahappen"}, &resp)
+			So(errors.Parse(err.Error()).Code, ShouldEqual, http.StatusNotFound)
+			err = svc.GetStockMovements(ctx, &warehouse.StockMovementsRequest{Sku: "111111", LocationId: "atlantis"}, &resp)
+			So(errors.Parse(err.Error()).Code, ShouldEqual, http.StatusNotFound)
+		})
+	})
+}
+
+func TestWarehouseService_Locations(t *testing.T) {
+	Convey("Given a warehouse service", t, func() {
+		ctx := context.Background()
+		repo := &fakeRepo{}
+		svc := service.NewWarehouseService(repo, make(chan *shipping.ItemShippedEvent), make(chan *catalog.ProductChangedEvent))
+
+		Convey("creating a location should add it to the warehouse's locations", func() {
+			var resp warehouse.CreateLocationResponse
+			err := svc.CreateLocation(ctx, &warehouse.CreateLocationRequest{
+				Location: &warehouse.Location{LocationId: "reno", Name: "Reno"}}, &resp)
+			So(err, ShouldBeNil)
+			So(resp.Location.LocationId, ShouldEqual, "reno")
+
+			var locations warehouse.LocationsResponse
+			So(svc.GetLocations(ctx, &warehouse.LocationsRequest{}, &locations), ShouldBeNil)
+			So(locations.Locations, ShouldResemble, []*warehouse.Location{
+				{LocationId: "main", Name: "main"},
+				{LocationId: "reno", Name: "Reno"},
+			})
+		})
+
+		Convey("creating a location that already exists should fail with a conflict", func() {
+			var resp warehouse.CreateLocationResponse
+			err := svc.CreateLocation(ctx, &warehouse.CreateLocationRequest{
+				Location: &warehouse.Location{LocationId: "main", Name: "Main Warehouse"}}, &resp)
+			So(err, ShouldNotBeNil)
+			So(errors.Parse(err.Error()).Code, ShouldEqual, http.StatusConflict)
+		})
+
+		Convey("creating a location should fail with a 400 for a bad request", func() {
+			var resp warehouse.CreateLocationResponse
+			for _, request := range []*warehouse.CreateLocationRequest{
+				nil,
+				{},
+				{Location: &warehouse.Location{Name: "Reno"}},
+				{Location: &warehouse.Location{LocationId: "reno:2", Name: "Reno"}},
+				{Location: &warehouse.Location{LocationId: "reno"}},
+			} {
+				err := svc.CreateLocation(ctx, request, &resp)
+				So(err, ShouldNotBeNil)
+				So(errors.Parse(err.Error()).Code, ShouldEqual, http.StatusBadRequest)
+			}
+		})
+
+		Convey("failed shipments should be listed newest first", func() {
+			repo.failed = []*warehouse.FailedShipment{
+				{FailureId: "1-0", Sku: "111111", LocationId: "atlantis"},
+				{FailureId: "2-0", Sku: "111111", LocationId: "atlantis"},
+			}
+			var resp warehouse.FailedShipmentsResponse
+			So(svc.GetFailedShipments(ctx, &warehouse.FailedShipmentsRequest{Limit: 1}, &resp), ShouldBeNil)
+			So(len(resp.FailedShipments), ShouldEqual, 1)
+			So(resp.FailedShipments[0].FailureId, ShouldEqual, "2-0")
 		})
 	})
 }
@@ -89,12 +435,34 @@ func TestWarehouseService_GetWarehouseDetails(t *testing.T) {
 type fakeRepo struct {
 	shouldFail bool
 	stockChan  chan string
+	bundleChan chan string
+	components map[string]uint32
+	ttl        time.Duration
+	released   uint64
+	shipments  map[string]bool
+	decrements map[string]int
+	locations  map[string]string
+	failed     []*warehouse.FailedShipment
 }
 
 func (r *fakeRepo) GetWarehouseDetails(sku string) (details *warehouse.WarehouseDetails, err error) {
 	if r.shouldFail {
 		return nil, stderrors.New("Faily Fail")
 	}
+	if sku == "KIT001" {
+		return &warehouse.WarehouseDetails{
+			Sku: "KIT001",
+			Components: []*warehouse.ComponentStock{
+				{Sku: "111111", Quantity: 1, StockRemaining: 42, Locations: []*warehouse.LocationStock{
+					{LocationId: "main", Name: "Main Warehouse", StockRemaining: 40, StockReserved: 2, StockAvailable: 38},
+					{LocationId: "reno", Name: "Reno", StockRemaining: 2, StockAvailable: 2},
+				}},
+				{Sku: "222222", Quantity: 2, StockRemaining: 7, Locations: []*warehouse.LocationStock{
+					{LocationId: "main", Name: "Main Warehouse", StockRemaining: 7, StockReserved: 2, StockAvailable: 5},
+				}},
+			},
+		}, nil
+	}
 	return &warehouse.WarehouseDetails{
 		ModelNumber:    "T-1000",
 		StockRemaining: 42,
@@ -103,11 +471,146 @@ func (r *fakeRepo) GetWarehouseDetails(sku string) (details *warehouse.Warehouse
 	}, nil
 }
 
-func (r *fakeRepo) DecrementStock(sku string) (err error) {
-	r.stockChan <- sku
-	return nil
+func (r *fakeRepo) DecrementStock(sku string, locationID string, orderID uint64, trackingNumber string,
+	quantity uint32) (duplicate bool, err error) {
+
+	if r.shipments == nil {
+		r.shipments, r.decrements = make(map[string]bool), make(map[string]int)
+	}
+	if locationID == "atlantis" {
+		return false, warehouseerrors.NoSuchLocation
+	}
+	shipment := fmt.Sprintf("%d:%s:%s", orderID, sku, trackingNumber)
+	if r.shipments[shipment] {
+		r.stockChan <- "duplicate " + sku
+		return true, nil
+	}
+	if quantity > 42 {
+		r.stockChan <- "insufficient " + sku
+		return false, warehouseerrors.InsufficientStock
+	}
+	r.shipments[shipment] = true
+	r.decrements[sku] += int(quantity)
+	r.stockChan <- sku + "@" + locationID
+	return false, nil
 }
 
 func (r *fakeRepo) SkuExists(sku string) (exists bool, err error) {
-	return sku == "111111", nil
+	return sku == "111111" || sku == "KIT001", nil
+}
+
+func (r *fakeRepo) SaveBundle(sku, manufacturer, model string, components map[string]uint32) (err error) {
+	r.components = components
+	r.bundleChan <- "saved " + sku
+	return nil
+}
+
+func (r *fakeRepo) RemoveBundle(sku string) (err error) {
+	r.bundleChan <- "removed " + sku
+	return nil
+}
+
+func (r *fakeRepo) ReserveStock(sku, locationID string, quantity uint32, ttl time.Duration) (reservation *warehouse.Reservation,
+	err error) {
+
+	if locationID == "atlantis" {
+		return nil, warehouseerrors.NoSuchLocation
+	}
+	if quantity > 42 {
+		return nil, warehouseerrors.InsufficientStock
+	}
+	r.ttl = ttl
+	return &warehouse.Reservation{ReservationId: 1, Sku: sku, Quantity: quantity, LocationId: "main",
+		ExpiresAt: time.Now().Add(ttl).Unix()}, nil
+}
+
+func (r *fakeRepo) ReleaseReservation(reservationID uint64) (err error) {
+	if reservationID == 99 {
+		return warehouseerrors.NoSuchReservation
+	}
+	r.released = reservationID
+	return nil
+}
+
+func (r *fakeRepo) CommitReservation(reservationID, orderID uint64) (reservation *warehouse.Reservation, err error) {
+	switch reservationID {
+	case 99:
+		return nil, warehouseerrors.NoSuchReservation
+	case 2:
+		return nil, warehouseerrors.ReservationCommitted
+	}
+	return &warehouse.Reservation{ReservationId: reservationID, Sku: "111111", Quantity: 2, LocationId: "main",
+		OrderId: orderID}, nil
+}
+
+func (r *fakeRepo) RecordStockMovement(sku, locationID string, delta int32, reason warehouse.MovementReason,
+	referenceID string) (movement *warehouse.StockMovement, err error) {
+
+	switch {
+	case sku == "KIT001":
+		return nil, warehouseerrors.BundleHasNoStock
+	case locationID == "atlantis":
+		return nil, warehouseerrors.NoSuchLocation
+	case delta < -42:
+		return nil, warehouseerrors.InsufficientStock
+	}
+	return &warehouse.StockMovement{MovementId: "4-0", Sku: sku, LocationId: "main", Delta: delta, Reason: reason,
+		ReferenceId: referenceID, Timestamp: time.Now().Unix()}, nil
+}
+
+func (r *fakeRepo) GetStockMovements(sku, locationID string, limit int) (movements []*warehouse.StockMovement, stock,
+	ledgerStock int64, err error) {
+
+	switch locationID {
+	case "atlantis":
+		return nil, 0, 0, warehouseerrors.NoSuchLocation
+	case "reno":
+		return nil, 2, 0, nil
+	}
+	movements = []*warehouse.StockMovement{
+		{MovementId: "1-0", Sku: sku, LocationId: "main", Delta: 50, Reason: warehouse.MovementReason_MR_ADJUSTMENT},
+		{MovementId: "2-0", Sku: sku, LocationId: "main", Delta: -5, Reason: warehouse.MovementReason_MR_SHIPMENT},
+		{MovementId: "3-0", Sku: sku, LocationId: "main", Delta: -3, Reason: warehouse.MovementReason_MR_SHIPMENT},
+	}
+	if len(movements) > limit {
+		movements = movements[len(movements)-limit:]
+	}
+	return movements, 42, 42, nil
+}
+
+func (r *fakeRepo) CreateLocation(locationID, name string) (location *warehouse.Location, err error) {
+	if _, ok := r.locations[locationID]; ok || locationID == "main" {
+		return nil, warehouseerrors.DuplicateLocation
+	}
+	if r.locations == nil {
+		r.locations = make(map[string]string)
+	}
+	r.locations[locationID] = name
+	return &warehouse.Location{LocationId: locationID, Name: name}, nil
+}
+
+func (r *fakeRepo) GetLocations() (locations []*warehouse.Location, err error) {
+	ids := make([]string, 0, len(r.locations))
+	for id := range r.locations {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	locations = []*warehouse.Location{{LocationId: "main", Name: "main"}}
+	for _, id := range ids {
+		locations = append(locations, &warehouse.Location{LocationId: id, Name: r.locations[id]})
+	}
+	return locations, nil
+}
+
+func (r *fakeRepo) RecordFailedShipment(shipment *warehouse.FailedShipment) (recorded *warehouse.FailedShipment, err error) {
+	r.failed = append(r.failed, shipment)
+	r.stockChan <- "failed " + shipment.Sku + ": " + shipment.Reason
+	return shipment, nil
+}
+
+func (r *fakeRepo) GetFailedShipments(limit uint32) (shipments []*warehouse.FailedShipment, err error) {
+	for i := len(r.failed) - 1; i >= 0 && len(shipments) < int(limit); i-- {
+		shipments = append(shipments, r.failed[i])
+	}
+	return shipments, nil
 }
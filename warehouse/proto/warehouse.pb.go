@@ -11,6 +11,28 @@ It has these top-level messages:
 	DetailsRequest
 	DetailsResponse
 	WarehouseDetails
+	ComponentStock
+	LocationStock
+	ReserveStockRequest
+	ReserveStockResponse
+	Reservation
+	ReleaseReservationRequest
+	ReleaseReservationResponse
+	CommitReservationRequest
+	CommitReservationResponse
+	StockMovement
+	RecordMovementRequest
+	RecordMovementResponse
+	StockMovementsRequest
+	StockMovementsResponse
+	Location
+	CreateLocationRequest
+	CreateLocationResponse
+	LocationsRequest
+	LocationsResponse
+	FailedShipment
+	FailedShipmentsRequest
+	FailedShipmentsResponse
 */
 package warehouse
 
@@ -35,6 +57,36 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+type MovementReason int32
+
+const (
+	MovementReason_MR_UNKNOWN    MovementReason = 0
+	MovementReason_MR_SHIPMENT   MovementReason = 1
+	MovementReason_MR_RECEIPT    MovementReason = 2
+	MovementReason_MR_ADJUSTMENT MovementReason = 3
+	MovementReason_MR_RETURN     MovementReason = 4
+)
+
+var MovementReason_name = map[int32]string{
+	0: "MR_UNKNOWN",
+	1: "MR_SHIPMENT",
+	2: "MR_RECEIPT",
+	3: "MR_ADJUSTMENT",
+	4: "MR_RETURN",
+}
+var MovementReason_value = map[string]int32{
+	"MR_UNKNOWN":    0,
+	"MR_SHIPMENT":   1,
+	"MR_RECEIPT":    2,
+	"MR_ADJUSTMENT": 3,
+	"MR_RETURN":     4,
+}
+
+func (x MovementReason) String() string {
+	return proto.EnumName(MovementReason_name, int32(x))
+}
+func (MovementReason) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
 type DetailsRequest struct {
 	Sku string `protobuf:"bytes,1,opt,name=sku" json:"sku,omitempty"`
 }
@@ -68,10 +120,14 @@ func (m *DetailsResponse) GetDetails() *WarehouseDetails {
 }
 
 type WarehouseDetails struct {
-	Sku            string `protobuf:"bytes,1,opt,name=sku" json:"sku,omitempty"`
-	StockRemaining uint32 `protobuf:"varint,2,opt,name=stock_remaining,json=stockRemaining" json:"stock_remaining,omitempty"`
-	Manufacturer   string `protobuf:"bytes,3,opt,name=manufacturer" json:"manufacturer,omitempty"`
-	ModelNumber    string `protobuf:"bytes,4,opt,name=model_number,json=modelNumber" json:"model_number,omitempty"`
+	Sku            string            `protobuf:"bytes,1,opt,name=sku" json:"sku,omitempty"`
+	StockRemaining uint32            `protobuf:"varint,2,opt,name=stock_remaining,json=stockRemaining" json:"stock_remaining,omitempty"`
+	Manufacturer   string            `protobuf:"bytes,3,opt,name=manufacturer" json:"manufacturer,omitempty"`
+	ModelNumber    string            `protobuf:"bytes,4,opt,name=model_number,json=modelNumber" json:"model_number,omitempty"`
+	Components     []*ComponentStock `protobuf:"bytes,5,rep,name=components" json:"components,omitempty"`
+	Locations      []*LocationStock  `protobuf:"bytes,6,rep,name=locations" json:"locations,omitempty"`
+	StockReserved  uint32            `protobuf:"varint,7,opt,name=stock_reserved,json=stockReserved" json:"stock_reserved,omitempty"`
+	StockAvailable uint32            `protobuf:"varint,8,opt,name=stock_available,json=stockAvailable" json:"stock_available,omitempty"`
 }
 
 func (m *WarehouseDetails) Reset()                    { *m = WarehouseDetails{} }
@@ -107,10 +163,733 @@ func (m *WarehouseDetails) GetModelNumber() string {
 	return ""
 }
 
+func (m *WarehouseDetails) GetComponents() []*ComponentStock {
+	if m != nil {
+		return m.Components
+	}
+	return nil
+}
+
+func (m *WarehouseDetails) GetLocations() []*LocationStock {
+	if m != nil {
+		return m.Locations
+	}
+	return nil
+}
+
+func (m *WarehouseDetails) GetStockReserved() uint32 {
+	if m != nil {
+		return m.StockReserved
+	}
+	return 0
+}
+
+func (m *WarehouseDetails) GetStockAvailable() uint32 {
+	if m != nil {
+		return m.StockAvailable
+	}
+	return 0
+}
+
+type ComponentStock struct {
+	Sku            string           `protobuf:"bytes,1,opt,name=sku" json:"sku,omitempty"`
+	Quantity       uint32           `protobuf:"varint,2,opt,name=quantity" json:"quantity,omitempty"`
+	StockRemaining uint32           `protobuf:"varint,3,opt,name=stock_remaining,json=stockRemaining" json:"stock_remaining,omitempty"`
+	Locations      []*LocationStock `protobuf:"bytes,4,rep,name=locations" json:"locations,omitempty"`
+	StockReserved  uint32           `protobuf:"varint,5,opt,name=stock_reserved,json=stockReserved" json:"stock_reserved,omitempty"`
+	StockAvailable uint32           `protobuf:"varint,6,opt,name=stock_available,json=stockAvailable" json:"stock_available,omitempty"`
+}
+
+func (m *ComponentStock) Reset()                    { *m = ComponentStock{} }
+func (m *ComponentStock) String() string            { return proto.CompactTextString(m) }
+func (*ComponentStock) ProtoMessage()               {}
+func (*ComponentStock) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+func (m *ComponentStock) GetSku() string {
+	if m != nil {
+		return m.Sku
+	}
+	return ""
+}
+
+func (m *ComponentStock) GetQuantity() uint32 {
+	if m != nil {
+		return m.Quantity
+	}
+	return 0
+}
+
+func (m *ComponentStock) GetStockRemaining() uint32 {
+	if m != nil {
+		return m.StockRemaining
+	}
+	return 0
+}
+
+func (m *ComponentStock) GetLocations() []*LocationStock {
+	if m != nil {
+		return m.Locations
+	}
+	return nil
+}
+
+func (m *ComponentStock) GetStockReserved() uint32 {
+	if m != nil {
+		return m.StockReserved
+	}
+	return 0
+}
+
+func (m *ComponentStock) GetStockAvailable() uint32 {
+	if m != nil {
+		return m.StockAvailable
+	}
+	return 0
+}
+
+type LocationStock struct {
+	LocationId     string `protobuf:"bytes,1,opt,name=location_id,json=locationId" json:"location_id,omitempty"`
+	Name           string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	StockRemaining uint32 `protobuf:"varint,3,opt,name=stock_remaining,json=stockRemaining" json:"stock_remaining,omitempty"`
+	StockReserved  uint32 `protobuf:"varint,4,opt,name=stock_reserved,json=stockReserved" json:"stock_reserved,omitempty"`
+	StockAvailable uint32 `protobuf:"varint,5,opt,name=stock_available,json=stockAvailable" json:"stock_available,omitempty"`
+}
+
+func (m *LocationStock) Reset()                    { *m = LocationStock{} }
+func (m *LocationStock) String() string            { return proto.CompactTextString(m) }
+func (*LocationStock) ProtoMessage()               {}
+func (*LocationStock) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
+func (m *LocationStock) GetLocationId() string {
+	if m != nil {
+		return m.LocationId
+	}
+	return ""
+}
+
+func (m *LocationStock) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *LocationStock) GetStockRemaining() uint32 {
+	if m != nil {
+		return m.StockRemaining
+	}
+	return 0
+}
+
+func (m *LocationStock) GetStockReserved() uint32 {
+	if m != nil {
+		return m.StockReserved
+	}
+	return 0
+}
+
+func (m *LocationStock) GetStockAvailable() uint32 {
+	if m != nil {
+		return m.StockAvailable
+	}
+	return 0
+}
+
+type ReserveStockRequest struct {
+	Sku        string `protobuf:"bytes,1,opt,name=sku" json:"sku,omitempty"`
+	Quantity   uint32 `protobuf:"varint,2,opt,name=quantity" json:"quantity,omitempty"`
+	LocationId string `protobuf:"bytes,3,opt,name=location_id,json=locationId" json:"location_id,omitempty"`
+	TtlSeconds uint32 `protobuf:"varint,4,opt,name=ttl_seconds,json=ttlSeconds" json:"ttl_seconds,omitempty"`
+}
+
+func (m *ReserveStockRequest) Reset()                    { *m = ReserveStockRequest{} }
+func (m *ReserveStockRequest) String() string            { return proto.CompactTextString(m) }
+func (*ReserveStockRequest) ProtoMessage()               {}
+func (*ReserveStockRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+func (m *ReserveStockRequest) GetSku() string {
+	if m != nil {
+		return m.Sku
+	}
+	return ""
+}
+
+func (m *ReserveStockRequest) GetQuantity() uint32 {
+	if m != nil {
+		return m.Quantity
+	}
+	return 0
+}
+
+func (m *ReserveStockRequest) GetLocationId() string {
+	if m != nil {
+		return m.LocationId
+	}
+	return ""
+}
+
+func (m *ReserveStockRequest) GetTtlSeconds() uint32 {
+	if m != nil {
+		return m.TtlSeconds
+	}
+	return 0
+}
+
+type ReserveStockResponse struct {
+	Reservation *Reservation `protobuf:"bytes,1,opt,name=reservation" json:"reservation,omitempty"`
+}
+
+func (m *ReserveStockResponse) Reset()                    { *m = ReserveStockResponse{} }
+func (m *ReserveStockResponse) String() string            { return proto.CompactTextString(m) }
+func (*ReserveStockResponse) ProtoMessage()               {}
+func (*ReserveStockResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+func (m *ReserveStockResponse) GetReservation() *Reservation {
+	if m != nil {
+		return m.Reservation
+	}
+	return nil
+}
+
+type Reservation struct {
+	ReservationId uint64 `protobuf:"varint,1,opt,name=reservation_id,json=reservationId" json:"reservation_id,omitempty"`
+	Sku           string `protobuf:"bytes,2,opt,name=sku" json:"sku,omitempty"`
+	Quantity      uint32 `protobuf:"varint,3,opt,name=quantity" json:"quantity,omitempty"`
+	LocationId    string `protobuf:"bytes,4,opt,name=location_id,json=locationId" json:"location_id,omitempty"`
+	ExpiresAt     int64  `protobuf:"varint,5,opt,name=expires_at,json=expiresAt" json:"expires_at,omitempty"`
+	OrderId       uint64 `protobuf:"varint,6,opt,name=order_id,json=orderId" json:"order_id,omitempty"`
+}
+
+func (m *Reservation) Reset()                    { *m = Reservation{} }
+func (m *Reservation) String() string            { return proto.CompactTextString(m) }
+func (*Reservation) ProtoMessage()               {}
+func (*Reservation) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
+func (m *Reservation) GetReservationId() uint64 {
+	if m != nil {
+		return m.ReservationId
+	}
+	return 0
+}
+
+func (m *Reservation) GetSku() string {
+	if m != nil {
+		return m.Sku
+	}
+	return ""
+}
+
+func (m *Reservation) GetQuantity() uint32 {
+	if m != nil {
+		return m.Quantity
+	}
+	return 0
+}
+
+func (m *Reservation) GetLocationId() string {
+	if m != nil {
+		return m.LocationId
+	}
+	return ""
+}
+
+func (m *Reservation) GetExpiresAt() int64 {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return 0
+}
+
+func (m *Reservation) GetOrderId() uint64 {
+	if m != nil {
+		return m.OrderId
+	}
+	return 0
+}
+
+type ReleaseReservationRequest struct {
+	ReservationId uint64 `protobuf:"varint,1,opt,name=reservation_id,json=reservationId" json:"reservation_id,omitempty"`
+}
+
+func (m *ReleaseReservationRequest) Reset()                    { *m = ReleaseReservationRequest{} }
+func (m *ReleaseReservationRequest) String() string            { return proto.CompactTextString(m) }
+func (*ReleaseReservationRequest) ProtoMessage()               {}
+func (*ReleaseReservationRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+
+func (m *ReleaseReservationRequest) GetReservationId() uint64 {
+	if m != nil {
+		return m.ReservationId
+	}
+	return 0
+}
+
+type ReleaseReservationResponse struct {
+}
+
+func (m *ReleaseReservationResponse) Reset()                    { *m = ReleaseReservationResponse{} }
+func (m *ReleaseReservationResponse) String() string            { return proto.CompactTextString(m) }
+func (*ReleaseReservationResponse) ProtoMessage()               {}
+func (*ReleaseReservationResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+
+// Committing a reservation stops it from expiring. The stock stays reserved for the order until the
+// item ships.
+type CommitReservationRequest struct {
+	ReservationId uint64 `protobuf:"varint,1,opt,name=reservation_id,json=reservationId" json:"reservation_id,omitempty"`
+	OrderId       uint64 `protobuf:"varint,2,opt,name=order_id,json=orderId" json:"order_id,omitempty"`
+}
+
+func (m *CommitReservationRequest) Reset()                    { *m = CommitReservationRequest{} }
+func (m *CommitReservationRequest) String() string            { return proto.CompactTextString(m) }
+func (*CommitReservationRequest) ProtoMessage()               {}
+func (*CommitReservationRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+
+func (m *CommitReservationRequest) GetReservationId() uint64 {
+	if m != nil {
+		return m.ReservationId
+	}
+	return 0
+}
+
+func (m *CommitReservationRequest) GetOrderId() uint64 {
+	if m != nil {
+		return m.OrderId
+	}
+	return 0
+}
+
+type CommitReservationResponse struct {
+	Reservation *Reservation `protobuf:"bytes,1,opt,name=reservation" json:"reservation,omitempty"`
+}
+
+func (m *CommitReservationResponse) Reset()                    { *m = CommitReservationResponse{} }
+func (m *CommitReservationResponse) String() string            { return proto.CompactTextString(m) }
+func (*CommitReservationResponse) ProtoMessage()               {}
+func (*CommitReservationResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+
+func (m *CommitReservationResponse) GetReservation() *Reservation {
+	if m != nil {
+		return m.Reservation
+	}
+	return nil
+}
+
+type StockMovement struct {
+	MovementId  string         `protobuf:"bytes,1,opt,name=movement_id,json=movementId" json:"movement_id,omitempty"`
+	Sku         string         `protobuf:"bytes,2,opt,name=sku" json:"sku,omitempty"`
+	LocationId  string         `protobuf:"bytes,3,opt,name=location_id,json=locationId" json:"location_id,omitempty"`
+	Delta       int32          `protobuf:"varint,4,opt,name=delta" json:"delta,omitempty"`
+	Reason      MovementReason `protobuf:"varint,5,opt,name=reason,enum=warehouse.MovementReason" json:"reason,omitempty"`
+	ReferenceId string         `protobuf:"bytes,6,opt,name=reference_id,json=referenceId" json:"reference_id,omitempty"`
+	Timestamp   int64          `protobuf:"varint,7,opt,name=timestamp" json:"timestamp,omitempty"`
+}
+
+func (m *StockMovement) Reset()                    { *m = StockMovement{} }
+func (m *StockMovement) String() string            { return proto.CompactTextString(m) }
+func (*StockMovement) ProtoMessage()               {}
+func (*StockMovement) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+
+func (m *StockMovement) GetMovementId() string {
+	if m != nil {
+		return m.MovementId
+	}
+	return ""
+}
+
+func (m *StockMovement) GetSku() string {
+	if m != nil {
+		return m.Sku
+	}
+	return ""
+}
+
+func (m *StockMovement) GetLocationId() string {
+	if m != nil {
+		return m.LocationId
+	}
+	return ""
+}
+
+func (m *StockMovement) GetDelta() int32 {
+	if m != nil {
+		return m.Delta
+	}
+	return 0
+}
+
+func (m *StockMovement) GetReason() MovementReason {
+	if m != nil {
+		return m.Reason
+	}
+	return MovementReason_MR_UNKNOWN
+}
+
+func (m *StockMovement) GetReferenceId() string {
+	if m != nil {
+		return m.ReferenceId
+	}
+	return ""
+}
+
+func (m *StockMovement) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+// Shipments are recorded by the warehouse as items ship, and can't be recorded through this request
+type RecordMovementRequest struct {
+	Sku         string         `protobuf:"bytes,1,opt,name=sku" json:"sku,omitempty"`
+	LocationId  string         `protobuf:"bytes,2,opt,name=location_id,json=locationId" json:"location_id,omitempty"`
+	Delta       int32          `protobuf:"varint,3,opt,name=delta" json:"delta,omitempty"`
+	Reason      MovementReason `protobuf:"varint,4,opt,name=reason,enum=warehouse.MovementReason" json:"reason,omitempty"`
+	ReferenceId string         `protobuf:"bytes,5,opt,name=reference_id,json=referenceId" json:"reference_id,omitempty"`
+}
+
+func (m *RecordMovementRequest) Reset()                    { *m = RecordMovementRequest{} }
+func (m *RecordMovementRequest) String() string            { return proto.CompactTextString(m) }
+func (*RecordMovementRequest) ProtoMessage()               {}
+func (*RecordMovementRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+
+func (m *RecordMovementRequest) GetSku() string {
+	if m != nil {
+		return m.Sku
+	}
+	return ""
+}
+
+func (m *RecordMovementRequest) GetLocationId() string {
+	if m != nil {
+		return m.LocationId
+	}
+	return ""
+}
+
+func (m *RecordMovementRequest) GetDelta() int32 {
+	if m != nil {
+		return m.Delta
+	}
+	return 0
+}
+
+func (m *RecordMovementRequest) GetReason() MovementReason {
+	if m != nil {
+		return m.Reason
+	}
+	return MovementReason_MR_UNKNOWN
+}
+
+func (m *RecordMovementRequest) GetReferenceId() string {
+	if m != nil {
+		return m.ReferenceId
+	}
+	return ""
+}
+
+type RecordMovementResponse struct {
+	Movement *StockMovement `protobuf:"bytes,1,opt,name=movement" json:"movement,omitempty"`
+}
+
+func (m *RecordMovementResponse) Reset()                    { *m = RecordMovementResponse{} }
+func (m *RecordMovementResponse) String() string            { return proto.CompactTextString(m) }
+func (*RecordMovementResponse) ProtoMessage()               {}
+func (*RecordMovementResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+
+func (m *RecordMovementResponse) GetMovement() *StockMovement {
+	if m != nil {
+		return m.Movement
+	}
+	return nil
+}
+
+type StockMovementsRequest struct {
+	Sku        string `protobuf:"bytes,1,opt,name=sku" json:"sku,omitempty"`
+	LocationId string `protobuf:"bytes,2,opt,name=location_id,json=locationId" json:"location_id,omitempty"`
+	Limit      uint32 `protobuf:"varint,3,opt,name=limit" json:"limit,omitempty"`
+}
+
+func (m *StockMovementsRequest) Reset()                    { *m = StockMovementsRequest{} }
+func (m *StockMovementsRequest) String() string            { return proto.CompactTextString(m) }
+func (*StockMovementsRequest) ProtoMessage()               {}
+func (*StockMovementsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+
+func (m *StockMovementsRequest) GetSku() string {
+	if m != nil {
+		return m.Sku
+	}
+	return ""
+}
+
+func (m *StockMovementsRequest) GetLocationId() string {
+	if m != nil {
+		return m.LocationId
+	}
+	return ""
+}
+
+func (m *StockMovementsRequest) GetLimit() uint32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+// The stock rebuilt from the ledger covers every movement, not only those returned. Stock counted
+// before the ledger was kept is entered in it as an opening adjustment when the warehouse starts.
+type StockMovementsResponse struct {
+	Movements      []*StockMovement `protobuf:"bytes,1,rep,name=movements" json:"movements,omitempty"`
+	LedgerStock    int64            `protobuf:"varint,2,opt,name=ledger_stock,json=ledgerStock" json:"ledger_stock,omitempty"`
+	StockRemaining int64            `protobuf:"varint,3,opt,name=stock_remaining,json=stockRemaining" json:"stock_remaining,omitempty"`
+	Balanced       bool             `protobuf:"varint,4,opt,name=balanced" json:"balanced,omitempty"`
+}
+
+func (m *StockMovementsResponse) Reset()                    { *m = StockMovementsResponse{} }
+func (m *StockMovementsResponse) String() string            { return proto.CompactTextString(m) }
+func (*StockMovementsResponse) ProtoMessage()               {}
+func (*StockMovementsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+
+func (m *StockMovementsResponse) GetMovements() []*StockMovement {
+	if m != nil {
+		return m.Movements
+	}
+	return nil
+}
+
+func (m *StockMovementsResponse) GetLedgerStock() int64 {
+	if m != nil {
+		return m.LedgerStock
+	}
+	return 0
+}
+
+func (m *StockMovementsResponse) GetStockRemaining() int64 {
+	if m != nil {
+		return m.StockRemaining
+	}
+	return 0
+}
+
+func (m *StockMovementsResponse) GetBalanced() bool {
+	if m != nil {
+		return m.Balanced
+	}
+	return false
+}
+
+type Location struct {
+	LocationId string `protobuf:"bytes,1,opt,name=location_id,json=locationId" json:"location_id,omitempty"`
+	Name       string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+}
+
+func (m *Location) Reset()                    { *m = Location{} }
+func (m *Location) String() string            { return proto.CompactTextString(m) }
+func (*Location) ProtoMessage()               {}
+func (*Location) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+
+func (m *Location) GetLocationId() string {
+	if m != nil {
+		return m.LocationId
+	}
+	return ""
+}
+
+func (m *Location) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+// The main warehouse always exists, and can't be created
+type CreateLocationRequest struct {
+	Location *Location `protobuf:"bytes,1,opt,name=location" json:"location,omitempty"`
+}
+
+func (m *CreateLocationRequest) Reset()                    { *m = CreateLocationRequest{} }
+func (m *CreateLocationRequest) String() string            { return proto.CompactTextString(m) }
+func (*CreateLocationRequest) ProtoMessage()               {}
+func (*CreateLocationRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+
+func (m *CreateLocationRequest) GetLocation() *Location {
+	if m != nil {
+		return m.Location
+	}
+	return nil
+}
+
+type CreateLocationResponse struct {
+	Location *Location `protobuf:"bytes,1,opt,name=location" json:"location,omitempty"`
+}
+
+func (m *CreateLocationResponse) Reset()                    { *m = CreateLocationResponse{} }
+func (m *CreateLocationResponse) String() string            { return proto.CompactTextString(m) }
+func (*CreateLocationResponse) ProtoMessage()               {}
+func (*CreateLocationResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+
+func (m *CreateLocationResponse) GetLocation() *Location {
+	if m != nil {
+		return m.Location
+	}
+	return nil
+}
+
+type LocationsRequest struct {
+}
+
+func (m *LocationsRequest) Reset()                    { *m = LocationsRequest{} }
+func (m *LocationsRequest) String() string            { return proto.CompactTextString(m) }
+func (*LocationsRequest) ProtoMessage()               {}
+func (*LocationsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+
+type LocationsResponse struct {
+	Locations []*Location `protobuf:"bytes,1,rep,name=locations" json:"locations,omitempty"`
+}
+
+func (m *LocationsResponse) Reset()                    { *m = LocationsResponse{} }
+func (m *LocationsResponse) String() string            { return proto.CompactTextString(m) }
+func (*LocationsResponse) ProtoMessage()               {}
+func (*LocationsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+
+func (m *LocationsResponse) GetLocations() []*Location {
+	if m != nil {
+		return m.Locations
+	}
+	return nil
+}
+
+// A shipment that couldn't be taken from stock, such as one from a location that doesn't exist, is
+// set aside so that the stock can be put right by hand
+type FailedShipment struct {
+	FailureId      string `protobuf:"bytes,1,opt,name=failure_id,json=failureId" json:"failure_id,omitempty"`
+	OrderId        uint64 `protobuf:"varint,2,opt,name=order_id,json=orderId" json:"order_id,omitempty"`
+	Sku            string `protobuf:"bytes,3,opt,name=sku" json:"sku,omitempty"`
+	LocationId     string `protobuf:"bytes,4,opt,name=location_id,json=locationId" json:"location_id,omitempty"`
+	TrackingNumber string `protobuf:"bytes,5,opt,name=tracking_number,json=trackingNumber" json:"tracking_number,omitempty"`
+	Quantity       uint32 `protobuf:"varint,6,opt,name=quantity" json:"quantity,omitempty"`
+	Reason         string `protobuf:"bytes,7,opt,name=reason" json:"reason,omitempty"`
+	Timestamp      int64  `protobuf:"varint,8,opt,name=timestamp" json:"timestamp,omitempty"`
+}
+
+func (m *FailedShipment) Reset()                    { *m = FailedShipment{} }
+func (m *FailedShipment) String() string            { return proto.CompactTextString(m) }
+func (*FailedShipment) ProtoMessage()               {}
+func (*FailedShipment) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+
+func (m *FailedShipment) GetFailureId() string {
+	if m != nil {
+		return m.FailureId
+	}
+	return ""
+}
+
+func (m *FailedShipment) GetOrderId() uint64 {
+	if m != nil {
+		return m.OrderId
+	}
+	return 0
+}
+
+func (m *FailedShipment) GetSku() string {
+	if m != nil {
+		return m.Sku
+	}
+	return ""
+}
+
+func (m *FailedShipment) GetLocationId() string {
+	if m != nil {
+		return m.LocationId
+	}
+	return ""
+}
+
+func (m *FailedShipment) GetTrackingNumber() string {
+	if m != nil {
+		return m.TrackingNumber
+	}
+	return ""
+}
+
+func (m *FailedShipment) GetQuantity() uint32 {
+	if m != nil {
+		return m.Quantity
+	}
+	return 0
+}
+
+func (m *FailedShipment) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *FailedShipment) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+type FailedShipmentsRequest struct {
+	Limit uint32 `protobuf:"varint,1,opt,name=limit" json:"limit,omitempty"`
+}
+
+func (m *FailedShipmentsRequest) Reset()                    { *m = FailedShipmentsRequest{} }
+func (m *FailedShipmentsRequest) String() string            { return proto.CompactTextString(m) }
+func (*FailedShipmentsRequest) ProtoMessage()               {}
+func (*FailedShipmentsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+
+func (m *FailedShipmentsRequest) GetLimit() uint32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type FailedShipmentsResponse struct {
+	FailedShipments []*FailedShipment `protobuf:"bytes,1,rep,name=failed_shipments,json=failedShipments" json:"failed_shipments,omitempty"`
+}
+
+func (m *FailedShipmentsResponse) Reset()                    { *m = FailedShipmentsResponse{} }
+func (m *FailedShipmentsResponse) String() string            { return proto.CompactTextString(m) }
+func (*FailedShipmentsResponse) ProtoMessage()               {}
+func (*FailedShipmentsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+
+func (m *FailedShipmentsResponse) GetFailedShipments() []*FailedShipment {
+	if m != nil {
+		return m.FailedShipments
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*DetailsRequest)(nil), "warehouse.DetailsRequest")
 	proto.RegisterType((*DetailsResponse)(nil), "warehouse.DetailsResponse")
 	proto.RegisterType((*WarehouseDetails)(nil), "warehouse.WarehouseDetails")
+	proto.RegisterType((*ComponentStock)(nil), "warehouse.ComponentStock")
+	proto.RegisterType((*LocationStock)(nil), "warehouse.LocationStock")
+	proto.RegisterType((*ReserveStockRequest)(nil), "warehouse.ReserveStockRequest")
+	proto.RegisterType((*ReserveStockResponse)(nil), "warehouse.ReserveStockResponse")
+	proto.RegisterType((*Reservation)(nil), "warehouse.Reservation")
+	proto.RegisterType((*ReleaseReservationRequest)(nil), "warehouse.ReleaseReservationRequest")
+	proto.RegisterType((*ReleaseReservationResponse)(nil), "warehouse.ReleaseReservationResponse")
+	proto.RegisterType((*CommitReservationRequest)(nil), "warehouse.CommitReservationRequest")
+	proto.RegisterType((*CommitReservationResponse)(nil), "warehouse.CommitReservationResponse")
+	proto.RegisterType((*StockMovement)(nil), "warehouse.StockMovement")
+	proto.RegisterType((*RecordMovementRequest)(nil), "warehouse.RecordMovementRequest")
+	proto.RegisterType((*RecordMovementResponse)(nil), "warehouse.RecordMovementResponse")
+	proto.RegisterType((*StockMovementsRequest)(nil), "warehouse.StockMovementsRequest")
+	proto.RegisterType((*StockMovementsResponse)(nil), "warehouse.StockMovementsResponse")
+	proto.RegisterType((*Location)(nil), "warehouse.Location")
+	proto.RegisterType((*CreateLocationRequest)(nil), "warehouse.CreateLocationRequest")
+	proto.RegisterType((*CreateLocationResponse)(nil), "warehouse.CreateLocationResponse")
+	proto.RegisterType((*LocationsRequest)(nil), "warehouse.LocationsRequest")
+	proto.RegisterType((*LocationsResponse)(nil), "warehouse.LocationsResponse")
+	proto.RegisterType((*FailedShipment)(nil), "warehouse.FailedShipment")
+	proto.RegisterType((*FailedShipmentsRequest)(nil), "warehouse.FailedShipmentsRequest")
+	proto.RegisterType((*FailedShipmentsResponse)(nil), "warehouse.FailedShipmentsResponse")
+	proto.RegisterEnum("warehouse.MovementReason", MovementReason_name, MovementReason_value)
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -122,6 +901,14 @@ var _ server.Option
 
 type WarehouseClient interface {
 	GetWarehouseDetails(ctx context.Context, in *DetailsRequest, opts ...client.CallOption) (*DetailsResponse, error)
+	ReserveStock(ctx context.Context, in *ReserveStockRequest, opts ...client.CallOption) (*ReserveStockResponse, error)
+	ReleaseReservation(ctx context.Context, in *ReleaseReservationRequest, opts ...client.CallOption) (*ReleaseReservationResponse, error)
+	CommitReservation(ctx context.Context, in *CommitReservationRequest, opts ...client.CallOption) (*CommitReservationResponse, error)
+	RecordStockMovement(ctx context.Context, in *RecordMovementRequest, opts ...client.CallOption) (*RecordMovementResponse, error)
+	GetStockMovements(ctx context.Context, in *StockMovementsRequest, opts ...client.CallOption) (*StockMovementsResponse, error)
+	CreateLocation(ctx context.Context, in *CreateLocationRequest, opts ...client.CallOption) (*CreateLocationResponse, error)
+	GetLocations(ctx context.Context, in *LocationsRequest, opts ...client.CallOption) (*LocationsResponse, error)
+	GetFailedShipments(ctx context.Context, in *FailedShipmentsRequest, opts ...client.CallOption) (*FailedShipmentsResponse, error)
 }
 
 type warehouseClient struct {
@@ -152,10 +939,98 @@ func (c *warehouseClient) GetWarehouseDetails(ctx context.Context, in *DetailsRe
 	return out, nil
 }
 
+func (c *warehouseClient) ReserveStock(ctx context.Context, in *ReserveStockRequest, opts ...client.CallOption) (*ReserveStockResponse, error) {
+	req := c.c.NewRequest(c.serviceName, "Warehouse.ReserveStock", in)
+	out := new(ReserveStockResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *warehouseClient) ReleaseReservation(ctx context.Context, in *ReleaseReservationRequest, opts ...client.CallOption) (*ReleaseReservationResponse, error) {
+	req := c.c.NewRequest(c.serviceName, "Warehouse.ReleaseReservation", in)
+	out := new(ReleaseReservationResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *warehouseClient) CommitReservation(ctx context.Context, in *CommitReservationRequest, opts ...client.CallOption) (*CommitReservationResponse, error) {
+	req := c.c.NewRequest(c.serviceName, "Warehouse.CommitReservation", in)
+	out := new(CommitReservationResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *warehouseClient) RecordStockMovement(ctx context.Context, in *RecordMovementRequest, opts ...client.CallOption) (*RecordMovementResponse, error) {
+	req := c.c.NewRequest(c.serviceName, "Warehouse.RecordStockMovement", in)
+	out := new(RecordMovementResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *warehouseClient) GetStockMovements(ctx context.Context, in *StockMovementsRequest, opts ...client.CallOption) (*StockMovementsResponse, error) {
+	req := c.c.NewRequest(c.serviceName, "Warehouse.GetStockMovements", in)
+	out := new(StockMovementsResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *warehouseClient) CreateLocation(ctx context.Context, in *CreateLocationRequest, opts ...client.CallOption) (*CreateLocationResponse, error) {
+	req := c.c.NewRequest(c.serviceName, "Warehouse.CreateLocation", in)
+	out := new(CreateLocationResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *warehouseClient) GetLocations(ctx context.Context, in *LocationsRequest, opts ...client.CallOption) (*LocationsResponse, error) {
+	req := c.c.NewRequest(c.serviceName, "Warehouse.GetLocations", in)
+	out := new(LocationsResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *warehouseClient) GetFailedShipments(ctx context.Context, in *FailedShipmentsRequest, opts ...client.CallOption) (*FailedShipmentsResponse, error) {
+	req := c.c.NewRequest(c.serviceName, "Warehouse.GetFailedShipments", in)
+	out := new(FailedShipmentsResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for Warehouse service
 
 type WarehouseHandler interface {
 	GetWarehouseDetails(context.Context, *DetailsRequest, *DetailsResponse) error
+	ReserveStock(context.Context, *ReserveStockRequest, *ReserveStockResponse) error
+	ReleaseReservation(context.Context, *ReleaseReservationRequest, *ReleaseReservationResponse) error
+	CommitReservation(context.Context, *CommitReservationRequest, *CommitReservationResponse) error
+	RecordStockMovement(context.Context, *RecordMovementRequest, *RecordMovementResponse) error
+	GetStockMovements(context.Context, *StockMovementsRequest, *StockMovementsResponse) error
+	CreateLocation(context.Context, *CreateLocationRequest, *CreateLocationResponse) error
+	GetLocations(context.Context, *LocationsRequest, *LocationsResponse) error
+	GetFailedShipments(context.Context, *FailedShipmentsRequest, *FailedShipmentsResponse) error
 }
 
 func RegisterWarehouseHandler(s server.Server, hdlr WarehouseHandler, opts ...server.HandlerOption) {
@@ -170,23 +1045,115 @@ func (h *Warehouse) GetWarehouseDetails(ctx context.Context, in *DetailsRequest,
 	return h.WarehouseHandler.GetWarehouseDetails(ctx, in, out)
 }
 
+func (h *Warehouse) ReserveStock(ctx context.Context, in *ReserveStockRequest, out *ReserveStockResponse) error {
+	return h.WarehouseHandler.ReserveStock(ctx, in, out)
+}
+
+func (h *Warehouse) ReleaseReservation(ctx context.Context, in *ReleaseReservationRequest, out *ReleaseReservationResponse) error {
+	return h.WarehouseHandler.ReleaseReservation(ctx, in, out)
+}
+
+func (h *Warehouse) CommitReservation(ctx context.Context, in *CommitReservationRequest, out *CommitReservationResponse) error {
+	return h.WarehouseHandler.CommitReservation(ctx, in, out)
+}
+
+func (h *Warehouse) RecordStockMovement(ctx context.Context, in *RecordMovementRequest, out *RecordMovementResponse) error {
+	return h.WarehouseHandler.RecordStockMovement(ctx, in, out)
+}
+
+func (h *Warehouse) GetStockMovements(ctx context.Context, in *StockMovementsRequest, out *StockMovementsResponse) error {
+	return h.WarehouseHandler.GetStockMovements(ctx, in, out)
+}
+
+func (h *Warehouse) CreateLocation(ctx context.Context, in *CreateLocationRequest, out *CreateLocationResponse) error {
+	return h.WarehouseHandler.CreateLocation(ctx, in, out)
+}
+
+func (h *Warehouse) GetLocations(ctx context.Context, in *LocationsRequest, out *LocationsResponse) error {
+	return h.WarehouseHandler.GetLocations(ctx, in, out)
+}
+
+func (h *Warehouse) GetFailedShipments(ctx context.Context, in *FailedShipmentsRequest, out *FailedShipmentsResponse) error {
+	return h.WarehouseHandler.GetFailedShipments(ctx, in, out)
+}
+
 func init() { proto.RegisterFile("warehouse.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 235 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0x2f, 0x4f, 0x2c, 0x4a,
-	0xcd, 0xc8, 0x2f, 0x2d, 0x4e, 0xd5, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0xe2, 0x84, 0x0b, 0x28,
-	0x29, 0x71, 0xf1, 0xb9, 0xa4, 0x96, 0x24, 0x66, 0xe6, 0x14, 0x07, 0xa5, 0x16, 0x96, 0xa6, 0x16,
-	0x97, 0x08, 0x09, 0x70, 0x31, 0x17, 0x67, 0x97, 0x4a, 0x30, 0x2a, 0x30, 0x6a, 0x70, 0x06, 0x81,
-	0x98, 0x4a, 0x1e, 0x5c, 0xfc, 0x70, 0x35, 0xc5, 0x05, 0xf9, 0x79, 0xc5, 0xa9, 0x42, 0xa6, 0x5c,
-	0xec, 0x29, 0x10, 0x21, 0xb0, 0x42, 0x6e, 0x23, 0x69, 0x3d, 0x84, 0x25, 0xe1, 0x30, 0x16, 0x4c,
-	0x17, 0x4c, 0xad, 0xd2, 0x14, 0x46, 0x2e, 0x01, 0x74, 0x59, 0x4c, 0x0b, 0x85, 0xd4, 0xb9, 0xf8,
-	0x8b, 0x4b, 0xf2, 0x93, 0xb3, 0xe3, 0x8b, 0x52, 0x73, 0x13, 0x33, 0xf3, 0x32, 0xf3, 0xd2, 0x25,
-	0x98, 0x14, 0x18, 0x35, 0x78, 0x83, 0xf8, 0xc0, 0xc2, 0x41, 0x30, 0x51, 0x21, 0x25, 0x2e, 0x9e,
-	0xdc, 0xc4, 0xbc, 0xd2, 0xb4, 0xc4, 0xe4, 0x92, 0xd2, 0xa2, 0xd4, 0x22, 0x09, 0x66, 0xb0, 0x19,
-	0x28, 0x62, 0x42, 0x8a, 0x5c, 0x3c, 0xb9, 0xf9, 0x29, 0xa9, 0x39, 0xf1, 0x79, 0xa5, 0xb9, 0x49,
-	0xa9, 0x45, 0x12, 0x2c, 0x60, 0x35, 0xdc, 0x60, 0x31, 0x3f, 0xb0, 0x90, 0x51, 0x24, 0x17, 0x27,
-	0xdc, 0x55, 0x42, 0x3e, 0x5c, 0xc2, 0xee, 0xa9, 0x25, 0x18, 0xae, 0x94, 0x44, 0xf2, 0x20, 0x6a,
-	0x88, 0x49, 0x49, 0x61, 0x93, 0x82, 0x04, 0x54, 0x12, 0x1b, 0x38, 0xc4, 0x8d, 0x01, 0x01, 0x00,
-	0x00, 0xff, 0xff, 0xf2, 0x06, 0xa6, 0xa6, 0x84, 0x01, 0x00, 0x00,
+	// 1194 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x57, 0xcd, 0x72, 0xe3, 0xc4,
+	0x13, 0xff, 0xcb, 0xb2, 0x1d, 0xab, 0x1d, 0x7f, 0x64, 0xb2, 0xeb, 0xbf, 0xa3, 0xcd, 0x92, 0x44,
+	0xec, 0xd6, 0xa6, 0x38, 0x84, 0xda, 0x00, 0x5b, 0x70, 0xa2, 0x42, 0x92, 0x4d, 0x0c, 0x89, 0x37,
+	0x35, 0x49, 0x6a, 0x29, 0xa0, 0x10, 0x13, 0x6b, 0x92, 0x55, 0x45, 0x1f, 0x5e, 0x69, 0x1c, 0xe0,
+	0x01, 0x78, 0x11, 0x1e, 0x82, 0xe2, 0xc2, 0x73, 0xf0, 0x14, 0x14, 0x57, 0x8e, 0x94, 0x47, 0x33,
+	0xb2, 0xc6, 0x92, 0xbd, 0x21, 0xb9, 0x49, 0xdd, 0xbf, 0xee, 0xe9, 0x5f, 0xb7, 0xba, 0x7b, 0x04,
+	0xad, 0x1f, 0x49, 0x44, 0xdf, 0x84, 0xa3, 0x98, 0x6e, 0x0d, 0xa3, 0x90, 0x85, 0xc8, 0x48, 0x05,
+	0x96, 0x05, 0xcd, 0x3d, 0xca, 0x88, 0xeb, 0xc5, 0x98, 0xbe, 0x1d, 0xd1, 0x98, 0xa1, 0x36, 0xe8,
+	0xf1, 0xf5, 0xa8, 0xab, 0xad, 0x6b, 0x9b, 0x06, 0x1e, 0x3f, 0x5a, 0x87, 0xd0, 0x4a, 0x31, 0xf1,
+	0x30, 0x0c, 0x62, 0x8a, 0x3e, 0x81, 0x05, 0x27, 0x11, 0x71, 0x60, 0x7d, 0xfb, 0xd1, 0xd6, 0xe4,
+	0x90, 0xd7, 0xf2, 0x49, 0x5a, 0x49, 0xac, 0xf5, 0x67, 0x09, 0xda, 0xd3, 0xda, 0xfc, 0x81, 0xe8,
+	0x19, 0xb4, 0x62, 0x16, 0x0e, 0xae, 0xed, 0x88, 0xfa, 0xc4, 0x0d, 0xdc, 0xe0, 0xaa, 0x5b, 0x5a,
+	0xd7, 0x36, 0x1b, 0xb8, 0xc9, 0xc5, 0x58, 0x4a, 0x91, 0x05, 0x8b, 0x3e, 0x09, 0x46, 0x97, 0x64,
+	0xc0, 0x46, 0x11, 0x8d, 0xba, 0x3a, 0xf7, 0xa1, 0xc8, 0xd0, 0x06, 0x2c, 0xfa, 0xa1, 0x43, 0x3d,
+	0x3b, 0x18, 0xf9, 0x17, 0x34, 0xea, 0x96, 0x39, 0xa6, 0xce, 0x65, 0x7d, 0x2e, 0x42, 0x9f, 0x01,
+	0x0c, 0x42, 0x7f, 0x18, 0x06, 0x34, 0x60, 0x71, 0xb7, 0xb2, 0xae, 0x6f, 0xd6, 0xb7, 0x57, 0x32,
+	0x84, 0x76, 0xa5, 0xf2, 0x94, 0x1f, 0x9f, 0x01, 0xa3, 0x17, 0x60, 0x78, 0xe1, 0x80, 0x30, 0x37,
+	0x0c, 0xe2, 0x6e, 0x95, 0x5b, 0x76, 0x33, 0x96, 0x47, 0x42, 0x97, 0x18, 0x4e, 0xa0, 0xe8, 0x29,
+	0x34, 0x25, 0xc5, 0x98, 0x46, 0x37, 0xd4, 0xe9, 0x2e, 0x70, 0x86, 0x0d, 0xc1, 0x30, 0x11, 0x4e,
+	0x32, 0x41, 0x6e, 0x88, 0xeb, 0x91, 0x0b, 0x8f, 0x76, 0x6b, 0x99, 0x4c, 0xec, 0x48, 0xa9, 0xf5,
+	0xb7, 0x06, 0x4d, 0x35, 0xcc, 0x82, 0xbc, 0x9a, 0x50, 0x7b, 0x3b, 0x22, 0x01, 0x73, 0xd9, 0xcf,
+	0x22, 0xa1, 0xe9, 0x7b, 0x51, 0xce, 0xf5, 0xc2, 0x9c, 0x2b, 0x8c, 0xcb, 0xf7, 0x61, 0x5c, 0xb9,
+	0x25, 0xe3, 0x6a, 0x21, 0xe3, 0x3f, 0x34, 0x68, 0x28, 0x87, 0xa1, 0x35, 0xa8, 0xcb, 0xe3, 0x6c,
+	0xd7, 0x11, 0xc4, 0x41, 0x8a, 0x7a, 0x0e, 0x42, 0x50, 0x0e, 0x88, 0x4f, 0x39, 0x77, 0x03, 0xf3,
+	0xe7, 0xdb, 0xf3, 0xce, 0xc7, 0x5f, 0xbe, 0x65, 0xfc, 0x95, 0xc2, 0xf8, 0x7f, 0xd1, 0x60, 0x59,
+	0x58, 0x25, 0xb9, 0x9a, 0xd5, 0x7f, 0x73, 0xcb, 0x36, 0xc5, 0x59, 0xcf, 0x71, 0x5e, 0x83, 0x3a,
+	0x63, 0x9e, 0x1d, 0xd3, 0x41, 0x18, 0x38, 0xb1, 0x88, 0x19, 0x18, 0xf3, 0x4e, 0x13, 0x89, 0x75,
+	0x02, 0x0f, 0xd4, 0x30, 0x44, 0x8b, 0x7f, 0x0a, 0xf5, 0x84, 0x29, 0xf7, 0x24, 0xda, 0xbc, 0x93,
+	0xa9, 0x34, 0x9e, 0x68, 0x71, 0x16, 0x3a, 0xae, 0x4c, 0x3d, 0xa3, 0x1c, 0x67, 0x2e, 0xa3, 0x96,
+	0xa5, 0x29, 0xe3, 0x46, 0x46, 0xda, 0x73, 0x24, 0xf1, 0x52, 0x31, 0x71, 0x7d, 0x3e, 0xf1, 0x72,
+	0x8e, 0xf8, 0x63, 0x00, 0xfa, 0xd3, 0xd0, 0x8d, 0x68, 0x6c, 0x13, 0xc6, 0x6b, 0xa0, 0x63, 0x43,
+	0x48, 0x76, 0x18, 0x5a, 0x81, 0x5a, 0x18, 0x39, 0x34, 0x1a, 0x1b, 0x57, 0x79, 0x38, 0x0b, 0xfc,
+	0xbd, 0xe7, 0x58, 0x5f, 0xc0, 0x0a, 0xa6, 0x1e, 0x25, 0x31, 0xcd, 0x52, 0x14, 0xe5, 0xb9, 0x1d,
+	0x19, 0x6b, 0x15, 0xcc, 0x22, 0x1f, 0x49, 0x6e, 0xad, 0xef, 0xa0, 0xbb, 0x1b, 0xfa, 0xbe, 0xcb,
+	0xee, 0x7c, 0x80, 0x12, 0x7f, 0x49, 0x8d, 0xff, 0x1c, 0x56, 0x0a, 0xbc, 0xdf, 0xbb, 0xac, 0x7f,
+	0x69, 0xd0, 0xe0, 0x9f, 0xc8, 0x71, 0x78, 0x43, 0x7d, 0x1a, 0xb0, 0x71, 0x0d, 0x7c, 0xf1, 0x9c,
+	0x69, 0x38, 0x29, 0x2a, 0x2c, 0xe9, 0x3b, 0xbf, 0xd7, 0x07, 0x50, 0x71, 0xa8, 0xc7, 0x08, 0xaf,
+	0x68, 0x05, 0x27, 0x2f, 0xe8, 0x39, 0x54, 0x23, 0x4a, 0xe2, 0x30, 0xe0, 0x85, 0x6c, 0x2a, 0xd3,
+	0x59, 0x86, 0x83, 0x39, 0x00, 0x0b, 0xe0, 0x78, 0xee, 0x47, 0xf4, 0x92, 0x46, 0x34, 0x18, 0x50,
+	0x59, 0x64, 0x03, 0xd7, 0x53, 0x59, 0xcf, 0x41, 0xab, 0x60, 0x30, 0xd7, 0xa7, 0x31, 0x23, 0xfe,
+	0x90, 0xcf, 0x5f, 0x1d, 0x4f, 0x04, 0xd6, 0x6f, 0x1a, 0x3c, 0xc4, 0x74, 0x10, 0x46, 0xce, 0xe4,
+	0x84, 0x59, 0x2d, 0x3a, 0x45, 0xab, 0x34, 0x9b, 0x96, 0x5e, 0x4c, 0xab, 0x7c, 0x57, 0x5a, 0x95,
+	0x1c, 0x2d, 0xab, 0x0f, 0x9d, 0xe9, 0xb8, 0x45, 0xf1, 0x3f, 0x86, 0x9a, 0xac, 0x8e, 0xa8, 0x7c,
+	0x76, 0x74, 0x2b, 0xc5, 0xc5, 0x29, 0xd2, 0xfa, 0x01, 0x1e, 0x2a, 0xaa, 0xf8, 0x7e, 0x79, 0xf0,
+	0x5c, 0xdf, 0x65, 0xa2, 0x9f, 0x93, 0x17, 0xeb, 0x77, 0x0d, 0x3a, 0xd3, 0x47, 0x88, 0x90, 0x5f,
+	0x80, 0x21, 0x03, 0x19, 0xdf, 0x35, 0xf4, 0xb9, 0x31, 0x4f, 0xa0, 0xe3, 0x3c, 0x79, 0xd4, 0xb9,
+	0xa2, 0x91, 0xcd, 0xe7, 0x2e, 0x0f, 0x45, 0xc7, 0xf5, 0x44, 0xc6, 0xad, 0x66, 0x8d, 0x7e, 0x3d,
+	0x37, 0xfa, 0x4d, 0xa8, 0x5d, 0x10, 0x8f, 0x04, 0x03, 0x31, 0xf4, 0x6b, 0x38, 0x7d, 0xb7, 0x3e,
+	0x87, 0x9a, 0xdc, 0x42, 0x77, 0x5a, 0x40, 0xd6, 0x21, 0x3c, 0xdc, 0x8d, 0x28, 0x61, 0x54, 0xba,
+	0x91, 0xd9, 0xfd, 0x10, 0x6a, 0xd2, 0x54, 0x14, 0x6b, 0xb9, 0x60, 0xcf, 0xe2, 0x14, 0x64, 0xf5,
+	0xa0, 0x33, 0xed, 0x49, 0x24, 0xf1, 0x3f, 0xbb, 0x42, 0xd0, 0x96, 0x52, 0x59, 0x6d, 0xeb, 0x25,
+	0x2c, 0x65, 0x64, 0xc2, 0xf3, 0xf3, 0xec, 0x6d, 0x20, 0x29, 0x4f, 0xa1, 0xeb, 0x09, 0xca, 0xfa,
+	0x47, 0x83, 0xe6, 0x4b, 0xe2, 0x7a, 0xd4, 0x39, 0x7d, 0xe3, 0x0e, 0xf9, 0x20, 0x79, 0x0c, 0x70,
+	0x49, 0x5c, 0x6f, 0x14, 0xd1, 0x49, 0xde, 0x0c, 0x21, 0x99, 0x3b, 0xeb, 0xe4, 0x27, 0xa8, 0xcf,
+	0xfc, 0x04, 0xf3, 0x8b, 0xe1, 0x19, 0xb4, 0x58, 0x44, 0x06, 0xd7, 0x6e, 0x70, 0x25, 0xef, 0x84,
+	0x49, 0x13, 0x35, 0xa5, 0x58, 0x5c, 0x0b, 0xb3, 0xeb, 0xa7, 0x3a, 0xb5, 0x7e, 0x3a, 0x69, 0xe7,
+	0x2e, 0x70, 0x5b, 0xf1, 0xa6, 0x8e, 0x94, 0xda, 0xf4, 0x48, 0xd9, 0x82, 0x8e, 0xca, 0x3c, 0x6d,
+	0xa5, 0xb4, 0x2f, 0xb4, 0x6c, 0x5f, 0xd8, 0xf0, 0xff, 0x1c, 0x5e, 0x24, 0x7e, 0x0f, 0xda, 0x97,
+	0x5c, 0x65, 0xc7, 0x52, 0x27, 0xf2, 0x9f, 0x1d, 0x22, 0xaa, 0x35, 0x6e, 0x5d, 0xaa, 0xde, 0x3e,
+	0x18, 0x40, 0x53, 0x9d, 0x33, 0xa8, 0x09, 0x70, 0x8c, 0xed, 0xf3, 0xfe, 0x57, 0xfd, 0x57, 0xaf,
+	0xfb, 0xed, 0xff, 0xa1, 0x16, 0xd4, 0x8f, 0xb1, 0x7d, 0x7a, 0xd8, 0x3b, 0x39, 0xde, 0xef, 0x9f,
+	0xb5, 0x35, 0x01, 0xc0, 0xfb, 0xbb, 0xfb, 0xbd, 0x93, 0xb3, 0x76, 0x09, 0x2d, 0x41, 0xe3, 0x18,
+	0xdb, 0x3b, 0x7b, 0x5f, 0x9e, 0x9f, 0x9e, 0x71, 0x88, 0x8e, 0x1a, 0x60, 0x70, 0xc8, 0xd9, 0x39,
+	0xee, 0xb7, 0xcb, 0xdb, 0xbf, 0x56, 0xc1, 0x48, 0x6f, 0xfd, 0xe8, 0x08, 0x96, 0x0f, 0x28, 0xcb,
+	0xfd, 0x05, 0x64, 0xa3, 0x56, 0xff, 0x48, 0x4c, 0xb3, 0x48, 0x25, 0xd2, 0xf0, 0x0a, 0x16, 0xb3,
+	0xb7, 0x17, 0xf4, 0x5e, 0x6e, 0x93, 0x29, 0xb7, 0x2b, 0x73, 0x6d, 0xa6, 0x5e, 0x38, 0x24, 0x80,
+	0xf2, 0x8b, 0x1b, 0x3d, 0x51, 0xcc, 0x66, 0xdc, 0x0d, 0xcc, 0xa7, 0xef, 0x40, 0x89, 0x23, 0xbe,
+	0x87, 0xa5, 0xdc, 0x7e, 0x46, 0xef, 0xab, 0xff, 0x1b, 0x85, 0x77, 0x03, 0xf3, 0xc9, 0x7c, 0x90,
+	0xf0, 0xff, 0x0d, 0x2c, 0x27, 0xf3, 0x5f, 0xdd, 0xd6, 0xeb, 0x4a, 0x74, 0x05, 0x7b, 0xcd, 0xdc,
+	0x98, 0x83, 0x10, 0xbe, 0xbf, 0x86, 0xa5, 0x03, 0xca, 0x14, 0xc7, 0xb1, 0xe2, 0xb9, 0x70, 0x53,
+	0x98, 0x1b, 0x73, 0x10, 0xc2, 0xf3, 0x39, 0x34, 0xd5, 0xe9, 0xa5, 0xb8, 0x2d, 0x1c, 0x91, 0xe6,
+	0xc6, 0x1c, 0x84, 0x70, 0xdb, 0x83, 0xc5, 0x03, 0xca, 0x8e, 0xd2, 0xdf, 0x90, 0x47, 0x05, 0xd3,
+	0x29, 0x0d, 0x73, 0xb5, 0x58, 0x29, 0x5c, 0x7d, 0x0b, 0xe8, 0x80, 0xb2, 0xa9, 0x86, 0x44, 0x1b,
+	0x33, 0xdb, 0x2d, 0x75, 0x6b, 0xcd, 0x83, 0x24, 0xce, 0x2f, 0xaa, 0xfc, 0xd7, 0xfc, 0xa3, 0x7f,
+	0x07, 0x00, 0xee, 0xc8, 0x32, 0x16, 0xad, 0x0f, 0x00, 0x00,
 }